@@ -0,0 +1,223 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+// These tests put an error budget, in meters, on each of the presets in
+// the SRID table, so that refactorings which nudge the results (of the
+// core offsets, say) are caught even when a test in degrees would pass.
+//
+// Each preset is checked at a few points computed by PROJ itself, and
+// over a grid sampling its area of use against an independent
+// implementation of the same projection.
+
+const wgs84A = 6378137.0
+const wgs84F = 1.0 / 298.257223563
+
+// forward and inverse budgets, in meters
+const fwdBudget = 1.0e-3
+const invBudget = 1.0e-3
+
+type accuracyPreset struct {
+	srid   string
+	oracle func(lon, lat float64) (x, y float64)
+
+	// area of use, in degrees
+	minLon, maxLon, minLat, maxLat float64
+
+	// reference outputs from PROJ: lon, lat, x, y
+	reference [][]float64
+}
+
+var accuracyPresets = []accuracyPreset{
+	{
+		srid:   "3395",
+		oracle: mercOracle(wgs84A, wgs84F),
+		minLon: -180.0, maxLon: 180.0, minLat: -80.0, maxLat: 84.0,
+		reference: [][]float64{
+			{-77.625583, 38.833846, -8641240.37, 4671101.60},
+			{2.352222, 48.856614, 261848.16, 6218371.80},
+		},
+	},
+	{
+		srid:   "3857",
+		oracle: mercOracle(wgs84A, 0.0),
+		minLon: -180.0, maxLon: 180.0, minLat: -85.06, maxLat: 85.06,
+		reference: [][]float64{
+			{-77.625583, 38.833846, -8641240.37, 4697899.31},
+			{2.352222, 48.856614, 261848.16, 6250566.72},
+		},
+	},
+	{
+		srid:   "4087",
+		oracle: eqcOracle(wgs84A),
+		minLon: -180.0, maxLon: 180.0, minLat: -90.0, maxLat: 90.0,
+		reference: [][]float64{
+			{-77.625583, 38.833846, -8641240.37, 4322963.96},
+			{2.352222, 48.856614, 261848.16, 5438693.39},
+		},
+	},
+	{
+		srid:   "32632",
+		oracle: utmOracle(wgs84A, wgs84F, 32, false),
+		minLon: 6.0, maxLon: 12.0, minLat: 0.0, maxLat: 84.0,
+		reference: [][]float64{
+			{12.0, 55.0, 691875.63, 6098907.83},
+		},
+	},
+	{
+		srid:   "32601",
+		oracle: utmOracle(wgs84A, wgs84F, 1, false),
+		minLon: -180.0, maxLon: -174.0, minLat: 0.0, maxLat: 84.0,
+	},
+	{
+		srid:   "32660",
+		oracle: utmOracle(wgs84A, wgs84F, 60, false),
+		minLon: 174.0, maxLon: 180.0, minLat: 0.0, maxLat: 84.0,
+	},
+	{
+		srid:   "32733",
+		oracle: utmOracle(wgs84A, wgs84F, 33, true),
+		minLon: 12.0, maxLon: 18.0, minLat: -80.0, maxLat: 0.0,
+	},
+}
+
+func TestAccuracyBudget(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, preset := range accuracyPresets {
+
+		for _, ref := range preset.reference {
+			xy, err := proj.Convert(preset.srid, ref[0:2])
+			assert.NoError(err)
+			assert.InDelta(ref[2], xy[0], 0.01, preset.srid)
+			assert.InDelta(ref[3], xy[1], 0.01, preset.srid)
+		}
+
+		lonlat := sampleGrid(preset.minLon, preset.maxLon, preset.minLat, preset.maxLat, 15)
+
+		xy, err := proj.Convert(preset.srid, lonlat)
+		assert.NoError(err)
+
+		maxFwd := 0.0
+		for i := 0; i < len(lonlat); i += 2 {
+			x, y := preset.oracle(lonlat[i], lonlat[i+1])
+			maxFwd = math.Max(maxFwd, math.Hypot(xy[i]-x, xy[i+1]-y))
+		}
+		assert.True(maxFwd <= fwdBudget,
+			fmt.Sprintf("%s: forward error %.6f m exceeds budget", preset.srid, maxFwd))
+
+		back, err := proj.Inverse(preset.srid, xy)
+		assert.NoError(err)
+
+		maxInv := 0.0
+		for i := 0; i < len(lonlat); i += 2 {
+			maxInv = math.Max(maxInv, groundDistance(lonlat[i], lonlat[i+1], back[i], back[i+1]))
+		}
+		assert.True(maxInv <= invBudget,
+			fmt.Sprintf("%s: inverse error %.6f m exceeds budget", preset.srid, maxInv))
+	}
+}
+
+// sampleGrid returns an n*n grid of lon/lat points covering the area
+func sampleGrid(minLon, maxLon, minLat, maxLat float64, n int) []float64 {
+	lonlat := make([]float64, 0, 2*n*n)
+	for i := 0; i < n; i++ {
+		lon := minLon + (maxLon-minLon)*float64(i)/float64(n-1)
+		for j := 0; j < n; j++ {
+			lat := minLat + (maxLat-minLat)*float64(j)/float64(n-1)
+			lonlat = append(lonlat, lon, lat)
+		}
+	}
+	return lonlat
+}
+
+// groundDistance approximates the distance in meters between two nearby
+// lon/lat points
+func groundDistance(lon1, lat1, lon2, lat2 float64) float64 {
+	dLon := math.Remainder(lon2-lon1, 360.0)
+	dx := wgs84A * dLon * math.Pi / 180.0 * math.Cos(lat1*math.Pi/180.0)
+	dy := wgs84A * (lat2 - lat1) * math.Pi / 180.0
+	return math.Hypot(dx, dy)
+}
+
+// mercOracle is the Mercator, in its isometric latitude form
+func mercOracle(a, f float64) func(lon, lat float64) (float64, float64) {
+	e := math.Sqrt(f * (2.0 - f))
+	return func(lon, lat float64) (float64, float64) {
+		lam := lon * math.Pi / 180.0
+		phi := lat * math.Pi / 180.0
+		psi := math.Asinh(math.Tan(phi)) - e*math.Atanh(e*math.Sin(phi))
+		return a * lam, a * psi
+	}
+}
+
+// eqcOracle is the Plate Carrée
+func eqcOracle(a float64) func(lon, lat float64) (float64, float64) {
+	return func(lon, lat float64) (float64, float64) {
+		return a * lon * math.Pi / 180.0, a * lat * math.Pi / 180.0
+	}
+}
+
+// utmOracle is UTM by way of Krüger's series, to sixth order in n
+// (Karney, "Transverse Mercator with an accuracy of a few nanometers")
+func utmOracle(a, f float64, zone int, south bool) func(lon, lat float64) (float64, float64) {
+	e := math.Sqrt(f * (2.0 - f))
+	n := f / (2.0 - f)
+	n2 := n * n
+	n3 := n2 * n
+	n4 := n3 * n
+	n5 := n4 * n
+	n6 := n5 * n
+
+	A := a / (1.0 + n) * (1.0 + n2/4.0 + n4/64.0 + n6/256.0)
+	alpha := []float64{
+		n/2.0 - 2.0/3.0*n2 + 5.0/16.0*n3 + 41.0/180.0*n4 - 127.0/288.0*n5 + 7891.0/37800.0*n6,
+		13.0/48.0*n2 - 3.0/5.0*n3 + 557.0/1440.0*n4 + 281.0/630.0*n5 - 1983433.0/1935360.0*n6,
+		61.0/240.0*n3 - 103.0/140.0*n4 + 15061.0/26880.0*n5 + 167603.0/181440.0*n6,
+		49561.0/161280.0*n4 - 179.0/168.0*n5 + 6601661.0/7257600.0*n6,
+		34729.0/80640.0*n5 - 3418889.0/1995840.0*n6,
+		212378941.0 / 319334400.0 * n6,
+	}
+
+	k0 := 0.9996
+	lon0 := float64(6*zone - 183)
+	y0 := 0.0
+	if south {
+		y0 = 10000000.0
+	}
+
+	return func(lon, lat float64) (float64, float64) {
+		lam := math.Remainder(lon-lon0, 360.0) * math.Pi / 180.0
+		phi := lat * math.Pi / 180.0
+
+		tau := math.Tan(phi)
+		sigma := math.Sinh(e * math.Atanh(e*tau/math.Sqrt(1.0+tau*tau)))
+		tauP := tau*math.Sqrt(1.0+sigma*sigma) - sigma*math.Sqrt(1.0+tau*tau)
+
+		xiP := math.Atan2(tauP, math.Cos(lam))
+		etaP := math.Asinh(math.Sin(lam) / math.Sqrt(tauP*tauP+math.Cos(lam)*math.Cos(lam)))
+
+		xi, eta := xiP, etaP
+		for j, aj := range alpha {
+			k := 2.0 * float64(j+1)
+			xi += aj * math.Sin(k*xiP) * math.Cosh(k*etaP)
+			eta += aj * math.Cos(k*xiP) * math.Sinh(k*etaP)
+		}
+
+		return 500000.0 + k0*A*eta, y0 + k0*A*xi
+	}
+}
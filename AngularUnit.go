@@ -0,0 +1,56 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+
+	"github.com/oahumap/proj/support"
+)
+
+var degree = support.AngularUnitsTable["deg"].ToRadians
+
+// geographicUnit returns the size in radians of the angular unit of a
+// geographic system.
+//
+// The unit is taken from +units (deg, rad or grad). Failing that, a
+// +to_meter factor is accepted, which for a geographic system is taken to
+// be the size of its unit in radians. The default is degrees.
+func geographicUnit(ps *support.ProjString) (float64, error) {
+	if id, ok := ps.GetAsString("units"); ok {
+		unit, ok := support.AngularUnitsTable[id]
+		if !ok {
+			return 0.0, fmt.Errorf("unknown angular unit: %s", id)
+		}
+		return unit.ToRadians, nil
+	}
+
+	if ps.ContainsKey("to_meter") {
+		f, ok := ps.GetAsFloat("to_meter")
+		if !ok || f <= 0.0 {
+			return 0.0, fmt.Errorf("invalid to_meter for geographic system")
+		}
+		return f, nil
+	}
+
+	return degree, nil
+}
+
+// rescale returns a copy of the input with every value multiplied by the
+// factor
+func rescale(input []float64, factor float64) []float64 {
+	output := make([]float64, len(input))
+	if factor == 1.0 {
+		copy(output, input)
+		return output
+	}
+	for i, v := range input {
+		output[i] = v * factor
+	}
+	return output
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAngularUnits(t *testing.T) {
+	assert := assert.New(t)
+
+	lonlat := []float64{2.337229, 48.836439, -90.0, 45.0}
+
+	// geographic systems in grads or radians
+	grads := "+proj=longlat +ellps=clrk80ign +units=grad"
+	out, err := proj.Convert(grads, lonlat)
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{2.59692111, 54.26271, -100.0, 50.0}, out, 1.0e-5)
+
+	back, err := proj.Inverse(grads, out)
+	assert.NoError(err)
+	assert.InDeltaSlice(lonlat, back, 1.0e-12)
+
+	radians := "+proj=longlat +ellps=WGS84 +to_meter=1"
+	out, err = proj.Inverse(radians, []float64{math.Pi / 2, -math.Pi / 4})
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{90.0, -45.0}, out, 1.0e-12)
+
+	// and plain degrees are unchanged
+	out, err = proj.Inverse("4326", lonlat)
+	assert.NoError(err)
+	assert.Equal(lonlat, out)
+
+	_, err = proj.Convert("+proj=longlat +units=furlong", lonlat)
+	assert.Error(err)
+	_, err = proj.Convert("+proj=longlat +to_meter=-1", lonlat)
+	assert.Error(err)
+}
+
+func TestTransformerAngularUnit(t *testing.T) {
+	assert := assert.New(t)
+
+	tr, err := proj.NewTransformer("3395")
+	assert.NoError(err)
+
+	expected, err := proj.Convert("3395", []float64{90.0, 45.0})
+	assert.NoError(err)
+
+	assert.NoError(tr.SetAngularUnit("rad"))
+	xy, err := tr.Forward([]float64{math.Pi / 2, math.Pi / 4})
+	assert.NoError(err)
+	assert.InDeltaSlice(expected, xy, 1.0e-6)
+
+	lonlat, err := tr.Inverse(xy)
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{math.Pi / 2, math.Pi / 4}, lonlat, 1.0e-12)
+
+	// from radians straight into a geographic system in grads
+	tr, err = proj.NewTransformer("+proj=longlat +units=grad")
+	assert.NoError(err)
+	assert.NoError(tr.SetAngularUnit("rad"))
+	out, err := tr.Forward([]float64{math.Pi / 2, math.Pi / 4})
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{100.0, 50.0}, out, 1.0e-12)
+
+	assert.Error(tr.SetAngularUnit("furlong"))
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/operations/cylindrical"
+	"github.com/oahumap/proj/support"
+)
+
+// The approximate fast path of spherical Mercator, as used by Web
+// Mercator: the northing is interpolated linearly in a table by latitude,
+// and the latitude in a table by northing, rather than computed with a
+// log and a tan or an exp and an atan. The tables are spaced so that the
+// error is under a centimeter on the sphere of Web Mercator: the
+// interpolation error of the northing is worst at the edge of the square,
+// where it grows with sec(lat)tan(lat), and that of the latitude is worst
+// around 40 degrees. Eastings are exact.
+const (
+	mercTableLatStep = 1.0 / 2048.0    // degrees
+	mercTableYStep   = 1.0 / (1 << 14) // of the northing, in units of the radius
+	mercTableMaxY    = math.Pi         // the northing at the edge of the square
+)
+
+// mercTableMaxLat is the latitude of the edge of the square, in degrees
+var mercTableMaxLat = support.RToDD(cylindrical.MercMaxLat)
+
+var (
+	mercTablesOnce sync.Once
+	mercYTable     []float64 // northings, by latitude from 0
+	mercLatTable   []float64 // latitudes, in degrees, by northing from 0
+)
+
+// mercTables returns the tables, building them on first use
+func mercTables() ([]float64, []float64) {
+	mercTablesOnce.Do(func() {
+		n := int(mercTableMaxLat/mercTableLatStep) + 2
+		mercYTable = make([]float64, n)
+		for i := range mercYTable {
+			phi := support.DDToR(float64(i) * mercTableLatStep)
+			mercYTable[i] = support.Log(math.Tan(support.PiOverFour + .5*phi))
+		}
+
+		maxY := mercTableMaxY
+		n = int(maxY/mercTableYStep) + 2
+		mercLatTable = make([]float64, n)
+		for i := range mercLatTable {
+			y := float64(i) * mercTableYStep
+			mercLatTable[i] = support.RToDD(support.PiOverTwo - 2.*math.Atan(support.Exp(-y)))
+		}
+	})
+	return mercYTable, mercLatTable
+}
+
+// interpolate returns the value of the table at v, a multiple of its
+// step, by linear interpolation; v must be within the table
+func interpolate(table []float64, v float64) float64 {
+	i := int(v)
+	f := v - float64(i)
+	return table[i] + f*(table[i+1]-table[i])
+}
+
+// SetApproximate turns the approximate fast path on or off for the
+// transformer. It is off by default.
+//
+// For now there is one: for spherical Mercator, and so for Web Mercator
+// (3857), Forward and Inverse look the northings and latitudes up in
+// precomputed tables, with linear interpolation, which is about ten times
+// faster than the exact formulas (see the benchmarks), and within a
+// centimeter of them on the Web Mercator sphere. Points outside the Web
+// Mercator square, and points being datum shifted, take the exact path.
+//
+// It fails, leaving the fast path off, for systems it doesn't apply to:
+// anything other than spherical Mercator, or Mercator with +lat_max,
+// +over or +geoc.
+func (t *Transformer) SetApproximate(on bool) error {
+	t.approximate = false
+	if !on {
+		return nil
+	}
+	if t.conv == nil {
+		return fmt.Errorf("no approximate path for geographic systems")
+	}
+	sys := t.conv.system
+	name, _ := sys.ProjString.GetAsString("proj")
+	if name != "merc" || sys.Ellipsoid.Es != 0.0 || sys.Over || sys.Geoc ||
+		sys.ProjString.ContainsKey("lat_max") || sys.Right != core.IOUnitsClassic {
+		return fmt.Errorf("no approximate path for %s", t.resolved)
+	}
+	t.approximate = true
+	return nil
+}
+
+// Approximate reports whether the approximate fast path is on
+func (t *Transformer) Approximate() bool {
+	return t.approximate
+}
+
+// convertApproximate is convert, for spherical Mercator, by the tables
+func (conv *conversion) convertApproximate(input []float64) ([]float64, error) {
+	if len(input)%2 != 0 {
+		return nil, fmt.Errorf("input array of lon/lat values must be an even number")
+	}
+	if conv.hub != nil {
+		return conv.convert(input)
+	}
+
+	yTable, _ := mercTables()
+	sys := conv.system
+	scale := sys.Ellipsoid.A * sys.K0
+	output := make([]float64, len(input))
+
+	for i := 0; i < len(input); i += 2 {
+		lam, lat := support.DDToR(input[i]), input[i+1]
+		if !(math.Abs(lat) <= mercTableMaxLat) || !(math.Abs(lam) <= 10) {
+			xy, err := conv.project(&core.CoordLP{Lam: lam, Phi: support.DDToR(lat)})
+			if err != nil {
+				return nil, err
+			}
+			output[i], output[i+1] = xy.X, xy.Y
+			continue
+		}
+
+		lam = support.Adjlon(support.Adjlon(lam) - sys.FromGreenwich - sys.Lam0)
+
+		y := math.Copysign(interpolate(yTable, math.Abs(lat)/mercTableLatStep), lat)
+		x := sys.FromMeter * (scale*lam + sys.X0)
+		y = sys.FromMeter * (scale*y + sys.Y0)
+		output[i], output[i+1] = sys.AxisForward(x, y)
+	}
+
+	return output, nil
+}
+
+// inverseApproximate is inverse, for spherical Mercator, by the tables
+func (conv *conversion) inverseApproximate(input []float64, stats *Stats) ([]float64, error) {
+	if len(input)%2 != 0 {
+		return nil, fmt.Errorf("input array of x/y values must be an even number")
+	}
+	if conv.hub != nil || conv.geographic != nil {
+		return conv.inverse(input, stats)
+	}
+
+	_, latTable := mercTables()
+	sys := conv.system
+	scale := sys.Ellipsoid.A * sys.K0
+	output := make([]float64, len(input))
+
+	for i := 0; i < len(input); i += 2 {
+		x, y := sys.AxisInverse(input[i], input[i+1])
+		u := (sys.ToMeter*y - sys.Y0) / scale
+		if !(math.Abs(u) <= mercTableMaxY) {
+			lp, err := conv.unproject(&core.CoordXY{X: input[i], Y: input[i+1]})
+			if err != nil {
+				return nil, err
+			}
+			output[i], output[i+1] = support.RToDD(lp.Lam), support.RToDD(lp.Phi)
+			continue
+		}
+
+		lam := (sys.ToMeter*x-sys.X0)/scale + sys.FromGreenwich + sys.Lam0
+		output[i] = support.RToDD(support.Adjlon(lam))
+		output[i+1] = math.Copysign(interpolate(latTable, math.Abs(u)/mercTableYStep), u)
+	}
+
+	if stats != nil {
+		stats.Points = len(input) / 2
+	}
+	return output, nil
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApproximate(t *testing.T) {
+	assert := assert.New(t)
+
+	exact, err := proj.NewTransformer("3857")
+	assert.NoError(err)
+	approx, err := proj.NewTransformer("3857")
+	assert.NoError(err)
+	assert.False(approx.Approximate())
+	assert.NoError(approx.SetApproximate(true))
+	assert.True(approx.Approximate())
+
+	// within a centimeter over the whole square, both ways, and the
+	// points beyond it take the exact path
+	var lonlat []float64
+	for lat := -85.0511; lat <= 85.0511; lat += 0.0123 {
+		lonlat = append(lonlat, 2.1*lat, lat)
+	}
+	lonlat = append(lonlat, -180.0, 85.0511287798066, 180.0, -85.0511287798066, 540.0, 89.0, 0.0, -89.5)
+	expected, err := exact.Forward(lonlat)
+	assert.NoError(err)
+	actual, err := approx.Forward(lonlat)
+	assert.NoError(err)
+	assert.InDeltaSlice(expected, actual, 0.01)
+	assert.Equal(expected[len(expected)-4:], actual[len(actual)-4:])
+
+	back, err := exact.Inverse(expected)
+	assert.NoError(err)
+	actual, err = approx.Inverse(expected)
+	assert.NoError(err)
+	for i := 0; i < len(back); i += 2 {
+		assert.InDelta(0.0, math.Remainder(back[i]-actual[i], 360.0), 1.0e-9)
+		assert.InDelta(back[i+1], actual[i+1], 0.01/111320.0)
+	}
+	assert.Equal(len(lonlat)/2, approx.Stats().Points)
+
+	// the exact path's errors still happen
+	_, err = approx.Forward([]float64{0.0, 90.0})
+	assert.Error(err)
+	_, err = approx.Forward([]float64{0.0})
+	assert.Error(err)
+
+	// other spherical Mercators, in other units and with other origins
+	for _, def := range []string{
+		"+proj=merc +R=6371000 +lon_0=100 +x_0=1000 +y_0=-2000 +units=ft",
+		"+proj=merc +a=6378137 +b=6378137 +k=0.5 +axis=wsu",
+	} {
+		exact, err := proj.NewTransformer(def)
+		assert.NoError(err)
+		approx, err := proj.NewTransformerWithConfig(def, proj.Config{Approximate: true})
+		assert.NoError(err)
+		assert.True(approx.Approximate(), def)
+		expected, err := exact.Forward(lonlat[:200])
+		assert.NoError(err)
+		actual, err := approx.Forward(lonlat[:200])
+		assert.NoError(err)
+		assert.InDeltaSlice(expected, actual, 0.04, def)
+		back, err := approx.Inverse(actual)
+		assert.NoError(err)
+		assert.InDeltaSlice(lonlat[:200], back, 1.0e-6, def)
+	}
+
+	// and nothing else
+	for _, def := range []string{"3395", "32633", "4326", "+proj=merc +R=6371000 +lat_max=80"} {
+		tr, err := proj.NewTransformer(def)
+		assert.NoError(err)
+		assert.Error(tr.SetApproximate(true), def)
+		assert.False(tr.Approximate())
+
+		// which the Config quietly leaves on the exact path
+		tr, err = proj.NewTransformerWithConfig(def, proj.Config{Approximate: true})
+		assert.NoError(err)
+		assert.False(tr.Approximate())
+	}
+}
+
+func benchmarkWebMercator(b *testing.B, approximate, inverse bool) {
+	tr, _ := proj.NewTransformer("3857")
+	_ = tr.SetApproximate(approximate)
+	input := make([]float64, 0, 20000)
+	for i := 0; i < 10000; i++ {
+		input = append(input, float64(i%360)-180.0, float64(i%170)-85.0)
+	}
+	if inverse {
+		input, _ = tr.Forward(input)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if inverse {
+			_, _ = tr.Inverse(input)
+		} else {
+			_, _ = tr.Forward(input)
+		}
+	}
+}
+
+func BenchmarkWebMercatorForwardExact(b *testing.B)       { benchmarkWebMercator(b, false, false) }
+func BenchmarkWebMercatorForwardApproximate(b *testing.B) { benchmarkWebMercator(b, true, false) }
+func BenchmarkWebMercatorInverseExact(b *testing.B)       { benchmarkWebMercator(b, false, true) }
+func BenchmarkWebMercatorInverseApproximate(b *testing.B) { benchmarkWebMercator(b, true, true) }
@@ -0,0 +1,91 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/oahumap/proj/support"
+)
+
+// ErrNoAreaOfUse is returned for a CRS whose area of use isn't known
+var ErrNoAreaOfUse = errors.New("area of use is not known")
+
+// AreaOf returns the area of use of a CRS, given as an SRID such as
+// "32633" or "EPSG:32633". The areas of the presets are built in; those
+// of other SRIDs are looked up with GetInfoFromEPSG, so fail in offline
+// mode. Proj strings have no area, and fail with ErrNoAreaOfUse.
+func AreaOf(crs string) (*AreaOfUse, error) {
+	srid, ok := parseSRID(crs)
+	if !ok {
+		return nil, ErrNoAreaOfUse
+	}
+	srid, err := redirect(srid)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry, ok := support.AreasTable[srid]; ok {
+		return &AreaOfUse{
+			Name:  entry.Name,
+			West:  entry.West,
+			South: entry.South,
+			East:  entry.East,
+			North: entry.North,
+		}, nil
+	}
+
+	// only the metadata is needed, whatever else is missing
+	info, err := GetInfoFromEPSG(fmt.Sprint(srid))
+	if info == nil {
+		return nil, err
+	}
+	if info.Area == nil {
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrNoAreaOfUse
+	}
+	return info.Area, nil
+}
+
+// Contains reports whether the area contains the lon/lat point, in
+// degrees. Areas whose West is greater than their East cross the
+// antimeridian.
+func (a *AreaOfUse) Contains(lon, lat float64) bool {
+	if !(lat >= a.South && lat <= a.North) {
+		return false
+	}
+	if a.West <= a.East {
+		return lon >= a.West && lon <= a.East
+	}
+	return lon >= a.West || lon <= a.East
+}
+
+// PartitionByArea splits lon/lat points, in degrees, by whether they are
+// in the area of use of a CRS, as given by AreaOf, so that points outside
+// it can be routed to another CRS. It returns the indexes of the points
+// (not of the values) inside and outside the area; points which are not
+// numbers are outside.
+func PartitionByArea(crs string, points []float64) (inside, outside []int, err error) {
+	area, err := AreaOf(crs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inside, outside = []int{}, []int{}
+	for i := 0; i+1 < len(points); i += 2 {
+		if area.Contains(points[i], points[i+1]) {
+			inside = append(inside, i/2)
+		} else {
+			outside = append(outside, i/2)
+		}
+	}
+	return inside, outside, nil
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAreaOf(t *testing.T) {
+	assert := assert.New(t)
+
+	area, err := proj.AreaOf("EPSG:32633")
+	assert.NoError(err)
+	assert.Equal(12.0, area.West)
+	assert.Equal(18.0, area.East)
+	assert.Equal(0.0, area.South)
+	assert.Equal(84.0, area.North)
+
+	// aliases are looked up as the code they stand for
+	area, err = proj.AreaOf("900913")
+	assert.NoError(err)
+	assert.Equal(85.06, area.North)
+
+	_, err = proj.AreaOf("+proj=utm +zone=33 +datum=WGS84")
+	assert.Equal(proj.ErrNoAreaOfUse, err)
+
+	proj.SetOfflineMode(true)
+	defer proj.SetOfflineMode(false)
+	_, err = proj.AreaOf("2154")
+	assert.Equal(proj.ErrOfflineMode, err)
+}
+
+func TestAreaContains(t *testing.T) {
+	assert := assert.New(t)
+
+	// NAD83 crosses the antimeridian
+	area, err := proj.AreaOf("4269")
+	assert.NoError(err)
+	assert.True(area.Contains(-100.0, 40.0))
+	assert.True(area.Contains(170.0, 50.0))
+	assert.False(area.Contains(10.0, 50.0))
+	assert.False(area.Contains(-100.0, 10.0))
+	assert.False(area.Contains(math.NaN(), 40.0))
+}
+
+func TestPartitionByArea(t *testing.T) {
+	assert := assert.New(t)
+
+	points := []float64{
+		15.0, 45.0, // in zone 33N
+		10.0, 45.0, // zone 32
+		15.0, -45.0, // southern hemisphere
+		12.0, 0.0, // on the corner
+		math.NaN(), 45.0,
+	}
+	inside, outside, err := proj.PartitionByArea("32633", points)
+	assert.NoError(err)
+	assert.Equal([]int{0, 3}, inside)
+	assert.Equal([]int{1, 2, 4}, outside)
+
+	inside, outside, err = proj.PartitionByArea("32633", nil)
+	assert.NoError(err)
+	assert.Empty(inside)
+	assert.Empty(outside)
+
+	_, _, err = proj.PartitionByArea("+proj=merc", points)
+	assert.Error(err)
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/oahumap/proj/support"
+)
+
+// bearingStep is the length, in meters on the ground, of the steps taken
+// to find the directions of the grid axes
+const bearingStep = 1.0
+
+// jacobian returns the derivatives of x and y with respect to distance
+// east and north on the ground, at the lon/lat point (degrees), as
+// dx/dE, dx/dN, dy/dE, dy/dN
+func (t *Transformer) jacobian(lon, lat float64) ([4]float64, error) {
+	var j [4]float64
+
+	if t.conv == nil {
+		return j, fmt.Errorf("grid bearings need a projected system")
+	}
+	if !(math.Abs(lat) < 90.0-1.0e-9) {
+		return j, fmt.Errorf("true bearings are undefined at latitude %g", lat)
+	}
+
+	e := t.conv.system.Ellipsoid
+	sinPhi, cosPhi := math.Sincos(support.DDToR(lat))
+	w := math.Sqrt(1.0 - e.Es*sinPhi*sinPhi)
+	m := e.A * (1.0 - e.Es) / (w * w * w) // meridian radius of curvature
+	n := e.A / w                          // prime vertical radius of curvature
+	dLon := support.RToDD(bearingStep / (n * cosPhi))
+	dLat := support.RToDD(bearingStep / m)
+
+	xy, err := t.conv.convert([]float64{
+		lon + dLon, lat,
+		lon - dLon, lat,
+		lon, lat + dLat,
+		lon, lat - dLat,
+	})
+	if err != nil {
+		return j, err
+	}
+
+	j[0] = (xy[0] - xy[2]) / (2.0 * bearingStep)
+	j[1] = (xy[4] - xy[6]) / (2.0 * bearingStep)
+	j[2] = (xy[1] - xy[3]) / (2.0 * bearingStep)
+	j[3] = (xy[5] - xy[7]) / (2.0 * bearingStep)
+	return j, nil
+}
+
+// GridBearing converts a bearing from true north, at the lon/lat point, to
+// a bearing from grid north (the direction of increasing y). The point
+// and the bearings are in the transformer's angular unit, and the result
+// is in [0, 360) degrees or the equivalent.
+//
+// The bearing is that of the projected direction, found from the
+// projection itself, so it is right even where the projection is not
+// conformal and the grid convergence alone isn't enough.
+func (t *Transformer) GridBearing(lon, lat, trueBearing float64) (float64, error) {
+	toDegrees := t.unit / degree
+	j, err := t.jacobian(lon*toDegrees, lat*toDegrees)
+	if err != nil {
+		return 0.0, err
+	}
+
+	sinTheta, cosTheta := math.Sincos(trueBearing * t.unit)
+	gx := j[0]*sinTheta + j[1]*cosTheta
+	gy := j[2]*sinTheta + j[3]*cosTheta
+	return t.turn(math.Atan2(gx, gy)), nil
+}
+
+// TrueBearing is the inverse of GridBearing: it converts a bearing from
+// grid north, at the lon/lat point, to a bearing from true north
+func (t *Transformer) TrueBearing(lon, lat, gridBearing float64) (float64, error) {
+	toDegrees := t.unit / degree
+	j, err := t.jacobian(lon*toDegrees, lat*toDegrees)
+	if err != nil {
+		return 0.0, err
+	}
+
+	det := j[0]*j[3] - j[1]*j[2]
+	if det == 0.0 {
+		return 0.0, fmt.Errorf("the projection is singular at (%g, %g)", lon, lat)
+	}
+
+	sinBeta, cosBeta := math.Sincos(gridBearing * t.unit)
+	east := (j[3]*sinBeta - j[1]*cosBeta) / det
+	north := (j[0]*cosBeta - j[2]*sinBeta) / det
+	return t.turn(math.Atan2(east, north)), nil
+}
+
+// Convergence returns the grid convergence at the lon/lat point: the
+// angle from true north to grid north, clockwise, in (-180, 180] degrees
+// or the equivalent. Where the projection is conformal, a grid bearing is
+// the true bearing less the convergence.
+func (t *Transformer) Convergence(lon, lat float64) (float64, error) {
+	north, err := t.GridBearing(lon, lat, 0.0)
+	if err != nil {
+		return 0.0, err
+	}
+
+	half := math.Pi / t.unit
+	if north < half {
+		return -north, nil
+	}
+	return 2.0*half - north, nil
+}
+
+// turn returns the angle, in radians, in the transformer's angular unit
+// and normalized to [0, a full turn)
+func (t *Transformer) turn(angle float64) float64 {
+	angle = math.Mod(angle, 2.0*math.Pi)
+	if angle < 0.0 {
+		angle += 2.0 * math.Pi
+	}
+	if angle >= 2.0*math.Pi {
+		angle = 0.0
+	}
+	return angle / t.unit
+}
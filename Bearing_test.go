@@ -0,0 +1,79 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBearings(t *testing.T) {
+	assert := assert.New(t)
+
+	// on a conformal cone the convergence is n times the longitude from
+	// the central meridian, and n = sin(lat_1) for a tangent cone
+	tr, err := proj.NewTransformer("+proj=lcc +R=6371000 +lat_1=45 +lat_0=45 +lon_0=0")
+	assert.NoError(err)
+	gamma, err := tr.Convergence(10.0, 45.0)
+	assert.NoError(err)
+	assert.InDelta(10.0*math.Sqrt(0.5), gamma, 1.0e-7)
+	gamma, err = tr.Convergence(-10.0, 30.0)
+	assert.NoError(err)
+	assert.InDelta(-10.0*math.Sqrt(0.5), gamma, 1.0e-7)
+
+	// and grid bearings are true bearings less the convergence, fractions
+	// of a degree and all
+	for _, trueBearing := range []float64{0.0, 0.25, 90.0, 181.5, 359.9} {
+		grid, err := tr.GridBearing(10.0, 45.0, trueBearing)
+		assert.NoError(err)
+		assert.InDelta(math.Mod(trueBearing-10.0*math.Sqrt(0.5)+360.0, 360.0), grid, 1.0e-7)
+		back, err := tr.TrueBearing(10.0, 45.0, grid)
+		assert.NoError(err)
+		assert.InDelta(trueBearing, back, 1.0e-7)
+	}
+
+	// in UTM it's about the longitude from the central meridian times the
+	// sine of the latitude
+	tr, err = proj.NewTransformer("+proj=utm +zone=32 +ellps=WGS84")
+	assert.NoError(err)
+	gamma, err = tr.Convergence(12.0, 50.0)
+	assert.NoError(err)
+	assert.InDelta(3.0*math.Sin(50.0*math.Pi/180.0), gamma, 0.01)
+
+	// where the projection isn't conformal the convergence isn't enough:
+	// at 60 degrees, eqc stretches east-west distances twice as much as
+	// north-south ones
+	tr, err = proj.NewTransformer("+proj=eqc +R=6371000")
+	assert.NoError(err)
+	grid, err := tr.GridBearing(20.0, 60.0, 45.0)
+	assert.NoError(err)
+	assert.InDelta(math.Atan2(2.0, 1.0)*180.0/math.Pi, grid, 1.0e-6)
+	back, err := tr.TrueBearing(20.0, 60.0, grid)
+	assert.NoError(err)
+	assert.InDelta(45.0, back, 1.0e-6)
+	gamma, err = tr.Convergence(20.0, 60.0)
+	assert.NoError(err)
+	assert.InDelta(0.0, gamma, 1.0e-7)
+
+	// in the transformer's angular unit
+	assert.NoError(tr.SetAngularUnit("grad"))
+	grid, err = tr.GridBearing(20.0/0.9, 60.0/0.9, 50.0)
+	assert.NoError(err)
+	assert.InDelta(math.Atan2(2.0, 1.0)*200.0/math.Pi, grid, 1.0e-6)
+
+	// there's no true north at a pole, and no grid in a geographic system
+	_, err = tr.Convergence(0.0, 100.0)
+	assert.Error(err)
+	tr, err = proj.NewTransformer("4326")
+	assert.NoError(err)
+	_, err = tr.GridBearing(0.0, 0.0, 0.0)
+	assert.Error(err)
+}
@@ -0,0 +1,262 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/support"
+)
+
+// CRS is a coordinate reference system, resolved and parsed once, from
+// which Transforms and Transformers can be made without going back to
+// its definition. A CRS is immutable, so can be shared freely.
+type CRS struct {
+	definition string              // as given to NewCRS
+	ps         *support.ProjString // the proj string it resolved to
+	geo        *geographic         // nil for projected systems
+}
+
+// NewCRS returns the CRS of the given definition: a proj string, an SRID
+// such as "3857" or "EPSG:3857", an OGC identifier such as
+// "urn:ogc:def:crs:EPSG::3857", or WKT giving the EPSG or ESRI code of the
+// CRS. Projected systems are checked by setting up their operation.
+func NewCRS(definition string) (*CRS, error) {
+	ps, err := resolveDefinition(definition)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &CRS{definition: definition, ps: ps}
+	if isGeographicSystem(ps) {
+		c.geo, err = geographicOf(ps)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+
+	if _, err := newConversion(ps.DeepCopy()); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Definition returns the definition the CRS was made from
+func (c *CRS) Definition() string {
+	return c.definition
+}
+
+// Proj4 returns the proj string the CRS resolved to
+func (c *CRS) Proj4() string {
+	return c.ps.Definition()
+}
+
+// String returns the definition the CRS was made from
+func (c *CRS) String() string {
+	return c.definition
+}
+
+// IsGeographic reports whether the CRS is a lon/lat system
+func (c *CRS) IsGeographic() bool {
+	return c.geo != nil
+}
+
+// Transformer returns a Transformer between 4326 and the CRS, as
+// NewTransformer does for its definition
+func (c *CRS) Transformer() (*Transformer, error) {
+	t, err := newTransformerOf(c.definition, c.ps.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+	d := DefaultConfig()
+	d.ValidateInput = false // the process-wide switch applies anyway
+	if err := t.apply(d); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Transform converts points from one CRS to another, through lon/lat on
+// Greenwich: each point is inverted from the source system, if it is
+// projected, and converted to the target. Geographic systems are in their
+// own angular unit and relative to their own prime meridian.
+//
+// As with InverseTo, no datum shift is applied unless SetDatumShift had
+// turned them on when the Transform was made: the points are then
+// shifted from the source datum to the target datum directly, in one
+// step through geocentric coordinates, so that points between systems on
+// the same datum are left as they are. Otherwise the two systems are
+// taken to be on the same datum.
+//
+// A Transform has conversion objects of its own, so different Transforms
+// between the same systems can be used concurrently, but, as with a
+// Transformer, a single Transform can't.
+type Transform struct {
+	src, dst *CRS
+	from, to transformSide
+}
+
+// transformSide is the system at one end of a Transform
+type transformSide struct {
+	conv  *conversion // nil for geographic systems
+	geo   *geographic // nil for projected systems
+	datum *core.Datum // nil unless datum shifts are on
+}
+
+// NewTransform returns the Transform from src to dst. The pole policy is
+// that of DefaultConfig.
+func NewTransform(src, dst *CRS) (*Transform, error) {
+	if src == nil || dst == nil {
+		return nil, fmt.Errorf("transform needs both a source and a target CRS")
+	}
+
+	t := &Transform{src: src, dst: dst}
+	var err error
+
+	t.from, err = transformEnd(src)
+	if err != nil {
+		return nil, err
+	}
+	t.to, err = transformEnd(dst)
+	if err != nil {
+		return nil, err
+	}
+	if t.from.datum != nil {
+		if _, err := core.DatumTransform3D(t.from.datum, t.to.datum, &core.CoordLPZ{}); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// transformEnd returns the side of a Transform for a CRS: the conversion
+// of a projected CRS, with the pole policy of DefaultConfig, or the
+// geographic system of a geographic one, which is shared, and its datum
+// if datum shifts are on
+func transformEnd(c *CRS) (transformSide, error) {
+	var side transformSide
+	if c.geo != nil {
+		side.geo = c.geo
+		if DatumShift() {
+			side.datum = c.geo.datum
+		}
+		return side, nil
+	}
+
+	conv, err := newConversion(c.ps.DeepCopy())
+	if err != nil {
+		return side, err
+	}
+	conv.system.PolePolicy = DefaultConfig().PolePolicy
+	side.conv = conv
+	if DatumShift() {
+		side.datum = conv.system.Datum()
+	}
+	return side, nil
+}
+
+// ConvertBetween converts points, e.g. [x0, y0, x1, y1, ...], from the
+// system src to the system dst in one call, as a Transform between them
+// would: inverting from src and converting to dst, with the points kept in
+// radians in between, rather than going through Inverse and Convert and
+// degrees. Either system may be given as for NewCRS.
+func ConvertBetween(src, dst string, input []float64) ([]float64, error) {
+	from, err := NewCRS(src)
+	if err != nil {
+		return nil, err
+	}
+	to, err := NewCRS(dst)
+	if err != nil {
+		return nil, err
+	}
+	t, err := NewTransform(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return t.Forward(input)
+}
+
+// Source returns the CRS the Transform converts from
+func (t *Transform) Source() *CRS {
+	return t.src
+}
+
+// Target returns the CRS the Transform converts to
+func (t *Transform) Target() *CRS {
+	return t.dst
+}
+
+// Forward converts points, e.g. [x0, y0, x1, y1, ...], from the source
+// CRS to the target CRS
+func (t *Transform) Forward(input []float64) ([]float64, error) {
+	return transform(t.from, t.to, input)
+}
+
+// Inverse converts points from the target CRS back to the source CRS
+func (t *Transform) Inverse(input []float64) ([]float64, error) {
+	return transform(t.to, t.from, input)
+}
+
+// transform converts points from one side of a Transform to the other
+func transform(from, to transformSide, input []float64) ([]float64, error) {
+	if len(input)%2 != 0 {
+		return nil, fmt.Errorf("input array of coordinate values must be an even number")
+	}
+	if from.geo != nil {
+		if err := checkLonLat(input, from.geo.unit, ValidateInput()); err != nil {
+			return nil, err
+		}
+	}
+
+	output := make([]float64, len(input))
+	for i := 0; i < len(input); i += 2 {
+		x, y, err := transformPoint(from, to, input[i], input[i+1])
+		if err != nil {
+			return nil, err
+		}
+		output[i], output[i+1] = x, y
+	}
+
+	return output, nil
+}
+
+// transformPoint converts one point, as per transform
+func transformPoint(from, to transformSide, x, y float64) (float64, float64, error) {
+	lp := &core.CoordLP{}
+	if from.geo != nil {
+		lp.Lam, lp.Phi = from.geo.toGreenwich(x, y)
+	} else {
+		inverted, err := from.conv.unproject(&core.CoordXY{X: x, Y: y})
+		if err != nil {
+			return 0.0, 0.0, err
+		}
+		lp.Lam, lp.Phi = inverted.Lam, inverted.Phi
+	}
+
+	// straight from one datum to the other; the points are 2D, so the
+	// height the shift gives is dropped
+	if from.datum != nil {
+		shifted, err := core.DatumTransform3D(from.datum, to.datum, &core.CoordLPZ{Lam: lp.Lam, Phi: lp.Phi})
+		if err != nil {
+			return 0.0, 0.0, err
+		}
+		lp.Lam, lp.Phi = shifted.Lam, shifted.Phi
+	}
+
+	if to.geo != nil {
+		x, y = to.geo.fromGreenwich(lp.Lam, lp.Phi)
+		return x, y, nil
+	}
+	projected, err := to.conv.project(lp)
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+	return projected.X, projected.Y, nil
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+const utm32WKT1 = `PROJCS["WGS 84 / UTM zone 32N",
+    GEOGCS["WGS 84",
+        DATUM["WGS_1984",
+            SPHEROID["WGS 84",6378137,298.257223563,AUTHORITY["EPSG","7030"]],
+            AUTHORITY["EPSG","6326"]],
+        PRIMEM["Greenwich",0,AUTHORITY["EPSG","8901"]],
+        UNIT["degree",0.0174532925199433,AUTHORITY["EPSG","9122"]],
+        AUTHORITY["EPSG","4326"]],
+    PROJECTION["Transverse_Mercator"],
+    PARAMETER["latitude_of_origin",0],
+    PARAMETER["central_meridian",9],
+    PARAMETER["scale_factor",0.9996],
+    PARAMETER["false_easting",500000],
+    PARAMETER["false_northing",0],
+    UNIT["metre",1,AUTHORITY["EPSG","9001"]],
+    AXIS["Easting",EAST],
+    AXIS["Northing",NORTH],
+    AUTHORITY["EPSG","32632"]]`
+
+const utm32WKT2 = `PROJCRS["WGS 84 / UTM zone 32N",
+    BASEGEOGCRS["WGS 84",
+        DATUM["World Geodetic System 1984",
+            ELLIPSOID["WGS 84",6378137,298.257223563]],
+        ID["EPSG",4326]],
+    CONVERSION["UTM zone 32N",
+        METHOD["Transverse Mercator",ID["EPSG",9807]]],
+    CS[Cartesian,2],
+        AXIS["(E)",east],
+        AXIS["(N)",north],
+        LENGTHUNIT["metre",1],
+    USAGE[SCOPE["Navigation and medium accuracy spatial referencing."],
+        AREA["Between 6°E and 12°E, northern hemisphere."],
+        BBOX[0,6,84,12]],
+    ID["EPSG",32632]]`
+
+func TestCRS(t *testing.T) {
+	assert := assert.New(t)
+
+	lonlat := []float64{9.0, 45.0, 10.5, 47.25}
+	expected, err := proj.Convert("32632", lonlat)
+	assert.NoError(err)
+
+	for _, def := range []string{"32632", "EPSG:32632", "urn:ogc:def:crs:EPSG::32632", utm32WKT1, utm32WKT2} {
+		crs, err := proj.NewCRS(def)
+		assert.NoError(err, def)
+		assert.False(crs.IsGeographic())
+		assert.Equal(def, crs.Definition())
+		assert.Contains(crs.Proj4(), "+zone=32")
+
+		tr, err := crs.Transformer()
+		assert.NoError(err)
+		actual, err := tr.Forward(lonlat)
+		assert.NoError(err)
+		assert.Equal(expected, actual, def)
+
+		// the string functions take WKT too
+		actual, err = proj.Convert(def, lonlat)
+		assert.NoError(err)
+		assert.Equal(expected, actual, def)
+	}
+
+	// WKT which doesn't say which CRS it is is parsed, if it's a kind of
+	// CRS we support
+	crs, err := proj.NewCRS(`GEOGCS["WGS 84",DATUM["WGS_1984",SPHEROID["WGS 84",6378137,298.257223563,AUTHORITY["EPSG","7030"]]]]`)
+	assert.NoError(err)
+	assert.True(crs.IsGeographic())
+	for srid, wkt := range map[string]string{
+		"32632": `PROJCS["WGS 84 / UTM zone 32N",GEOGCS["WGS 84",DATUM["WGS_1984",SPHEROID["WGS 84",6378137,298.257223563]],PRIMEM["Greenwich",0],UNIT["degree",0.0174532925199433]],PROJECTION["Transverse_Mercator"],PARAMETER["latitude_of_origin",0],PARAMETER["central_meridian",9],PARAMETER["scale_factor",0.9996],PARAMETER["false_easting",500000],PARAMETER["false_northing",0],UNIT["metre",1]]`,
+		"3413":  `PROJCRS["WGS 84 / NSIDC Sea Ice Polar Stereographic North",BASEGEOGCRS["WGS 84",DATUM["World Geodetic System 1984",ELLIPSOID["WGS 84",6378137,298.257223563,LENGTHUNIT["metre",1]]],PRIMEM["Greenwich",0,ANGLEUNIT["degree",0.0174532925199433]]],CONVERSION["US NSIDC Sea Ice polar stereographic north",METHOD["Polar Stereographic (variant B)",ID["EPSG",9829]],PARAMETER["Latitude of standard parallel",70,ANGLEUNIT["degree",0.0174532925199433]],PARAMETER["Longitude of origin",-45,ANGLEUNIT["degree",0.0174532925199433]],PARAMETER["False easting",0,LENGTHUNIT["metre",1]],PARAMETER["False northing",0,LENGTHUNIT["metre",1]]],CS[Cartesian,2],AXIS["easting (X)",south,MERIDIAN[45,ANGLEUNIT["degree",0.0174532925199433]],ORDER[1],LENGTHUNIT["metre",1]],AXIS["northing (Y)",south,MERIDIAN[135,ANGLEUNIT["degree",0.0174532925199433]],ORDER[2],LENGTHUNIT["metre",1]]]`,
+		"3857":  `PROJCS["WGS_1984_Web_Mercator_Auxiliary_Sphere",GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",SPHEROID["WGS_1984",6378137.0,298.257223563]],PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]],PROJECTION["Mercator_Auxiliary_Sphere"],PARAMETER["False_Easting",0.0],PARAMETER["False_Northing",0.0],PARAMETER["Central_Meridian",0.0],PARAMETER["Standard_Parallel_1",0.0],PARAMETER["Auxiliary_Sphere_Type",0.0],UNIT["Meter",1.0]]`,
+	} {
+		expected, err := proj.Convert(srid, lonlat)
+		assert.NoError(err)
+		actual, err := proj.Convert(wkt, lonlat)
+		assert.NoError(err, srid)
+		assert.InDeltaSlice(expected, actual, 1.0e-6, srid)
+	}
+	// on 3413, Greenwich is below the pole, whatever its WKT 2 axes say
+	greenwich, err := proj.Convert("3413", []float64{0.0, 75.0})
+	assert.NoError(err)
+	assert.True(greenwich[0] > 0.0 && greenwich[1] < 0.0)
+
+	_, err = proj.NewCRS(`GEOCCS["WGS 84",DATUM["WGS_1984",SPHEROID["WGS 84",6378137,298.257223563]]]`)
+	assert.Error(err)
+	_, err = proj.NewCRS("+proj=nosuch")
+	assert.Error(err)
+}
+
+func TestTransform(t *testing.T) {
+	assert := assert.New(t)
+
+	wgs84, err := proj.NewCRS("4326")
+	assert.NoError(err)
+	utm32, err := proj.NewCRS("32632")
+	assert.NoError(err)
+	web, err := proj.NewCRS("3857")
+	assert.NoError(err)
+	paris, err := proj.NewCRS("+proj=longlat +ellps=clrk80ign +pm=paris +units=grad +no_defs")
+	assert.NoError(err)
+	assert.True(paris.IsGeographic())
+
+	lonlat := []float64{9.0, 45.0, 10.5, 47.25}
+
+	// from lon/lat, as Convert
+	tr, err := proj.NewTransform(wgs84, utm32)
+	assert.NoError(err)
+	assert.Equal(wgs84, tr.Source())
+	assert.Equal(utm32, tr.Target())
+	expected, err := proj.Convert("32632", lonlat)
+	assert.NoError(err)
+	actual, err := tr.Forward(lonlat)
+	assert.NoError(err)
+	assert.InDeltaSlice(expected, actual, 1.0e-6)
+	back, err := tr.Inverse(actual)
+	assert.NoError(err)
+	assert.InDeltaSlice(lonlat, back, 1.0e-9)
+
+	// between projected systems
+	tr, err = proj.NewTransform(utm32, web)
+	assert.NoError(err)
+	xy, err := proj.Convert("32632", lonlat)
+	assert.NoError(err)
+	expected, err = proj.Convert("3857", lonlat)
+	assert.NoError(err)
+	actual, err = tr.Forward(xy)
+	assert.NoError(err)
+	assert.InDeltaSlice(expected, actual, 1.0e-6)
+	back, err = tr.Inverse(actual)
+	assert.NoError(err)
+	assert.InDeltaSlice(xy, back, 1.0e-6)
+
+	// into another unit and prime meridian
+	tr, err = proj.NewTransform(web, paris)
+	assert.NoError(err)
+	expected, err = proj.Convert(paris.Proj4(), lonlat)
+	assert.NoError(err)
+	web2, err := proj.Convert("3857", lonlat)
+	assert.NoError(err)
+	actual, err = tr.Forward(web2)
+	assert.NoError(err)
+	assert.InDeltaSlice(expected, actual, 1.0e-9)
+
+	_, err = tr.Forward([]float64{1.0})
+	assert.Error(err)
+	_, err = proj.NewTransform(nil, web)
+	assert.Error(err)
+}
+
+func TestConvertBetween(t *testing.T) {
+	assert := assert.New(t)
+
+	lonlat := []float64{9.0, 45.0, 10.5, 47.25}
+	web, err := proj.Convert("3857", lonlat)
+	assert.NoError(err)
+
+	// straight from web mercator to a UTM zone, and back
+	expected, err := proj.Convert("32632", lonlat)
+	assert.NoError(err)
+	actual, err := proj.ConvertBetween("EPSG:3857", "+proj=utm +zone=32 +datum=WGS84 +units=m +no_defs", web)
+	assert.NoError(err)
+	assert.InDeltaSlice(expected, actual, 1.0e-6)
+	back, err := proj.ConvertBetween("32632", "3857", actual)
+	assert.NoError(err)
+	assert.InDeltaSlice(web, back, 1.0e-6)
+
+	// and to lon/lat, as Inverse
+	actual, err = proj.ConvertBetween("3857", "4326", web)
+	assert.NoError(err)
+	assert.InDeltaSlice(lonlat, actual, 1.0e-9)
+
+	_, err = proj.ConvertBetween("3857", "no such system", web)
+	assert.Error(err)
+	_, err = proj.ConvertBetween("3857", "32632", []float64{1.0})
+	assert.Error(err)
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/merror"
+	"github.com/oahumap/proj/support"
+)
+
+// DefaultChunkSize is the number of points the Chunked methods convert at
+// a time, unless the transformer's Config says otherwise
+const DefaultChunkSize = 65536
+
+// ForwardChunked converts lon/lat points as per Forward, but a chunk at a
+// time, so that huge inputs need no output array as big as themselves:
+// each chunk of x/y points is passed to emit, with the index in the input
+// of its first value, in a buffer of one chunk which is reused for the
+// next. emit must copy out whatever it keeps.
+//
+// The context is checked before each chunk, and its error returned if it
+// is done. Processing stops at the first error, from a point, the
+// context or emit; the chunks before it have been emitted. Each chunk is
+// reported to the metrics function as a batch.
+func (t *Transformer) ForwardChunked(ctx context.Context, input []float64, emit func(first int, output []float64) error) error {
+	return t.chunked(ctx, "ForwardChunked", input, emit, t.forwardPoint, nil)
+}
+
+// InverseChunked converts x/y points as per Inverse, a chunk at a time,
+// as ForwardChunked does. Unlike Inverse, it stops at the first point
+// which fails to converge; Stats covers the points up to it. While
+// inverse checking is on, each chunk is checked before it is emitted, and
+// the *InverseOutlierError of the first with outliers returned; their
+// indexes are of the points in the whole input.
+func (t *Transformer) InverseChunked(ctx context.Context, input []float64, emit func(first int, output []float64) error) error {
+	t.stats = Stats{}
+	return t.chunked(ctx, "InverseChunked", input, emit, t.inversePoint, t.outliers)
+}
+
+func (t *Transformer) chunked(ctx context.Context, method string, input []float64,
+	emit func(int, []float64) error, point func(a, b float64) (float64, float64, error),
+	check func(first int, chunk, output []float64) error) error {
+
+	if len(input)%2 != 0 {
+		return fmt.Errorf("input array of coordinate values must be an even number")
+	}
+
+	size := t.chunk
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+	buffer := make([]float64, 2*min(size, len(input)/2))
+
+	for first := 0; first < len(input); first += len(buffer) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		start := time.Now()
+		chunk := input[first:min(first+len(buffer), len(input))]
+		output := buffer[:len(chunk)]
+
+		var err error
+		for i := 0; i < len(chunk); i += 2 {
+			output[i], output[i+1], err = point(chunk[i], chunk[i+1])
+			if err != nil {
+				if rangeErr, ok := err.(InputRangeError); ok {
+					rangeErr.Index += first + i
+					err = rangeErr
+				}
+				break
+			}
+		}
+		if err == nil && check != nil {
+			err = check(first, chunk, output)
+		}
+		t.observe(method, len(chunk)/2, start, 0, err)
+		if err != nil {
+			return err
+		}
+
+		if err := emit(first, output); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// outliers checks the lon/lat points of a chunk of InverseChunked as
+// Inverse does its output, first being the index in the input of the
+// chunk's first value
+func (t *Transformer) outliers(first int, chunk, output []float64) error {
+	err := findOutliers(t.definition, chunk, output, t.unit, t.checkingInverse())
+	if outlierErr, ok := err.(*InverseOutlierError); ok {
+		for i := range outlierErr.Outliers {
+			outlierErr.Outliers[i].Index += first / 2
+		}
+	}
+	return err
+}
+
+// inversePoint converts one x/y point to lon/lat, in the transformer's
+// unit, recording its convergence
+func (t *Transformer) inversePoint(x, y float64) (float64, float64, error) {
+	if t.conv == nil {
+		t.stats.Points++
+		lam, phi := t.geo.toGreenwich(x, y)
+		return lam / t.unit, phi / t.unit, nil
+	}
+
+	lp, iterations, residual, err := t.conv.unprojectWithConvergence(&core.CoordXY{X: x, Y: y})
+	var cerr merror.ConvergenceError
+	failed := errors.As(err, &cerr)
+	if err == nil || failed {
+		t.stats.add(t.conv.converter, iterations, residual, failed)
+	}
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+
+	lon, lat := support.RToDD(lp.Lam), support.RToDD(lp.Phi)
+	if t.unit != degree {
+		lon, lat = lon*degree/t.unit, lat*degree/t.unit
+	}
+	return lon, lat, nil
+}
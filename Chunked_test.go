@@ -0,0 +1,106 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunked(t *testing.T) {
+	assert := assert.New(t)
+
+	lonlat := []float64{}
+	for i := 0; i < 10; i++ {
+		lonlat = append(lonlat, 6.0+0.5*float64(i), 40.0+float64(i))
+	}
+
+	counters := &proj.Counters{}
+	tr, err := proj.NewTransformerWithConfig("32632", proj.Config{ChunkSize: 3, Metrics: counters.Observe})
+	assert.NoError(err)
+	expected, err := tr.Forward(lonlat)
+	assert.NoError(err)
+
+	// the chunks cover the input in order, in a reused buffer
+	actual := []float64{}
+	firsts := []int{}
+	var buffer *float64
+	err = tr.ForwardChunked(context.Background(), lonlat, func(first int, output []float64) error {
+		if buffer != nil {
+			assert.Equal(buffer, &output[0])
+		}
+		buffer = &output[0]
+		firsts = append(firsts, first)
+		actual = append(actual, output...)
+		return nil
+	})
+	assert.NoError(err)
+	assert.Equal([]int{0, 6, 12, 18}, firsts)
+	assert.Equal(expected, actual)
+	assert.Equal(int64(5), counters.Batches.Load())
+
+	back := []float64{}
+	err = tr.InverseChunked(context.Background(), expected, func(first int, output []float64) error {
+		back = append(back, output...)
+		return nil
+	})
+	assert.NoError(err)
+	assert.InDeltaSlice(lonlat, back, 1.0e-9)
+	assert.Equal(10, tr.Stats().Points)
+
+	// emit errors and cancellation stop it
+	stop := errors.New("stop")
+	calls := 0
+	err = tr.ForwardChunked(context.Background(), lonlat, func(int, []float64) error {
+		calls++
+		return stop
+	})
+	assert.Equal(stop, err)
+	assert.Equal(1, calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls = 0
+	err = tr.ForwardChunked(ctx, lonlat, func(int, []float64) error {
+		calls++
+		cancel()
+		return nil
+	})
+	assert.Equal(context.Canceled, err)
+	assert.Equal(1, calls)
+
+	// bad points name their index in the whole input
+	proj.SetValidateInput(true)
+	defer proj.SetValidateInput(false)
+	bad := append([]float64{}, lonlat...)
+	bad[15] = 95.0
+	err = tr.ForwardChunked(context.Background(), bad, func(int, []float64) error { return nil })
+	var rangeErr proj.InputRangeError
+	assert.True(errors.As(err, &rangeErr))
+	assert.Equal(15, rangeErr.Index)
+
+	// as do outliers, while inverse checking is on
+	over, err := proj.NewTransformerWithConfig("+proj=merc +ellps=WGS84 +over", proj.Config{ChunkSize: 2, CheckInverse: true})
+	assert.NoError(err)
+	calls = 0
+	err = over.InverseChunked(context.Background(), []float64{1.0e6, 1.0e6, 2.0e6, 1.0e6, 3.0e7, 1.0e6}, func(int, []float64) error {
+		calls++
+		return nil
+	})
+	var outlierErr *proj.InverseOutlierError
+	assert.True(errors.As(err, &outlierErr))
+	assert.Equal(2, outlierErr.Outliers[0].Index)
+	assert.Equal(1, calls)
+
+	assert.Error(tr.ForwardChunked(context.Background(), lonlat[:3], func(int, []float64) error { return nil }))
+	_, err = proj.NewTransformerWithConfig("32632", proj.Config{ChunkSize: -1})
+	assert.Error(err)
+}
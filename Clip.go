@@ -0,0 +1,331 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/oahumap/proj/support"
+)
+
+// Box is a lon/lat bounding box, for clipping. Unlike an AreaOfUse, it
+// never crosses the antimeridian: West is at most East.
+type Box struct {
+	West, South, East, North float64
+}
+
+// Contains reports whether the box contains the point, boundary included
+func (b Box) Contains(lon, lat float64) bool {
+	return lon >= b.West && lon <= b.East && lat >= b.South && lat <= b.North
+}
+
+// ClipLine clips a line of lon/lat points, laid out as for Convert, to the
+// box, by Cohen-Sutherland. Since a line may leave the box and come back,
+// the result is a list of lines, in order; it is empty if the line misses
+// the box entirely. Legs which only touch the box, at a corner, add
+// nothing.
+//
+// The clipping is done in lon/lat space, edges being straight in
+// longitude and latitude, as for a polygon on a plate carrée map.
+func ClipLine(line []float64, box Box) ([][]float64, error) {
+	err := checkClipInput(line, box)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(line) / 2
+	if n == 1 {
+		if box.Contains(line[0], line[1]) {
+			return [][]float64{{line[0], line[1]}}, nil
+		}
+		return nil, nil
+	}
+
+	var pieces [][]float64
+	open := false // whether the last piece reaches the current point
+	for i := 0; i+1 < n; i++ {
+		x0, y0, x1, y1 := line[2*i], line[2*i+1], line[2*i+2], line[2*i+3]
+		cx0, cy0, cx1, cy1, ok := box.clipSegment(x0, y0, x1, y1)
+		clipped := cx0 != x0 || cy0 != y0 || cx1 != x1 || cy1 != y1
+		if !ok || (clipped && cx0 == cx1 && cy0 == cy1) {
+			open = false
+			continue
+		}
+		if open {
+			last := len(pieces) - 1
+			pieces[last] = append(pieces[last], cx1, cy1)
+		} else {
+			pieces = append(pieces, []float64{cx0, cy0, cx1, cy1})
+		}
+		open = cx1 == x1 && cy1 == y1
+	}
+	return pieces, nil
+}
+
+// ClipPolygon clips a polygon ring of lon/lat points, laid out as for
+// Convert, to the box, by Sutherland-Hodgman. The result is a single
+// ring, closed if the input was; where the polygon is concave and leaves
+// the box more than once, its parts are joined by edges along the box's
+// boundary. It is empty if the ring misses the box entirely.
+func ClipPolygon(ring []float64, box Box) ([]float64, error) {
+	err := checkClipInput(ring, box)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(ring) / 2
+	closed := n > 1 && ring[0] == ring[2*n-2] && ring[1] == ring[2*n-1]
+	if closed {
+		n--
+	}
+	if n < 3 {
+		return nil, fmt.Errorf("ring has fewer than 3 points")
+	}
+
+	output := append([]float64{}, ring[:2*n]...)
+	for edge := 0; edge < 4 && len(output) > 0; edge++ {
+		input := output
+		output = nil
+		m := len(input) / 2
+		px, py := input[2*m-2], input[2*m-1]
+		pin := box.inside(edge, px, py)
+		for i := 0; i < m; i++ {
+			x, y := input[2*i], input[2*i+1]
+			in := box.inside(edge, x, y)
+			if in != pin {
+				ix, iy := box.intersect(edge, px, py, x, y)
+				output = append(output, ix, iy)
+			}
+			if in {
+				output = append(output, x, y)
+			}
+			px, py, pin = x, y, in
+		}
+	}
+
+	// vertices on the box's boundary come out twice
+	output = dropRepeats(output)
+	if len(output) < 6 {
+		return nil, nil
+	}
+	if closed {
+		output = append(output, output[0], output[1])
+	}
+	return output, nil
+}
+
+// ClipLineToDomain is ClipLine for the valid domain of the transformer's
+// operation, so that a line can be converted without tolerance errors
+// partway along. The points are in the transformer's lon/lat units, and
+// so are the pieces returned.
+//
+// The domain is taken about the central meridian, and the line is
+// followed across the antimeridian, so the pieces may cross it too.
+// Operations without a declared domain leave the line whole.
+func (t *Transformer) ClipLineToDomain(line []float64) ([][]float64, error) {
+	box, cm, ok := t.domainBox()
+	if !ok {
+		if len(line)%2 != 0 {
+			return nil, fmt.Errorf("input array of points must be an even number")
+		}
+		return [][]float64{append([]float64{}, line...)}, nil
+	}
+
+	pieces, err := ClipLine(t.toDomain(line, cm), box)
+	if err != nil {
+		return nil, err
+	}
+	for _, piece := range pieces {
+		t.fromDomain(piece, cm)
+	}
+	return pieces, nil
+}
+
+// ClipPolygonToDomain is ClipPolygon for the valid domain of the
+// transformer's operation, as per ClipLineToDomain
+func (t *Transformer) ClipPolygonToDomain(ring []float64) ([]float64, error) {
+	box, cm, ok := t.domainBox()
+	if !ok {
+		if len(ring)%2 != 0 {
+			return nil, fmt.Errorf("input array of points must be an even number")
+		}
+		return append([]float64{}, ring...), nil
+	}
+
+	output, err := ClipPolygon(t.toDomain(ring, cm), box)
+	if err != nil {
+		return nil, err
+	}
+	t.fromDomain(output, cm)
+	return output, nil
+}
+
+//---------------------------------------------------------------------
+
+// the Cohen-Sutherland outcodes, which are also the edges for
+// Sutherland-Hodgman
+const (
+	clipWest = 1 << iota
+	clipEast
+	clipSouth
+	clipNorth
+)
+
+// checkClipInput checks the points and the box are fit for clipping
+func checkClipInput(points []float64, box Box) error {
+	if len(points)%2 != 0 {
+		return fmt.Errorf("input array of points must be an even number")
+	}
+	if !(box.West <= box.East && box.South <= box.North) {
+		return fmt.Errorf("invalid box: %v", box)
+	}
+	for i, v := range points {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("point %d is not finite", i/2)
+		}
+	}
+	return nil
+}
+
+// dropRepeats returns the ring without the points which repeat the one
+// before, the first point following the last
+func dropRepeats(ring []float64) []float64 {
+	m := len(ring) / 2
+	var output []float64
+	for i := 0; i < m; i++ {
+		j := (i + m - 1) % m
+		if ring[2*i] != ring[2*j] || ring[2*i+1] != ring[2*j+1] {
+			output = append(output, ring[2*i], ring[2*i+1])
+		}
+	}
+	return output
+}
+
+// outcode returns the edges of the box the point lies beyond
+func (b Box) outcode(x, y float64) int {
+	code := 0
+	if x < b.West {
+		code |= clipWest
+	} else if x > b.East {
+		code |= clipEast
+	}
+	if y < b.South {
+		code |= clipSouth
+	} else if y > b.North {
+		code |= clipNorth
+	}
+	return code
+}
+
+// clipSegment returns the part of the segment inside the box, and
+// whether there is one
+func (b Box) clipSegment(x0, y0, x1, y1 float64) (float64, float64, float64, float64, bool) {
+	code0 := b.outcode(x0, y0)
+	code1 := b.outcode(x1, y1)
+	for {
+		switch {
+		case code0|code1 == 0:
+			return x0, y0, x1, y1, true
+		case code0&code1 != 0:
+			return 0, 0, 0, 0, false
+		}
+
+		// move the end outside the box onto the edge it lies beyond
+		code := code0
+		if code == 0 {
+			code = code1
+		}
+		var x, y float64
+		switch {
+		case code&clipNorth != 0:
+			x, y = x0+(x1-x0)*(b.North-y0)/(y1-y0), b.North
+		case code&clipSouth != 0:
+			x, y = x0+(x1-x0)*(b.South-y0)/(y1-y0), b.South
+		case code&clipEast != 0:
+			x, y = b.East, y0+(y1-y0)*(b.East-x0)/(x1-x0)
+		default:
+			x, y = b.West, y0+(y1-y0)*(b.West-x0)/(x1-x0)
+		}
+		if code == code0 {
+			x0, y0 = x, y
+			code0 = b.outcode(x0, y0)
+		} else {
+			x1, y1 = x, y
+			code1 = b.outcode(x1, y1)
+		}
+	}
+}
+
+// inside reports whether the point is on the inner side of the edge
+func (b Box) inside(edge int, x, y float64) bool {
+	switch 1 << uint(edge) {
+	case clipWest:
+		return x >= b.West
+	case clipEast:
+		return x <= b.East
+	case clipSouth:
+		return y >= b.South
+	}
+	return y <= b.North
+}
+
+// intersect returns where the segment from p to q meets the edge
+func (b Box) intersect(edge int, px, py, qx, qy float64) (float64, float64) {
+	switch 1 << uint(edge) {
+	case clipWest:
+		return b.West, py + (qy-py)*(b.West-px)/(qx-px)
+	case clipEast:
+		return b.East, py + (qy-py)*(b.East-px)/(qx-px)
+	case clipSouth:
+		return px + (qx-px)*(b.South-py)/(qy-py), b.South
+	}
+	return px + (qx-px)*(b.North-py)/(qy-py), b.North
+}
+
+// domainBox returns the domain of the transformer's operation, in degrees
+// with longitudes relative to the central meridian, and the central
+// meridian; it is false if there is no domain to clip to
+func (t *Transformer) domainBox() (Box, float64, bool) {
+	if t.conv == nil {
+		return Box{}, 0, false
+	}
+	domain := t.conv.system.Domain
+	if domain == nil {
+		return Box{}, 0, false
+	}
+	cm := support.RToDD(t.conv.system.Lam0 + t.conv.system.FromGreenwich)
+	return Box{West: domain.MinLam, South: domain.MinPhi, East: domain.MaxLam, North: domain.MaxPhi}, cm, true
+}
+
+// toDomain returns the points in degrees, with longitudes relative to the
+// central meridian: the first in [-180, 180], and each of the others
+// within 180 degrees of the one before, so that the geometry stays in one
+// piece across the antimeridian
+func (t *Transformer) toDomain(points []float64, cm float64) []float64 {
+	output := make([]float64, len(points))
+	for i := 0; i+1 < len(points); i += 2 {
+		lon := support.RToDD(points[i]*t.unit) - cm
+		if i == 0 {
+			output[i] = math.Remainder(lon, 360.0)
+		} else {
+			output[i] = output[i-2] + math.Remainder(lon-output[i-2], 360.0)
+		}
+		output[i+1] = support.RToDD(points[i+1] * t.unit)
+	}
+	return output
+}
+
+// fromDomain is the inverse of toDomain, in place, with longitudes in
+// [-180, 180]
+func (t *Transformer) fromDomain(points []float64, cm float64) {
+	for i := 0; i+1 < len(points); i += 2 {
+		points[i] = support.DDToR(math.Remainder(points[i]+cm, 360.0)) / t.unit
+		points[i+1] = support.DDToR(points[i+1]) / t.unit
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClipLine(t *testing.T) {
+	assert := assert.New(t)
+
+	box := proj.Box{West: 0.0, South: 0.0, East: 10.0, North: 10.0}
+
+	// in and out and in again, with a leg which only touches the corner
+	pieces, err := proj.ClipLine([]float64{
+		-5.0, 5.0,
+		5.0, 5.0,
+		5.0, 15.0,
+		15.0, 5.0,
+		5.0, 5.0,
+		5.0, 2.0,
+	}, box)
+	assert.NoError(err)
+	assert.Equal([][]float64{
+		{0.0, 5.0, 5.0, 5.0, 5.0, 10.0},
+		{10.0, 5.0, 5.0, 5.0, 5.0, 2.0},
+	}, pieces)
+
+	// wholly inside, and wholly outside
+	pieces, err = proj.ClipLine([]float64{1.0, 1.0, 2.0, 2.0, 3.0, 1.0}, box)
+	assert.NoError(err)
+	assert.Equal([][]float64{{1.0, 1.0, 2.0, 2.0, 3.0, 1.0}}, pieces)
+	pieces, err = proj.ClipLine([]float64{-1.0, 11.0, 11.0, 11.0}, box)
+	assert.NoError(err)
+	assert.Empty(pieces)
+	pieces, err = proj.ClipLine([]float64{3.0, 3.0}, box)
+	assert.NoError(err)
+	assert.Equal([][]float64{{3.0, 3.0}}, pieces)
+
+	// a leg which ends on the corner, and comes back in from there
+	pieces, err = proj.ClipLine([]float64{15.0, 5.0, 10.0, 10.0, 5.0, 5.0}, box)
+	assert.NoError(err)
+	assert.Equal([][]float64{{10.0, 10.0, 5.0, 5.0}}, pieces)
+
+	_, err = proj.ClipLine([]float64{1.0, 1.0, 2.0}, box)
+	assert.Error(err)
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err = proj.ClipLine([]float64{1.0, 1.0, 2.0, v}, box)
+		assert.Error(err)
+		_, err = proj.ClipPolygon([]float64{1.0, 1.0, 2.0, 1.0, v, 2.0}, box)
+		assert.Error(err)
+	}
+	_, err = proj.ClipLine([]float64{1.0, 1.0}, proj.Box{West: 10.0, East: 0.0})
+	assert.Error(err)
+}
+
+func TestClipPolygon(t *testing.T) {
+	assert := assert.New(t)
+
+	box := proj.Box{West: 0.0, South: 0.0, East: 10.0, North: 10.0}
+
+	// a diamond sticking out of the east side of the box
+	ring, err := proj.ClipPolygon([]float64{5.0, 5.0, 10.0, 0.0, 15.0, 5.0, 10.0, 10.0, 5.0, 5.0}, box)
+	assert.NoError(err)
+	assert.Equal([]float64{5.0, 5.0, 10.0, 0.0, 10.0, 10.0, 5.0, 5.0}, ring)
+
+	// a ring around the box is cut down to it, and it is left open if it
+	// was given open
+	ring, err = proj.ClipPolygon([]float64{-5.0, -5.0, 15.0, -5.0, 15.0, 15.0, -5.0, 15.0}, box)
+	assert.NoError(err)
+	assert.Equal([]float64{0.0, 10.0, 0.0, 0.0, 10.0, 0.0, 10.0, 10.0}, ring)
+
+	ring, err = proj.ClipPolygon([]float64{20.0, 20.0, 30.0, 20.0, 30.0, 30.0}, box)
+	assert.NoError(err)
+	assert.Empty(ring)
+
+	_, err = proj.ClipPolygon([]float64{1.0, 1.0, 2.0, 2.0, 1.0, 1.0}, box)
+	assert.Error(err)
+}
+
+func TestClipToDomain(t *testing.T) {
+	assert := assert.New(t)
+
+	// tmerc is good for 45 degrees either side of its central meridian,
+	// here across the antimeridian
+	tr, err := proj.NewTransformer("+proj=tmerc +lon_0=170 +ellps=GRS80")
+	assert.NoError(err)
+
+	line := []float64{100.0, 10.0, 160.0, 10.0, -150.0, 10.0, -100.0, 10.0}
+	_, status, err := tr.ForwardWithStatus(line)
+	assert.NoError(err)
+	assert.Equal(proj.StatusOutsideDomain, status[0])
+
+	pieces, err := tr.ClipLineToDomain(line)
+	assert.NoError(err)
+	assert.Len(pieces, 1)
+	assert.InDeltaSlice([]float64{125.0, 10.0, 160.0, 10.0, -150.0, 10.0, -145.0, 10.0}, pieces[0], 1.0e-9)
+	_, status, err = tr.ForwardWithStatus(pieces[0])
+	assert.NoError(err)
+	for _, s := range status {
+		assert.Equal(proj.StatusOK, s)
+	}
+
+	ring, err := tr.ClipPolygonToDomain([]float64{100.0, 0.0, -100.0, 0.0, -100.0, 20.0, 100.0, 20.0, 100.0, 0.0})
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{125.0, 0.0, -145.0, 0.0, -145.0, 20.0, 125.0, 20.0, 125.0, 0.0}, ring, 1.0e-9)
+
+	// no domain, no clipping
+	tr, err = proj.NewTransformer("4326")
+	assert.NoError(err)
+	pieces, err = tr.ClipLineToDomain(line)
+	assert.NoError(err)
+	assert.Equal([][]float64{line}, pieces)
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/oahumap/proj/support"
+)
+
+// Config bundles the options of the package, so that they can be set
+// together: for the whole process with SetDefaultConfig, or for one
+// transformer with NewTransformerWithConfig.
+//
+// The zero Config is the package's own default.
+type Config struct {
+	// process-wide only; NewTransformerWithConfig ignores these
+	Offline            bool // see SetOfflineMode
+	RedirectSuperseded bool // see SetRedirectSuperseded
+	HighPrecision      bool // see SetHighPrecision
+
+	// process-wide, or per transformer; see SetValidateInput,
+	// SetCheckInverse and SetDatumShift
+	ValidateInput bool
+	CheckInverse  bool
+	DatumShift    bool
+
+	// per transformer; SetDefaultConfig sets them for NewTransformer
+	PolePolicy  PolePolicy  // see Transformer.SetPolePolicy
+	AngularUnit string      // see Transformer.SetAngularUnit; "" is "deg"
+	Workers     int         // for the streams, when given none
+	ChunkSize   int         // points per chunk for the Chunked methods; 0 is DefaultChunkSize
+	Metrics     func(Batch) // see Transformer.SetMetrics; for logging, say
+	Approximate bool        // see Transformer.SetApproximate; ignored where there's no fast path
+}
+
+// transformerDefaults holds the per-transformer fields of the default
+// Config; the process-wide ones live in their own switches
+var transformerDefaults atomic.Pointer[Config]
+
+// DefaultConfig returns the process-wide Config, as last set by
+// SetDefaultConfig and the individual Set functions
+func DefaultConfig() Config {
+	var c Config
+	if d := transformerDefaults.Load(); d != nil {
+		c = *d
+	}
+	c.Offline = OfflineMode()
+	c.RedirectSuperseded = RedirectSuperseded()
+	c.HighPrecision = HighPrecision()
+	c.ValidateInput = ValidateInput()
+	c.CheckInverse = CheckInverse()
+	c.DatumShift = DatumShift()
+	return c
+}
+
+// SetDefaultConfig sets the process-wide Config: it calls
+// SetOfflineMode, SetRedirectSuperseded, SetHighPrecision,
+// SetValidateInput, SetCheckInverse and SetDatumShift, and transformers
+// made by NewTransformer from then on get the rest of it.
+// Nothing is changed if the Config isn't valid.
+func SetDefaultConfig(c Config) error {
+	if err := c.check(); err != nil {
+		return err
+	}
+	SetOfflineMode(c.Offline)
+	SetRedirectSuperseded(c.RedirectSuperseded)
+	SetHighPrecision(c.HighPrecision)
+	SetValidateInput(c.ValidateInput)
+	SetCheckInverse(c.CheckInverse)
+	SetDatumShift(c.DatumShift)
+	transformerDefaults.Store(&c)
+	return nil
+}
+
+// NewTransformerWithConfig is NewTransformer, with the per-transformer
+// options taken from c rather than the default Config. ValidateInput
+// turns validation on for this transformer even while it is off for the
+// process, and CheckInverse does the same for checking its inverses;
+// DatumShift turns datum shifts on or off for it, whatever the
+// process does.
+func NewTransformerWithConfig(proj4 string, c Config) (*Transformer, error) {
+	if err := c.check(); err != nil {
+		return nil, err
+	}
+	t, err := newTransformer(proj4)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.apply(c); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// check returns an error for options which can't be applied
+func (c *Config) check() error {
+	if c.AngularUnit != "" {
+		if _, ok := support.AngularUnitsTable[c.AngularUnit]; !ok {
+			return fmt.Errorf("unknown angular unit: %s", c.AngularUnit)
+		}
+	}
+	if c.ChunkSize < 0 {
+		return fmt.Errorf("invalid chunk size: %d", c.ChunkSize)
+	}
+	switch c.PolePolicy {
+	case PoleError, PoleInfinity, PoleClamp:
+	default:
+		return fmt.Errorf("unknown pole policy: %d", c.PolePolicy)
+	}
+	return nil
+}
+
+// apply sets the per-transformer options of a checked Config; it fails
+// if datum shifts can't be applied
+func (t *Transformer) apply(c Config) error {
+	if c.AngularUnit != "" {
+		_ = t.SetAngularUnit(c.AngularUnit)
+	}
+	t.SetPolePolicy(c.PolePolicy)
+	t.SetMetrics(c.Metrics)
+	t.validate = c.ValidateInput
+	t.checkInverse = c.CheckInverse
+	t.workers = c.Workers
+	t.chunk = c.ChunkSize
+	_ = t.SetApproximate(c.Approximate)
+	return t.SetDatumShift(c.DatumShift)
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	// the zero Config is the default, and DefaultConfig follows the
+	// individual switches
+	defer func() { assert.NoError(proj.SetDefaultConfig(proj.Config{})) }()
+	assert.Equal(proj.Config{}, proj.DefaultConfig())
+	proj.SetOfflineMode(true)
+	assert.True(proj.DefaultConfig().Offline)
+	proj.SetOfflineMode(false)
+
+	// per transformer
+	counters := &proj.Counters{}
+	tr, err := proj.NewTransformerWithConfig("3395", proj.Config{
+		ValidateInput: true,
+		PolePolicy:    proj.PoleInfinity,
+		AngularUnit:   "rad",
+		Metrics:       counters.Observe,
+	})
+	assert.NoError(err)
+	xy, err := tr.Forward([]float64{0.1, math.Pi / 2.0})
+	assert.NoError(err)
+	assert.True(math.IsInf(xy[1], 1))
+	_, err = tr.Forward([]float64{0.1, 2.0})
+	assert.Error(err)
+	assert.Contains(counters.String(), `"batches": 2`)
+	assert.False(proj.ValidateInput())
+
+	// which doesn't change the process
+	tr, err = proj.NewTransformer("3395")
+	assert.NoError(err)
+	_, err = tr.Forward([]float64{0.0, 90.0})
+	assert.Error(err)
+	_, err = tr.Forward([]float64{190.0, 0.0})
+	assert.NoError(err)
+
+	// process-wide
+	assert.NoError(proj.SetDefaultConfig(proj.Config{
+		RedirectSuperseded: true,
+		ValidateInput:      true,
+		PolePolicy:         proj.PoleClamp,
+	}))
+	assert.True(proj.RedirectSuperseded())
+	assert.True(proj.ValidateInput())
+	assert.Equal(proj.PoleClamp, proj.DefaultConfig().PolePolicy)
+	tr, err = proj.NewTransformer("3395")
+	assert.NoError(err)
+	_, err = tr.Forward([]float64{0.0, 90.0})
+	assert.NoError(err)
+	_, err = tr.Forward([]float64{190.0, 0.0})
+	assert.Error(err)
+
+	// bad options are rejected, and change nothing
+	assert.Error(proj.SetDefaultConfig(proj.Config{AngularUnit: "furlong", ValidateInput: false}))
+	assert.True(proj.ValidateInput())
+	_, err = proj.NewTransformerWithConfig("3395", proj.Config{PolePolicy: 7})
+	assert.Error(err)
+}
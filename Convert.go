@@ -9,11 +9,14 @@ package proj
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/merror"
 	"github.com/oahumap/proj/support"
 
 	// need to pull in the operations table entries
@@ -45,20 +48,23 @@ const (
 // The returned output is a similar array of x/y points, e.g. [x0, y0, x1,
 // y1, x2, y2, ...].
 // If the proj4 string represents WGS84 or a geographic coordinate system,
-// returns the input coordinates unchanged.
+// returns the input coordinates unchanged, unless the system's angular
+// unit is not degrees (e.g. "+proj=longlat +units=grad") or its prime
+// meridian is not Greenwich, in which case they are converted to that
+// unit and made relative to that meridian.
+//
+// Instead of a proj4 string, an SRID such as "3857" or "EPSG:3857" may be
+// given; it is resolved using FromSRID.
+//
+// The systems of the most recently used definitions are kept set up, as
+// Converters, so that converting small batches to the same few systems
+// over and over doesn't set them up each time.
 func Convert(proj4 string, input []float64) ([]float64, error) {
-	if isGeographicSystem(proj4) {
-		result := make([]float64, len(input))
-		copy(result, input)
-		return result, nil
-	}
-
-	conv, err := newConversion(proj4)
+	c, err := converterFor(proj4)
 	if err != nil {
 		return nil, err
 	}
-
-	return conv.convert(input)
+	return c.Forward(input)
 }
 
 // Inverse converts from a projected X/Y of a coordinate system to
@@ -70,79 +76,273 @@ func Convert(proj4 string, input []float64) ([]float64, error) {
 //
 // The returned output is a similar array of lon/lat points, e.g. [lon0, lat0, lon1,
 // lat1, lon2, lat2, ...].
+//
+// As with Convert, an SRID may be given instead of a proj4 string, and
+// geographic systems are converted from their angular unit and prime
+// meridian to degrees on Greenwich.
 func Inverse(proj4 string, input []float64) ([]float64, error) {
-	conv, err := newConversion(proj4)
+	c, err := converterFor(proj4)
+	if err != nil {
+		return nil, err
+	}
+	return c.Inverse(input)
+}
+
+// OutOfDomain reports which of the lon/lat input points lie outside the
+// valid domain of the projection, such as points more than 45 degrees from
+// the central meridian of a transverse Mercator (90 degrees with +exact).
+//
+// Only the transverse Mercators, tmerc, etmerc and utm, declare a domain
+// so far; the other projections accept every point, even where they give
+// nonsense, so for them nothing is ever out of the domain.
+//
+// The input is laid out as for Convert. The returned values are point
+// indices, not array indices.
+func OutOfDomain(proj4 string, input []float64) ([]int, error) {
+	c, err := converterFor(proj4)
 	if err != nil {
 		return nil, err
 	}
+	if c.geo != nil {
+		return []int{}, nil
+	}
 
-	return conv.inverse(input)
+	conv, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+	defer c.pool.Put(conv)
+	return conv.outOfDomain(input)
 }
 
+// ConvertClipped is like Convert, except that points outside the valid
+// domain of the projection are dropped before the conversion rather than
+// being turned into garbage coordinates.
+//
+// The indices of the dropped points are returned along with the converted
+// points, as per OutOfDomain.
+func ConvertClipped(proj4 string, input []float64) ([]float64, []int, error) {
+	c, err := converterFor(proj4)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.geo != nil {
+		output, err := c.Forward(input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return output, []int{}, nil
+	}
+	if err := checkLonLat(input, degree, ValidateInput()); err != nil {
+		return nil, nil, err
+	}
+
+	conv, err := c.get()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer c.pool.Put(conv)
+
+	dropped, err := conv.outOfDomain(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kept := make([]float64, 0, len(input)-2*len(dropped))
+	j := 0
+	for i := 0; i < len(input)/2; i++ {
+		if j < len(dropped) && dropped[j] == i {
+			j++
+			continue
+		}
+		kept = append(kept, input[2*i], input[2*i+1])
+	}
+
+	output, err := conv.convert(kept)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return output, dropped, nil
+}
+
+// Projection is the information about an EPSG code returned by
+// GetInfoFromEPSG. Besides the raw definitions, the metadata callers
+// usually want to display is parsed out of them; fields the EPSG
+// metadata says nothing about are left empty.
 type Projection struct {
 	Code    string
 	Name    string
 	Proj4   string
 	OGCWKT  string
 	ESRIWKT string
+
+	Type              string     // e.g. "ProjectedCRS" or "GeographicCRS"
+	Datum             string     // e.g. "North American Datum 1983"
+	Ellipsoid         string     // e.g. "GRS 1980"
+	SemiMajorAxis     float64    // meters
+	InverseFlattening float64    // zero for a sphere
+	Unit              string     // of the first axis, e.g. "US survey foot"
+	UnitFactor        float64    // size of the unit in meters, or radians for angular units
+	Area              *AreaOfUse // nil if unknown
+	Deprecated        bool
+	SupersededBy      string // the code replacing this one, if known
+	RedirectedFrom    string // the superseded code asked for, if redirected
 }
 
 // GetInfoFromEPSG retrieves the info for a given EPSG code from epsg.io.
 // It validates also if the proj4 string is supported by the library.
+//
+// If the proj4 string doesn't give the linear unit of the system, or
+// gives it as a +to_meter factor, it is set to the unit the EPSG metadata
+// gives, so that systems in e.g. US survey feet convert to feet.
+//
+// The code may be given with an "EPSG:" or "ESRI:" prefix. Aliases, such
+// as 900913 for 3857, are looked up as the code they stand for, which is
+// recorded in RedirectedFrom. A deprecated code, such as 3785, is looked
+// up as given, with its replacement recorded in SupersededBy; if epsg.io
+// doesn't know it either, a SupersededError is returned. If
+// SetRedirectSuperseded is on, the replacement is looked up instead.
+//
+// Only the proj4 string is required. If any of the other formats can't
+// be fetched (or, for the JSON, parsed), the Projection is returned with
+// the fields it could fill in, together with a *MultiError saying what
+// was missing; without the JSON, there is no metadata and the unit is
+// left as the proj4 string gives it.
+//
+// epsg.io is reached as set by SetEPSGResolver. In offline mode, it fails
+// with ErrOfflineMode.
 func GetInfoFromEPSG(epsg string) (*Projection, error) {
-	proj4Str, err := getFromEPSGAPI(epsg, "proj4")
-	if err != nil {
-		return nil, err
+	requested := epsg
+	srid, ok := parseSRID(epsg)
+	if ok {
+		epsg = strconv.Itoa(srid)
 	}
-	ps, err := support.NewProjString(proj4Str)
-	if err != nil {
-		return nil, err
+	entry, superseded := support.SupersededTable[srid]
+	redirected := superseded && (entry.Alias || RedirectSuperseded())
+	if redirected {
+		epsg = strconv.Itoa(entry.ReplacedBy)
 	}
 
-	ogcWKT, err := getFromEPSGAPI(epsg, "prettywkt")
+	proj4Str, err := getFromEPSGAPI(epsg, "proj4")
 	if err != nil {
+		if superseded && !redirected && !errors.Is(err, ErrOfflineMode) {
+			return nil, SupersededError{SRID: srid, ReplacedBy: entry.ReplacedBy}
+		}
 		return nil, err
 	}
-	esriWKT, err := getFromEPSGAPI(epsg, "esriwkt")
+	_, err = support.NewProjString(proj4Str)
 	if err != nil {
 		return nil, err
 	}
-	jsonStr, err := getFromEPSGAPI(epsg, "json")
-	if err != nil {
-		return nil, err
+
+	// the other formats are optional: what can't be had is left empty,
+	// and reported alongside the rest
+	var failures []error
+	optional := func(what string) string {
+		str, err := getFromEPSGAPI(epsg, what)
+		if err != nil {
+			failures = append(failures, &FormatError{Format: what, Err: err})
+		}
+		return str
 	}
+	ogcWKT := optional("prettywkt")
+	esriWKT := optional("esriwkt")
+	jsonStr := optional("json")
 
 	// Parse the JSON string into a map
 	var jsonData map[string]any
-	err = json.Unmarshal([]byte(jsonStr), &jsonData)
-	if err != nil {
-		return nil, err
+	if jsonStr != "" {
+		err = json.Unmarshal([]byte(jsonStr), &jsonData)
+		if err != nil {
+			failures = append(failures, &FormatError{Format: "json", Err: err})
+			jsonData = nil
+		}
 	}
 
-	_, _, err = core.NewSystem(ps)
+	if jsonData != nil {
+		proj4Str, err = applyEPSGUnit(proj4Str, jsonData)
+		if err != nil {
+			return nil, err
+		}
+	}
+	ps, err := support.NewProjString(proj4Str)
 	if err != nil {
 		return nil, err
 	}
 
-	// Extract name from JSON data
-	name := ""
-	if nameValue, ok := jsonData["name"]; ok {
-		if nameStr, ok := nameValue.(string); ok {
-			name = nameStr
-		}
+	if isGeographicSystem(ps) {
+		_, err = geographicUnit(ps)
+	} else {
+		_, _, err = core.NewSystem(ps)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return &Projection{
+	p := &Projection{
 		Code:    epsg,
-		Name:    name,
 		Proj4:   proj4Str,
 		OGCWKT:  ogcWKT,
 		ESRIWKT: esriWKT,
-	}, nil
+	}
+	if jsonData != nil {
+		p.setMetadata(jsonData)
+	}
+
+	if redirected {
+		p.RedirectedFrom = requested
+	} else if superseded {
+		p.SupersededBy = strconv.Itoa(entry.ReplacedBy)
+		p.Deprecated = true
+	}
+
+	if len(failures) > 0 {
+		return p, &MultiError{Errors: failures}
+	}
+	return p, nil
+}
+
+// FormatError is a failure to fetch, or to parse, one of the formats of
+// an EPSG code's definition
+type FormatError struct {
+	Format string // as epsg.io names it: "prettywkt", "esriwkt" or "json"
+	Err    error
+}
+
+func (e *FormatError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Format, e.Err)
+}
+
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError is returned by GetInfoFromEPSG, along with the Projection,
+// when some of the optional formats couldn't be had; each of its Errors
+// is a *FormatError
+type MultiError struct {
+	Errors []error
+}
+
+func (e *MultiError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *MultiError) Unwrap() []error {
+	return e.Errors
 }
 
 func getFromEPSGAPI(epsg, what string) (string, error) {
-	resp, err := http.Get(fmt.Sprintf("https://epsg.io/%s.%s", epsg, what))
+	if OfflineMode() {
+		return "", ErrOfflineMode
+	}
+
+	resp, err := currentEPSGResolver.Load().get(epsg + "." + what)
 	if err != nil {
 		return "", err
 	}
@@ -157,12 +357,8 @@ func getFromEPSGAPI(epsg, what string) (string, error) {
 	return string(str), nil
 }
 
-// isGeographicSystem checks if a proj4 string represents a geographic coordinate system
-func isGeographicSystem(proj4 string) bool {
-	ps, err := support.NewProjString(proj4)
-	if err != nil {
-		return false
-	}
+// isGeographicSystem checks if a proj string represents a geographic coordinate system
+func isGeographicSystem(ps *support.ProjString) bool {
 	proj, _ := ps.GetAsString("proj")
 	return proj == "longlat" || proj == "latlong" || proj == "latlon" || proj == "lonlat"
 }
@@ -175,15 +371,17 @@ type conversion struct {
 	system     *core.System
 	operation  core.IOperation
 	converter  core.IConvertLPToXY
+	geographic *geographic // the system inverse converts to; nil for 4326
+
+	datum *core.Datum // of the system
+	hub   *core.Datum // the datum lon/lat points are shifted from, if any
 }
 
 // newConversion creates a conversion object for the destination systems.
-func newConversion(proj4 string) (*conversion, error) {
-	ps, err := support.NewProjString(proj4)
-	if err != nil {
-		return nil, err
-	}
-
+//
+// The ProjString becomes part of the conversion's system, and may be
+// modified.
+func newConversion(ps *support.ProjString) (*conversion, error) {
 	sys, opx, err := core.NewSystem(ps)
 	if err != nil {
 		return nil, err
@@ -205,35 +403,81 @@ func newConversion(proj4 string) (*conversion, error) {
 
 // convert performs the projection on the given input points
 func (conv *conversion) convert(input []float64) ([]float64, error) {
+	output := make([]float64, len(input))
+
+	err := conv.forEach(input, func(i int, x, y float64) error {
+		output[i] = x
+		output[i+1] = y
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// forEach projects the given input points, passing each result, and its
+// index in the input, to emit
+func (conv *conversion) forEach(input []float64, emit func(i int, x, y float64) error) error {
 	if conv == nil || conv.converter == nil {
-		return nil, fmt.Errorf("conversion not initialized")
+		return fmt.Errorf("conversion not initialized")
 	}
 
 	if len(input)%2 != 0 {
-		return nil, fmt.Errorf("input array of lon/lat values must be an even number")
+		return fmt.Errorf("input array of lon/lat values must be an even number")
 	}
 
-	output := make([]float64, len(input))
-
 	lp := &core.CoordLP{}
 
 	for i := 0; i < len(input); i += 2 {
 		lp.Lam = support.DDToR(input[i])
 		lp.Phi = support.DDToR(input[i+1])
 
-		xy, err := conv.converter.Forward(lp)
+		xy, err := conv.project(lp)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		output[i] = xy.X
-		output[i+1] = xy.Y
+		err = emit(i, xy.X, xy.Y)
+		if err != nil {
+			return err
+		}
 	}
 
-	return output, nil
+	return nil
+}
+
+// outOfDomain returns the indices of the lon/lat points the operation
+// can't sensibly convert
+func (conv *conversion) outOfDomain(input []float64) ([]int, error) {
+	if len(input)%2 != 0 {
+		return nil, fmt.Errorf("input array of lon/lat values must be an even number")
+	}
+
+	indices := []int{}
+
+	lp := &core.CoordLP{}
+
+	for i := 0; i < len(input); i += 2 {
+		lp.Lam = support.DDToR(input[i])
+		lp.Phi = support.DDToR(input[i+1])
+
+		if !conv.system.InDomain(lp) {
+			indices = append(indices, i/2)
+		}
+	}
+
+	return indices, nil
 }
 
-func (conv *conversion) inverse(input []float64) ([]float64, error) {
+// inverse performs the inverse projection on the given input points,
+// giving lon/lat points in the conversion's geographic system
+//
+// If stats is non-nil, it is filled in with the convergence diagnostics of
+// the batch; in that case the whole batch is run even if some points fail
+// to converge, and the first such failure is returned afterwards.
+func (conv *conversion) inverse(input []float64, stats *Stats) ([]float64, error) {
 	if conv == nil || conv.converter == nil {
 		return nil, fmt.Errorf("conversion not initialized")
 	}
@@ -246,11 +490,27 @@ func (conv *conversion) inverse(input []float64) ([]float64, error) {
 
 	xy := &core.CoordXY{}
 
+	var convergenceErr error
+
 	for i := 0; i < len(input); i += 2 {
 		xy.X = input[i]
 		xy.Y = input[i+1]
 
-		lp, err := conv.converter.Inverse(xy)
+		lp, iterations, residual, err := conv.unprojectWithConvergence(xy)
+
+		if stats != nil {
+			var cerr merror.ConvergenceError
+			isConvergenceErr := errors.As(err, &cerr)
+			if err == nil || isConvergenceErr {
+				stats.add(conv.converter, iterations, residual, isConvergenceErr)
+			}
+			if isConvergenceErr {
+				if convergenceErr == nil {
+					convergenceErr = err
+				}
+				continue
+			}
+		}
 
 		if err != nil {
 			return nil, err
@@ -258,9 +518,17 @@ func (conv *conversion) inverse(input []float64) ([]float64, error) {
 
 		l, p := lp.Lam, lp.Phi
 
+		if conv.geographic != nil {
+			output[i], output[i+1] = conv.geographic.fromGreenwich(l, p)
+			continue
+		}
 		output[i] = support.RToDD(l)
 		output[i+1] = support.RToDD(p)
 	}
 
+	if convergenceErr != nil {
+		return nil, convergenceErr
+	}
+
 	return output, nil
 }
@@ -0,0 +1,244 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/support"
+)
+
+// Convert3D converts lon/lat/height points to the system, as Convert
+// does lon/lat points. The input is triples of degrees and meters above
+// the ellipsoid, and so is the output for a geographic system; for a
+// projected system it is triples of x, y and the height in the system's
+// +vunits, plus its +z_0, as for x_0 and y_0; for a geocentric
+// (+proj=geocent) system it is the earth-centered, earth-fixed X, Y and
+// Z, in its +units.
+//
+// The heights matter when datum shifts are on: the points are shifted
+// from WGS84 to the datum of the system in 3D, so that a 7-parameter
+// shift moves them as it should, and their heights come out above the
+// system's ellipsoid.
+func Convert3D(proj4 string, input []float64) ([]float64, error) {
+	c, err := converterFor(proj4)
+	if err != nil {
+		return nil, err
+	}
+	return c.Forward3D(input)
+}
+
+// Inverse3D converts points of the system to lon/lat/height points, as
+// Inverse does to lon/lat points; it is the inverse of Convert3D.
+func Inverse3D(proj4 string, input []float64) ([]float64, error) {
+	c, err := converterFor(proj4)
+	if err != nil {
+		return nil, err
+	}
+	return c.Inverse3D(input)
+}
+
+// Forward3D converts lon/lat/height points to the system, as Convert3D
+// does
+func (c *Converter) Forward3D(input []float64) ([]float64, error) {
+	if len(input)%3 != 0 {
+		return nil, fmt.Errorf("input array of lon/lat/height values must be a multiple of three")
+	}
+	if err := checkLonLatH(input, ValidateInput()); err != nil {
+		return nil, err
+	}
+
+	hub := processHub()
+	output := make([]float64, len(input))
+
+	switch {
+	case c.geocent != nil:
+		for i := 0; i < len(input); i += 3 {
+			lpz, err := shift3D(hub, c.geocent.datum, pointLPZ(input, i))
+			if err != nil {
+				return nil, err
+			}
+			output[i], output[i+1], output[i+2] = c.geocent.toXYZ(lpz)
+		}
+
+	case c.geo != nil:
+		for i := 0; i < len(input); i += 3 {
+			lpz, err := shift3D(hub, c.geo.datum, pointLPZ(input, i))
+			if err != nil {
+				return nil, err
+			}
+			output[i] = (lpz.Lam - c.geo.pm) / c.geo.unit
+			output[i+1] = lpz.Phi / c.geo.unit
+			output[i+2] = lpz.Z
+		}
+
+	default:
+		conv, err := c.get()
+		if err != nil {
+			return nil, err
+		}
+		defer c.pool.Put(conv)
+		for i := 0; i < len(input); i += 3 {
+			xyz, err := conv.project3D(pointLPZ(input, i))
+			if err != nil {
+				return nil, err
+			}
+			output[i], output[i+1], output[i+2] = xyz.X, xyz.Y, xyz.Z
+		}
+	}
+
+	return output, nil
+}
+
+// Inverse3D converts points of the system to lon/lat/height points, as
+// Inverse3D does
+func (c *Converter) Inverse3D(input []float64) ([]float64, error) {
+	if len(input)%3 != 0 {
+		return nil, fmt.Errorf("input array of x/y/z values must be a multiple of three")
+	}
+
+	hub := processHub()
+	output := make([]float64, len(input))
+	var lpz *core.CoordLPZ
+	var err error
+
+	var conv *conversion
+	if c.geocent == nil && c.geo == nil {
+		conv, err = c.get()
+		if err != nil {
+			return nil, err
+		}
+		defer c.pool.Put(conv)
+	}
+
+	for i := 0; i < len(input); i += 3 {
+		switch {
+		case c.geocent != nil:
+			lpz, err = shift3D(c.geocent.datum, hub, c.geocent.fromXYZ(input[i], input[i+1], input[i+2]))
+		case c.geo != nil:
+			lam, phi := input[i]*c.geo.unit+c.geo.pm, input[i+1]*c.geo.unit
+			lpz, err = shift3D(c.geo.datum, hub, &core.CoordLPZ{Lam: lam, Phi: phi, Z: input[i+2]})
+		default:
+			lpz, err = conv.unproject3D(&core.CoordXYZ{X: input[i], Y: input[i+1], Z: input[i+2]})
+		}
+		if err != nil {
+			return nil, err
+		}
+		output[i], output[i+1], output[i+2] = support.RToDD(lpz.Lam), support.RToDD(lpz.Phi), lpz.Z
+	}
+
+	return output, nil
+}
+
+// pointLPZ returns the lon/lat/height point at input[i], in radians
+func pointLPZ(input []float64, i int) *core.CoordLPZ {
+	return &core.CoordLPZ{Lam: support.DDToR(input[i]), Phi: support.DDToR(input[i+1]), Z: input[i+2]}
+}
+
+// checkLonLatH is checkLonLat, for lon/lat/height points in degrees
+func checkLonLatH(input []float64, validate bool) error {
+	if !validate {
+		return nil
+	}
+	for i := 0; i+2 < len(input); i += 3 {
+		if err := checkPoint(input, i, degree); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shift3D shifts a lon/lat/height point from one datum to the other; a
+// nil datum, as when datum shifts are off, changes nothing
+func shift3D(from, to *core.Datum, lpz *core.CoordLPZ) (*core.CoordLPZ, error) {
+	if from == nil || to == nil {
+		return lpz, nil
+	}
+	return core.DatumTransform3D(from, to, lpz)
+}
+
+// project3D is project, for lon/lat/height points: the height is shifted
+// with the point, and then scaled and offset as the system says
+func (conv *conversion) project3D(lpz *core.CoordLPZ) (*core.CoordXYZ, error) {
+	lpz, err := shift3D(conv.hub, conv.datum, lpz)
+	if err != nil {
+		return nil, err
+	}
+	xy, err := conv.converter.Forward(&core.CoordLP{Lam: lpz.Lam, Phi: lpz.Phi})
+	if err != nil {
+		return nil, err
+	}
+	sys := conv.system
+	return &core.CoordXYZ{X: xy.X, Y: xy.Y, Z: sys.VFromMeter * (lpz.Z + sys.Z0)}, nil
+}
+
+// unproject3D is the inverse of project3D
+func (conv *conversion) unproject3D(xyz *core.CoordXYZ) (*core.CoordLPZ, error) {
+	lp, err := conv.converter.Inverse(&core.CoordXY{X: xyz.X, Y: xyz.Y})
+	if err != nil {
+		return nil, err
+	}
+	sys := conv.system
+	lpz := &core.CoordLPZ{Lam: lp.Lam, Phi: lp.Phi, Z: sys.VToMeter*xyz.Z - sys.Z0}
+	return shift3D(conv.datum, conv.hub, lpz)
+}
+
+//---------------------------------------------------------------------------
+
+// geocentric is a geocentric system, +proj=geocent: earth-centered,
+// earth-fixed X/Y/Z on its datum, in its linear unit
+type geocentric struct {
+	toMeter float64
+	datum   *core.Datum
+}
+
+// isGeocentricSystem reports whether the proj string is of a geocentric
+// system, which isn't a projection but which Convert3D can convert to
+func isGeocentricSystem(ps *support.ProjString) bool {
+	proj, _ := ps.GetAsString("proj")
+	return proj == "geocent"
+}
+
+func geocentricOf(ps *support.ProjString) (*geocentric, error) {
+	g := &geocentric{toMeter: 1.0}
+
+	if id, ok := ps.GetAsString("units"); ok {
+		unit, ok := support.UnitsTable[id]
+		if !ok {
+			return nil, fmt.Errorf("unknown unit: %s", id)
+		}
+		g.toMeter = unit.ToMeters
+	}
+	if ps.ContainsKey("to_meter") {
+		f, ok := ps.GetAsFloat("to_meter")
+		if !ok || f <= 0.0 {
+			return nil, fmt.Errorf("invalid to_meter for geocentric system")
+		}
+		g.toMeter = f
+	}
+
+	var err error
+	g.datum, err = core.NewDatum(ps)
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// toXYZ returns the X/Y/Z of a lon/lat/height point on the system's datum
+func (g *geocentric) toXYZ(lpz *core.CoordLPZ) (float64, float64, float64) {
+	x, y, z := core.GeodeticToGeocentric(g.datum.Ellipsoid, lpz.Lam, lpz.Phi, lpz.Z)
+	return x / g.toMeter, y / g.toMeter, z / g.toMeter
+}
+
+// fromXYZ is the inverse of toXYZ
+func (g *geocentric) fromXYZ(x, y, z float64) *core.CoordLPZ {
+	lam, phi, h := core.GeocentricToGeodetic(g.datum.Ellipsoid, x*g.toMeter, y*g.toMeter, z*g.toMeter)
+	return &core.CoordLPZ{Lam: lam, Phi: phi, Z: h}
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvert3D(t *testing.T) {
+	assert := assert.New(t)
+
+	// EPSG Guidance Note 7-2, 2.2.1: geodetic to geocentric on WGS 84
+	lonlath := []float64{2.0 + 7.0/60.0 + 46.38/3600.0, 53.0 + 48.0/60.0 + 33.82/3600.0, 73.0}
+	ecef := []float64{3771793.968, 140253.342, 5124304.349}
+
+	xyz, err := proj.Convert3D("+proj=geocent +datum=WGS84 +units=m", lonlath)
+	assert.NoError(err)
+	assert.InDeltaSlice(ecef, xyz, 1.0e-3)
+	back, err := proj.Inverse3D("+proj=geocent +datum=WGS84 +units=m", xyz)
+	assert.NoError(err)
+	assert.InDeltaSlice(lonlath, back, 1.0e-9)
+
+	xyz, err = proj.Convert3D("+proj=geocent +datum=WGS84 +units=km", lonlath)
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{ecef[0] / 1000.0, ecef[1] / 1000.0, ecef[2] / 1000.0}, xyz, 1.0e-6)
+
+	// geocentric systems have no 2D points
+	_, err = proj.Convert("+proj=geocent +datum=WGS84", lonlath[:2])
+	assert.Error(err)
+	_, err = proj.Inverse("+proj=geocent +datum=WGS84", ecef[:2])
+	assert.Error(err)
+
+	// projections carry the heights through, in their vertical unit
+	lonlath = []float64{10.0, 50.0, 100.0, 11.0, 51.0, -20.0}
+	xy, err := proj.Convert("32632", []float64{10.0, 50.0, 11.0, 51.0})
+	assert.NoError(err)
+	xyz, err = proj.Convert3D("32632", lonlath)
+	assert.NoError(err)
+	assert.Equal([]float64{xy[0], xy[1], 100.0, xy[2], xy[3], -20.0}, xyz)
+	back, err = proj.Inverse3D("32632", xyz)
+	assert.NoError(err)
+	assert.InDeltaSlice(lonlath, back, 1.0e-9)
+
+	xyz, err = proj.Convert3D("+proj=utm +zone=32 +datum=WGS84 +vunits=ft", lonlath)
+	assert.NoError(err)
+	assert.InDelta(100.0/0.3048, xyz[2], 1.0e-9)
+	back, err = proj.Inverse3D("+proj=utm +zone=32 +datum=WGS84 +vunits=ft", xyz)
+	assert.NoError(err)
+	assert.InDeltaSlice(lonlath, back, 1.0e-9)
+
+	// +z_0 is a false height, added as +x_0 and +y_0 are
+	xyz, err = proj.Convert3D("+proj=utm +zone=32 +datum=WGS84 +vunits=ft +z_0=10", lonlath)
+	assert.NoError(err)
+	assert.InDelta(100.0/0.3048+10.0/0.3048, xyz[2], 1.0e-9)
+	back, err = proj.Inverse3D("+proj=utm +zone=32 +datum=WGS84 +vunits=ft +z_0=10", xyz)
+	assert.NoError(err)
+	assert.InDeltaSlice(lonlath, back, 1.0e-9)
+
+	// and so do geographic systems
+	xyz, err = proj.Convert3D("4326", lonlath)
+	assert.NoError(err)
+	assert.Equal(lonlath, xyz)
+
+	// failures
+	_, err = proj.Convert3D("32632", []float64{10.0, 50.0})
+	assert.Error(err)
+	_, err = proj.Inverse3D("32632", []float64{500000.0, 0.0, 0.0, 1.0})
+	assert.Error(err)
+	_, err = proj.Convert3D("+proj=geocent +datum=WGS84 +units=bogus", lonlath)
+	assert.Error(err)
+
+	// validation knows which value of a triple is which
+	proj.SetValidateInput(true)
+	defer proj.SetValidateInput(false)
+	_, err = proj.Convert3D("32632", []float64{10.0, 50.0, 100.0, 10.0, 95.0, 0.0})
+	rangeErr, ok := err.(proj.InputRangeError)
+	assert.True(ok)
+	assert.Equal(4, rangeErr.Index)
+	assert.True(rangeErr.Latitude)
+	assert.Contains(err.Error(), "latitude 95")
+}
+
+func TestConvert3DDatumShift(t *testing.T) {
+	assert := assert.New(t)
+
+	// EPSG Guidance Note 7-2, 2.4.3.1: WGS 84 to WGS 72, by the
+	// 7-parameter position vector transformation, in geocentric
+	// coordinates
+	wgs72 := "+proj=geocent +a=6378135 +rf=298.26 +towgs84=0,0,4.5,0,0,0.554,0.219 +units=m"
+	wgs84, err := proj.Inverse3D("+proj=geocent +datum=WGS84", []float64{3657660.78, 255778.43, 5201387.75})
+	assert.NoError(err)
+
+	proj.SetDatumShift(true)
+	defer proj.SetDatumShift(false)
+
+	xyz, err := proj.Convert3D(wgs72, wgs84)
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{3657660.66, 255768.55, 5201382.11}, xyz, 1.0e-2)
+	back, err := proj.Inverse3D(wgs72, xyz)
+	assert.NoError(err)
+	assert.InDeltaSlice(wgs84[:2], back[:2], 1.0e-9)
+	assert.InDelta(wgs84[2], back[2], 1.0e-4)
+
+	// the heights change with the datum, and the round trip is good to a
+	// micrometer, as it isn't in 2D
+	ed50 := "+proj=longlat +ellps=intl +towgs84=-87,-98,-121,0,0,0,0 +no_defs"
+	lonlath := []float64{2.0, 49.0, 100.0}
+	shifted, err := proj.Convert3D(ed50, lonlath)
+	assert.NoError(err)
+	assert.InDelta(100.0, shifted[2], 100.0)
+	assert.NotEqual(100.0, shifted[2])
+	back, err = proj.Inverse3D(ed50, shifted)
+	assert.NoError(err)
+	assert.InDeltaSlice(lonlath[:2], back[:2], 1.0e-9)
+	assert.InDelta(lonlath[2], back[2], 1.0e-6)
+
+	// and projections use the same shift as Convert, but with the
+	// heights, which moves the points by a millimeter or two
+	xy, err := proj.Convert(ed50UTM31, lonlath[:2])
+	assert.NoError(err)
+	xyz, err = proj.Convert3D(ed50UTM31, lonlath)
+	assert.NoError(err)
+	assert.InDeltaSlice(xy, xyz[:2], 1.0e-2)
+	assert.InDelta(shifted[2], xyz[2], 1.0e-6)
+	back, err = proj.Inverse3D(ed50UTM31, xyz)
+	assert.NoError(err)
+	assert.InDeltaSlice(lonlath[:2], back[:2], 1.0e-9)
+	assert.InDelta(lonlath[2], back[2], 1.0e-6)
+}
@@ -150,11 +150,11 @@ func TestEnsureRaisedError(t *testing.T) {
 			pt:          []float64{-180.0, 90.0},
 			expectedErr: "tolerance condition error",
 		},
-		"4326 not supported as source srid": {
+		"9999 not a known srid": {
 			op:          "convert",
-			epsgCode:    "4326",
+			epsgCode:    "9999",
 			pt:          []float64{0, 0},
-			expectedErr: "epsg code is not a supported projection",
+			expectedErr: "unknown srid: 9999",
 		},
 		"convert bad point count": {
 			op:          "convert",
@@ -200,3 +200,44 @@ func ExampleConvert() {
 // 	assert.NoError(t, err)
 // 	fmt.Printf("Proj4: %s\n", out.Proj4)
 // }
+
+func TestConvertClipped(t *testing.T) {
+	assert := assert.New(t)
+
+	// UTM zone 32 has its central meridian at 9E
+	utm := "+proj=utm +zone=32 +ellps=GRS80"
+	input := []float64{
+		12.0, 55.0, // in the zone
+		60.0, 55.0, // 51 degrees east of the central meridian
+		-40.0, 10.0, // 49 degrees west of it
+		8.0, -30.0,
+	}
+
+	indices, err := proj.OutOfDomain(utm, input)
+	assert.NoError(err)
+	assert.Equal([]int{1, 2}, indices)
+
+	output, dropped, err := proj.ConvertClipped(utm, input)
+	assert.NoError(err)
+	assert.Equal([]int{1, 2}, dropped)
+	assert.Len(output, 4)
+	assert.InDelta(691875.63, output[0], 1e-2)
+	assert.InDelta(6098907.83, output[1], 1e-2)
+
+	expected, err := proj.Convert(utm, input[6:8])
+	assert.NoError(err)
+	assert.Equal(expected, output[2:4])
+
+	// the exact transverse Mercator is valid over the hemisphere
+	indices, err = proj.OutOfDomain(utm+" +exact", append(input, 100.0, 0.0))
+	assert.NoError(err)
+	assert.Equal([]int{4}, indices)
+
+	// mercator is valid for every longitude
+	indices, err = proj.OutOfDomain("3395", input)
+	assert.NoError(err)
+	assert.Empty(indices)
+
+	_, err = proj.OutOfDomain(utm, input[:3])
+	assert.Error(err)
+}
@@ -0,0 +1,225 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"container/list"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/oahumap/proj/support"
+)
+
+// Converter converts points between 4326 (lon/lat degrees, 2D) and a
+// system exactly as Convert and Inverse do, with the process-wide
+// settings in force at each call, but resolves its definition and sets
+// up its operation only once.
+//
+// Unlike a Transformer, a Converter has no options or statistics of its
+// own, and it is safe for concurrent use: each call has a conversion
+// object to itself, which is kept for reuse afterwards.
+type Converter struct {
+	definition string
+	ps         *support.ProjString // the resolved definition; never modified
+	geo        *geographic         // for geographic systems; copied for each call
+	geocent    *geocentric         // for geocentric systems, which only Forward3D and Inverse3D convert
+	pool       sync.Pool           // of *conversion, for projected systems
+}
+
+// NewConverter returns a Converter for the given system. As with Convert,
+// an SRID or WKT may be given instead of a proj4 string.
+func NewConverter(proj4 string) (*Converter, error) {
+	ps, err := resolveDefinition(proj4)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Converter{definition: proj4, ps: ps}
+	if isGeocentricSystem(ps) {
+		c.geocent, err = geocentricOf(ps)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+	if isGeographicSystem(ps) {
+		c.geo, err = geographicOf(ps)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+
+	conv, err := newConversion(ps.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+	c.pool.Put(conv)
+	return c, nil
+}
+
+// Definition returns the definition the Converter was made from
+func (c *Converter) Definition() string {
+	return c.definition
+}
+
+// Forward converts lon/lat points to the system, as Convert does
+func (c *Converter) Forward(input []float64) ([]float64, error) {
+	if err := checkLonLat(input, degree, ValidateInput()); err != nil {
+		return nil, err
+	}
+	if c.geo != nil {
+		g, err := c.geographic()
+		if err != nil {
+			return nil, err
+		}
+		return g.fromGreenwichPoints(input, degree)
+	}
+
+	conv, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+	defer c.pool.Put(conv)
+	return conv.convert(input)
+}
+
+// Inverse converts points of the system to lon/lat, as Inverse does
+func (c *Converter) Inverse(input []float64) ([]float64, error) {
+	var output []float64
+	if c.geo != nil {
+		g, err := c.geographic()
+		if err != nil {
+			return nil, err
+		}
+		output, err = g.toGreenwichPoints(input, degree)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		conv, err := c.get()
+		if err != nil {
+			return nil, err
+		}
+		defer c.pool.Put(conv)
+		output, err = conv.inverse(input, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := findOutliers(c.definition, input, output, degree, CheckInverse()); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// errGeocentric2D is the error of converting lon/lat points to or from a
+// geocentric system, whose points are 3D
+var errGeocentric2D = fmt.Errorf("geocentric systems need lon/lat/height points: use Convert3D or Inverse3D")
+
+// geographic returns a copy of the geographic system for one call, with
+// the hub of the process
+func (c *Converter) geographic() (*geographic, error) {
+	g := *c.geo
+	if err := g.setHub(processHub()); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// get returns a conversion for one call, with the hub of the process;
+// the caller puts it back in the pool when done
+func (c *Converter) get() (*conversion, error) {
+	if c.geocent != nil {
+		return nil, errGeocentric2D
+	}
+	conv, _ := c.pool.Get().(*conversion)
+	if conv == nil {
+		var err error
+		conv, err = newConversion(c.ps.DeepCopy())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := conv.setHub(processHub()); err != nil {
+		c.pool.Put(conv)
+		return nil, err
+	}
+	return conv, nil
+}
+
+//---------------------------------------------------------------------------
+
+// converterCacheSize is the number of definitions the package-level
+// functions keep Converters for
+const converterCacheSize = 64
+
+// converters is the cache of the package-level functions, so that calling
+// Convert over and over with the same system sets it up only once
+var converters = &converterCache{
+	order: list.New(),
+	items: map[string]*list.Element{},
+}
+
+// converterCache is a least-recently-used cache of Converters, by
+// definition
+type converterCache struct {
+	mu    sync.Mutex
+	order *list.List // of *cachedConverter, the most recently used first
+	items map[string]*list.Element
+}
+
+type cachedConverter struct {
+	key       string
+	converter *Converter
+}
+
+// converterFor returns the Converter for the definition, from the cache
+// if it has one. The switches which change what a definition resolves to,
+// or how it is set up, are part of the key; the empty definition, which
+// is the default target CRS, isn't cached.
+func converterFor(definition string) (*Converter, error) {
+	if strings.TrimSpace(definition) == "" {
+		return NewConverter(definition)
+	}
+	key := strconv.FormatBool(RedirectSuperseded()) + " " +
+		strconv.FormatBool(HighPrecision()) + " " + definition
+
+	cache := converters
+	cache.mu.Lock()
+	if e, ok := cache.items[key]; ok {
+		cache.order.MoveToFront(e)
+		cache.mu.Unlock()
+		return e.Value.(*cachedConverter).converter, nil
+	}
+	cache.mu.Unlock()
+
+	// set up outside the lock; if another call beats us to it, either
+	// Converter will do
+	c, err := NewConverter(definition)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if e, ok := cache.items[key]; ok {
+		cache.order.MoveToFront(e)
+		return e.Value.(*cachedConverter).converter, nil
+	}
+	cache.items[key] = cache.order.PushFront(&cachedConverter{key: key, converter: c})
+	if cache.order.Len() > converterCacheSize {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.items, oldest.Value.(*cachedConverter).key)
+	}
+	return c, nil
+}
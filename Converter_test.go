@@ -0,0 +1,117 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConverter(t *testing.T) {
+	assert := assert.New(t)
+
+	// the same as the package-level functions
+	for _, def := range []string{"3857", "32633", "+proj=longlat +units=grad", "4326", utm32WKT1} {
+		c, err := proj.NewConverter(def)
+		assert.NoError(err, def)
+		assert.Equal(def, c.Definition())
+
+		expected, err := proj.Convert(def, inputA)
+		assert.NoError(err, def)
+		actual, err := c.Forward(inputA)
+		assert.NoError(err, def)
+		assert.Equal(expected, actual, def)
+
+		back, err := proj.Inverse(def, expected)
+		assert.NoError(err, def)
+		actual, err = c.Inverse(expected)
+		assert.NoError(err, def)
+		assert.Equal(back, actual, def)
+	}
+
+	_, err := proj.NewConverter("+proj=nosuch")
+	assert.Error(err)
+	c, err := proj.NewConverter("3857")
+	assert.NoError(err)
+	_, err = c.Forward([]float64{1.0})
+	assert.Error(err)
+
+	// and it follows the process-wide switches as they change
+	proj.SetValidateInput(true)
+	_, err = c.Forward([]float64{0.0, 91.0})
+	proj.SetValidateInput(false)
+	assert.Error(err)
+	c, err = proj.NewConverter(ed50UTM31)
+	assert.NoError(err)
+	unshifted, err := c.Forward(inputA)
+	assert.NoError(err)
+	proj.SetDatumShift(true)
+	shifted, err := c.Forward(inputA)
+	proj.SetDatumShift(false)
+	assert.NoError(err)
+	assert.NotEqual(unshifted, shifted)
+
+	// a single Converter can be shared between goroutines
+	c, err = proj.NewConverter("+proj=lcc +lat_1=33 +lat_2=45 +lat_0=39 +lon_0=-96 +ellps=GRS80")
+	assert.NoError(err)
+	lonlat := []float64{-100.0, 40.0, -90.0, 35.0}
+	expected, err := c.Forward(lonlat)
+	assert.NoError(err)
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100 && errs[i] == nil; j++ {
+				xy, err := c.Forward(lonlat)
+				if err == nil {
+					_, err = c.Inverse(xy)
+				}
+				errs[i] = err
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		assert.NoError(err)
+	}
+	actual, err := c.Forward(lonlat)
+	assert.NoError(err)
+	assert.Equal(expected, actual)
+
+	// the package-level functions keep their systems set up, but more
+	// than they keep are fine too
+	for i := 0; i < 3; i++ {
+		for x0 := 0; x0 < 100; x0++ {
+			def := "+proj=tmerc +ellps=GRS80 +x_0=" + strconv.Itoa(x0)
+			out, err := proj.Convert(def, []float64{0.0, 0.0})
+			assert.NoError(err)
+			assert.InDeltaSlice([]float64{float64(x0), 0.0}, out, 1.0e-9)
+		}
+	}
+}
+
+func BenchmarkConvertSmallBatch(b *testing.B) {
+	input := []float64{-100.0, 40.0}
+	for i := 0; i < b.N; i++ {
+		_, _ = proj.Convert("+proj=lcc +lat_1=33 +lat_2=45 +lat_0=39 +lon_0=-96 +ellps=GRS80", input)
+	}
+}
+
+func BenchmarkConverterSmallBatch(b *testing.B) {
+	input := []float64{-100.0, 40.0}
+	c, _ := proj.NewConverter("+proj=lcc +lat_1=33 +lat_2=45 +lat_0=39 +lon_0=-96 +ellps=GRS80")
+	for i := 0; i < b.N; i++ {
+		_, _ = c.Forward(input)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/oahumap/proj/support"
+)
+
+// DatumRegion is a region, such as an island, with a datum shift of its
+// own: for the Old Hawaiian datum, the shift for all of the islands is
+// out by as much as 25 m on some of them.
+type DatumRegion struct {
+	Datum   string    // as given by +datum, e.g. "OldHawaiian"
+	Area    AreaOfUse // named for the region, e.g. "Oahu"
+	ToWGS84 []float64 // the Helmert parameters, as for +towgs84
+}
+
+// DatumRegions returns the regions with shifts of their own for the
+// datum, as named by +datum; there are regions for "OldHawaiian",
+// "Guam1963" and "AmericanSamoa1962"
+func DatumRegions(datum string) []DatumRegion {
+	entries := support.DatumRegionsTable[datum]
+	regions := make([]DatumRegion, len(entries))
+	for i, entry := range entries {
+		regions[i] = DatumRegion{
+			Datum: entry.Datum,
+			Area: AreaOfUse{
+				Name:  entry.Region,
+				West:  entry.West,
+				South: entry.South,
+				East:  entry.East,
+				North: entry.North,
+			},
+			ToWGS84: regionShift(entry),
+		}
+	}
+	return regions
+}
+
+// RegionalCRS returns the proj string of the CRS, given as for
+// NewTransformer, with the datum shift for the named region of its
+// datum, so that historical survey data is brought into WGS 84 with the
+// parameters for its own island rather than those for the whole datum.
+//
+// The datum is recognized by +datum, or by the shift of any of the
+// datum's presets, such as 4135 and 3561 to 3565 for Old Hawaiian.
+func RegionalCRS(definition, region string) (string, error) {
+	ps, name, err := regionalDatum(definition)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range support.DatumRegionsTable[name] {
+		if entry.Region == region {
+			return withShift(ps, name, regionShift(entry)), nil
+		}
+	}
+	return "", fmt.Errorf("no region %q for datum %s", region, name)
+}
+
+// RegionalCRSAt is RegionalCRS for the region containing the lon/lat
+// point, in degrees. Outside all of the regions, the shift for the whole
+// datum is used.
+func RegionalCRSAt(definition string, lon, lat float64) (string, error) {
+	ps, name, err := regionalDatum(definition)
+	if err != nil {
+		return "", err
+	}
+	for _, region := range DatumRegions(name) {
+		if region.Area.Contains(lon, lat) {
+			return withShift(ps, name, region.ToWGS84), nil
+		}
+	}
+	shift, _ := support.DatumsTable[name].Definition.GetAsFloats("towgs84")
+	return withShift(ps, name, shift), nil
+}
+
+//---------------------------------------------------------------------
+
+// regionalDatum returns the definition's proj string and the name of its
+// datum, which must have regional shifts
+func regionalDatum(definition string) (*support.ProjString, string, error) {
+	ps, err := resolveDefinition(definition)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if name, ok := ps.GetAsString("datum"); ok {
+		if _, ok := support.DatumRegionsTable[name]; !ok {
+			return nil, "", fmt.Errorf("no regional datum shifts for datum %s", name)
+		}
+		return ps, name, nil
+	}
+
+	// a shift of the datum, for the whole of it or for any region
+	shift, ok := ps.GetAsFloats("towgs84")
+	if ok {
+		names := make([]string, 0, len(support.DatumRegionsTable))
+		for name := range support.DatumRegionsTable {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			datumShift, _ := support.DatumsTable[name].Definition.GetAsFloats("towgs84")
+			if sameShift(shift, datumShift) {
+				return ps, name, nil
+			}
+			for _, entry := range support.DatumRegionsTable[name] {
+				if sameShift(shift, regionShift(entry)) {
+					return ps, name, nil
+				}
+			}
+		}
+	}
+	return nil, "", fmt.Errorf("no regional datum shifts for %s", definition)
+}
+
+// regionShift returns the Helmert parameters of the region
+func regionShift(entry *support.DatumRegionsTableEntry) []float64 {
+	ps, err := support.NewProjString(entry.ToWGS84)
+	if err != nil {
+		panic(err)
+	}
+	shift, _ := ps.GetAsFloats("towgs84")
+	return shift
+}
+
+// sameShift reports whether two sets of Helmert parameters are the same,
+// missing parameters being zero
+func sameShift(a, b []float64) bool {
+	for i := 0; i < 7; i++ {
+		var u, v float64
+		if i < len(a) {
+			u = a[i]
+		}
+		if i < len(b) {
+			v = b[i]
+		}
+		if u != v {
+			return false
+		}
+	}
+	return true
+}
+
+// withShift returns the proj string with the datum replaced by its
+// ellipsoid and the given shift
+func withShift(ps *support.ProjString, name string, shift []float64) string {
+	towgs84 := support.Pair{Key: "towgs84", Value: joinFloats(shift)}
+	ellps := support.Pair{Key: "ellps", Value: support.DatumsTable[name].EllipseID}
+
+	out := &support.ProjString{}
+	for _, pair := range ps.Pairs {
+		switch pair.Key {
+		case "datum":
+			if !ps.ContainsKey("ellps") {
+				out.Add(ellps)
+			}
+			if !ps.ContainsKey("towgs84") {
+				out.Add(towgs84)
+			}
+		case "towgs84":
+			out.Add(towgs84)
+		default:
+			out.Add(pair)
+		}
+	}
+	return out.Definition()
+}
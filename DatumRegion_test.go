@@ -0,0 +1,64 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatumRegions(t *testing.T) {
+	assert := assert.New(t)
+
+	regions := proj.DatumRegions("OldHawaiian")
+	assert.Len(regions, 4)
+	assert.Equal("Oahu", regions[2].Area.Name)
+	assert.Equal([]float64{58, -283, -182}, regions[2].ToWGS84)
+	assert.Len(proj.DatumRegions("Guam1963"), 1)
+	assert.Empty(proj.DatumRegions("WGS84"))
+
+	// the island's shift in place of the mean, for a preset
+	def, err := proj.RegionalCRS("3563", "Oahu")
+	assert.NoError(err)
+	assert.Equal("+proj=tmerc +lat_0=21.16666666666667 +lon_0=-158 +k=0.99999 +x_0=152400.3048006096 +y_0=0 +ellps=clrk66 +towgs84=58,-283,-182 +units=us-ft +no_defs", def)
+
+	// and back again, from the shift of another island
+	def, err = proj.RegionalCRS(def, "Hawaii")
+	assert.NoError(err)
+	assert.Contains(def, "+towgs84=89,-279,-183")
+
+	// by datum name, with the ellipsoid filled in
+	def, err = proj.RegionalCRS("+proj=longlat +datum=OldHawaiian +no_defs", "Kauai")
+	assert.NoError(err)
+	assert.Equal("+proj=longlat +ellps=clrk66 +towgs84=45,-290,-172 +no_defs", def)
+	_, err = proj.NewTransformer("+proj=utm +zone=4 +datum=OldHawaiian")
+	assert.NoError(err)
+
+	// by where the data is: Honolulu is on Oahu, and Niihau has no shift
+	// of its own
+	def, err = proj.RegionalCRSAt("4135", -157.8583, 21.3069)
+	assert.NoError(err)
+	assert.Equal("+proj=longlat +ellps=clrk66 +towgs84=58,-283,-182 +no_defs", def)
+	def, err = proj.RegionalCRSAt("4135", -160.15, 21.9)
+	assert.NoError(err)
+	assert.Equal("+proj=longlat +ellps=clrk66 +towgs84=61,-285,-181 +no_defs", def)
+	def, err = proj.RegionalCRSAt("4675", 144.8, 13.45)
+	assert.NoError(err)
+	assert.Equal("+proj=longlat +ellps=clrk66 +towgs84=-100,-248,259 +no_defs", def)
+
+	_, err = proj.RegionalCRS("3563", "Niihau")
+	assert.Error(err)
+	_, err = proj.RegionalCRS("4326", "Oahu")
+	assert.Error(err)
+	_, err = proj.RegionalCRS("+proj=longlat +datum=WGS84", "Oahu")
+	assert.Error(err)
+	_, err = proj.RegionalCRSAt("bogus", 0.0, 0.0)
+	assert.Error(err)
+}
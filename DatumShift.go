@@ -0,0 +1,168 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"sync/atomic"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/support"
+)
+
+var datumShift atomic.Bool
+
+// SetDatumShift turns datum shifts on or off for the whole process. They
+// are off by default, so that lon/lat points are taken to be on the datum
+// of the system they are converted to or from.
+//
+// While they are on, Convert, ConvertClipped, Inverse and InverseTo shift
+// the points between WGS84 (or InverseTo's target) and the datum of the
+// system, by the 3- or 7-parameter Helmert transformation of its
+// +towgs84, and transformers made from then on do the same between their
+// hub and their system. Systems without datum information are not
+// shifted; grid shifts, other than +nadgrids=@null, are not supported,
+// and fail.
+func SetDatumShift(shift bool) {
+	datumShift.Store(shift)
+}
+
+// DatumShift reports whether datum shifts are on
+func DatumShift() bool {
+	return datumShift.Load()
+}
+
+// SetDatumShift turns datum shifts on or off for the transformer, as
+// SetDatumShift does for the process. It fails, leaving them off, if the
+// system or the hub needs a grid shift.
+func (t *Transformer) SetDatumShift(shift bool) error {
+	t.shift = shift
+	err := t.updateShift()
+	if err != nil {
+		t.shift = false
+		_ = t.updateShift()
+	}
+	return err
+}
+
+// updateShift sets the transformer's conversion to shift points between
+// the hub's datum and the system's, if datum shifts are on, and works out
+// whether the shift is instead assumed to be the identity
+func (t *Transformer) updateShift() error {
+	known := t.hubDatum.known && t.datum.known
+	t.identityShift = !known || (t.hubDatum.shift != t.datum.shift && !t.shift)
+
+	var hub *core.Datum
+	if t.shift {
+		hub = t.hubShift
+	}
+	if t.conv != nil {
+		return t.conv.setHub(hub)
+	}
+	return t.geo.setHub(hub)
+}
+
+//---------------------------------------------------------------------
+
+// wgs84Datum is the datum of 4326, the default hub
+var wgs84Datum = func() *core.Datum {
+	ps, err := support.NewProjString(wgs84Definition)
+	if err != nil {
+		panic(err)
+	}
+	d, err := core.NewDatum(ps)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}()
+
+// processHub returns the datum lon/lat points are shifted from by the
+// package-level functions: WGS84, if datum shifts are on, or nil
+func processHub() *core.Datum {
+	if DatumShift() {
+		return wgs84Datum
+	}
+	return nil
+}
+
+// setHub sets the datum of the lon/lat points, which are then shifted to
+// and from the datum of the system; nil turns shifting off
+func (conv *conversion) setHub(hub *core.Datum) error {
+	conv.hub = hub
+	if hub == nil {
+		return nil
+	}
+	conv.datum = conv.system.Datum()
+	_, err := core.DatumTransform(hub, conv.datum, &core.CoordLP{})
+	if err != nil {
+		conv.hub = nil
+	}
+	return err
+}
+
+// project converts a lon/lat point, in radians on Greenwich, shifting it
+// to the system's datum first if there is a hub
+func (conv *conversion) project(lp *core.CoordLP) (*core.CoordXY, error) {
+	if conv.hub != nil {
+		var err error
+		lp, err = core.DatumTransform(conv.hub, conv.datum, lp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return conv.converter.Forward(lp)
+}
+
+// unproject is the inverse of project
+func (conv *conversion) unproject(xy *core.CoordXY) (*core.CoordLP, error) {
+	lp, _, _, err := conv.unprojectWithConvergence(xy)
+	return lp, err
+}
+
+// unprojectWithConvergence is unproject, also returning how the inverse
+// converged, as per core.IIterativeInverse
+func (conv *conversion) unprojectWithConvergence(xy *core.CoordXY) (*core.CoordLP, int, float64, error) {
+	var lp *core.CoordLP
+	var err error
+	iterations, residual := 0, 0.0
+	if op, ok := conv.converter.(*core.ConvertLPToXY); ok {
+		lp, iterations, residual, err = op.InverseWithConvergence(xy)
+	} else {
+		lp, err = conv.converter.Inverse(xy)
+	}
+	if err != nil || conv.hub == nil {
+		return lp, iterations, residual, err
+	}
+	lp, err = core.DatumTransform(conv.datum, conv.hub, lp)
+	return lp, iterations, residual, err
+}
+
+// setHub sets the datum of the lon/lat points given on Greenwich, which
+// are then shifted to and from the datum of the geographic system; nil
+// turns shifting off
+func (g *geographic) setHub(hub *core.Datum) error {
+	g.hub = hub
+	if hub == nil {
+		return nil
+	}
+	_, err := core.DatumTransform(hub, g.datum, &core.CoordLP{})
+	if err != nil {
+		g.hub = nil
+	}
+	return err
+}
+
+// shift shifts a lon/lat point between datums which setHub has checked
+// can be shifted between
+func shift(from, to *core.Datum, lam, phi float64) (float64, float64) {
+	lp, err := core.DatumTransform(from, to, &core.CoordLP{Lam: lam, Phi: phi})
+	if err != nil {
+		return lam, phi
+	}
+	return lp.Lam, lp.Phi
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+// ED50 / UTM zone 31N
+const ed50UTM31 = "+proj=utm +zone=31 +ellps=intl +towgs84=-87,-98,-121,0,0,0,0 +units=m +no_defs"
+
+func TestDatumShift(t *testing.T) {
+	assert := assert.New(t)
+
+	lonlat := []float64{2.0, 49.0, 3.5, 43.25}
+
+	// off by default
+	assert.False(proj.DatumShift())
+	unshifted, err := proj.Convert(ed50UTM31, lonlat)
+	assert.NoError(err)
+
+	tr, err := proj.NewTransformerWithConfig(ed50UTM31, proj.Config{DatumShift: true})
+	assert.NoError(err)
+	shifted, err := tr.Forward(lonlat)
+	assert.NoError(err)
+
+	// ED50 is out from WGS84 by a hundred meters or so in France
+	for i := 0; i < len(lonlat); i += 2 {
+		d := math.Hypot(shifted[i]-unshifted[i], shifted[i+1]-unshifted[i+1])
+		assert.True(d > 50.0 && d < 300.0, "%g", d)
+	}
+	// the heights are dropped, which costs about a millimeter on the way
+	// back
+	back, err := tr.Inverse(shifted)
+	assert.NoError(err)
+	assert.InDeltaSlice(lonlat, back, 1.0e-7)
+
+	// the transformer says so
+	assert.False(tr.Provenance().AssumedIdentity)
+	e := tr.Explain()
+	assert.Equal("datum shift", e[1].Name)
+	assert.False(e[1].Skipped)
+	assert.NoError(tr.SetHub("4326", proj.HubRequireDatum))
+	assert.NoError(tr.SetDatumShift(false))
+	assert.True(tr.Provenance().AssumedIdentity)
+	out, err := tr.Forward(lonlat)
+	assert.NoError(err)
+	assert.Equal(unshifted, out)
+
+	// and the package-level functions do the same, process-wide
+	proj.SetDatumShift(true)
+	defer proj.SetDatumShift(false)
+	out, err = proj.Convert(ed50UTM31, lonlat)
+	assert.NoError(err)
+	assert.InDeltaSlice(shifted, out, 1.0e-6)
+	out, err = proj.Inverse(ed50UTM31, shifted)
+	assert.NoError(err)
+	assert.InDeltaSlice(lonlat, out, 1.0e-7)
+
+	// into a geographic system on the datum, and back to WGS84 from it
+	ed50, err := proj.Convert("+proj=longlat +ellps=intl +towgs84=-87,-98,-121", lonlat)
+	assert.NoError(err)
+	assert.NotEqual(lonlat, ed50)
+	out, err = proj.InverseTo(ed50UTM31, "+proj=longlat +ellps=intl +towgs84=-87,-98,-121", shifted)
+	assert.NoError(err)
+	assert.InDeltaSlice(ed50, out, 1.0e-8)
+	out, err = proj.Inverse("+proj=longlat +ellps=intl +towgs84=-87,-98,-121", ed50)
+	assert.NoError(err)
+	assert.InDeltaSlice(lonlat, out, 1.0e-7)
+
+	// and so do Transforms, between projected and geographic systems
+	out, err = proj.ConvertBetween("4326", ed50UTM31, lonlat)
+	assert.NoError(err)
+	assert.InDeltaSlice(shifted, out, 1.0e-6)
+	wgs84, err := proj.Convert("32631", lonlat)
+	assert.NoError(err)
+	// less the millimeter the heights cost
+	out, err = proj.ConvertBetween(ed50UTM31, "32631", shifted)
+	assert.NoError(err)
+	assert.InDeltaSlice(wgs84, out, 1.0e-2)
+	out, err = proj.ConvertBetween("+proj=longlat +ellps=intl +towgs84=-87,-98,-121", "4326", ed50)
+	assert.NoError(err)
+	assert.InDeltaSlice(lonlat, out, 1.0e-7)
+
+	src, err := proj.NewCRS(ed50UTM31)
+	assert.NoError(err)
+	dst, err := proj.NewCRS("32631")
+	assert.NoError(err)
+	transform, err := proj.NewTransform(src, dst)
+	assert.NoError(err)
+	xs, ys := []float64{shifted[0], shifted[2]}, []float64{shifted[1], shifted[3]}
+	assert.NoError(transform.TransformXY(xs, ys))
+	assert.InDeltaSlice([]float64{wgs84[0], wgs84[2]}, xs, 1.0e-2)
+	assert.InDeltaSlice([]float64{wgs84[1], wgs84[3]}, ys, 1.0e-2)
+
+	// nor between systems on the same datum, which are left as they are
+	for _, dst := range []string{"+proj=longlat +ellps=intl +towgs84=-87,-98,-121,0,0,0,0", ed50UTM31 + " +south"} {
+		actual, err := proj.ConvertBetween(ed50UTM31, dst, shifted)
+		assert.NoError(err)
+		proj.SetDatumShift(false)
+		expected, err := proj.ConvertBetween(ed50UTM31, dst, shifted)
+		assert.NoError(err)
+		proj.SetDatumShift(true)
+		assert.Equal(expected, actual, dst)
+	}
+
+	// systems on WGS84, or without datum information, aren't shifted,
+	// and neither is Web Mercator, on the null grid
+	for _, srid := range []string{"32631", "+proj=utm +zone=31 +ellps=intl", "3857"} {
+		proj.SetDatumShift(false)
+		expected, err := proj.Convert(srid, lonlat)
+		assert.NoError(err)
+		proj.SetDatumShift(true)
+		actual, err := proj.Convert(srid, lonlat)
+		assert.NoError(err)
+		assert.Equal(expected, actual, srid)
+	}
+
+	// real grids aren't supported
+	_, err = proj.Convert("+proj=utm +zone=31 +ellps=clrk66 +nadgrids=conus", lonlat)
+	assert.Error(err)
+	_, err = proj.NewTransformer("+proj=utm +zone=31 +ellps=clrk66 +nadgrids=conus")
+	assert.Error(err)
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// The environment variables giving the default CRSs, which are read when
+// the package is initialized
+const (
+	EnvSourceCRS = "PROJ_DEFAULT_SOURCE_CRS"
+	EnvTargetCRS = "PROJ_DEFAULT_TARGET_CRS"
+)
+
+// ErrNoDefaultCRS is returned when an empty definition is given, meaning
+// the default target CRS, and there isn't one
+var ErrNoDefaultCRS = errors.New("no CRS given, and no default target CRS is set")
+
+var defaultSource, defaultTarget atomic.Pointer[string]
+
+func init() {
+	source := strings.TrimSpace(os.Getenv(EnvSourceCRS))
+	target := strings.TrimSpace(os.Getenv(EnvTargetCRS))
+	defaultSource.Store(&source)
+	defaultTarget.Store(&target)
+}
+
+// SetDefaultCRS sets the CRSs used, for the whole process, when none is
+// given: an empty definition passed to Convert, Inverse, NewTransformer,
+// NewCRS and the like means the target, and DefaultTransform converts
+// from the source to the target. An empty source is 4326, and an empty
+// target leaves no default.
+//
+// They start out as the environment variables PROJ_DEFAULT_SOURCE_CRS and
+// PROJ_DEFAULT_TARGET_CRS say, so that a script can be pointed at another
+// CRS without changing it. Nothing is changed if either doesn't resolve.
+func SetDefaultCRS(source, target string) error {
+	source = strings.TrimSpace(source)
+	target = strings.TrimSpace(target)
+	for _, def := range []string{source, target} {
+		if def == "" {
+			continue
+		}
+		if _, err := NewCRS(def); err != nil {
+			return err
+		}
+	}
+	defaultSource.Store(&source)
+	defaultTarget.Store(&target)
+	return nil
+}
+
+// DefaultCRS returns the default source and target CRSs, as set by
+// SetDefaultCRS or the environment; target is empty if there is none
+func DefaultCRS() (source, target string) {
+	source, target = *defaultSource.Load(), *defaultTarget.Load()
+	if source == "" {
+		source = "4326"
+	}
+	return source, target
+}
+
+// DefaultTransform returns the Transform from the default source CRS to
+// the default target CRS
+func DefaultTransform() (*Transform, error) {
+	source, target := DefaultCRS()
+	if target == "" {
+		return nil, ErrNoDefaultCRS
+	}
+	src, err := NewCRS(source)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := NewCRS(target)
+	if err != nil {
+		return nil, err
+	}
+	return NewTransform(src, dst)
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultCRS(t *testing.T) {
+	assert := assert.New(t)
+
+	source, target := proj.DefaultCRS()
+	defer proj.SetDefaultCRS(source, target)
+	assert.NoError(proj.SetDefaultCRS("", ""))
+
+	// no default: an empty definition is an error
+	source, target = proj.DefaultCRS()
+	assert.Equal("4326", source)
+	assert.Equal("", target)
+	_, err := proj.Convert("", []float64{9.0, 45.0})
+	assert.True(errors.Is(err, proj.ErrNoDefaultCRS))
+	_, err = proj.DefaultTransform()
+	assert.True(errors.Is(err, proj.ErrNoDefaultCRS))
+
+	assert.NoError(proj.SetDefaultCRS("", "EPSG:32632"))
+	lonlat := []float64{9.0, 45.0}
+	expected, err := proj.Convert("32632", lonlat)
+	assert.NoError(err)
+	actual, err := proj.Convert("", lonlat)
+	assert.NoError(err)
+	assert.Equal(expected, actual)
+	tr, err := proj.NewTransformer("")
+	assert.NoError(err)
+	actual, err = tr.Forward(lonlat)
+	assert.NoError(err)
+	assert.Equal(expected, actual)
+
+	// from a projected source
+	assert.NoError(proj.SetDefaultCRS("3857", "32632"))
+	web, err := proj.Convert("3857", lonlat)
+	assert.NoError(err)
+	transform, err := proj.DefaultTransform()
+	assert.NoError(err)
+	assert.Equal("3857", transform.Source().Definition())
+	actual, err = transform.Forward(web)
+	assert.NoError(err)
+	assert.InDeltaSlice(expected, actual, 1.0e-6)
+
+	// bad definitions change nothing
+	assert.Error(proj.SetDefaultCRS("4326", "+proj=nosuch"))
+	assert.Error(proj.SetDefaultCRS("99999", "4326"))
+	source, target = proj.DefaultCRS()
+	assert.Equal("3857", source)
+	assert.Equal("32632", target)
+}
@@ -0,0 +1,306 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/support"
+)
+
+// Impact classifies what a difference between two systems does to the
+// coordinates they give for the same point
+type Impact int
+
+// The impacts, from least to most severe
+const (
+	ImpactScale        Impact = iota // coordinates are scaled
+	ImpactOffset                     // coordinates are shifted by a constant
+	ImpactDistortion                 // coordinates differ by varying amounts
+	ImpactIncompatible               // coordinates are not comparable at all
+)
+
+func (i Impact) String() string {
+	switch i {
+	case ImpactScale:
+		return "scale"
+	case ImpactOffset:
+		return "offset"
+	case ImpactDistortion:
+		return "distortion"
+	case ImpactIncompatible:
+		return "incompatible"
+	}
+	return "unknown"
+}
+
+// Difference is one way in which two systems differ
+type Difference struct {
+	Parameter   string // e.g. "x_0", or "datum" for the datum as a whole
+	A, B        string // the values in each system; "" if not given
+	Impact      Impact
+	Description string // e.g. "datum differs: expect offsets of up to a few hundred m"
+}
+
+// defaultValues are those of parameters which have a default, when
+// compared as written
+var defaultValues = map[string]string{"lat_ts": "0"}
+
+// cosmeticKeys have no effect on coordinates
+var cosmeticKeys = map[string]bool{"no_defs": true, "wktext": true, "type": true, "title": true}
+
+// diffedKeys are compared by their effect on the system, rather than as
+// written, so that e.g. "+datum=WGS84" and "+ellps=WGS84 +towgs84=0,0,0"
+// are the same
+var diffedKeys = map[string]bool{
+	"proj": true, "datum": true, "towgs84": true, "nadgrids": true,
+	"ellps": true, "a": true, "b": true, "rf": true, "f": true, "es": true, "e": true, "R": true,
+	"lon_0": true, "lat_0": true, "zone": true, "south": true, "k": true, "k_0": true,
+	"x_0": true, "y_0": true, "units": true, "to_meter": true,
+}
+
+// DiffCRS explains how two systems, given as proj strings or SRIDs,
+// differ, to help track down the cause of coordinates which are off. Each
+// difference says what it does to the coordinates, and roughly by how
+// much; no differences means the systems are equivalent.
+//
+// Parameters are compared by their effect, so that defaults and the
+// various ways of giving a datum or ellipsoid don't show up as
+// differences.
+func DiffCRS(a, b string) ([]Difference, error) {
+	psA, err := resolveDefinition(a)
+	if err != nil {
+		return nil, err
+	}
+	psB, err := resolveDefinition(b)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := []Difference{}
+	add := func(parameter, valueA, valueB string, impact Impact, format string, args ...interface{}) {
+		diffs = append(diffs, Difference{
+			Parameter:   parameter,
+			A:           valueA,
+			B:           valueB,
+			Impact:      impact,
+			Description: fmt.Sprintf(format, args...),
+		})
+	}
+
+	projA, _ := psA.GetAsString("proj")
+	projB, _ := psB.GetAsString("proj")
+	geoA, geoB := isGeographicSystem(psA), isGeographicSystem(psB)
+	if geoA != geoB || (!geoA && projA != projB) {
+		add("proj", projA, projB, ImpactIncompatible, "projection differs: coordinates are not comparable")
+		return diffs, nil
+	}
+
+	datumA, err := datumOf(psA)
+	if err != nil {
+		return nil, err
+	}
+	datumB, err := datumOf(psB)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case datumA.known && datumB.known && datumA.shift != datumB.shift:
+		add("datum", datumName(datumA), datumName(datumB), ImpactDistortion,
+			"datum differs: expect offsets of up to a few hundred m")
+	case datumA.known != datumB.known:
+		add("datum", datumName(datumA), datumName(datumB), ImpactDistortion,
+			"datum is only given for one system: expect offsets of up to a few hundred m if they differ")
+	}
+
+	ellA, ellB := ellipsoidOf(psA), ellipsoidOf(psB)
+	if ellA != nil && ellB != nil && (ellA.A != ellB.A || math.Abs(ellA.Es-ellB.Es) > 1e-12) {
+		offset := math.Abs(ellA.A-ellB.A) + ellA.A*math.Abs(ellA.F-ellB.F)
+		add("ellipsoid", describeEllipsoid(ellA), describeEllipsoid(ellB), ImpactDistortion,
+			"ellipsoid differs: expect offsets of up to %s m", approx(offset))
+	}
+
+	if geoA {
+		unitA, err := geographicUnit(psA)
+		if err != nil {
+			return nil, err
+		}
+		unitB, err := geographicUnit(psB)
+		if err != nil {
+			return nil, err
+		}
+		if unitA != unitB {
+			add("units", unitName(psA), unitName(psB), ImpactScale,
+				"angular units differ: expect B's values to be %.10g times A's", unitA/unitB)
+		}
+	} else {
+		err = diffProjected(psA, psB, add)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	diffRemaining(psA, psB, add)
+
+	return diffs, nil
+}
+
+type addFunc func(parameter, valueA, valueB string, impact Impact, format string, args ...interface{})
+
+// diffProjected compares the parameters all projections have
+func diffProjected(psA, psB *support.ProjString, add addFunc) error {
+	sysA, _, err := core.NewSystem(psA.DeepCopy())
+	if err != nil {
+		return err
+	}
+	sysB, _, err := core.NewSystem(psB.DeepCopy())
+	if err != nil {
+		return err
+	}
+
+	const eps = 1e-12
+
+	if dlon := support.RToDD(sysA.Lam0 - sysB.Lam0); math.Abs(dlon) > eps {
+		add("lon_0", degrees(sysA.Lam0), degrees(sysB.Lam0), ImpactDistortion,
+			"central meridian differs by %.10g°: expect large position-dependent shifts", math.Abs(dlon))
+	}
+	if dlat := support.RToDD(sysA.Phi0 - sysB.Phi0); math.Abs(dlat) > eps {
+		add("lat_0", degrees(sysA.Phi0), degrees(sysB.Phi0), ImpactDistortion,
+			"latitude of origin differs by %.10g°: expect large position-dependent shifts", math.Abs(dlat))
+	}
+	if math.Abs(sysA.K0-sysB.K0) > eps {
+		add("k_0", number(sysA.K0), number(sysB.K0), ImpactScale,
+			"scale factor differs: expect B's coordinates to be about %.10g times A's", sysB.K0/sysA.K0)
+	}
+	if dx := sysB.X0 - sysA.X0; math.Abs(dx) > eps {
+		add("x_0", number(sysA.X0), number(sysB.X0), ImpactOffset,
+			"false easting differs: expect B's x to be offset by %s m", number(dx))
+	}
+	if dy := sysB.Y0 - sysA.Y0; math.Abs(dy) > eps {
+		add("y_0", number(sysA.Y0), number(sysB.Y0), ImpactOffset,
+			"false northing differs: expect B's y to be offset by %s m", number(dy))
+	}
+	if math.Abs(sysA.ToMeter-sysB.ToMeter) > eps*sysA.ToMeter {
+		add("units", unitName(psA), unitName(psB), ImpactScale,
+			"units differ: expect B's values to be %.10g times A's", sysA.ToMeter/sysB.ToMeter)
+	}
+
+	return nil
+}
+
+// diffRemaining compares, as written, the parameters not compared by
+// their effect
+func diffRemaining(psA, psB *support.ProjString, add addFunc) {
+	keys := map[string]bool{}
+	for _, ps := range []*support.ProjString{psA, psB} {
+		for _, pair := range ps.Pairs {
+			if !diffedKeys[pair.Key] && !cosmeticKeys[pair.Key] {
+				keys[pair.Key] = true
+			}
+		}
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		valueA, okA := psA.GetAsString(key)
+		valueB, okB := psB.GetAsString(key)
+		if def, ok := defaultValues[key]; ok {
+			valueA, valueB = valueOr(valueA, okA, def), valueOr(valueB, okB, def)
+			okA, okB = true, true
+		}
+		if okA && okB && sameValue(valueA, valueB) {
+			continue
+		}
+		add(key, valueA, valueB, ImpactDistortion, "%s differs: expect position-dependent differences", key)
+	}
+}
+
+func valueOr(value string, ok bool, def string) string {
+	if !ok {
+		return def
+	}
+	return value
+}
+
+// sameValue compares parameter values numerically, if they are numbers
+func sameValue(a, b string) bool {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		return fa == fb
+	}
+	return a == b
+}
+
+// ellipsoidOf returns the ellipsoid of the system, or nil if it has none
+func ellipsoidOf(ps *support.ProjString) *core.Ellipsoid {
+	ps = ps.DeepCopy()
+	if name, ok := ps.GetAsString("datum"); ok {
+		if entry, ok := support.DatumsTable[name]; ok && entry.EllipseID != "" {
+			ps.Add(support.Pair{Key: "ellps", Value: entry.EllipseID})
+		}
+	}
+
+	ellipsoid, err := core.NewEllipsoidFromSystem(&core.System{ProjString: ps})
+	if err != nil || ellipsoid.A == 0.0 {
+		return nil
+	}
+	return ellipsoid
+}
+
+func describeEllipsoid(e *core.Ellipsoid) string {
+	if e.Es == 0.0 {
+		return "R=" + number(e.A)
+	}
+	return "a=" + number(e.A) + " rf=" + strconv.FormatFloat(1.0/e.F, 'g', 12, 64)
+}
+
+func datumName(d datum) string {
+	switch {
+	case !d.known:
+		return ""
+	case d.shift == "":
+		return "WGS84"
+	}
+	return d.shift
+}
+
+func unitName(ps *support.ProjString) string {
+	if units, ok := ps.GetAsString("units"); ok {
+		return units
+	}
+	if toMeter, ok := ps.GetAsString("to_meter"); ok {
+		return "to_meter=" + toMeter
+	}
+	return ""
+}
+
+func degrees(r float64) string {
+	return strconv.FormatFloat(support.RToDD(r), 'g', 12, 64)
+}
+
+func number(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// approx gives a rough size, to two significant figures
+func approx(f float64) string {
+	if f == 0.0 {
+		return "0"
+	}
+	scale := math.Pow(10, math.Floor(math.Log10(f))-1)
+	return number(math.Round(f/scale) * scale)
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffCRS(t *testing.T) {
+	assert := assert.New(t)
+
+	// equivalent, though written differently
+	diffs, err := proj.DiffCRS("32633", "+proj=utm +zone=33 +ellps=WGS84 +towgs84=0,0,0 +type=crs")
+	assert.NoError(err)
+	assert.Empty(diffs)
+
+	diffs, err = proj.DiffCRS("32633", "32634")
+	assert.NoError(err)
+	assert.Len(diffs, 1)
+	assert.Equal(proj.Difference{
+		Parameter:   "lon_0",
+		A:           "15",
+		B:           "21",
+		Impact:      proj.ImpactDistortion,
+		Description: "central meridian differs by 6°: expect large position-dependent shifts",
+	}, diffs[0])
+
+	diffs, err = proj.DiffCRS("32633", "32733")
+	assert.NoError(err)
+	assert.Len(diffs, 1)
+	assert.Equal("y_0", diffs[0].Parameter)
+	assert.Equal(proj.ImpactOffset, diffs[0].Impact)
+	assert.Equal("false northing differs: expect B's y to be offset by 10000000 m", diffs[0].Description)
+
+	diffs, err = proj.DiffCRS("32633", "+proj=utm +zone=33 +ellps=intl +towgs84=-87,-98,-121")
+	assert.NoError(err)
+	assert.Len(diffs, 2)
+	assert.Equal("datum", diffs[0].Parameter)
+	assert.Equal("towgs84=-87,-98,-121", diffs[0].B)
+	assert.Equal("ellipsoid", diffs[1].Parameter)
+	assert.Equal("a=6378388 rf=297", diffs[1].B)
+	assert.Equal("ellipsoid differs: expect offsets of up to 340 m", diffs[1].Description)
+
+	diffs, err = proj.DiffCRS("+proj=utm +zone=18 +ellps=GRS80", "+proj=utm +zone=18 +ellps=GRS80 +units=us-ft")
+	assert.NoError(err)
+	assert.Len(diffs, 1)
+	assert.Equal(proj.ImpactScale, diffs[0].Impact)
+	assert.Equal("us-ft", diffs[0].B)
+
+	diffs, err = proj.DiffCRS("+proj=lcc +lat_1=33 +lat_2=45 +ellps=GRS80", "+proj=lcc +lat_1=33.0 +lat_2=44 +ellps=GRS80")
+	assert.NoError(err)
+	assert.Len(diffs, 1)
+	assert.Equal("lat_2", diffs[0].Parameter)
+
+	diffs, err = proj.DiffCRS("4326", "+proj=longlat +datum=WGS84 +units=grad")
+	assert.NoError(err)
+	assert.Len(diffs, 1)
+	assert.Equal("units", diffs[0].Parameter)
+
+	diffs, err = proj.DiffCRS("3857", "4326")
+	assert.NoError(err)
+	assert.Len(diffs, 1)
+	assert.Equal(proj.ImpactIncompatible, diffs[0].Impact)
+	assert.Equal("incompatible", diffs[0].Impact.String())
+
+	_, err = proj.DiffCRS("3857", "999999")
+	assert.Error(err)
+}
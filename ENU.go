@@ -0,0 +1,131 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/support"
+)
+
+// wgs84Ellipsoid is the ellipsoid of the local tangent planes
+var wgs84Ellipsoid, _ = core.NewEllipsoid(6378137.0, 1.0/298.257223563)
+
+// enuFrame is a local east/north/up frame: its origin in ECEF and the
+// sines and cosines of its longitude and latitude
+type enuFrame struct {
+	x0, y0, z0     float64
+	sinLam, cosLam float64
+	sinPhi, cosPhi float64
+}
+
+func newENUFrame(originLon, originLat float64) (*enuFrame, error) {
+	if !(math.Abs(originLat) <= 90.0) || math.IsNaN(originLon) || math.IsInf(originLon, 0) {
+		return nil, fmt.Errorf("invalid ENU origin: (%g, %g)", originLon, originLat)
+	}
+
+	f := &enuFrame{}
+	lam := support.DDToR(originLon)
+	phi := support.DDToR(originLat)
+	f.sinLam, f.cosLam = math.Sincos(lam)
+	f.sinPhi, f.cosPhi = math.Sincos(phi)
+	f.x0, f.y0, f.z0 = toECEF(lam, phi, 0.0)
+	return f, nil
+}
+
+// toECEF returns the earth-centered, earth-fixed coordinates of the point
+func toECEF(lam, phi, h float64) (x, y, z float64) {
+	e := wgs84Ellipsoid
+	sinPhi, cosPhi := math.Sincos(phi)
+	n := e.A / math.Sqrt(1.0-e.Es*sinPhi*sinPhi)
+	x = (n + h) * cosPhi * math.Cos(lam)
+	y = (n + h) * cosPhi * math.Sin(lam)
+	z = (n*e.OneEs + h) * sinPhi
+	return x, y, z
+}
+
+// fromECEF returns the longitude, latitude and height of the point. The
+// latitude is found by fixed-point iteration, which converges to well
+// below a micrometer everywhere but near the center of the earth.
+func fromECEF(x, y, z float64) (lam, phi, h float64) {
+	e := wgs84Ellipsoid
+	p := support.Hypot(x, y)
+	lam = math.Atan2(y, x)
+	phi = math.Atan2(z, p*e.OneEs)
+	for i := 0; i < 10; i++ {
+		sinPhi := math.Sin(phi)
+		n := e.A / math.Sqrt(1.0-e.Es*sinPhi*sinPhi)
+		next := math.Atan2(z+n*e.Es*sinPhi, p)
+		done := math.Abs(next-phi) < 1.0e-14
+		phi = next
+		if done {
+			break
+		}
+	}
+	sinPhi, cosPhi := math.Sincos(phi)
+	h = p*cosPhi + z*sinPhi - e.A*math.Sqrt(1.0-e.Es*sinPhi*sinPhi)
+	return lam, phi, h
+}
+
+// ToENU converts lon/lat/height points to the local east/north/up frame
+// whose origin is at the given lon/lat on the ellipsoid. The points are
+// triples of degrees and meters above the WGS84 ellipsoid, and the result
+// is triples of meters east, north and up.
+//
+// The conversion goes through earth-centered, earth-fixed coordinates and
+// is exact, rather than a map projection, so it is good to well under a
+// centimeter however far the points are from the origin, and FromENU
+// undoes it to the same accuracy.
+func ToENU(originLon, originLat float64, points []float64) ([]float64, error) {
+	if len(points)%3 != 0 {
+		return nil, fmt.Errorf("input array of lon/lat/height values must be a multiple of three")
+	}
+	f, err := newENUFrame(originLon, originLat)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]float64, len(points))
+	for i := 0; i < len(points); i += 3 {
+		x, y, z := toECEF(support.DDToR(points[i]), support.DDToR(points[i+1]), points[i+2])
+		dx, dy, dz := x-f.x0, y-f.y0, z-f.z0
+		output[i] = -f.sinLam*dx + f.cosLam*dy
+		output[i+1] = -f.sinPhi*f.cosLam*dx - f.sinPhi*f.sinLam*dy + f.cosPhi*dz
+		output[i+2] = f.cosPhi*f.cosLam*dx + f.cosPhi*f.sinLam*dy + f.sinPhi*dz
+	}
+
+	return output, nil
+}
+
+// FromENU is the inverse of ToENU: it converts east/north/up triples in
+// the frame at the given origin back to lon/lat/height.
+func FromENU(originLon, originLat float64, points []float64) ([]float64, error) {
+	if len(points)%3 != 0 {
+		return nil, fmt.Errorf("input array of east/north/up values must be a multiple of three")
+	}
+	f, err := newENUFrame(originLon, originLat)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]float64, len(points))
+	for i := 0; i < len(points); i += 3 {
+		east, north, up := points[i], points[i+1], points[i+2]
+		x := f.x0 - f.sinLam*east - f.sinPhi*f.cosLam*north + f.cosPhi*f.cosLam*up
+		y := f.y0 + f.cosLam*east - f.sinPhi*f.sinLam*north + f.cosPhi*f.sinLam*up
+		z := f.z0 + f.cosPhi*north + f.sinPhi*up
+		lam, phi, h := fromECEF(x, y, z)
+		output[i] = support.RToDD(lam)
+		output[i+1] = support.RToDD(phi)
+		output[i+2] = h
+	}
+
+	return output, nil
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestENU(t *testing.T) {
+	assert := assert.New(t)
+
+	const lon0, lat0 = -122.4, 37.8
+
+	// the origin, and straight up from it
+	enu, err := proj.ToENU(lon0, lat0, []float64{lon0, lat0, 0.0, lon0, lat0, 120.0})
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{0.0, 0.0, 0.0, 0.0, 0.0, 120.0}, enu, 1.0e-6)
+
+	// a few meters away, east and north are the arc lengths along the
+	// prime vertical and the meridian
+	const a, es = 6378137.0, 0.0066943799901413165
+	sinPhi := math.Sin(lat0 * math.Pi / 180.0)
+	w := math.Sqrt(1.0 - es*sinPhi*sinPhi)
+	m := a * (1.0 - es) / (w * w * w)
+	n := a / w
+	const d = 1.0e-5
+	enu, err = proj.ToENU(lon0, lat0, []float64{lon0 + d, lat0, 0.0, lon0, lat0 + d, 0.0})
+	assert.NoError(err)
+	assert.InDelta(n*math.Cos(lat0*math.Pi/180.0)*d*math.Pi/180.0, enu[0], 1.0e-4)
+	assert.InDelta(0.0, enu[1], 1.0e-4)
+	assert.InDelta(m*d*math.Pi/180.0, enu[4], 1.0e-4)
+	assert.InDelta(0.0, enu[3], 1.0e-4)
+
+	// a long way away the plane falls below the points
+	enu, err = proj.ToENU(lon0, lat0, []float64{lon0, lat0 + 1.0, 0.0})
+	assert.NoError(err)
+	assert.InDelta(-970.0, enu[2], 10.0)
+
+	// and back again, from near and far, including over the poles
+	points := []float64{
+		lon0 + 0.001, lat0 - 0.002, 35.0,
+		lon0 + 3.0, lat0 + 2.0, 1500.0,
+		58.0, -33.0, -20.0,
+		0.0, 90.0, 10.0,
+		lon0, -90.0, 0.0,
+	}
+	for _, origin := range [][2]float64{{lon0, lat0}, {0.0, 90.0}, {179.9, -45.0}} {
+		enu, err = proj.ToENU(origin[0], origin[1], points)
+		assert.NoError(err)
+		lonlat, err := proj.FromENU(origin[0], origin[1], enu)
+		assert.NoError(err)
+		for i := 0; i < len(points); i += 3 {
+			if math.Abs(points[i+1]) != 90.0 {
+				assert.InDelta(points[i], lonlat[i], 1.0e-9)
+			}
+			assert.InDelta(points[i+1], lonlat[i+1], 1.0e-9)
+			assert.InDelta(points[i+2], lonlat[i+2], 1.0e-6)
+		}
+	}
+
+	_, err = proj.ToENU(lon0, lat0, []float64{1.0, 2.0})
+	assert.Error(err)
+	_, err = proj.FromENU(lon0, 91.0, []float64{1.0, 2.0, 3.0})
+	assert.Error(err)
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"strings"
+)
+
+// AreaOfUse is where a CRS is meant to be used, as given by EPSG
+type AreaOfUse struct {
+	Name  string  // e.g. "United States (USA) - New York - counties of Bronx; ..."
+	West  float64 // degrees
+	South float64
+	East  float64
+	North float64
+}
+
+// setMetadata fills in the projection's metadata from the PROJJSON of
+// its CRS, as served by epsg.io
+func (p *Projection) setMetadata(jsonData map[string]any) {
+	p.Name, _ = jsonData["name"].(string)
+	p.Type, _ = jsonData["type"].(string)
+
+	// projected (and other derived) systems have their datum in their
+	// base system
+	geodetic := jsonData
+	if base, ok := jsonData["base_crs"].(map[string]any); ok {
+		geodetic = base
+	}
+	datum, ok := geodetic["datum"].(map[string]any)
+	if !ok {
+		datum, _ = geodetic["datum_ensemble"].(map[string]any)
+	}
+	p.Datum, _ = datum["name"].(string)
+
+	ellipsoid, _ := datum["ellipsoid"].(map[string]any)
+	p.Ellipsoid, _ = ellipsoid["name"].(string)
+	p.SemiMajorAxis, _ = ellipsoid["semi_major_axis"].(float64)
+	if p.SemiMajorAxis == 0.0 {
+		p.SemiMajorAxis, _ = ellipsoid["radius"].(float64)
+	}
+	p.InverseFlattening, _ = ellipsoid["inverse_flattening"].(float64)
+
+	cs, _ := jsonData["coordinate_system"].(map[string]any)
+	if name, factor, ok := axisUnit(cs); ok {
+		p.Unit = name
+		p.UnitFactor = factor
+	}
+
+	// the area is either at the top level or, in newer PROJJSON, in the
+	// first of the usages
+	usage := jsonData
+	if usages, ok := jsonData["usages"].([]any); ok && len(usages) > 0 {
+		usage, _ = usages[0].(map[string]any)
+	}
+	if bbox, ok := usage["bbox"].(map[string]any); ok {
+		p.Area = &AreaOfUse{}
+		p.Area.Name, _ = usage["area"].(string)
+		p.Area.West, _ = bbox["west_longitude"].(float64)
+		p.Area.South, _ = bbox["south_latitude"].(float64)
+		p.Area.East, _ = bbox["east_longitude"].(float64)
+		p.Area.North, _ = bbox["north_latitude"].(float64)
+	}
+
+	deprecated, _ := jsonData["deprecated"].(bool)
+	p.Deprecated = deprecated || strings.HasSuffix(p.Name, "(deprecated)")
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+const nyLongIslandJSON = `{
+	"type": "ProjectedCRS",
+	"name": "NAD83 / New York Long Island (ftUS)",
+	"base_crs": {
+		"name": "NAD83",
+		"datum": {
+			"type": "GeodeticReferenceFrame",
+			"name": "North American Datum 1983",
+			"ellipsoid": {"name": "GRS 1980", "semi_major_axis": 6378137, "inverse_flattening": 298.257222101}
+		}
+	},
+	"coordinate_system": {"subtype": "Cartesian", "axis": [
+		{"name": "Easting", "direction": "east", "unit": {"type": "LinearUnit", "name": "US survey foot", "conversion_factor": 0.304800609601219}},
+		{"name": "Northing", "direction": "north", "unit": {"type": "LinearUnit", "name": "US survey foot", "conversion_factor": 0.304800609601219}}]},
+	"usages": [{"scope": "Engineering survey, topographic mapping.",
+		"area": "United States (USA) - New York - Long Island",
+		"bbox": {"south_latitude": 40.47, "west_longitude": -74.26, "north_latitude": 41.3, "east_longitude": -71.8}}],
+	"id": {"authority": "EPSG", "code": 2263}
+}`
+
+const wgs84JSON = `{
+	"type": "GeographicCRS",
+	"name": "WGS 84",
+	"datum_ensemble": {
+		"name": "World Geodetic System 1984 ensemble",
+		"ellipsoid": {"name": "WGS 84", "semi_major_axis": 6378137, "inverse_flattening": 298.257223563}
+	},
+	"coordinate_system": {"subtype": "ellipsoidal", "axis": [
+		{"name": "Geodetic latitude", "direction": "north", "unit": "degree"},
+		{"name": "Geodetic longitude", "direction": "east", "unit": "degree"}]},
+	"scope": "Horizontal component of 3D system.",
+	"area": "World.",
+	"bbox": {"south_latitude": -90, "west_longitude": -180, "north_latitude": 90, "east_longitude": 180}
+}`
+
+func TestGetInfoFromEPSGMetadata(t *testing.T) {
+	assert := assert.New(t)
+
+	lcc := "+proj=lcc +lat_0=40.1666666666667 +lon_0=-74 +lat_1=41.0333333333333 +lat_2=40.6666666666667 +x_0=300000 +y_0=0 +ellps=GRS80 +units=us-ft +no_defs"
+	withFakeEPSG(fakeCRS("2263", lcc, nyLongIslandJSON), func() {
+		p, err := proj.GetInfoFromEPSG("2263")
+		assert.NoError(err)
+		assert.Equal("NAD83 / New York Long Island (ftUS)", p.Name)
+		assert.Equal("ProjectedCRS", p.Type)
+		assert.Equal("North American Datum 1983", p.Datum)
+		assert.Equal("GRS 1980", p.Ellipsoid)
+		assert.Equal(6378137.0, p.SemiMajorAxis)
+		assert.Equal(298.257222101, p.InverseFlattening)
+		assert.Equal("US survey foot", p.Unit)
+		assert.Equal(0.304800609601219, p.UnitFactor)
+		assert.Equal(&proj.AreaOfUse{
+			Name: "United States (USA) - New York - Long Island",
+			West: -74.26, South: 40.47, East: -71.8, North: 41.3,
+		}, p.Area)
+		assert.False(p.Deprecated)
+	})
+
+	withFakeEPSG(fakeCRS("4326", "+proj=longlat +datum=WGS84 +no_defs", wgs84JSON), func() {
+		p, err := proj.GetInfoFromEPSG("4326")
+		assert.NoError(err)
+		assert.Equal("GeographicCRS", p.Type)
+		assert.Equal("World Geodetic System 1984 ensemble", p.Datum)
+		assert.Equal("WGS 84", p.Ellipsoid)
+		assert.Equal("degree", p.Unit)
+		assert.InDelta(math.Pi/180.0, p.UnitFactor, 1e-15)
+		assert.Equal("World.", p.Area.Name)
+		assert.Equal(180.0, p.Area.East)
+	})
+
+	withFakeEPSG(fakeCRS("2263", lcc, `{"type": "ProjectedCRS", "name": "NAD83 / New York Long Island (ftUS) (deprecated)"}`), func() {
+		p, err := proj.GetInfoFromEPSG("2263")
+		assert.NoError(err)
+		assert.True(p.Deprecated)
+		assert.Empty(p.Datum)
+		assert.Nil(p.Area)
+	})
+}
+
+func TestGetInfoFromEPSGPartial(t *testing.T) {
+	assert := assert.New(t)
+
+	lcc := "+proj=lcc +lat_0=40.1666666666667 +lon_0=-74 +lat_1=41.0333333333333 +lat_2=40.6666666666667 +x_0=300000 +y_0=0 +ellps=GRS80 +units=us-ft +no_defs"
+
+	// without the ESRI WKT, the rest is still there
+	responses := fakeCRS("2263", lcc, nyLongIslandJSON)
+	delete(responses, "/2263.esriwkt")
+	withFakeEPSG(responses, func() {
+		p, err := proj.GetInfoFromEPSG("2263")
+		assert.Error(err)
+		var multi *proj.MultiError
+		assert.True(errors.As(err, &multi))
+		assert.Len(multi.Errors, 1)
+		var format *proj.FormatError
+		assert.True(errors.As(err, &format))
+		assert.Equal("esriwkt", format.Format)
+
+		assert.NotNil(p)
+		assert.Equal(lcc, p.Proj4)
+		assert.Equal("PROJCS[]", p.OGCWKT)
+		assert.Empty(p.ESRIWKT)
+		assert.Equal("NAD83 / New York Long Island (ftUS)", p.Name)
+
+		// and the area of use can still be had
+		area, err := proj.AreaOf("2263")
+		assert.NoError(err)
+		assert.Equal(-74.26, area.West)
+	})
+
+	// without the JSON, or with JSON that doesn't parse, there is no
+	// metadata
+	for _, json := range []string{"", "{"} {
+		responses = fakeCRS("2263", lcc, json)
+		if json == "" {
+			delete(responses, "/2263.json")
+		}
+		delete(responses, "/2263.prettywkt")
+		withFakeEPSG(responses, func() {
+			p, err := proj.GetInfoFromEPSG("2263")
+			var multi *proj.MultiError
+			assert.True(errors.As(err, &multi))
+			assert.Len(multi.Errors, 2)
+			assert.Contains(err.Error(), "prettywkt: ")
+			assert.Contains(err.Error(), "json: ")
+			assert.Equal(lcc, p.Proj4)
+			assert.Equal("PROJCS[]", p.ESRIWKT)
+			assert.Empty(p.Name)
+			assert.Nil(p.Area)
+
+			_, err = proj.AreaOf("2263")
+			assert.True(errors.As(err, &multi))
+		})
+	}
+
+	// but without the proj4 string, there is nothing
+	responses = fakeCRS("2263", lcc, nyLongIslandJSON)
+	delete(responses, "/2263.proj4")
+	withFakeEPSG(responses, func() {
+		p, err := proj.GetInfoFromEPSG("2263")
+		assert.Error(err)
+		assert.Nil(p)
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// EPSGResolver says how GetInfoFromEPSG reaches epsg.io, or a mirror of it
+type EPSGResolver struct {
+	// BaseURL is that of epsg.io or the mirror; requests go to
+	// BaseURL/<code>.<format>
+	BaseURL string
+
+	// Proxy is the proxy to use; if nil, the proxy is taken from the
+	// environment (HTTP_PROXY and so on), as with the default client
+	Proxy *url.URL
+
+	// Retries is how many times a request is retried after a transient
+	// failure: a network error, a 429 or a 5xx status
+	Retries int
+
+	// Backoff is the wait before the first retry, which doubles for each
+	// retry after that
+	Backoff time.Duration
+}
+
+// DefaultEPSGResolver is the resolver used unless SetEPSGResolver is called
+var DefaultEPSGResolver = EPSGResolver{
+	BaseURL: "https://epsg.io",
+	Retries: 2,
+	Backoff: 250 * time.Millisecond,
+}
+
+// epsgResolver is the resolver in use, and its client
+type epsgResolver struct {
+	EPSGResolver
+	client *http.Client // nil for http.DefaultClient
+}
+
+var currentEPSGResolver atomic.Pointer[epsgResolver]
+
+func init() {
+	currentEPSGResolver.Store(&epsgResolver{EPSGResolver: DefaultEPSGResolver})
+}
+
+// SetEPSGResolver sets how GetInfoFromEPSG reaches epsg.io, for the whole
+// process. An empty BaseURL means that of DefaultEPSGResolver.
+func SetEPSGResolver(resolver EPSGResolver) error {
+	if resolver.BaseURL == "" {
+		resolver.BaseURL = DefaultEPSGResolver.BaseURL
+	}
+	resolver.BaseURL = strings.TrimRight(resolver.BaseURL, "/")
+	u, err := url.Parse(resolver.BaseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid EPSG resolver URL: %q", resolver.BaseURL)
+	}
+	if resolver.Retries < 0 || resolver.Backoff < 0 {
+		return fmt.Errorf("invalid EPSG resolver retries: %d, %v", resolver.Retries, resolver.Backoff)
+	}
+
+	r := &epsgResolver{EPSGResolver: resolver}
+	if resolver.Proxy != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = http.ProxyURL(resolver.Proxy)
+		r.client = &http.Client{Transport: transport}
+	}
+	currentEPSGResolver.Store(r)
+	return nil
+}
+
+// get fetches the document, retrying transient failures
+func (r *epsgResolver) get(path string) (*http.Response, error) {
+	client := r.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	wait := r.Backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := client.Get(r.BaseURL + "/" + path)
+		transient := err != nil ||
+			resp.StatusCode == http.StatusTooManyRequests ||
+			resp.StatusCode >= 500
+		if !transient || attempt >= r.Retries {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		time.Sleep(wait)
+		wait *= 2
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+const wgs84Proj4 = "+proj=longlat +datum=WGS84 +no_defs"
+
+// flakyEPSG fails the first few requests for each path with a 503
+type flakyEPSG struct {
+	fakeEPSG
+	failures int
+	requests map[string]int
+	hosts    map[string]bool
+}
+
+func (f *flakyEPSG) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.requests[req.URL.Path]++
+	f.hosts[req.URL.Host] = true
+	if f.requests[req.URL.Path] <= f.failures {
+		return &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Body:       io.NopCloser(strings.NewReader("")),
+			Request:    req,
+		}, nil
+	}
+	return f.fakeEPSG.RoundTrip(req)
+}
+
+func TestEPSGResolver(t *testing.T) {
+	assert := assert.New(t)
+	defer proj.SetEPSGResolver(proj.DefaultEPSGResolver)
+
+	mirrored := fakeEPSG{}
+	for path, body := range fakeCRS("4326", wgs84Proj4, wgs84JSON) {
+		mirrored["/mirror"+path] = body
+	}
+	flaky := &flakyEPSG{
+		fakeEPSG: mirrored,
+		failures: 2,
+		requests: map[string]int{},
+		hosts:    map[string]bool{},
+	}
+	withFakeEPSG(nil, func() {
+		http.DefaultClient.Transport = flaky
+
+		// a mirror, with enough retries
+		err := proj.SetEPSGResolver(proj.EPSGResolver{
+			BaseURL: "https://epsg.example.com/mirror/",
+			Retries: 2,
+			Backoff: time.Millisecond,
+		})
+		assert.NoError(err)
+		p, err := proj.GetInfoFromEPSG("4326")
+		if assert.NoError(err) {
+			assert.Equal(wgs84Proj4, p.Proj4)
+		}
+		assert.Equal(map[string]bool{"epsg.example.com": true}, flaky.hosts)
+		assert.Equal(3, flaky.requests["/mirror/4326.proj4"])
+
+		// not enough
+		flaky.requests = map[string]int{}
+		err = proj.SetEPSGResolver(proj.EPSGResolver{BaseURL: "https://epsg.example.com/mirror", Retries: 1})
+		assert.NoError(err)
+		_, err = proj.GetInfoFromEPSG("4326")
+		assert.Error(err)
+		assert.Equal(2, flaky.requests["/mirror/4326.proj4"])
+
+		// a missing code isn't retried
+		flaky.requests = map[string]int{}
+		flaky.failures = 0
+		_, err = proj.GetInfoFromEPSG("4327")
+		assert.Error(err)
+		assert.Equal(1, flaky.requests["/mirror/4327.proj4"])
+	})
+
+	// through a proxy
+	proxied := []string{}
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		body, ok := fakeCRS("4326", wgs84Proj4, wgs84JSON)[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		io.WriteString(w, body)
+	}))
+	defer proxy.Close()
+	proxyURL, err := url.Parse(proxy.URL)
+	assert.NoError(err)
+	err = proj.SetEPSGResolver(proj.EPSGResolver{BaseURL: "http://epsg.internal", Proxy: proxyURL})
+	assert.NoError(err)
+	p, err := proj.GetInfoFromEPSG("4326")
+	if assert.NoError(err) {
+		assert.Equal(wgs84Proj4, p.Proj4)
+	}
+	assert.Contains(proxied, "http://epsg.internal/4326.proj4")
+
+	assert.Error(proj.SetEPSGResolver(proj.EPSGResolver{BaseURL: "epsg.io"}))
+	assert.Error(proj.SetEPSGResolver(proj.EPSGResolver{Retries: -1}))
+}
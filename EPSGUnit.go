@@ -0,0 +1,91 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/oahumap/proj/support"
+)
+
+// epsgAxisUnit returns the name and size in meters of the unit of the
+// first axis of a projected CRS, as given in its PROJJSON; ok is false if
+// the CRS isn't projected or says nothing about its unit.
+func epsgAxisUnit(jsonData map[string]any) (name string, toMeter float64, ok bool) {
+	if jsonData["type"] != "ProjectedCRS" {
+		return "", 0.0, false
+	}
+	cs, _ := jsonData["coordinate_system"].(map[string]any)
+	return axisUnit(cs)
+}
+
+// axisUnit returns the name and size of the unit of the first axis of a
+// PROJJSON coordinate system, in meters or radians.
+//
+// PROJJSON gives units either by name, for the common ones, or as an
+// object with a conversion factor; it leaves the unit out altogether when
+// it is metres.
+func axisUnit(cs map[string]any) (name string, factor float64, ok bool) {
+	axes, _ := cs["axis"].([]any)
+	if len(axes) == 0 {
+		return "", 0.0, false
+	}
+	axis, _ := axes[0].(map[string]any)
+
+	switch unit := axis["unit"].(type) {
+	case nil:
+		return "metre", 1.0, true
+	case string:
+		switch unit {
+		case "metre", "unity":
+			return unit, 1.0, true
+		case "degree":
+			return unit, support.DDToR(1.0), true
+		}
+	case map[string]any:
+		name, _ := unit["name"].(string)
+		factor, ok := unit["conversion_factor"].(float64)
+		if ok && factor > 0.0 {
+			return name, factor, true
+		}
+	}
+
+	return "", 0.0, false
+}
+
+// applyEPSGUnit makes the proj string use the unit the EPSG metadata says
+// the CRS is in, if it doesn't say so itself: otherwise, the conversions
+// would silently be in meters.
+func applyEPSGUnit(proj4 string, jsonData map[string]any) (string, error) {
+	name, toMeter, ok := epsgAxisUnit(jsonData)
+	if !ok {
+		return proj4, nil
+	}
+
+	ps, err := support.NewProjString(proj4)
+	if err != nil {
+		return "", err
+	}
+	if units, ok := ps.GetAsString("units"); ok {
+		if entry, ok := support.UnitsTable[units]; ok && math.Abs(entry.ToMeters-toMeter) <= 1e-12*toMeter {
+			return proj4, nil
+		}
+	}
+
+	id, ok := support.UnitsFor(toMeter)
+	if !ok {
+		return "", fmt.Errorf("unsupported unit: %s", name)
+	}
+
+	ps, err = support.MergeProjStrings(proj4, "+units="+id)
+	if err != nil {
+		return "", err
+	}
+	return ps.Definition(), nil
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeEPSG serves canned epsg.io responses, keyed by path
+type fakeEPSG map[string]string
+
+func (f fakeEPSG) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, ok := f[req.URL.Path]
+	status := http.StatusOK
+	if !ok {
+		status = http.StatusNotFound
+	}
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// withFakeEPSG runs f with epsg.io replaced by the given responses
+func withFakeEPSG(responses fakeEPSG, f func()) {
+	transport := http.DefaultClient.Transport
+	defer func() { http.DefaultClient.Transport = transport }()
+	http.DefaultClient.Transport = responses
+
+	f()
+}
+
+const usFootJSON = `{"type": "ProjectedCRS", "name": "%s",
+	"coordinate_system": {"subtype": "Cartesian", "axis": [
+		{"name": "Easting", "direction": "east", "unit": {"type": "LinearUnit", "name": "US survey foot", "conversion_factor": 0.304800609601219}},
+		{"name": "Northing", "direction": "north", "unit": {"type": "LinearUnit", "name": "US survey foot", "conversion_factor": 0.304800609601219}}]}}`
+
+func fakeCRS(code, proj4, json string) fakeEPSG {
+	return fakeEPSG{
+		"/" + code + ".proj4":     proj4,
+		"/" + code + ".prettywkt": "PROJCS[]",
+		"/" + code + ".esriwkt":   "PROJCS[]",
+		"/" + code + ".json":      strings.Replace(json, "%s", code, 1),
+	}
+}
+
+func TestGetInfoFromEPSGUnits(t *testing.T) {
+	assert := assert.New(t)
+
+	lonlat := []float64{-74.0, 40.7}
+
+	// a proj string with the unit already in it is left alone
+	utm := "+proj=utm +zone=18 +ellps=GRS80 +units=us-ft +no_defs"
+	withFakeEPSG(fakeCRS("900001", utm, usFootJSON), func() {
+		p, err := proj.GetInfoFromEPSG("900001")
+		assert.NoError(err)
+		assert.Equal(utm, p.Proj4)
+	})
+
+	// one with a to_meter factor gets the unit instead, and so converts to feet
+	withFakeEPSG(fakeCRS("900002", "+proj=utm +zone=18 +ellps=GRS80 +to_meter=0.3048006096012192 +no_defs", usFootJSON), func() {
+		p, err := proj.GetInfoFromEPSG("900002")
+		assert.NoError(err)
+		assert.Equal("+proj=utm +zone=18 +ellps=GRS80 +no_defs +units=us-ft", p.Proj4)
+
+		m, err := proj.Convert("+proj=utm +zone=18 +ellps=GRS80", lonlat)
+		assert.NoError(err)
+		ft, err := proj.Convert(p.Proj4, lonlat)
+		assert.NoError(err)
+		assert.InDelta(m[0]/0.304800609601219, ft[0], 1e-6)
+		assert.InDelta(m[1]/0.304800609601219, ft[1], 1e-6)
+	})
+
+	// PROJJSON leaves out metres
+	withFakeEPSG(fakeCRS("900003", "+proj=utm +zone=18 +ellps=GRS80 +no_defs",
+		`{"type": "ProjectedCRS", "coordinate_system": {"axis": [{"name": "Easting"}]}}`), func() {
+		p, err := proj.GetInfoFromEPSG("900003")
+		assert.NoError(err)
+		assert.Equal("+proj=utm +zone=18 +ellps=GRS80 +no_defs +units=m", p.Proj4)
+	})
+
+	// units we don't know are an error, rather than silently meters
+	withFakeEPSG(fakeCRS("900004", "+proj=utm +zone=18 +ellps=GRS80 +no_defs",
+		strings.Replace(usFootJSON, "0.304800609601219", "0.5", 2)), func() {
+		_, err := proj.GetInfoFromEPSG("900004")
+		assert.Error(err)
+	})
+}
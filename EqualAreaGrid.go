@@ -0,0 +1,208 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrOutsideGrid is returned for a point which falls in none of the cells
+// of an EqualAreaGrid
+var ErrOutsideGrid = errors.New("point is outside the grid")
+
+// the projections whose cells all have the same area on the ground
+var equalAreaProjections = map[string]bool{
+	"aea":  true,
+	"cea":  true,
+	"laea": true,
+	"leac": true,
+}
+
+// EqualAreaGrid bins lon/lat points into the cells of a grid on an
+// equal-area projection, such as NSIDC's EASE-Grid 2.0, so that counts
+// per cell are densities without any correction for latitude.
+//
+// The cells are squares of CellSize, Rows high and Cols wide, whose
+// outer edges are at MinX on the left and MaxY at the top: row 0 is the
+// top row and column 0 the left one, as in an image. A point is in the
+// cell whose edges surround it, so the cell of x is
+// floor((x - MinX) / CellSize), and the center of column c is at
+// MinX + (c + 0.5) * CellSize. (This differs from Grid, whose cells are
+// centered on their multiples of the resolution.)
+//
+// An EqualAreaGrid is safe for concurrent use.
+type EqualAreaGrid struct {
+	MinX, MaxY float64 // the left and top edges of the grid, in the system's units
+	CellSize   float64 // the width and height of a cell, in the system's units
+	Rows, Cols int
+
+	converter *Converter
+}
+
+// NewEqualAreaGrid returns the grid of rows by cols cells of cellSize,
+// whose top left corner is (minX, maxY), on the system, which must be an
+// equal-area projection (aea, cea, laea or leac). As with Convert, an
+// SRID or WKT may be given instead of a proj4 string.
+func NewEqualAreaGrid(proj4 string, minX, maxY, cellSize float64, rows, cols int) (*EqualAreaGrid, error) {
+	if !(cellSize > 0.0) || rows <= 0 || cols <= 0 {
+		return nil, fmt.Errorf("grid must have a positive cell size and number of rows and columns")
+	}
+	if math.IsNaN(minX) || math.IsInf(minX, 0) || math.IsNaN(maxY) || math.IsInf(maxY, 0) {
+		return nil, fmt.Errorf("grid corner must be finite")
+	}
+
+	c, err := NewConverter(proj4)
+	if err != nil {
+		return nil, err
+	}
+	if id, _ := c.ps.GetAsString("proj"); !equalAreaProjections[id] {
+		return nil, fmt.Errorf("%s is not an equal-area projection", proj4)
+	}
+
+	return &EqualAreaGrid{
+		MinX:      minX,
+		MaxY:      maxY,
+		CellSize:  cellSize,
+		Rows:      rows,
+		Cols:      cols,
+		converter: c,
+	}, nil
+}
+
+// The regions of NSIDC's EASE-Grid 2.0, by the SRIDs of their projections
+const (
+	EASEGlobal = "6933" // cylindrical equal-area, true at 30N and 30S
+	EASENorth  = "6931" // Lambert azimuthal equal-area, about the north pole
+	EASESouth  = "6932" // Lambert azimuthal equal-area, about the south pole
+)
+
+// the half width of the EASE-Grid 2.0 global grid, and of the polar grids
+const (
+	easeGlobalHalfWidth = 17367530.44516138
+	easePolarHalfWidth  = 9000000.0
+)
+
+// the rows and columns of the global grid at each standard resolution, in
+// km; it reaches to about 85 degrees north and south, the 25 km grid not
+// quite as far as the others
+var easeGlobalDims = map[float64][2]int{
+	1:  {14616, 34704},
+	3:  {4872, 11568},
+	9:  {1624, 3856},
+	25: {584, 1388},
+	36: {406, 964},
+}
+
+// the columns, and rows, of the polar grids at each standard resolution
+var easePolarCols = map[float64]int{1: 18000, 3: 6000, 9: 2000, 25: 720, 36: 500}
+
+// EASEGrid2 returns the EASE-Grid 2.0 of the region (EASEGlobal, EASENorth
+// or EASESouth) at the nominal resolution in km: 1, 3, 9, 25 or 36. The
+// cells of the global grid are not quite their nominal size; a 25 km cell
+// is 25025.26 m wide, for instance.
+func EASEGrid2(region string, km float64) (*EqualAreaGrid, error) {
+	switch region {
+	case EASEGlobal:
+		dims, ok := easeGlobalDims[km]
+		if !ok {
+			return nil, fmt.Errorf("EASE-Grid 2.0 has no %g km grid", km)
+		}
+		size := 2.0 * easeGlobalHalfWidth / float64(dims[1])
+		return NewEqualAreaGrid(region, -easeGlobalHalfWidth, float64(dims[0])*size/2.0, size, dims[0], dims[1])
+
+	case EASENorth, EASESouth:
+		cols, ok := easePolarCols[km]
+		if !ok {
+			return nil, fmt.Errorf("EASE-Grid 2.0 has no %g km grid", km)
+		}
+		size := 2.0 * easePolarHalfWidth / float64(cols)
+		return NewEqualAreaGrid(region, -easePolarHalfWidth, easePolarHalfWidth, size, cols, cols)
+	}
+	return nil, fmt.Errorf("unknown EASE-Grid 2.0 region: %s", region)
+}
+
+// Definition returns the definition of the grid's system
+func (g *EqualAreaGrid) Definition() string {
+	return g.converter.Definition()
+}
+
+// CellArea returns the area of each cell, in the square of the system's
+// unit
+func (g *EqualAreaGrid) CellArea() float64 {
+	return g.CellSize * g.CellSize
+}
+
+// cellOf returns the row and column of the x/y point, and whether it is
+// in the grid at all; points on the edge between two cells are in the one
+// to the right or below, except on the right and bottom edges of the
+// grid, which are in the last column and row, as with longitude 180 on a
+// global grid
+func (g *EqualAreaGrid) cellOf(x, y float64) (int, int, bool) {
+	fc := (x - g.MinX) / g.CellSize
+	fr := (g.MaxY - y) / g.CellSize
+	col := math.Floor(fc)
+	row := math.Floor(fr)
+	if fc == float64(g.Cols) {
+		col--
+	}
+	if fr == float64(g.Rows) {
+		row--
+	}
+
+	// also catches NaN
+	if !(row >= 0 && row < float64(g.Rows) && col >= 0 && col < float64(g.Cols)) {
+		return -1, -1, false
+	}
+	return int(row), int(col), true
+}
+
+// Cell returns the row and column of the cell the lon/lat point (degrees)
+// is in, or ErrOutsideGrid
+func (g *EqualAreaGrid) Cell(lon, lat float64) (row, col int, err error) {
+	xy, err := g.converter.Forward([]float64{lon, lat})
+	if err != nil {
+		return -1, -1, err
+	}
+	row, col, ok := g.cellOf(xy[0], xy[1])
+	if !ok {
+		return -1, -1, fmt.Errorf("%w: (%g, %g)", ErrOutsideGrid, lon, lat)
+	}
+	return row, col, nil
+}
+
+// Cells returns the rows and columns of the cells of the lon/lat points
+// (degrees), as [row0, col0, row1, col1, ...]. The row and column of a
+// point outside the grid are both -1, so that a batch isn't failed for
+// a few points off its edge.
+func (g *EqualAreaGrid) Cells(input []float64) ([]int, error) {
+	xy, err := g.converter.Forward(input)
+	if err != nil {
+		return nil, err
+	}
+	output := make([]int, len(xy))
+	for i := 0; i < len(xy); i += 2 {
+		output[i], output[i+1], _ = g.cellOf(xy[i], xy[i+1])
+	}
+	return output, nil
+}
+
+// CellCenter returns the lon/lat (degrees) of the center of the cell
+func (g *EqualAreaGrid) CellCenter(row, col int) (lon, lat float64, err error) {
+	if row < 0 || row >= g.Rows || col < 0 || col >= g.Cols {
+		return 0.0, 0.0, fmt.Errorf("cell (%d, %d) is outside the %d by %d grid", row, col, g.Rows, g.Cols)
+	}
+	x := g.MinX + (float64(col)+0.5)*g.CellSize
+	y := g.MaxY - (float64(row)+0.5)*g.CellSize
+	lonlat, err := g.converter.Inverse([]float64{x, y})
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+	return lonlat[0], lonlat[1], nil
+}
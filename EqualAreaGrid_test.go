@@ -0,0 +1,114 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEASEGrid2(t *testing.T) {
+	assert := assert.New(t)
+
+	// the 25 km global grid, as NSIDC gives it
+	g, err := proj.EASEGrid2(proj.EASEGlobal, 25.0)
+	assert.NoError(err)
+	assert.Equal(584, g.Rows)
+	assert.Equal(1388, g.Cols)
+	assert.InDelta(25025.26000, g.CellSize, 1.0e-5)
+	assert.InDelta(626.2636e6, g.CellArea(), 1.0e3)
+	assert.Equal("6933", g.Definition())
+
+	// (0, 0) is on the corner of four cells, and is in the one below and
+	// to the right of it
+	row, col, err := g.Cell(0.0, 0.0)
+	assert.NoError(err)
+	assert.Equal(292, row)
+	assert.Equal(694, col)
+	row, col, err = g.Cell(-0.001, 0.001)
+	assert.NoError(err)
+	assert.Equal(291, row)
+	assert.Equal(693, col)
+
+	// the corners
+	row, col, err = g.Cell(-180.0, 84.0)
+	assert.NoError(err)
+	assert.Equal([]int{0, 0}, []int{row, col})
+	row, col, err = g.Cell(180.0, -84.0)
+	assert.NoError(err)
+	assert.Equal([]int{583, 1387}, []int{row, col})
+
+	// and beyond them
+	_, _, err = g.Cell(0.0, 89.0)
+	assert.True(errors.Is(err, proj.ErrOutsideGrid))
+
+	cells, err := g.Cells([]float64{0.0, 0.0, 0.0, 89.0, -0.001, 0.001})
+	assert.NoError(err)
+	assert.Equal([]int{292, 694, -1, -1, 291, 693}, cells)
+
+	// the centers of cells are in them, half a cell from their edges
+	for _, rc := range [][2]int{{0, 0}, {291, 693}, {292, 694}, {583, 1387}, {100, 1000}} {
+		lon, lat, err := g.CellCenter(rc[0], rc[1])
+		assert.NoError(err)
+		row, col, err := g.Cell(lon, lat)
+		assert.NoError(err)
+		assert.Equal(rc, [2]int{row, col})
+	}
+	lon, lat, err := g.CellCenter(292, 694)
+	assert.NoError(err)
+	// the columns are equally spaced in longitude
+	assert.InDelta(0.5*360.0/1388.0, lon, 1.0e-9)
+	assert.True(lat < 0.0)
+	_, _, err = g.CellCenter(584, 0)
+	assert.Error(err)
+
+	// the polar grids are centered on the pole
+	for _, region := range []string{proj.EASENorth, proj.EASESouth} {
+		g, err = proj.EASEGrid2(region, 36.0)
+		assert.NoError(err)
+		assert.Equal(500, g.Rows)
+		assert.Equal(36000.0, g.CellSize)
+	}
+	g, err = proj.EASEGrid2(proj.EASENorth, 25.0)
+	assert.NoError(err)
+	row, col, err = g.Cell(0.0, 90.0)
+	assert.NoError(err)
+	assert.Equal([]int{360, 360}, []int{row, col})
+	_, _, err = g.Cell(0.0, -10.0)
+	assert.True(errors.Is(err, proj.ErrOutsideGrid))
+
+	_, err = proj.EASEGrid2(proj.EASEGlobal, 12.5)
+	assert.Error(err)
+	_, err = proj.EASEGrid2("3857", 25.0)
+	assert.Error(err)
+}
+
+func TestNewEqualAreaGrid(t *testing.T) {
+	assert := assert.New(t)
+
+	// 100 km cells over Europe, on the ETRS89 Lambert azimuthal equal-area
+	laea := "+proj=laea +lat_0=52 +lon_0=10 +x_0=4321000 +y_0=3210000 +ellps=GRS80 +units=m +no_defs"
+	g, err := proj.NewEqualAreaGrid(laea, 2000000.0, 6000000.0, 100000.0, 40, 50)
+	assert.NoError(err)
+	row, col, err := g.Cell(10.0, 52.0)
+	assert.NoError(err)
+	assert.Equal([]int{27, 23}, []int{row, col})
+
+	// only equal-area systems will do
+	_, err = proj.NewEqualAreaGrid("3857", 0.0, 0.0, 1000.0, 10, 10)
+	assert.Error(err)
+	_, err = proj.NewEqualAreaGrid("4326", 0.0, 0.0, 1.0, 10, 10)
+	assert.Error(err)
+	_, err = proj.NewEqualAreaGrid(laea, 0.0, 0.0, 0.0, 10, 10)
+	assert.Error(err)
+	_, err = proj.NewEqualAreaGrid(laea, 0.0, 0.0, 1000.0, 0, 10)
+	assert.Error(err)
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/support"
+)
+
+// Step is one of the steps a Transformer executes, as listed by Explain
+type Step struct {
+	Name       string // e.g. "unit conversion" or "false origin"
+	Operation  string // the PROJ operation, or "" if PROJ folds it into the projection
+	Parameters string // as proj string keys, e.g. "+xy_in=deg +xy_out=rad"
+
+	// Skipped is set for steps which are listed for completeness but
+	// not executed: datum shifts, unless SetDatumShift turned them on
+	Skipped bool
+}
+
+// Explanation is the ordered list of steps of a Transformer's forward
+// direction; the inverse executes them in reverse.
+type Explanation []Step
+
+// String returns the steps as a PROJ pipeline, as `projinfo -o PROJ`
+// prints it; skipped steps are left out, as are the steps which are part
+// of the projection there.
+func (e Explanation) String() string {
+	s := "+proj=pipeline"
+	for _, step := range e {
+		if step.Skipped || step.Operation == "" {
+			continue
+		}
+		s += " +step +proj=" + step.Operation
+		if step.Parameters != "" {
+			s += " " + step.Parameters
+		}
+	}
+	return s
+}
+
+// Explain returns the steps the transformer executes for Forward, with
+// their parameters, without converting anything, for debugging.
+func (t *Transformer) Explain() Explanation {
+	steps := Explanation{}
+
+	if t.conv == nil {
+		if t.geo.pm != 0.0 {
+			steps = append(steps, Step{
+				Name:       "prime meridian",
+				Parameters: fmt.Sprintf("+pm=%g", support.RToDD(t.geo.pm)),
+			})
+		}
+		return append(steps, Step{
+			Name:       "unit conversion",
+			Operation:  "unitconvert",
+			Parameters: "+xy_in=" + angularUnitID(t.unit) + " +xy_out=" + angularUnitID(t.geo.unit),
+		})
+	}
+
+	sys := t.conv.system
+	steps = append(steps, Step{
+		Name:       "unit conversion",
+		Operation:  "unitconvert",
+		Parameters: "+xy_in=" + angularUnitID(t.unit) + " +xy_out=rad",
+	})
+
+	if t.datum.shift != "" {
+		name, op := "datum shift", "helmert"
+		if strings.HasPrefix(t.datum.shift, "nadgrids=") {
+			name, op = "grids", "hgridshift"
+		}
+		steps = append(steps, Step{Name: name, Operation: op, Parameters: "+" + t.datum.shift, Skipped: t.conv.hub == nil})
+	}
+
+	if sys.Geoc {
+		steps = append(steps, Step{Name: "geocentric latitude", Parameters: "+geoc"})
+	}
+	if sys.FromGreenwich != 0.0 {
+		steps = append(steps, Step{
+			Name:       "prime meridian",
+			Parameters: fmt.Sprintf("+pm=%g", support.RToDD(sys.FromGreenwich)),
+		})
+	}
+	if sys.Lam0 != 0.0 {
+		steps = append(steps, Step{
+			Name:       "central meridian",
+			Parameters: fmt.Sprintf("+lon_0=%g", support.RToDD(sys.Lam0)),
+		})
+	}
+
+	desc := t.conv.operation.GetDescription()
+	steps = append(steps, Step{
+		Name:       "projection: " + desc.Description,
+		Operation:  desc.ID,
+		Parameters: projectionParameters(sys),
+	})
+
+	if sys.Right == core.IOUnitsClassic {
+		steps = append(steps, Step{
+			Name:       "semimajor axis",
+			Parameters: fmt.Sprintf("+a=%g", sys.Ellipsoid.A),
+		})
+	}
+	if sys.X0 != 0.0 || sys.Y0 != 0.0 {
+		steps = append(steps, Step{
+			Name:       "false origin",
+			Parameters: fmt.Sprintf("+x_0=%g +y_0=%g", sys.X0, sys.Y0),
+		})
+	}
+	if sys.FromMeter != 1.0 {
+		steps = append(steps, Step{
+			Name:       "unit conversion",
+			Parameters: fmt.Sprintf("+to_meter=%g", 1.0/sys.FromMeter),
+		})
+	}
+	if sys.Axis != "enu" {
+		steps = append(steps, Step{
+			Name:       "axis order",
+			Operation:  "axisswap",
+			Parameters: "+order=" + axisOrder(sys.Axis),
+		})
+	}
+
+	return steps
+}
+
+// projectionParameters returns the keys of the system's proj string
+// which PROJ's projection step would take, i.e. not those of the datum
+// shift
+func projectionParameters(sys *core.System) string {
+	others := map[string]bool{
+		"proj": true, "towgs84": true, "nadgrids": true,
+		"no_defs": true, "type": true, "wktext": true,
+		"axis": true,
+	}
+	words := []string{}
+	for _, pair := range sys.ProjString.Pairs {
+		if others[pair.Key] {
+			continue
+		}
+		if pair.Value == "" {
+			words = append(words, "+"+pair.Key)
+		} else {
+			words = append(words, "+"+pair.Key+"="+pair.Value)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// axisOrder returns the +axis orientation as PROJ's axisswap takes it,
+// e.g. "-1,-2" for "wsu"
+func axisOrder(axis string) string {
+	order := map[byte]string{'e': "1", 'w': "-1", 'n': "2", 's': "-2"}
+	return order[axis[0]] + "," + order[axis[1]]
+}
+
+// angularUnitID returns the id of the angular unit of the given size in
+// radians, or the size itself if it has none
+func angularUnitID(toRadians float64) string {
+	ids := []string{}
+	for id := range support.AngularUnitsTable {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if support.AngularUnitsTable[id].ToRadians == toRadians {
+			return id
+		}
+	}
+	return fmt.Sprintf("%g", toRadians)
+}
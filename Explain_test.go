@@ -0,0 +1,84 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplain(t *testing.T) {
+	assert := assert.New(t)
+
+	names := func(e proj.Explanation) []string {
+		s := []string{}
+		for _, step := range e {
+			s = append(s, step.Name)
+		}
+		return s
+	}
+
+	tr, err := proj.NewTransformer("32632")
+	assert.NoError(err)
+	e := tr.Explain()
+	assert.Equal([]string{
+		"unit conversion",
+		"central meridian",
+		"projection: Universal Transverse Mercator (UTM)",
+		"semimajor axis",
+		"false origin",
+	}, names(e))
+	assert.Equal("+xy_in=deg +xy_out=rad", e[0].Parameters)
+	assert.Equal("+lon_0=9", e[1].Parameters)
+	assert.Equal("utm", e[2].Operation)
+	assert.Equal("+x_0=500000 +y_0=0", e[4].Parameters)
+	assert.Equal("+proj=pipeline +step +proj=unitconvert +xy_in=deg +xy_out=rad "+
+		"+step +proj=utm +zone=32 +datum=WGS84 +units=m +ellps=WGS84", e.String())
+
+	// the angular unit, linear unit and datum shift all show up, though
+	// the datum shift isn't applied
+	tr, err = proj.NewTransformer("+proj=etmerc +lon_0=-2 +x_0=400000 +ellps=airy " +
+		"+towgs84=446.448,-125.157,542.06 +units=us-ft +pm=paris")
+	assert.NoError(err)
+	assert.NoError(tr.SetAngularUnit("grad"))
+	e = tr.Explain()
+	assert.Equal([]string{
+		"unit conversion",
+		"datum shift",
+		"prime meridian",
+		"central meridian",
+		"projection: Extended Transverse Mercator (UTM)",
+		"semimajor axis",
+		"false origin",
+		"unit conversion",
+	}, names(e))
+	assert.Equal("+xy_in=grad +xy_out=rad", e[0].Parameters)
+	assert.True(e[1].Skipped)
+	assert.Equal("+towgs84=446.448,-125.157,542.06", e[1].Parameters)
+	assert.Equal("+pm=2.337229166666667", e[2].Parameters)
+	assert.Equal("+to_meter=0.304800609601219", e[7].Parameters)
+	assert.NotContains(e.String(), "towgs84")
+
+	// the axes are turned round last
+	tr, err = proj.NewTransformer("2048")
+	assert.NoError(err)
+	e = tr.Explain()
+	assert.Equal("axis order", e[len(e)-1].Name)
+	assert.NotContains(e[len(e)-2].Parameters, "axis")
+	assert.True(strings.HasSuffix(e.String(), " +step +proj=axisswap +order=-1,-2"))
+
+	// a geographic system only rescales
+	tr, err = proj.NewTransformer("4326")
+	assert.NoError(err)
+	assert.Equal(proj.Explanation{{
+		Name: "unit conversion", Operation: "unitconvert", Parameters: "+xy_in=deg +xy_out=deg",
+	}}, tr.Explain())
+}
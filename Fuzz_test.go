@@ -0,0 +1,54 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj"
+)
+
+// FuzzDefinition converts points with arbitrary definitions, as a server
+// taking CRS strings from its clients would: this must fail with an
+// error rather than panic
+func FuzzDefinition(f *testing.F) {
+	for _, def := range []string{
+		"3857",
+		"EPSG:32632",
+		"urn:ogc:def:crs:OGC:1.3:CRS84",
+		"+proj=utm +zone=61 +south",
+		"+proj=lcc +lat_1=90 +lat_2=-90 +ellps=GRS80",
+		"+proj=longlat +pm=2d20'14.025\"E +units=grad",
+		"+proj=merc +towgs84=1,2,3,4,5,6,7,8 +lat_ts=89.9999999",
+		"+proj=omerc +alpha=90 +gamma=90 +k=0 +rf=0",
+		"+proj=etmerc +a=-1 +es=1 +units=us-ft +to_meter=0",
+	} {
+		f.Add(def, 10.0, 45.0)
+	}
+
+	// nothing should go to the network, but make sure
+	defer proj.SetOfflineMode(proj.OfflineMode())
+	proj.SetOfflineMode(true)
+
+	f.Fuzz(func(t *testing.T, def string, lon, lat float64) {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("%q at (%g, %g): panic: %v", def, lon, lat, r)
+			}
+		}()
+
+		xy, err := proj.Convert(def, []float64{lon, lat})
+		if err == nil {
+			_, _ = proj.Inverse(def, xy)
+		}
+		if tr, err := proj.NewTransformer(def); err == nil {
+			_, _, _ = tr.ForwardWithStatus([]float64{lon, lat})
+			_ = tr.Explain()
+		}
+	})
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/support"
+)
+
+// geographic is a geographic system in which lon/lat points are given:
+// its unit and its prime meridian, and its datum
+type geographic struct {
+	unit float64 // the size of the unit, in radians
+	pm   float64 // the longitude of the prime meridian, in radians east of Greenwich
+
+	datum *core.Datum
+	hub   *core.Datum // the datum points on Greenwich are shifted from, if any
+}
+
+// newGeographic returns the geographic system of the definition, which
+// may be a proj string or an SRID
+func newGeographic(definition string) (*geographic, error) {
+	ps, err := resolveDefinition(definition)
+	if err != nil {
+		return nil, err
+	}
+	return geographicOf(ps)
+}
+
+func geographicOf(ps *support.ProjString) (*geographic, error) {
+	if !isGeographicSystem(ps) {
+		return nil, fmt.Errorf("not a geographic system: %s", ps.Definition())
+	}
+
+	g := &geographic{}
+	var err error
+
+	g.unit, err = geographicUnit(ps)
+	if err != nil {
+		return nil, err
+	}
+	g.pm, err = primeMeridian(ps)
+	if err != nil {
+		return nil, err
+	}
+	g.datum, err = core.NewDatum(ps)
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// primeMeridian returns the longitude of the prime meridian of the
+// system, in radians east of Greenwich, as core.NewSystem reads it
+func primeMeridian(ps *support.ProjString) (float64, error) {
+	name, ok := ps.GetAsString("pm")
+	if !ok {
+		return 0.0, nil
+	}
+	if pm, ok := support.MeridiansTable[name]; ok {
+		name = pm.Definition
+	}
+	return support.DMSToR(name)
+}
+
+// fromGreenwich converts a lon/lat point in radians, on the Greenwich
+// meridian, to the system's unit and prime meridian
+func (g *geographic) fromGreenwich(lam, phi float64) (float64, float64) {
+	if g.hub != nil {
+		lam, phi = shift(g.hub, g.datum, lam, phi)
+	}
+	return (lam - g.pm) / g.unit, phi / g.unit
+}
+
+// toGreenwich is the inverse of fromGreenwich
+func (g *geographic) toGreenwich(lon, lat float64) (float64, float64) {
+	lam, phi := lon*g.unit+g.pm, lat*g.unit
+	if g.hub != nil {
+		lam, phi = shift(g.datum, g.hub, lam, phi)
+	}
+	return lam, phi
+}
+
+// fromGreenwichPoints converts lon/lat points, in the given unit and on
+// the Greenwich meridian, to the system's unit and prime meridian
+func (g *geographic) fromGreenwichPoints(input []float64, unit float64) ([]float64, error) {
+	if g.pm == 0.0 && g.hub == nil {
+		return rescale(input, unit/g.unit), nil
+	}
+	if len(input)%2 != 0 {
+		return nil, fmt.Errorf("input array of lon/lat values must be an even number")
+	}
+	output := make([]float64, len(input))
+	for i := 0; i < len(input); i += 2 {
+		output[i], output[i+1] = g.fromGreenwich(input[i]*unit, input[i+1]*unit)
+	}
+	return output, nil
+}
+
+// toGreenwichPoints is the inverse of fromGreenwichPoints
+func (g *geographic) toGreenwichPoints(input []float64, unit float64) ([]float64, error) {
+	if g.pm == 0.0 && g.hub == nil {
+		return rescale(input, g.unit/unit), nil
+	}
+	if len(input)%2 != 0 {
+		return nil, fmt.Errorf("input array of lon/lat values must be an even number")
+	}
+	output := make([]float64, len(input))
+	for i := 0; i < len(input); i += 2 {
+		lam, phi := g.toGreenwich(input[i], input[i+1])
+		output[i], output[i+1] = lam/unit, phi/unit
+	}
+	return output, nil
+}
+
+// InverseTo converts x/y points of the system proj4 to lon/lat points
+// in the geographic system target, rather than in 4326 as Inverse does:
+// the points are in the target's angular unit and relative to its prime
+// meridian. Either system may be given as a proj string or an SRID.
+//
+// As with Inverse, no datum shift is applied unless SetDatumShift has
+// turned them on: the target is taken to be on the same datum as the
+// source.
+func InverseTo(proj4 string, target string, input []float64) ([]float64, error) {
+	g, err := newGeographic(target)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := converterFor(proj4)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.geo != nil {
+		if len(input)%2 != 0 {
+			return nil, fmt.Errorf("input array of lon/lat values must be an even number")
+		}
+		source := *c.geo
+		if DatumShift() {
+			if err := source.setHub(g.datum); err != nil {
+				return nil, err
+			}
+		}
+		output := make([]float64, len(input))
+		for i := 0; i < len(input); i += 2 {
+			lam, phi := source.toGreenwich(input[i], input[i+1])
+			output[i], output[i+1] = g.fromGreenwich(lam, phi)
+		}
+		return output, nil
+	}
+
+	conv, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		conv.geographic = nil
+		c.pool.Put(conv)
+	}()
+	conv.geographic = g
+	if DatumShift() {
+		if err := conv.setHub(g.datum); err != nil {
+			return nil, err
+		}
+	}
+
+	return conv.inverse(input, nil)
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInverseTo(t *testing.T) {
+	assert := assert.New(t)
+
+	lonlat := []float64{77.625583, 38.833846, -3.0, -50.0}
+	xy, err := proj.Convert("3395", lonlat)
+	assert.NoError(err)
+
+	// 4326 is what Inverse gives
+	expected, err := proj.Inverse("3395", xy)
+	assert.NoError(err)
+	actual, err := proj.InverseTo("3395", "4326", xy)
+	assert.NoError(err)
+	assert.Equal(expected, actual)
+	actual, err = proj.InverseTo("3395", "+proj=longlat +ellps=WGS84", xy)
+	assert.NoError(err)
+	assert.Equal(expected, actual)
+
+	// other units, and another prime meridian
+	const paris = 2.0 + 20.0/60.0 + 14.025/3600.0
+	actual, err = proj.InverseTo("3395", "+proj=longlat +ellps=WGS84 +pm=paris +units=grad", xy)
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{
+		(77.625583 - paris) / 0.9, 38.833846 / 0.9,
+		(-3.0 - paris) / 0.9, -50.0 / 0.9,
+	}, actual, 1.0e-9)
+
+	// from one geographic system to another
+	actual, err = proj.InverseTo("+proj=longlat +ellps=WGS84 +pm=paris", "4258", []float64{0.0, 45.0})
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{paris, 45.0}, actual, 1.0e-12)
+
+	_, err = proj.InverseTo("3395", "3857", xy)
+	assert.Error(err)
+	_, err = proj.InverseTo("3395", "4326", []float64{1.0})
+	assert.Error(err)
+	_, err = proj.InverseTo("4326", "4326", []float64{1.0})
+	assert.Error(err)
+}
+
+func TestPrimeMeridianChains(t *testing.T) {
+	assert := assert.New(t)
+
+	const paris = 2.0 + 20.0/60.0 + 14.025/3600.0
+	const madrid = -(3.0 + 41.0/60.0 + 16.58/3600.0)
+
+	// NTF (Paris) / Lambert zone II, and NTF (Paris) itself, in grads
+	const lambert = "+proj=lcc +lat_1=46.8 +lat_0=46.8 +lon_0=0 +k_0=0.99987742 " +
+		"+x_0=600000 +y_0=2200000 +a=6378249.2 +b=6356515 +pm=paris +units=m"
+	const ntf = "+proj=longlat +a=6378249.2 +b=6356515 +pm=paris +units=grad"
+
+	// on the Paris meridian, and a point off it, on Greenwich
+	lonlat := []float64{paris, 48.8566, 4.8357, 45.764}
+
+	xy, err := proj.Convert(lambert, lonlat)
+	assert.NoError(err)
+	assert.InDelta(600000.0, xy[0], 1.0e-6)
+
+	// to and from the geographic system, both ways round
+	expected := []float64{0.0, 48.8566 / 0.9, (4.8357 - paris) / 0.9, 45.764 / 0.9}
+	actual, err := proj.InverseTo(lambert, ntf, xy)
+	assert.NoError(err)
+	assert.InDeltaSlice(expected, actual, 1.0e-9)
+	actual, err = proj.Convert(ntf, lonlat)
+	assert.NoError(err)
+	assert.InDeltaSlice(expected, actual, 1.0e-12)
+	actual, _, err = proj.ConvertClipped(ntf, lonlat)
+	assert.NoError(err)
+	assert.InDeltaSlice(expected, actual, 1.0e-12)
+	actual, err = proj.Inverse(ntf, expected)
+	assert.NoError(err)
+	assert.InDeltaSlice(lonlat, actual, 1.0e-12)
+	actual, err = proj.InverseTo(ntf, "4326", expected)
+	assert.NoError(err)
+	assert.InDeltaSlice(lonlat, actual, 1.0e-12)
+	actual, err = proj.Inverse(lambert, xy)
+	assert.NoError(err)
+	assert.InDeltaSlice(lonlat, actual, 1.0e-9)
+
+	// from Paris to Madrid, through Greenwich
+	actual, err = proj.InverseTo(lambert, "+proj=longlat +ellps=intl +pm=madrid", xy)
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{paris - madrid, 48.8566, 4.8357 - madrid, 45.764}, actual, 1.0e-9)
+	actual, err = proj.InverseTo(ntf, "+proj=longlat +ellps=intl +pm=madrid", expected)
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{paris - madrid, 48.8566, 4.8357 - madrid, 45.764}, actual, 1.0e-12)
+
+	// and a transformer, in radians
+	tr, err := proj.NewTransformer(ntf)
+	assert.NoError(err)
+	assert.NoError(tr.SetAngularUnit("rad"))
+	radians := []float64{paris * math.Pi / 180.0, 0.5}
+	actual, err = tr.Forward(radians)
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{0.0, 0.5 * 200.0 / math.Pi}, actual, 1.0e-12)
+	actual, err = tr.Inverse(actual)
+	assert.NoError(err)
+	assert.InDeltaSlice(radians, actual, 1.0e-15)
+	_, err = tr.Forward([]float64{1.0})
+	assert.Error(err)
+}
@@ -0,0 +1,29 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"github.com/oahumap/proj/core"
+)
+
+// SetHighPrecision turns extended-precision setup on or off for the whole
+// process. It is off by default.
+//
+// While it is on, the systems set up from then on compute the constants
+// which float64 gets wrong near a degenerate case in 128-bit precision:
+// the cone constants of lcc and aea, whose standard parallels may be
+// nearly the same. Setting up is slower, by tens of microseconds; the
+// conversions themselves are float64 either way, and as fast.
+func SetHighPrecision(on bool) {
+	core.SetHighPrecision(on)
+}
+
+// HighPrecision reports whether extended-precision setup is on
+func HighPrecision() bool {
+	return core.HighPrecision()
+}
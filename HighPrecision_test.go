@@ -0,0 +1,62 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighPrecision(t *testing.T) {
+	assert := assert.New(t)
+
+	// standard parallels 1e-7° apart make the same cone, to far better
+	// than a nanometer, as the tangent one halfway between them, but
+	// float64 gets the cone constant right to only 8 digits or so, which
+	// is centimeters a thousand kilometers out
+	lonlat := []float64{10.0, 55.0, -5.0, 35.0, 20.0, 60.0}
+	assert.False(proj.HighPrecision())
+	for _, p := range []string{"lcc", "aea"} {
+		secant := "+proj=" + p + " +lat_0=45 +lat_1=45 +lat_2=45.0000001 +lon_0=5 +ellps=GRS80"
+		tangent := "+proj=" + p + " +lat_0=45 +lat_1=45.00000005 +lat_2=45.00000005 +lon_0=5 +ellps=GRS80"
+		expected, err := proj.Convert(tangent, lonlat)
+		assert.NoError(err)
+
+		actual, err := proj.Convert(secant, lonlat)
+		assert.NoError(err)
+		worst := 0.0
+		for i := range actual {
+			worst = math.Max(worst, math.Abs(actual[i]-expected[i]))
+		}
+		assert.True(worst > 1.0e-3, p)
+
+		proj.SetHighPrecision(true)
+		actual, err = proj.Convert(secant, lonlat)
+		proj.SetHighPrecision(false)
+		assert.NoError(err)
+		assert.InDeltaSlice(expected, actual, 1.0e-6, p)
+	}
+
+	// the Config switch is the same one
+	defer func() { assert.NoError(proj.SetDefaultConfig(proj.Config{})) }()
+	assert.NoError(proj.SetDefaultConfig(proj.Config{HighPrecision: true}))
+	assert.True(proj.HighPrecision())
+	assert.True(proj.DefaultConfig().HighPrecision)
+
+	// it changes nothing for well-conditioned parameters
+	proj.SetHighPrecision(false)
+	expected, err := proj.Convert("102007", lonlat)
+	assert.NoError(err)
+	proj.SetHighPrecision(true)
+	actual, err := proj.Convert("102007", lonlat)
+	assert.NoError(err)
+	assert.InDeltaSlice(expected, actual, 1.0e-6)
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/support"
+)
+
+// ErrIdentityDatumShift is returned, under HubRequireDatum, when a
+// transformation would silently treat two datums as being the same
+var ErrIdentityDatumShift = errors.New("datum shift would be assumed to be the identity")
+
+// HubPolicy determines what a Transformer does when its projected system
+// is not known to be on the same datum as the hub, the geographic system
+// its lon/lat points are in. Unless datum shifts are turned on, none are
+// applied, so in that case the shift is really the identity.
+type HubPolicy int
+
+// The hub policies
+const (
+	HubAssumeIdentity HubPolicy = iota // assume the datums are the same (the default)
+	HubRequireDatum                    // fail unless the datums are known to be the same
+)
+
+// datum identifies a datum by its shift to WGS84, as far as the proj
+// string tells us
+type datum struct {
+	known bool
+	shift string // "" for WGS84 and its equivalents
+}
+
+// datumOf returns the datum of the system, following the same keys as
+// core.NewSystem does
+func datumOf(ps *support.ProjString) (datum, error) {
+	ps = ps.DeepCopy()
+
+	if name, ok := ps.GetAsString("datum"); ok {
+		entry, ok := support.DatumsTable[name]
+		if !ok {
+			return datum{}, fmt.Errorf("no such datum: %s", name)
+		}
+		ps.AddList(entry.Definition)
+	}
+
+	if grids, ok := ps.GetAsString("nadgrids"); ok {
+		if grids == "@null" {
+			return datum{known: true}, nil
+		}
+		return datum{known: true, shift: "nadgrids=" + grids}, nil
+	}
+
+	if ps.ContainsKey("towgs84") {
+		values, ok := ps.GetAsFloats("towgs84")
+		if !ok {
+			return datum{}, fmt.Errorf("invalid towgs84 parameters")
+		}
+		for _, v := range values {
+			if v != 0.0 {
+				return datum{known: true, shift: "towgs84=" + joinFloats(values)}, nil
+			}
+		}
+		return datum{known: true}, nil
+	}
+
+	return datum{}, nil
+}
+
+func joinFloats(values []float64) string {
+	words := make([]string, len(values))
+	for i, v := range values {
+		words[i] = strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return strings.Join(words, ",")
+}
+
+// SetHub sets the geographic system, given as a proj string or SRID, that
+// the transformer's lon/lat points are in; by default, 4326. Only the
+// datum of the hub matters: its units are set by SetAngularUnit.
+//
+// Under HubRequireDatum, it fails with ErrIdentityDatumShift unless the
+// hub and the transformer's system both have datum information (a datum,
+// towgs84 or nadgrids), and that information says they are the same or
+// datum shifts are on to shift between them.
+func (t *Transformer) SetHub(definition string, policy HubPolicy) error {
+	ps, err := resolveDefinition(definition)
+	if err != nil {
+		return err
+	}
+	if !isGeographicSystem(ps) {
+		return fmt.Errorf("hub is not a geographic system: %s", definition)
+	}
+
+	hub, err := datumOf(ps)
+	if err != nil {
+		return err
+	}
+
+	identity := !hub.known || !t.datum.known || (hub.shift != t.datum.shift && !t.shift)
+
+	if policy == HubRequireDatum && identity {
+		switch {
+		case !hub.known:
+			return fmt.Errorf("%w: no datum information for hub %s", ErrIdentityDatumShift, definition)
+		case !t.datum.known:
+			return fmt.Errorf("%w: no datum information for %s", ErrIdentityDatumShift, t.definition)
+		default:
+			return fmt.Errorf("%w: %s and %s are on different datums", ErrIdentityDatumShift, definition, t.definition)
+		}
+	}
+
+	hubShift, err := core.NewDatum(ps)
+	if err != nil {
+		return err
+	}
+	previous, previousShift := t.hubDatum, t.hubShift
+	t.hubDatum, t.hubShift = hub, hubShift
+	if err := t.updateShift(); err != nil {
+		t.hubDatum, t.hubShift = previous, previousShift
+		_ = t.updateShift()
+		return err
+	}
+
+	t.hub = definition
+	if resolved := ps.Definition(); resolved != definition {
+		t.hub += " " + resolved
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformerHub(t *testing.T) {
+	assert := assert.New(t)
+
+	// on WGS84, or equivalents thereof
+	for _, def := range []string{
+		"32633",
+		"3857", // nadgrids=@null
+		"+proj=utm +zone=32 +ellps=GRS80 +towgs84=0,0,0 +units=m",
+	} {
+		tr, err := proj.NewTransformer(def)
+		assert.NoError(err)
+		assert.False(tr.Provenance().AssumedIdentity, def)
+		assert.NoError(tr.SetHub("4326", proj.HubRequireDatum), def)
+	}
+
+	// no datum information
+	tr, err := proj.NewTransformer("+proj=utm +zone=33 +ellps=intl +units=m")
+	assert.NoError(err)
+	assert.True(tr.Provenance().AssumedIdentity)
+	err = tr.SetHub("4326", proj.HubRequireDatum)
+	assert.ErrorIs(err, proj.ErrIdentityDatumShift)
+	assert.NoError(tr.SetHub("4326", proj.HubAssumeIdentity))
+	assert.True(tr.Provenance().AssumedIdentity)
+
+	// a different datum, unless the hub is on it too
+	tr, err = proj.NewTransformer("+proj=utm +zone=30 +datum=airy +units=m")
+	assert.NoError(err)
+	assert.True(tr.Provenance().AssumedIdentity)
+	err = tr.SetHub("4326", proj.HubRequireDatum)
+	assert.ErrorIs(err, proj.ErrIdentityDatumShift)
+	assert.NoError(tr.SetHub("+proj=longlat +datum=airy", proj.HubRequireDatum))
+	p := tr.Provenance()
+	assert.False(p.AssumedIdentity)
+	assert.Equal("+proj=longlat +datum=airy", p.Source)
+
+	// the hub must be geographic, and have datum information if required
+	err = tr.SetHub("3857", proj.HubAssumeIdentity)
+	assert.Error(err)
+	err = tr.SetHub("+proj=longlat +ellps=airy", proj.HubRequireDatum)
+	assert.ErrorIs(err, proj.ErrIdentityDatumShift)
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"iter"
+	"math"
+
+	"github.com/oahumap/proj/core"
+)
+
+// Points converts a sequence of lon/lat points lazily, as per Forward:
+// each point is converted when the x/y sequence returned asks for it, so
+// that no slices are built, and a pipeline of iterators can be fed one
+// point at a time. The sequence can be ranged over more than once if seq
+// can.
+//
+// There is nowhere to return an error, so points which can't be
+// converted come out as NaN, NaN, as ForwardWithStatus has them. Metrics
+// are not reported.
+func (t *Transformer) Points(seq iter.Seq2[float64, float64]) iter.Seq2[float64, float64] {
+	return func(yield func(float64, float64) bool) {
+		for lon, lat := range seq {
+			x, y, err := t.forwardPoint(lon, lat)
+			if err != nil {
+				x, y = math.NaN(), math.NaN()
+			}
+			if !yield(x, y) {
+				return
+			}
+		}
+	}
+}
+
+// forwardPoint converts one lon/lat point, in the transformer's unit
+func (t *Transformer) forwardPoint(lon, lat float64) (float64, float64, error) {
+	if t.validating() {
+		point := [2]float64{lon, lat}
+		if err := checkPoint(point[:], 0, t.unit); err != nil {
+			return 0.0, 0.0, err
+		}
+	}
+
+	if t.conv == nil {
+		x, y := t.geo.fromGreenwich(lon*t.unit, lat*t.unit)
+		return x, y, nil
+	}
+
+	xy, err := t.conv.project(&core.CoordLP{Lam: lon * t.unit, Phi: lat * t.unit})
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+	return xy.X, xy.Y, nil
+}
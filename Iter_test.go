@@ -0,0 +1,78 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"iter"
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+// pairs returns the lon/lat points of a flat slice as a sequence
+func pairs(points []float64) iter.Seq2[float64, float64] {
+	return func(yield func(float64, float64) bool) {
+		for i := 0; i+1 < len(points); i += 2 {
+			if !yield(points[i], points[i+1]) {
+				return
+			}
+		}
+	}
+}
+
+func TestTransformerPoints(t *testing.T) {
+	assert := assert.New(t)
+
+	lonlat := []float64{9.0, 45.0, 10.5, 47.25, -3.0, -50.0}
+
+	for _, def := range []string{"32632", "3857", "+proj=longlat +ellps=WGS84 +pm=paris +units=grad"} {
+		tr, err := proj.NewTransformer(def)
+		assert.NoError(err)
+		expected, err := tr.Forward(lonlat)
+		assert.NoError(err)
+
+		actual := []float64{}
+		for x, y := range tr.Points(pairs(lonlat)) {
+			actual = append(actual, x, y)
+		}
+		assert.Equal(expected, actual, def)
+	}
+
+	tr, err := proj.NewTransformer("3857")
+	assert.NoError(err)
+
+	// stopping early stops the input too
+	read := 0
+	counted := func(yield func(float64, float64) bool) {
+		for i := 0; i < 100; i++ {
+			read++
+			if !yield(0.0, 0.0) {
+				return
+			}
+		}
+	}
+	for range tr.Points(counted) {
+		break
+	}
+	assert.Equal(1, read)
+
+	// failures are NaN, and the rest carry on
+	assert.NoError(tr.SetAngularUnit("rad"))
+	proj.SetValidateInput(true)
+	defer proj.SetValidateInput(false)
+	actual := []float64{}
+	for x, y := range tr.Points(pairs([]float64{0.0, 2.0, 0.1, 0.2})) {
+		actual = append(actual, x, y)
+	}
+	assert.True(math.IsNaN(actual[0]) && math.IsNaN(actual[1]))
+	expected, err := tr.Forward([]float64{0.1, 0.2})
+	assert.NoError(err)
+	assert.Equal(expected, actual[2:])
+}
@@ -0,0 +1,249 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+)
+
+// ConvertLabelPoint converts a polygon of lon/lat points to the projected
+// system and returns its visual center there, as an x/y pair: the point
+// inside it farthest from its edges (the pole of inaccessibility), where
+// a label sits best. Unlike the centroid, it is always inside the
+// polygon, even one shaped like a C.
+//
+// The polygon is given as its rings, each laid out as for Convert, the
+// outer ring first and any holes after it. The point is found by the
+// polylabel algorithm, to within precision, in the units of the system.
+func ConvertLabelPoint(proj4 string, rings [][]float64, precision float64) ([]float64, error) {
+	// in degrees, whatever the default Config says
+	t, err := NewTransformerWithConfig(proj4, Config{AngularUnit: "deg"})
+	if err != nil {
+		return nil, err
+	}
+	return t.ForwardLabelPoint(rings, precision)
+}
+
+// ForwardLabelPoint is ConvertLabelPoint for the transformer
+func (t *Transformer) ForwardLabelPoint(rings [][]float64, precision float64) ([]float64, error) {
+	if !(precision > 0.0) {
+		return nil, fmt.Errorf("precision must be positive")
+	}
+	projected, err := t.forwardRings(rings)
+	if err != nil {
+		return nil, err
+	}
+	x, y := labelPoint(projected, precision)
+	return []float64{x, y}, nil
+}
+
+// ConvertCentroid converts a polygon of lon/lat points, given as for
+// ConvertLabelPoint, to the projected system and returns its centroid
+// there, as an x/y pair. The centroid in the projected system is not the
+// projection of the centroid in lon/lat.
+func ConvertCentroid(proj4 string, rings [][]float64) ([]float64, error) {
+	// in degrees, whatever the default Config says
+	t, err := NewTransformerWithConfig(proj4, Config{AngularUnit: "deg"})
+	if err != nil {
+		return nil, err
+	}
+	return t.ForwardCentroid(rings)
+}
+
+// ForwardCentroid is ConvertCentroid for the transformer
+func (t *Transformer) ForwardCentroid(rings [][]float64) ([]float64, error) {
+	projected, err := t.forwardRings(rings)
+	if err != nil {
+		return nil, err
+	}
+	x, y := centroid(projected)
+	return []float64{x, y}, nil
+}
+
+//---------------------------------------------------------------------
+
+// forwardRings converts the rings of a polygon, checking there is an
+// outer one
+func (t *Transformer) forwardRings(rings [][]float64) ([][]float64, error) {
+	if len(rings) == 0 {
+		return nil, fmt.Errorf("polygon has no rings")
+	}
+	projected := make([][]float64, len(rings))
+	for i, ring := range rings {
+		if len(ring)%2 != 0 {
+			return nil, fmt.Errorf("ring %d has an odd number of coordinates: %d", i, len(ring))
+		}
+		if len(ring) < 6 {
+			return nil, fmt.Errorf("ring %d has fewer than 3 points", i)
+		}
+		var err error
+		projected[i], err = t.Forward(ring)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return projected, nil
+}
+
+// centroid returns the area-weighted centroid of the polygon, the holes
+// taking away from the outer ring; a polygon with no area falls back to
+// the mean of its outer ring's points
+func centroid(rings [][]float64) (float64, float64) {
+	// relative to a point of the polygon, so that large false eastings
+	// and northings don't swamp the areas
+	ox, oy := rings[0][0], rings[0][1]
+
+	area, cx, cy := 0.0, 0.0, 0.0
+	for i, ring := range rings {
+		a, x, y := 0.0, 0.0, 0.0
+		n := len(ring) / 2
+		for k, j := 0, n-1; k < n; j, k = k, k+1 {
+			x0, y0 := ring[2*j]-ox, ring[2*j+1]-oy
+			x1, y1 := ring[2*k]-ox, ring[2*k+1]-oy
+			f := x0*y1 - x1*y0
+			a += f
+			x += (x0 + x1) * f
+			y += (y0 + y1) * f
+		}
+		// the outer ring adds, the holes subtract, whichever way round
+		// they go
+		sign := 1.0
+		if (a < 0.0) != (i > 0) {
+			sign = -1.0
+		}
+		area += sign * a
+		cx += sign * x
+		cy += sign * y
+	}
+
+	if area == 0.0 {
+		n := len(rings[0]) / 2
+		for i := 0; i < n; i++ {
+			cx += rings[0][2*i] - ox
+			cy += rings[0][2*i+1] - oy
+		}
+		return ox + cx/float64(n), oy + cy/float64(n)
+	}
+	return ox + cx/(3.0*area), oy + cy/(3.0*area)
+}
+
+// labelCell is a square of the polylabel search
+type labelCell struct {
+	x, y     float64 // center
+	h        float64 // half the side
+	d        float64 // distance from the center to the polygon, negative outside
+	max      float64 // the farthest any point in the cell can be from the polygon
+	priority int     // insertion order, to break ties
+}
+
+func newLabelCell(x, y, h float64, rings [][]float64) *labelCell {
+	d := polygonDistance(x, y, rings)
+	return &labelCell{x: x, y: y, h: h, d: d, max: d + h*math.Sqrt2}
+}
+
+// labelQueue orders cells by the best distance they could hold
+type labelQueue []*labelCell
+
+func (q labelQueue) Len() int { return len(q) }
+func (q labelQueue) Less(i, j int) bool {
+	if q[i].max != q[j].max {
+		return q[i].max > q[j].max
+	}
+	return q[i].priority < q[j].priority
+}
+func (q labelQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *labelQueue) Push(x any)   { *q = append(*q, x.(*labelCell)) }
+func (q *labelQueue) Pop() any {
+	old := *q
+	cell := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return cell
+}
+
+// labelPoint finds the pole of inaccessibility of the polygon, by
+// polylabel: the bounding box is covered with square cells, and the
+// cells which might hold a point farther from the edges than the best
+// found so far are split into quarters, best first, until none could
+// beat it by more than the precision
+func labelPoint(rings [][]float64, precision float64) (float64, float64) {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	outer := rings[0]
+	for i := 0; i+1 < len(outer); i += 2 {
+		minX, maxX = math.Min(minX, outer[i]), math.Max(maxX, outer[i])
+		minY, maxY = math.Min(minY, outer[i+1]), math.Max(maxY, outer[i+1])
+	}
+	width, height := maxX-minX, maxY-minY
+	size := math.Min(width, height)
+	if size == 0.0 {
+		return minX, minY
+	}
+
+	queue := &labelQueue{}
+	count := 0
+	push := func(cell *labelCell) {
+		cell.priority = count
+		count++
+		heap.Push(queue, cell)
+	}
+	h := size / 2.0
+	for x := minX; x < maxX; x += size {
+		for y := minY; y < maxY; y += size {
+			push(newLabelCell(x+h, y+h, h, rings))
+		}
+	}
+
+	// the centroid is often a good start, but not always inside; nor is
+	// the middle of the bounding box
+	cx, cy := centroid(rings)
+	best := newLabelCell(cx, cy, 0.0, rings)
+	middle := newLabelCell(minX+width/2.0, minY+height/2.0, 0.0, rings)
+	if middle.d > best.d {
+		best = middle
+	}
+
+	for queue.Len() > 0 {
+		cell := heap.Pop(queue).(*labelCell)
+		if cell.d > best.d {
+			best = cell
+		}
+		if cell.max-best.d <= precision {
+			continue
+		}
+		h := cell.h / 2.0
+		push(newLabelCell(cell.x-h, cell.y-h, h, rings))
+		push(newLabelCell(cell.x+h, cell.y-h, h, rings))
+		push(newLabelCell(cell.x-h, cell.y+h, h, rings))
+		push(newLabelCell(cell.x+h, cell.y+h, h, rings))
+	}
+	return best.x, best.y
+}
+
+// polygonDistance returns the distance from the point to the nearest edge
+// of the polygon, negative if the point is outside it
+func polygonDistance(x, y float64, rings [][]float64) float64 {
+	inside := false
+	distance := math.Inf(1)
+	for _, ring := range rings {
+		n := len(ring) / 2
+		for i, j := 0, n-1; i < n; j, i = i, i+1 {
+			xi, yi := ring[2*i], ring[2*i+1]
+			xj, yj := ring[2*j], ring[2*j+1]
+			if (yi > y) != (yj > y) && x < xi+(y-yi)*(xj-xi)/(yj-yi) {
+				inside = !inside
+			}
+			distance = math.Min(distance, pointSegmentDistance(x, y, xi, yi, xj, yj))
+		}
+	}
+	if !inside {
+		return -distance
+	}
+	return distance
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertLabelPoint(t *testing.T) {
+	assert := assert.New(t)
+
+	// a C, open to the east, whose centroid falls in the opening
+	c := []float64{
+		9.0, 45.0, 9.3, 45.0, 9.3, 45.1, 9.1, 45.1,
+		9.1, 45.2, 9.3, 45.2, 9.3, 45.3, 9.0, 45.3, 9.0, 45.0,
+	}
+	inOpening := func(lon, lat float64) bool {
+		return lon > 9.1 && lat > 45.1 && lat < 45.2
+	}
+
+	xy, err := proj.ConvertCentroid("32632", [][]float64{c})
+	assert.NoError(err)
+	tr, err := proj.NewTransformer("32632")
+	assert.NoError(err)
+	lonLat, err := tr.Inverse(xy)
+	assert.NoError(err)
+	assert.True(inOpening(lonLat[0], lonLat[1]), lonLat)
+
+	xy, err = proj.ConvertLabelPoint("32632", [][]float64{c}, 1.0)
+	assert.NoError(err)
+	lonLat, err = tr.Inverse(xy)
+	assert.NoError(err)
+	assert.False(inOpening(lonLat[0], lonLat[1]), lonLat)
+	assert.True(lonLat[0] > 9.0 && lonLat[0] < 9.3 && lonLat[1] > 45.0 && lonLat[1] < 45.3, lonLat)
+
+	// a lon/lat square is taller than it is wide, so any point on the
+	// vertical through the middle is as far from the sides, but the
+	// centroid is the middle; a hole pushes the label point aside
+	square := []float64{9.0, 45.0, 9.1, 45.0, 9.1, 45.1, 9.0, 45.1}
+	middle, err := proj.Convert("32632", []float64{9.05, 45.05})
+	assert.NoError(err)
+	xy, err = tr.ForwardLabelPoint([][]float64{square}, 1.0)
+	assert.NoError(err)
+	assert.InDelta(middle[0], xy[0], 5.0)
+	assert.InDelta(middle[1], xy[1], 1700.0)
+	xy, err = tr.ForwardCentroid([][]float64{square})
+	assert.NoError(err)
+	assert.InDeltaSlice(middle, xy, 5.0)
+
+	hole := []float64{9.04, 45.04, 9.06, 45.04, 9.06, 45.06, 9.04, 45.06}
+	xy, err = tr.ForwardLabelPoint([][]float64{square, hole}, 1.0)
+	assert.NoError(err)
+	assert.True(xy[0] > middle[0]+1000.0 || xy[0] < middle[0]-1000.0 ||
+		xy[1] > middle[1]+1000.0 || xy[1] < middle[1]-1000.0, xy)
+	xy, err = tr.ForwardCentroid([][]float64{square, hole})
+	assert.NoError(err)
+	assert.InDeltaSlice(middle, xy, 5.0)
+
+	_, err = tr.ForwardLabelPoint([][]float64{square}, 0.0)
+	assert.Error(err)
+	_, err = tr.ForwardLabelPoint(nil, 1.0)
+	assert.Error(err)
+	_, err = tr.ForwardCentroid([][]float64{{9.0, 45.0, 9.1, 45.0}})
+	assert.Error(err)
+	_, err = proj.ConvertLabelPoint("bogus", [][]float64{square}, 1.0)
+	assert.Error(err)
+}
+
+func TestConvertLabelPointDefaultConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	square := []float64{9.0, 45.0, 9.1, 45.0, 9.1, 45.1, 9.0, 45.1}
+	middle, err := proj.Convert("32632", []float64{9.05, 45.05})
+	assert.NoError(err)
+
+	// the rings are in degrees when the default is radians
+	defer func() { assert.NoError(proj.SetDefaultConfig(proj.Config{})) }()
+	assert.NoError(proj.SetDefaultConfig(proj.Config{AngularUnit: "rad"}))
+
+	xy, err := proj.ConvertCentroid("32632", [][]float64{square})
+	assert.NoError(err)
+	assert.InDeltaSlice(middle, xy, 5.0)
+	xy, err = proj.ConvertLabelPoint("32632", [][]float64{square}, 1.0)
+	assert.NoError(err)
+	assert.InDelta(middle[0], xy[0], 5.0)
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/oahumap/proj/merror"
+)
+
+// Batch describes one call to a Transformer's conversion methods, for
+// monitoring
+type Batch struct {
+	Method   string // e.g. "Forward" or "InverseWithStatus"
+	Points   int    // points in the input
+	Failures int    // points which failed; see SetMetrics
+	Err      error  // the error returned, if any
+	Duration time.Duration
+}
+
+// SetMetrics sets a function to be called after every batch the
+// transformer converts, including each chunk of a stream; nil turns this
+// off. The function is called from the goroutine doing the conversion,
+// so it must be quick, and for streams it must be safe for concurrent
+// use. Counters.Observe is such a function.
+//
+// The failures of a batch are the points marked StatusFailed, for the
+// WithStatus methods; the points which failed to converge, for Inverse;
+// and otherwise 1 if the batch failed and 0 if not.
+func (t *Transformer) SetMetrics(observe func(Batch)) {
+	t.metrics = observe
+}
+
+// observe reports a batch to the metrics function, if there is one
+func (t *Transformer) observe(method string, points int, start time.Time, failures int, err error) {
+	if t.metrics == nil {
+		return
+	}
+	if failures == 0 && err != nil {
+		failures = 1
+	}
+	t.metrics(Batch{
+		Method:   method,
+		Points:   points,
+		Failures: failures,
+		Err:      err,
+		Duration: time.Since(start),
+	})
+}
+
+// Counters accumulates batches, for services to publish, e.g. with
+// expvar.Publish (it is an expvar.Var) or as Prometheus counters. It is
+// safe for concurrent use.
+type Counters struct {
+	Batches     atomic.Int64 // batches converted
+	Points      atomic.Int64 // points in them
+	Failures    atomic.Int64 // points which failed
+	Errors      atomic.Int64 // batches which returned an error
+	Nanoseconds atomic.Int64 // time spent converting
+}
+
+// Observe adds the batch to the counters. Pass it to SetMetrics.
+func (c *Counters) Observe(b Batch) {
+	c.Batches.Add(1)
+	c.Points.Add(int64(b.Points))
+	c.Failures.Add(int64(b.Failures))
+	if b.Err != nil {
+		c.Errors.Add(1)
+	}
+	c.Nanoseconds.Add(int64(b.Duration))
+}
+
+// String returns the counters as a JSON object
+func (c *Counters) String() string {
+	return fmt.Sprintf(`{"batches": %d, "points": %d, "failures": %d, "errors": %d, "seconds": %g}`,
+		c.Batches.Load(), c.Points.Load(), c.Failures.Load(), c.Errors.Load(),
+		time.Duration(c.Nanoseconds.Load()).Seconds())
+}
+
+// inverseFailures returns the failures of a call to Inverse
+func (t *Transformer) inverseFailures(err error) int {
+	var cerr merror.ConvergenceError
+	if errors.As(err, &cerr) {
+		return t.stats.Failures
+	}
+	return 0
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	tr, err := proj.NewTransformer("3395")
+	assert.NoError(err)
+
+	batches := []proj.Batch{}
+	tr.SetMetrics(func(b proj.Batch) {
+		batches = append(batches, b)
+	})
+
+	xy, err := tr.Forward([]float64{1.0, 2.0, 3.0, 4.0})
+	assert.NoError(err)
+	_, err = tr.Inverse(xy)
+	assert.NoError(err)
+	_, err = tr.Forward([]float64{1.0})
+	assert.Error(err)
+	_, status, err := tr.ForwardWithStatus([]float64{1.0, 2.0, 3.0, 90.0, 5.0, -90.0})
+	assert.NoError(err)
+	assert.Len(status, 3)
+
+	assert.Len(batches, 4)
+	assert.Equal("Forward", batches[0].Method)
+	assert.Equal(2, batches[0].Points)
+	assert.Equal(0, batches[0].Failures)
+	assert.NoError(batches[0].Err)
+	assert.Equal("Inverse", batches[1].Method)
+	assert.Equal(1, batches[2].Failures)
+	assert.Error(batches[2].Err)
+	assert.Equal("ForwardWithStatus", batches[3].Method)
+	assert.Equal(3, batches[3].Points)
+	assert.Equal(2, batches[3].Failures)
+
+	tr.SetMetrics(nil)
+	_, err = tr.Forward([]float64{1.0, 2.0})
+	assert.NoError(err)
+	assert.Len(batches, 4)
+}
+
+func TestCounters(t *testing.T) {
+	assert := assert.New(t)
+
+	counters := &proj.Counters{}
+	var _ expvar.Var = counters
+
+	tr, err := proj.NewTransformer("3857")
+	assert.NoError(err)
+	tr.SetMetrics(counters.Observe)
+
+	// streams are counted chunk by chunk, from several goroutines
+	const n = 50
+	in := make(chan []float64, n)
+	for i := 0; i < n; i++ {
+		in <- []float64{float64(i), 10.0, float64(i), 20.0}
+	}
+	close(in)
+	out, err := tr.ForwardStream(context.Background(), in, 4)
+	assert.NoError(err)
+	for range out {
+	}
+
+	_, err = tr.Inverse([]float64{math.NaN()})
+	assert.Error(err)
+
+	assert.Equal(int64(n+1), counters.Batches.Load())
+	assert.Equal(int64(2*n), counters.Points.Load())
+	assert.Equal(int64(1), counters.Failures.Load())
+	assert.Equal(int64(1), counters.Errors.Load())
+	assert.True(counters.Nanoseconds.Load() > 0)
+
+	var published map[string]float64
+	assert.NoError(json.Unmarshal([]byte(counters.String()), &published))
+	assert.Equal(float64(n+1), published["batches"])
+	assert.Equal(float64(2*n), published["points"])
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"strings"
+)
+
+// ogcCodes are the SRIDs of the OGC's own CRS codes, which are lon/lat
+// like all the geographic systems here
+var ogcCodes = map[string]string{
+	"CRS84": "4326", // WGS 84
+	"CRS83": "4269", // NAD83
+}
+
+// fromOGC rewrites an OGC identifier of a CRS as "AUTHORITY:CODE", e.g.
+// "urn:ogc:def:crs:EPSG::3857" and
+// "http://www.opengis.net/def/crs/EPSG/0/3857" as "EPSG:3857". The OGC's
+// own codes, such as CRS84, are rewritten as the SRIDs they are the same
+// as. ok is false if the definition isn't an OGC identifier.
+func fromOGC(def string) (string, bool) {
+	s := strings.TrimSpace(def)
+	lower := strings.ToLower(s)
+
+	var auth, code string
+	switch {
+	case strings.HasPrefix(lower, "urn:ogc:def:crs:") || strings.HasPrefix(lower, "urn:x-ogc:def:crs:"):
+		// urn:ogc:def:crs:AUTHORITY:[VERSION]:CODE, where older forms
+		// leave out the version
+		parts := strings.Split(s[strings.Index(lower, ":crs:")+len(":crs:"):], ":")
+		if len(parts) < 2 {
+			return "", false
+		}
+		auth, code = parts[0], parts[len(parts)-1]
+	case strings.Contains(lower, "opengis.net/def/crs/"):
+		// http://www.opengis.net/def/crs/AUTHORITY/VERSION/CODE
+		parts := strings.Split(s[strings.Index(lower, "/def/crs/")+len("/def/crs/"):], "/")
+		if len(parts) != 3 {
+			return "", false
+		}
+		auth, code = parts[0], parts[2]
+	case strings.HasPrefix(strings.ToUpper(s), "OGC:"):
+		auth, code = "OGC", s[len("OGC:"):]
+	default:
+		auth, code = "OGC", s
+	}
+
+	if strings.EqualFold(auth, "OGC") {
+		srid, ok := ogcCodes[strings.ToUpper(code)]
+		if !ok {
+			return "", false
+		}
+		return "EPSG:" + srid, true
+	}
+	if code == "" {
+		return "", false
+	}
+	return strings.ToUpper(auth) + ":" + code, true
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOGCIdentifiers(t *testing.T) {
+	assert := assert.New(t)
+
+	input := []float64{-77.625583, 38.833846}
+	expected, err := proj.Convert("3857", input)
+	assert.NoError(err)
+
+	for _, id := range []string{
+		"urn:ogc:def:crs:EPSG::3857",
+		"urn:ogc:def:crs:EPSG:9.8.15:3857",
+		"URN:OGC:DEF:CRS:epsg::3857",
+		"urn:x-ogc:def:crs:EPSG:3857",
+		"http://www.opengis.net/def/crs/EPSG/0/3857",
+		"https://www.opengis.net/def/crs/EPSG/0/3857",
+		" http://www.opengis.net/def/crs/EPSG/0/900913 ",
+	} {
+		xy, err := proj.Convert(id, input)
+		assert.NoError(err, id)
+		assert.Equal(expected, xy, id)
+	}
+
+	// CRS84 is lon/lat WGS 84, i.e. 4326
+	for _, id := range []string{
+		"CRS84",
+		"crs84",
+		"OGC:CRS84",
+		"urn:ogc:def:crs:OGC:1.3:CRS84",
+		"urn:ogc:def:crs:OGC::CRS84",
+		"http://www.opengis.net/def/crs/OGC/1.3/CRS84",
+	} {
+		lonlat, err := proj.Convert(id, input)
+		assert.NoError(err, id)
+		assert.Equal(input, lonlat, id)
+
+		tr, err := proj.NewTransformer(id)
+		assert.NoError(err, id)
+		assert.Equal(6378137.0, tr.Ellipsoid().A)
+	}
+
+	area, err := proj.AreaOf("urn:ogc:def:crs:EPSG::32633")
+	assert.NoError(err)
+	assert.Equal(12.0, area.West)
+
+	for _, id := range []string{
+		"urn:ogc:def:crs:EPSG::",
+		"urn:ogc:def:crs:OGC:1.3:CRS27",
+		"http://www.opengis.net/def/crs/EPSG/3857",
+		"urn:ogc:def:crs:EPSG::99999",
+		"CRS99",
+	} {
+		_, err = proj.Convert(id, input)
+		assert.Error(err, id)
+	}
+}
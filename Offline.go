@@ -0,0 +1,33 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrOfflineMode is returned by anything that would use the network,
+// such as GetInfoFromEPSG, while offline mode is on
+var ErrOfflineMode = errors.New("network access is disabled (offline mode)")
+
+var offlineMode atomic.Bool
+
+// SetOfflineMode turns offline mode on or off for the whole process.
+//
+// In offline mode, no code path in this package makes network requests,
+// so that environments with no egress allowed can be sure of it; such
+// code paths fail with ErrOfflineMode instead.
+func SetOfflineMode(offline bool) {
+	offlineMode.Store(offline)
+}
+
+// OfflineMode reports whether offline mode is on
+func OfflineMode() bool {
+	return offlineMode.Load()
+}
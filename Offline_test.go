@@ -0,0 +1,35 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOfflineMode(t *testing.T) {
+	assert := assert.New(t)
+
+	defer proj.SetOfflineMode(proj.OfflineMode())
+
+	proj.SetOfflineMode(true)
+	assert.True(proj.OfflineMode())
+
+	_, err := proj.GetInfoFromEPSG("2154")
+	assert.True(errors.Is(err, proj.ErrOfflineMode))
+
+	// local conversions are unaffected
+	_, err = proj.Convert("3857", []float64{2.352222, 48.856614})
+	assert.NoError(err)
+
+	proj.SetOfflineMode(false)
+	assert.False(proj.OfflineMode())
+}
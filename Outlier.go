@@ -0,0 +1,90 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+)
+
+// Outlier is a point whose inverse came out beyond the range of lon/lat
+type Outlier struct {
+	Index    int     // of the point, not the array value
+	X, Y     float64 // the point as given
+	Lon, Lat float64 // what it came out as, in degrees or the transformer's unit
+}
+
+// InverseOutlierError is returned, while inverse checking is on, for a
+// batch some of whose points came out of the inverse with a longitude
+// outside [-180, 180] or a latitude outside [-90, 90], or not a number.
+// That seldom means anything but that the points were not really in the
+// system they were said to be in, such as meters given as a geographic
+// system, or points of another projection altogether.
+type InverseOutlierError struct {
+	Definition string    // of the system the points were said to be in
+	Points     int       // in the batch
+	Outliers   []Outlier // in the order of the points
+}
+
+func (e *InverseOutlierError) Error() string {
+	o := e.Outliers[0]
+	return fmt.Sprintf("%d of %d points are beyond lon/lat once inverted, such as point %d, (%g, %g) to (%g, %g): are they really in %s?",
+		len(e.Outliers), e.Points, o.Index, o.X, o.Y, o.Lon, o.Lat, e.Definition)
+}
+
+var checkInverse atomic.Bool
+
+// SetCheckInverse turns inverse checking on or off for the whole process.
+// It is off by default.
+//
+// While it is on, Inverse and the Inverse methods of Converter and
+// Transformer check every lon/lat point they give, and fail with an
+// *InverseOutlierError listing the points outside [-180, 180] by
+// [-90, 90] (or the same ranges in the transformer's angular unit),
+// rather than passing the nonsense on. Systems with +over may give
+// longitudes beyond 180 on purpose, and shouldn't be checked.
+func SetCheckInverse(check bool) {
+	checkInverse.Store(check)
+}
+
+// CheckInverse reports whether inverse checking is on
+func CheckInverse() bool {
+	return checkInverse.Load()
+}
+
+// checkingInverse reports whether the transformer checks its inverses,
+// which it does if its Config or the process says so
+func (t *Transformer) checkingInverse() bool {
+	return t.checkInverse || CheckInverse()
+}
+
+// findOutliers returns an *InverseOutlierError for the lon/lat points of
+// output beyond lon/lat, which are the inverses of the x/y points of
+// input, if check is set; unit is the size of the output's unit in
+// radians
+func findOutliers(definition string, input, output []float64, unit float64, check bool) error {
+	if !check {
+		return nil
+	}
+	half := math.Pi / unit
+	var outliers []Outlier
+	for i := 0; i+1 < len(output); i += 2 {
+		if !(math.Abs(output[i]) <= half) || !(math.Abs(output[i+1]) <= half/2.0) {
+			outliers = append(outliers, Outlier{
+				Index: i / 2,
+				X:     input[i], Y: input[i+1],
+				Lon: output[i], Lat: output[i+1],
+			})
+		}
+	}
+	if outliers == nil {
+		return nil
+	}
+	return &InverseOutlierError{Definition: definition, Points: len(output) / 2, Outliers: outliers}
+}
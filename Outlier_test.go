@@ -0,0 +1,75 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckInverse(t *testing.T) {
+	assert := assert.New(t)
+
+	// UTM meters, said to be lon/lat
+	meters := []float64{10.0, 50.0, 500000.0, 5540000.0, 20.0, 60.0}
+
+	// off by default
+	assert.False(proj.CheckInverse())
+	_, err := proj.Inverse("4326", meters)
+	assert.NoError(err)
+
+	proj.SetCheckInverse(true)
+	defer proj.SetCheckInverse(false)
+
+	_, err = proj.Inverse("4326", meters)
+	var outlierErr *proj.InverseOutlierError
+	assert.True(errors.As(err, &outlierErr))
+	assert.Equal("4326", outlierErr.Definition)
+	assert.Equal(3, outlierErr.Points)
+	assert.Equal([]proj.Outlier{{Index: 1, X: 500000.0, Y: 5540000.0, Lon: 500000.0, Lat: 5540000.0}}, outlierErr.Outliers)
+	assert.Contains(err.Error(), "1 of 3 points")
+	assert.Contains(err.Error(), "really in 4326")
+
+	// points which are in the system pass
+	out, err := proj.Inverse("32632", meters[2:4])
+	assert.NoError(err)
+	assert.InDelta(9.0, out[0], 1.0e-6)
+
+	// and projections can give outliers too, such as Mercator allowed
+	// to go round the world
+	over := "+proj=merc +datum=WGS84 +over"
+	_, err = proj.Inverse(over, []float64{0.0, 0.0, 2.5e7, 0.0})
+	assert.True(errors.As(err, &outlierErr))
+	assert.Equal(1, outlierErr.Outliers[0].Index)
+	assert.InDelta(224.6, outlierErr.Outliers[0].Lon, 0.1)
+
+	c, err := proj.NewConverter(over)
+	assert.NoError(err)
+	_, err = c.Inverse([]float64{2.5e7, 0.0})
+	assert.Error(err)
+
+	// transformers check in their own unit, and may be asked to check
+	// while the process doesn't
+	proj.SetCheckInverse(false)
+	tr, err := proj.NewTransformerWithConfig(over, proj.Config{AngularUnit: "rad", CheckInverse: true})
+	assert.NoError(err)
+	out, err = tr.Inverse([]float64{1.0e7, 0.0})
+	assert.NoError(err)
+	assert.InDelta(1.0e7/6378137.0, out[0], 1.0e-9)
+	_, err = tr.Inverse([]float64{2.5e7, 0.0})
+	assert.True(errors.As(err, &outlierErr))
+	assert.InDelta(2.5e7/6378137.0, outlierErr.Outliers[0].Lon, 1.0e-9)
+
+	tr, err = proj.NewTransformerWithConfig("4326", proj.Config{CheckInverse: true})
+	assert.NoError(err)
+	_, err = tr.Inverse(meters)
+	assert.Error(err)
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import "unsafe"
+
+// Point is implemented by the caller's own point types, so that they can
+// be converted without first being flattened into a []float64.
+//
+// XY returns the point's coordinates, lon/lat or x/y; WithXY returns a
+// copy of the point with its coordinates replaced, leaving any other
+// fields as they were.
+type Point[T any] interface {
+	XY() (x, y float64)
+	WithXY(x, y float64) T
+}
+
+// ConvertPoints is Convert for a slice of points
+func ConvertPoints[T Point[T]](proj4 string, points []T) ([]T, error) {
+	output, err := Convert(proj4, flatten(points))
+	if err != nil {
+		return nil, err
+	}
+	return unflatten(points, output), nil
+}
+
+// InversePoints is Inverse for a slice of points
+func InversePoints[T Point[T]](proj4 string, points []T) ([]T, error) {
+	output, err := Inverse(proj4, flatten(points))
+	if err != nil {
+		return nil, err
+	}
+	return unflatten(points, output), nil
+}
+
+func flatten[T Point[T]](points []T) []float64 {
+	flat := make([]float64, 2*len(points))
+	for i, p := range points {
+		flat[2*i], flat[2*i+1] = p.XY()
+	}
+	return flat
+}
+
+func unflatten[T Point[T]](points []T, flat []float64) []T {
+	out := make([]T, len(points))
+	for i, p := range points {
+		out[i] = p.WithXY(flat[2*i], flat[2*i+1])
+	}
+	return out
+}
+
+// ConvertPairs is Convert for points laid out as [][2]float64. The pairs
+// are passed to Convert as they are, without copying, and the output is
+// returned in the same layout, again without copying.
+func ConvertPairs(proj4 string, pairs [][2]float64) ([][2]float64, error) {
+	output, err := Convert(proj4, flatPairs(pairs))
+	if err != nil {
+		return nil, err
+	}
+	return asPairs(output), nil
+}
+
+// InversePairs is Inverse for points laid out as [][2]float64, without
+// copying, as for ConvertPairs
+func InversePairs(proj4 string, pairs [][2]float64) ([][2]float64, error) {
+	output, err := Inverse(proj4, flatPairs(pairs))
+	if err != nil {
+		return nil, err
+	}
+	return asPairs(output), nil
+}
+
+// flatPairs returns the pairs as a []float64 sharing their memory, which
+// is the flat layout already
+func flatPairs(pairs [][2]float64) []float64 {
+	if len(pairs) == 0 {
+		return []float64{}
+	}
+	return unsafe.Slice(&pairs[0][0], 2*len(pairs))
+}
+
+// asPairs returns the flat points as a [][2]float64 sharing their memory
+func asPairs(flat []float64) [][2]float64 {
+	if len(flat) < 2 {
+		return [][2]float64{}
+	}
+	return unsafe.Slice((*[2]float64)(unsafe.Pointer(&flat[0])), len(flat)/2)
+}
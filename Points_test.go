@@ -0,0 +1,81 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+type site struct {
+	Name string
+	X, Y float64
+}
+
+func (s site) XY() (float64, float64) { return s.X, s.Y }
+
+func (s site) WithXY(x, y float64) site {
+	s.X, s.Y = x, y
+	return s
+}
+
+func TestConvertPoints(t *testing.T) {
+	assert := assert.New(t)
+
+	sites := []site{{"b", inputB[0], inputB[1]}}
+
+	xy, err := proj.ConvertPoints("3857", sites)
+	assert.NoError(err)
+	assert.Len(xy, 1)
+	assert.Equal("b", xy[0].Name)
+	assert.InDelta(-8641240.37, xy[0].X, 1e-2)
+	assert.InDelta(4697899.31, xy[0].Y, 1e-2)
+
+	// the input is left alone
+	assert.Equal(inputB[0], sites[0].X)
+
+	lonlat, err := proj.InversePoints("3857", xy)
+	assert.NoError(err)
+	assert.Equal("b", lonlat[0].Name)
+	assert.InDelta(inputB[0], lonlat[0].X, 1e-8)
+	assert.InDelta(inputB[1], lonlat[0].Y, 1e-8)
+
+	_, err = proj.ConvertPoints("+proj=nosuch", sites)
+	assert.Error(err)
+}
+
+func TestConvertPairs(t *testing.T) {
+	assert := assert.New(t)
+
+	pairs := [][2]float64{{inputB[0], inputB[1]}, {0.0, 0.0}}
+
+	xy, err := proj.ConvertPairs("3857", pairs)
+	assert.NoError(err)
+	assert.Len(xy, 2)
+	assert.InDelta(-8641240.37, xy[0][0], 1e-2)
+	assert.InDelta(4697899.31, xy[0][1], 1e-2)
+	assert.InDelta(0.0, xy[1][1], 1e-9)
+
+	// the input is left alone
+	assert.Equal(inputB[0], pairs[0][0])
+
+	lonlat, err := proj.InversePairs("3857", xy)
+	assert.NoError(err)
+	assert.InDelta(inputB[0], lonlat[0][0], 1e-9)
+	assert.InDelta(inputB[1], lonlat[0][1], 1e-9)
+	assert.InDelta(-8641240.37, xy[0][0], 1e-2)
+
+	xy, err = proj.ConvertPairs("3857", nil)
+	assert.NoError(err)
+	assert.Empty(xy)
+
+	_, err = proj.ConvertPairs("bogus", pairs)
+	assert.Error(err)
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+//go:build network
+
+// This test checks the embedded SRID presets against epsg.io, to catch
+// drift from the upstream definitions. It needs network access, so it only
+// runs with:
+//
+//	go test -tags network -run TestPresetsAgainstEPSG .
+
+package proj_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/oahumap/proj/support"
+	"github.com/stretchr/testify/assert"
+)
+
+// the tolerances, in meters and degrees; the presets with a datum shift
+// to WGS 84 are skipped, since epsg.io applies it and Convert doesn't
+const (
+	presetTolerance    = 0.01
+	presetTolerance4DD = 1e-7
+)
+
+// epsgGet fetches a JSON document from epsg.io
+func epsgGet(path string, query url.Values, v interface{}) error {
+	u := "https://epsg.io/" + path
+	if query != nil {
+		u += "?" + query.Encode()
+	}
+	resp, err := http.Get(u)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", u, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// epsgSamples returns lon/lat points in the area of use of the SRID, as
+// shown on its epsg.io map: the middle of the area, and a point part way
+// across it
+func epsgSamples(srid int) ([]float64, error) {
+	var crs struct {
+		Usages []struct {
+			BBox struct {
+				South float64 `json:"south_latitude"`
+				West  float64 `json:"west_longitude"`
+				North float64 `json:"north_latitude"`
+				East  float64 `json:"east_longitude"`
+			} `json:"bbox"`
+		} `json:"usages"`
+	}
+	err := epsgGet(fmt.Sprintf("%d.json", srid), nil, &crs)
+	if err != nil {
+		return nil, err
+	}
+	if len(crs.Usages) == 0 {
+		return nil, fmt.Errorf("no area of use for %d", srid)
+	}
+
+	bbox := crs.Usages[0].BBox
+	if bbox.East < bbox.West {
+		// crosses the antimeridian
+		bbox.East += 360.0
+	}
+	lon := func(f float64) float64 {
+		l := bbox.West + f*(bbox.East-bbox.West)
+		if l > 180.0 {
+			l -= 360.0
+		}
+		return l
+	}
+	lat := func(f float64) float64 {
+		return bbox.South + f*(bbox.North-bbox.South)
+	}
+
+	return []float64{lon(0.5), lat(0.5), lon(0.2), lat(0.7)}, nil
+}
+
+// epsgTransform converts a lon/lat point to the SRID, using epsg.io
+func epsgTransform(srid int, lon, lat float64) (float64, float64, error) {
+	var xyz struct {
+		X json.Number `json:"x"`
+		Y json.Number `json:"y"`
+	}
+	query := url.Values{
+		"x":     {strconv.FormatFloat(lon, 'f', -1, 64)},
+		"y":     {strconv.FormatFloat(lat, 'f', -1, 64)},
+		"s_srs": {"4326"},
+		"t_srs": {strconv.Itoa(srid)},
+	}
+	err := epsgGet("trans", query, &xyz)
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+
+	x, err := xyz.X.Float64()
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+	y, err := xyz.Y.Float64()
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+	return x, y, nil
+}
+
+func TestPresetsAgainstEPSG(t *testing.T) {
+	// check epsg.io is there at all, rather than failing every preset
+	_, err := epsgSamples(4326)
+	if err != nil {
+		t.Skipf("epsg.io is unreachable: %v", err)
+	}
+
+	srids := []int{}
+	for srid, entry := range support.SRIDsTable {
+		if entry.AuthName == "EPSG" {
+			srids = append(srids, srid)
+		}
+	}
+	sort.Ints(srids)
+
+	for _, srid := range srids {
+		srid := srid
+		t.Run(strconv.Itoa(srid), func(t *testing.T) {
+			assert := assert.New(t)
+
+			ps, _ := support.SRIDPreset(srid)
+			if shift, ok := ps.GetAsFloats("towgs84"); ok {
+				for _, v := range shift {
+					if v != 0.0 {
+						t.Skip("datum shift")
+					}
+				}
+			}
+
+			samples, err := epsgSamples(srid)
+			if !assert.NoError(err) {
+				return
+			}
+
+			xys, err := proj.Convert(strconv.Itoa(srid), samples)
+			if !assert.NoError(err) {
+				return
+			}
+
+			tolerance := presetTolerance
+			if proj, _ := ps.GetAsString("proj"); proj == "longlat" {
+				tolerance = presetTolerance4DD
+			}
+
+			for i := 0; i < len(samples); i += 2 {
+				x, y, err := epsgTransform(srid, samples[i], samples[i+1])
+				if !assert.NoError(err) {
+					continue
+				}
+				assert.InDelta(x, xys[i], tolerance, "x of (%g, %g)", samples[i], samples[i+1])
+				assert.InDelta(y, xys[i+1], tolerance, "y of (%g, %g)", samples[i], samples[i+1])
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"runtime/debug"
+)
+
+const modulePath = "github.com/oahumap/proj"
+
+// wgs84Definition is the default hub of every Transformer
+const wgs84Definition = "+proj=longlat +datum=WGS84 +no_defs"
+
+// Provenance is a machine-readable record of what a Transformer does, for
+// attaching to output datasets in workflows which need an audit trail.
+// It marshals to JSON.
+type Provenance struct {
+	Source         string           `json:"source"`
+	Target         string           `json:"target"`
+	Steps          []ProvenanceStep `json:"steps"`
+	Grids          []string         `json:"grids"` // grid files used; always empty for now
+	LibraryVersion string           `json:"library_version"`
+
+	// AssumedIdentity is set if the source and target are not known to
+	// be on the same datum, but no datum shift is applied between them,
+	// because one has no datum information or datum shifts are off, so
+	// that the shift has been assumed to be the identity. When a shift
+	// is applied, it is in the steps instead.
+	AssumedIdentity bool `json:"assumed_identity_datum_shift"`
+}
+
+// ProvenanceStep describes one operation applied by a Transformer
+type ProvenanceStep struct {
+	Operation  string `json:"operation"` // e.g. "utm", or "helmert" for a datum shift
+	Name       string `json:"name"`      // e.g. "Universal Transverse Mercator (UTM)"
+	Parameters string `json:"parameters"`
+}
+
+// Provenance returns the record of the transformer's forward direction;
+// the inverse direction simply has the source and target swapped.
+//
+// The target is the definition as given, which may be an SRID, followed
+// by its proj string if different. The steps' parameters are those
+// actually used, after expanding datums and such. A datum shift, if
+// applied, goes through WGS84, so is a step from the hub's datum to WGS84
+// by its +towgs84, unless the hub is on WGS84, and a step from WGS84 to
+// the target's datum, by the inverse of its +towgs84, unless the target
+// is on WGS84.
+func (t *Transformer) Provenance() *Provenance {
+	p := &Provenance{
+		Source:          t.hub,
+		Target:          t.definition,
+		Steps:           []ProvenanceStep{},
+		Grids:           []string{},
+		LibraryVersion:  libraryVersion(),
+		AssumedIdentity: t.identityShift,
+	}
+
+	if t.resolved != t.definition {
+		p.Target += " " + t.resolved
+	}
+
+	if t.shift && t.hubDatum.known && t.datum.known && t.hubDatum.shift != t.datum.shift {
+		if t.hubDatum.shift != "" {
+			p.Steps = append(p.Steps, ProvenanceStep{
+				Operation:  "helmert",
+				Name:       "Helmert datum shift, from the hub's datum to WGS84",
+				Parameters: "+" + t.hubDatum.shift,
+			})
+		}
+		if t.datum.shift != "" {
+			p.Steps = append(p.Steps, ProvenanceStep{
+				Operation:  "helmert",
+				Name:       "Helmert datum shift, from WGS84 to the target's datum",
+				Parameters: "+inv +" + t.datum.shift,
+			})
+		}
+	}
+
+	if t.conv != nil {
+		desc := t.conv.operation.GetDescription()
+		p.Steps = append(p.Steps, ProvenanceStep{
+			Operation:  desc.ID,
+			Name:       desc.Description,
+			Parameters: t.conv.system.ProjString.Definition(),
+		})
+	}
+
+	return p
+}
+
+// libraryVersion returns the version of this module in the running
+// binary, as recorded by the go tool
+func libraryVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+
+	if info.Main.Path == modulePath {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			if dep.Replace != nil {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+
+	return "unknown"
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvenance(t *testing.T) {
+	assert := assert.New(t)
+
+	tr, err := proj.NewTransformer("32633")
+	assert.NoError(err)
+
+	p := tr.Provenance()
+	assert.Equal("EPSG:4326 +proj=longlat +datum=WGS84 +no_defs", p.Source)
+	assert.Equal("32633 +proj=utm +zone=33 +datum=WGS84 +units=m +no_defs", p.Target)
+	assert.Len(p.Steps, 1)
+	assert.Equal("utm", p.Steps[0].Operation)
+	assert.Equal("Universal Transverse Mercator (UTM)", p.Steps[0].Name)
+	assert.Contains(p.Steps[0].Parameters, "+ellps=WGS84")
+	assert.Empty(p.Grids)
+	assert.NotEmpty(p.LibraryVersion)
+
+	b, err := json.Marshal(p)
+	assert.NoError(err)
+	m := map[string]interface{}{}
+	assert.NoError(json.Unmarshal(b, &m))
+	for _, key := range []string{"source", "target", "steps", "grids", "library_version"} {
+		assert.Contains(m, key)
+	}
+
+	// proj strings are recorded as given; geographic systems have no steps
+	tr, err = proj.NewTransformer("+proj=longlat +ellps=GRS80")
+	assert.NoError(err)
+	p = tr.Provenance()
+	assert.Equal("+proj=longlat +ellps=GRS80", p.Target)
+	assert.Empty(p.Steps)
+}
+
+func TestProvenanceDatumShift(t *testing.T) {
+	assert := assert.New(t)
+
+	ed50UTM31 := "+proj=utm +zone=31 +ellps=intl +towgs84=-87,-98,-121"
+	tr, err := proj.NewTransformer(ed50UTM31)
+	assert.NoError(err)
+
+	// unshifted, the shift is assumed to be the identity
+	p := tr.Provenance()
+	assert.True(p.AssumedIdentity)
+	assert.Len(p.Steps, 1)
+	assert.Equal("utm", p.Steps[0].Operation)
+
+	// shifted, it is a step of its own, before the projection
+	assert.NoError(tr.SetDatumShift(true))
+	p = tr.Provenance()
+	assert.False(p.AssumedIdentity)
+	assert.Len(p.Steps, 2)
+	assert.Equal("helmert", p.Steps[0].Operation)
+	assert.Equal("+inv +towgs84=-87,-98,-121", p.Steps[0].Parameters)
+	assert.Equal("utm", p.Steps[1].Operation)
+
+	// from a hub which is not on WGS84 either, the shift goes through it
+	assert.NoError(tr.SetHub("+proj=longlat +ellps=bessel +towgs84=565.4,50.3,465.6", proj.HubAssumeIdentity))
+	p = tr.Provenance()
+	assert.Len(p.Steps, 3)
+	assert.Equal("+towgs84=565.4,50.3,465.6", p.Steps[0].Parameters)
+	assert.Equal("+inv +towgs84=-87,-98,-121", p.Steps[1].Parameters)
+
+	// and between systems on the same datum, there is none
+	assert.NoError(tr.SetHub("+proj=longlat +ellps=intl +towgs84=-87,-98,-121", proj.HubAssumeIdentity))
+	p = tr.Provenance()
+	assert.False(p.AssumedIdentity)
+	assert.Len(p.Steps, 1)
+}
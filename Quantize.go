@@ -0,0 +1,96 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrQuantizeOverflow is returned when a point lies too far from the
+// grid's origin for its cell to fit in an int32
+var ErrQuantizeOverflow = errors.New("quantized coordinate overflows int32")
+
+// Grid maps x/y coordinates onto integer cells, such as those of a vector
+// tile: the cell of x is round((x - OriginX) / Resolution), and likewise
+// for y.
+type Grid struct {
+	OriginX, OriginY float64 // the coordinates of cell (0, 0)
+	Resolution       float64 // the size of a cell, in the system's units
+	YDown            bool    // whether cell rows increase as y decreases, as in tiles
+}
+
+// cell returns the cell of the point
+func (g Grid) cell(x, y float64) (int32, int32, error) {
+	cx := math.Round((x - g.OriginX) / g.Resolution)
+	cy := (y - g.OriginY) / g.Resolution
+	if g.YDown {
+		cy = -cy
+	}
+	cy = math.Round(cy)
+
+	// also catches NaN
+	if !(cx >= math.MinInt32 && cx <= math.MaxInt32 && cy >= math.MinInt32 && cy <= math.MaxInt32) {
+		return 0, 0, fmt.Errorf("%w: (%g, %g)", ErrQuantizeOverflow, x, y)
+	}
+	return int32(cx), int32(cy), nil
+}
+
+// ForwardInt32 converts lon/lat points, as per Forward, and quantizes them
+// onto the grid without building a float64 output. The cells are appended
+// to output, which may be nil, or a slice to reuse, e.g. output[:0].
+//
+// On error, the cells of the points before the failing one have already
+// been appended.
+func (t *Transformer) ForwardInt32(input []float64, grid Grid, output []int32) ([]int32, error) {
+	start := time.Now()
+	output, err := t.forwardInt32(input, grid, output)
+	t.observe("ForwardInt32", len(input)/2, start, 0, err)
+	return output, err
+}
+
+func (t *Transformer) forwardInt32(input []float64, grid Grid, output []int32) ([]int32, error) {
+	if !(grid.Resolution > 0.0) {
+		return output, fmt.Errorf("grid resolution must be positive")
+	}
+	if err := checkLonLat(input, t.unit, t.validating()); err != nil {
+		return output, err
+	}
+
+	emit := func(i int, x, y float64) error {
+		cx, cy, err := grid.cell(x, y)
+		if err != nil {
+			return err
+		}
+		output = append(output, cx, cy)
+		return nil
+	}
+
+	if t.conv == nil {
+		if len(input)%2 != 0 {
+			return output, fmt.Errorf("input array of lon/lat values must be an even number")
+		}
+		for i := 0; i < len(input); i += 2 {
+			lon, lat := t.geo.fromGreenwich(input[i]*t.unit, input[i+1]*t.unit)
+			err := emit(i, lon, lat)
+			if err != nil {
+				return output, err
+			}
+		}
+		return output, nil
+	}
+
+	if t.unit != degree {
+		input = rescale(input, t.unit/degree)
+	}
+
+	err := t.conv.forEach(input, emit)
+	return output, err
+}
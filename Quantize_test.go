@@ -0,0 +1,76 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformerForwardInt32(t *testing.T) {
+	assert := assert.New(t)
+
+	tr, err := proj.NewTransformer("3857")
+	assert.NoError(err)
+
+	// the single z0 tile, with an extent of 4096
+	const half = 20037508.342789244
+	grid := proj.Grid{OriginX: -half, OriginY: half, Resolution: 2.0 * half / 4096.0, YDown: true}
+
+	input := []float64{0.0, 0.0, 90.0, 0.0, -179.0, 80.0, 12.3, -45.6}
+	cells, err := tr.ForwardInt32(input, grid, nil)
+	assert.NoError(err)
+	assert.Equal([]int32{2048, 2048}, cells[0:2])
+	assert.Equal([]int32{3072, 2048}, cells[2:4])
+
+	xys, err := tr.Forward(input)
+	assert.NoError(err)
+	for i := 0; i < len(xys); i += 2 {
+		assert.Equal(int32(math.Round((xys[i]+half)/grid.Resolution)), cells[i])
+		assert.Equal(int32(math.Round((half-xys[i+1])/grid.Resolution)), cells[i+1])
+	}
+
+	// the output is appended to
+	reused, err := tr.ForwardInt32(input[0:2], grid, cells[:0])
+	assert.NoError(err)
+	assert.Equal([]int32{2048, 2048}, reused)
+	assert.Equal(&cells[0], &reused[0])
+
+	// y up
+	grid.YDown = false
+	cells, err = tr.ForwardInt32([]float64{0.0, 10.0}, grid, nil)
+	assert.NoError(err)
+	assert.Equal([]int32{2048, -1934}, cells)
+
+	// too far from the origin
+	grid.Resolution = 0.001
+	_, err = tr.ForwardInt32([]float64{170.0, 0.0}, grid, nil)
+	assert.ErrorIs(err, proj.ErrQuantizeOverflow)
+
+	grid.Resolution = 0.0
+	_, err = tr.ForwardInt32(input, grid, nil)
+	assert.Error(err)
+
+	_, err = tr.ForwardInt32([]float64{1.0}, proj.Grid{Resolution: 1.0}, nil)
+	assert.Error(err)
+}
+
+func TestTransformerForwardInt32Geographic(t *testing.T) {
+	assert := assert.New(t)
+
+	tr, err := proj.NewTransformer("4326")
+	assert.NoError(err)
+
+	grid := proj.Grid{OriginX: -180.0, OriginY: 90.0, Resolution: 0.5, YDown: true}
+	cells, err := tr.ForwardInt32([]float64{0.0, 0.0, -180.0, 90.0, 10.2, -10.2}, grid, nil)
+	assert.NoError(err)
+	assert.Equal([]int32{360, 180, 0, 0, 380, 200}, cells)
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/oahumap/proj/core"
+)
+
+type registryJSON struct {
+	GeneralParameters []core.Parameter `json:"general_parameters"`
+	Operations        []operationJSON  `json:"operations"`
+}
+
+type operationJSON struct {
+	ID         string           `json:"id"`
+	Name       string           `json:"name"`
+	Notes      string           `json:"notes,omitempty"`
+	Parameters []core.Parameter `json:"parameters"`
+	Domain     *domainJSON      `json:"domain,omitempty"`
+}
+
+type domainJSON struct {
+	MinLon float64 `json:"min_lon"`
+	MaxLon float64 `json:"max_lon"`
+	MinLat float64 `json:"min_lat"`
+	MaxLat float64 `json:"max_lat"`
+}
+
+// RegistryJSON describes every registered operation, and the parameters
+// it takes, as JSON, for tools which generate documentation or validate
+// proj strings. Operations registered from outside this repo, with
+// core.RegisterConvertLPToXY and core.RegisterParameters, are included.
+//
+// The parameters all operations take are listed once, as
+// "general_parameters"; each operation lists only its own. Operation
+// domains are in degrees, with longitudes relative to lon_0.
+func RegistryJSON() ([]byte, error) {
+	registry := registryJSON{
+		GeneralParameters: core.GeneralParameters,
+		Operations:        []operationJSON{},
+	}
+
+	for _, desc := range core.OperationDescriptionTable {
+		op := operationJSON{
+			ID:         desc.ID,
+			Name:       desc.Description,
+			Notes:      strings.Join(strings.Fields(desc.Description2), " "),
+			Parameters: desc.Parameters,
+		}
+		if op.Parameters == nil {
+			op.Parameters = []core.Parameter{}
+		}
+		if d := desc.Domain; d != nil {
+			op.Domain = &domainJSON{MinLon: d.MinLam, MaxLon: d.MaxLam, MinLat: d.MinPhi, MaxLat: d.MaxPhi}
+		}
+		registry.Operations = append(registry.Operations, op)
+	}
+	sort.Slice(registry.Operations, func(i, j int) bool {
+		return registry.Operations[i].ID < registry.Operations[j].ID
+	})
+
+	return json.MarshalIndent(registry, "", "  ")
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := proj.RegistryJSON()
+	assert.NoError(err)
+
+	type parameter struct {
+		Name, Type, Unit, Default, Description string
+	}
+	var registry struct {
+		GeneralParameters []parameter `json:"general_parameters"`
+		Operations        []struct {
+			ID, Name, Notes string
+			Parameters      []parameter
+			Domain          *struct {
+				MinLon float64 `json:"min_lon"`
+				MaxLat float64 `json:"max_lat"`
+			}
+		}
+	}
+	assert.NoError(json.Unmarshal(b, &registry))
+
+	assert.Equal(parameter{Name: "proj", Type: "string", Description: "the operation"}, registry.GeneralParameters[0])
+
+	ids := []string{}
+	for _, op := range registry.Operations {
+		ids = append(ids, op.ID)
+		assert.NotNil(op.Parameters, op.ID)
+
+		switch op.ID {
+		case "merc":
+			assert.Equal("Cyl, Sph&Ell lat_ts=", op.Notes)
+			assert.Equal([]parameter{{
+				Name: "lat_ts", Type: "angle", Unit: "degrees",
+				Description: "latitude of true scale; overrides k_0",
+			}, {
+				Name: "lat_max", Type: "angle", Unit: "degrees",
+				Description: "latitude beyond which the pole policy applies",
+			}}, op.Parameters)
+			assert.Nil(op.Domain)
+		case "utm":
+			assert.Equal("zone", op.Parameters[0].Name)
+			assert.Equal("int", op.Parameters[0].Type)
+			assert.Equal(parameter{Name: "south", Type: "flag", Description: "southern hemisphere"}, op.Parameters[1])
+			assert.Equal(-45.0, op.Domain.MinLon)
+			assert.Equal(90.0, op.Domain.MaxLat)
+		case "omerc":
+			assert.Len(op.Parameters, 10)
+		case "august":
+			assert.Empty(op.Parameters)
+		}
+	}
+	assert.True(sort.StringsAreSorted(ids))
+	assert.Subset(ids, []string{"aea", "airy", "august", "eqc", "etmerc", "lcc", "leac", "merc", "labrd", "omerc", "tmerc", "utm", "wintri"})
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oahumap/proj/support"
+)
+
+// FromSRID returns the proj4 definition PostGIS uses for the given SRID.
+//
+// Using the same definitions as the database (rather than those from
+// epsg.io, which occasionally differ) means coordinates round-trip exactly
+// with what PostGIS would compute.
+//
+// Aliases, such as 900913 for 3857, give their system's definition. A
+// deprecated SRID, such as 3785, fails with a SupersededError naming its
+// replacement, unless SetRedirectSuperseded is on.
+func FromSRID(srid int) (string, error) {
+	srid, err := redirect(srid)
+	if err != nil {
+		return "", err
+	}
+
+	entry, ok := support.SRIDsTable[srid]
+	if !ok {
+		return "", fmt.Errorf("unknown srid: %d", srid)
+	}
+	return entry.Definition, nil
+}
+
+// ResolveDefinition returns the proj string of the given definition, as
+// the functions which take one resolve it: a proj string, an SRID, an OGC
+// identifier of one or WKT. The result is the caller's to modify.
+func ResolveDefinition(def string) (*support.ProjString, error) {
+	return resolveDefinition(def)
+}
+
+// resolveDefinition parses the given definition, which may be either a
+// proj4 string or an SRID such as "3857" or "EPSG:3857". SRIDs come from
+// the precompiled presets, so need no parsing.
+//
+// WKT is resolved by the EPSG or ESRI code it gives for the CRS, if that
+// is one of the presets, and otherwise parsed with support.ParseWKT. An
+// empty definition is the default target CRS.
+func resolveDefinition(def string) (*support.ProjString, error) {
+	if strings.TrimSpace(def) == "" {
+		_, target := DefaultCRS()
+		if target == "" {
+			return nil, ErrNoDefaultCRS
+		}
+		def = target
+	}
+
+	srid, ok := parseSRID(def)
+	if ok && isWKT(def) {
+		_, preset := support.SRIDPreset(srid)
+		_, superseded := support.SupersededTable[srid]
+		ok = preset || superseded
+	}
+	if !ok {
+		if isWKT(def) {
+			return support.ParseWKT(def)
+		}
+		return support.NewProjString(def)
+	}
+
+	srid, err := redirect(srid)
+	if err != nil {
+		return nil, err
+	}
+
+	ps, ok := support.SRIDPreset(srid)
+	if !ok {
+		return nil, fmt.Errorf("unknown srid: %d", srid)
+	}
+	return ps, nil
+}
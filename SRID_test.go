@@ -0,0 +1,245 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromSRID(t *testing.T) {
+	assert := assert.New(t)
+
+	def, err := proj.FromSRID(3857)
+	assert.NoError(err)
+	assert.Contains(def, "+nadgrids=@null")
+
+	_, err = proj.FromSRID(9999)
+	assert.Error(err)
+
+	// an SRID and its definition give the same results
+	for _, code := range []int{3395, 3857, 4087, 32633} {
+		srid := strconv.Itoa(code)
+		def, err := proj.FromSRID(code)
+		assert.NoError(err)
+
+		a, err := proj.Convert(srid, inputA)
+		assert.NoError(err, srid)
+		b, err := proj.Convert(def, inputA)
+		assert.NoError(err, srid)
+		assert.Equal(a, b, srid)
+
+		inv, err := proj.Inverse(srid, a)
+		assert.NoError(err, srid)
+		assert.InDeltaSlice(inputA, inv, 1.0e-8, srid)
+	}
+
+	// the Plate Carree codes are all the same system
+	expected, err := proj.Convert("4087", inputA)
+	assert.NoError(err)
+	for _, srid := range []string{"32662", "54001", "ESRI:54001"} {
+		actual, err := proj.Convert(srid, inputA)
+		assert.NoError(err, srid)
+		assert.Equal(expected, actual, srid)
+	}
+
+	// the Laborde grid is the same from Paris and from Greenwich, with its
+	// false origin at the center of Madagascar
+	madagascar := []float64{46.4372291666667, -18.9, 47.5, -18.9, 44.0, -25.0, 49.3, -12.0}
+	expected, err = proj.Convert("8441", madagascar)
+	assert.NoError(err)
+	assert.InDelta(400000.0, expected[0], 1.0e-6)
+	assert.InDelta(800000.0, expected[1], 1.0e-6)
+	actual, err := proj.Convert("29701", madagascar)
+	assert.NoError(err)
+	assert.InDeltaSlice(expected, actual, 1.0e-6)
+	inv, err := proj.Inverse("8441", expected)
+	assert.NoError(err)
+	assert.InDeltaSlice(madagascar, inv, 1.0e-6)
+
+	// the Hawaii State Plane zones have their false origins at their
+	// origins, on NAD83(PA11) and on the Old Hawaiian datum, in meters and
+	// in US survey feet
+	for _, tc := range []struct {
+		srid     string
+		lon, lat float64
+		x        float64
+	}{
+		{"6628", -155.5, 18.83333333333333, 500000.0},
+		{"6629", -156.6666666666667, 20.33333333333333, 500000.0},
+		{"6630", -158.0, 21.16666666666667, 500000.0},
+		{"6631", -159.5, 21.83333333333333, 500000.0},
+		{"6632", -160.1666666666667, 21.66666666666667, 500000.0},
+		{"6633", -158.0, 21.16666666666667, 1640416.67},
+		{"3561", -155.5, 18.83333333333333, 500000.0},
+		{"3562", -156.6666666666667, 20.33333333333333, 500000.0},
+		{"3563", -158.0, 21.16666666666667, 500000.0},
+		{"3564", -159.5, 21.83333333333333, 500000.0},
+		{"3565", -160.1666666666667, 21.66666666666667, 500000.0},
+		{"102007", -157.0, 13.0, 0.0},
+	} {
+		xy, err := proj.Convert(tc.srid, []float64{tc.lon, tc.lat})
+		assert.NoError(err, tc.srid)
+		assert.InDeltaSlice([]float64{tc.x, 0.0}, xy, 1.0e-4, tc.srid)
+	}
+
+	// Honolulu in the Oahu zone, in meters and in feet, and in the UTM
+	// zone on either datum
+	honolulu := []float64{-157.8583, 21.3069}
+	meters, err := proj.Convert("6630", honolulu)
+	assert.NoError(err)
+	assert.InDelta(514702.1, meters[0], 0.1)
+	assert.InDelta(15533.1, meters[1], 0.1)
+	feet, err := proj.Convert("6633", honolulu)
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{(meters[0] + 0.001016) / 0.3048006096012192, meters[1] / 0.3048006096012192}, feet, 1.0e-4)
+	expected, err = proj.Convert("32604", honolulu)
+	assert.NoError(err)
+	actual, err = proj.Convert("6634", honolulu)
+	assert.NoError(err)
+	assert.InDeltaSlice(expected, actual, 1.0e-3)
+	area, err := proj.AreaOf("6630")
+	assert.NoError(err)
+	assert.True(area.Contains(honolulu[0], honolulu[1]))
+
+	// the Lo grids are transverse Mercator with westings and southings:
+	// Cape Town is west of 19°E, so its westing is positive
+	capeTown := []float64{18.4241, -33.9249}
+	expected, err = proj.Convert("+proj=tmerc +lat_0=0 +lon_0=19 +k=1 +x_0=0 +y_0=0 +ellps=WGS84 +units=m", capeTown)
+	assert.NoError(err)
+	lo19, err := proj.NewTransformer("2048")
+	assert.NoError(err)
+	actual, err = lo19.Forward(capeTown)
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{-expected[0], -expected[1]}, actual, 1.0e-6)
+	assert.True(actual[0] > 0.0 && actual[1] > 0.0)
+	back, err := lo19.Inverse(actual)
+	assert.NoError(err)
+	assert.InDeltaSlice(capeTown, back, 1.0e-9)
+
+	// the EASE-Grids, at the corners NSIDC gives for them: EASE-Grid 2.0
+	// North and South span 9000 km each way from the pole, and Global is
+	// 1388 columns of 25025.26 m
+	for _, tc := range []struct {
+		srid   string
+		lonlat []float64
+		xy     []float64
+	}{
+		{"6931", []float64{-135.0, -84.6340497}, []float64{-9000000.0, 9000000.0}},
+		{"6932", []float64{-45.0, 84.6340497}, []float64{-9000000.0, 9000000.0}},
+		{"6933", []float64{-180.0, 85.0445664}, []float64{-17367530.45, 7314540.83}},
+		{"6933", []float64{180.0, -85.0445664}, []float64{694.0 * 25025.26, -7314540.83}},
+	} {
+		actual, err := proj.Convert(tc.srid, tc.lonlat)
+		assert.NoError(err, tc.srid)
+		assert.InDeltaSlice(tc.xy, actual, 0.02, tc.srid)
+
+		// the corners on the antimeridian may come back on its other side
+		back, err := proj.Inverse(tc.srid, tc.xy)
+		assert.NoError(err, tc.srid)
+		assert.InDelta(0.0, math.Remainder(back[0]-tc.lonlat[0], 360.0), 1.0e-7, tc.srid)
+		assert.InDelta(tc.lonlat[1], back[1], 1.0e-7, tc.srid)
+	}
+
+	// the original EASE-Grids are on a sphere of 6371228 m: the polar
+	// grids put the equator R√2 from the pole, and Global is 1383 columns
+	// of 25067.525 m, to the precision NSIDC gives the cell size
+	r := 6371228.0
+	for _, tc := range []struct {
+		srid   string
+		lonlat []float64
+		xy     []float64
+		delta  float64
+	}{
+		{"3408", []float64{0.0, 90.0}, []float64{0.0, 0.0}, 1.0e-6},
+		{"3408", []float64{0.0, 0.0}, []float64{0.0, -r * math.Sqrt2}, 1.0e-6},
+		{"3409", []float64{90.0, 0.0}, []float64{r * math.Sqrt2, 0.0}, 1.0e-6},
+		{"3410", []float64{180.0, 0.0}, []float64{691.5 * 25067.525, 0.0}, 1.0},
+	} {
+		actual, err := proj.Convert(tc.srid, tc.lonlat)
+		assert.NoError(err, tc.srid)
+		assert.InDeltaSlice(tc.xy, actual, tc.delta, tc.srid)
+	}
+
+	// ETRS89-LAEA Europe, with the example of EPSG's Guidance Note 7-2;
+	// ETRS89 is WGS 84 to within a meter, so no shift is made either way
+	laea, err := proj.Convert("3035", []float64{5.0, 50.0})
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{3962799.45, 2999718.85}, laea, 0.01)
+	back, err = proj.Inverse("EPSG:3035", laea)
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{5.0, 50.0}, back, 1.0e-9)
+
+	// the stereographic presets, with the examples of EPSG's Guidance Note
+	// 7-2: UPS North is its polar stereographic variant A, and 3031 its
+	// variant B turned 70 degrees to the central meridian of 0. RD New is
+	// on the Bessel ellipsoid, and no datum shift is made by default
+	for _, tc := range []struct {
+		srid   string
+		lonlat []float64
+		xy     []float64
+	}{
+		{"32661", []float64{44.0, 73.0}, []float64{3320416.75, 632668.43}},
+		{"3031", []float64{50.0, -75.0}, []float64{1255380.79, 1053389.56}},
+		{"28992", []float64{6.0, 53.0}, []float64{196105.283, 557057.739}},
+	} {
+		actual, err := proj.Convert(tc.srid, tc.lonlat)
+		assert.NoError(err, tc.srid)
+		assert.InDeltaSlice(tc.xy, actual, 0.01, tc.srid)
+		back, err := proj.Inverse(tc.srid, tc.xy)
+		assert.NoError(err, tc.srid)
+		assert.InDeltaSlice(tc.lonlat, back, 1.0e-7, tc.srid)
+	}
+
+	// the planetary presets need their IAU prefix. A degree of longitude
+	// on the equator of Mars is 59.27 km, and a pixel of LOLA's 64
+	// pixels-per-degree equirectangular grids of the Moon is 0.4738 km
+	out, err := proj.Convert("IAU:49910", []float64{1.0, 0.0})
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{59274.6975, 0.0}, out, 1.0e-3)
+	out, err = proj.Convert("IAU2000:30110", []float64{1.0 / 64.0, -1.0 / 64.0})
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{473.8024, -473.8024}, out, 1.0e-3)
+	out, err = proj.Inverse("urn:ogc:def:crs:IAU2000::30110", out)
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{1.0 / 64.0, -1.0 / 64.0}, out, 1.0e-12)
+	area, err = proj.AreaOf("IAU:49900")
+	assert.NoError(err)
+	assert.Equal("Mars", area.Name)
+	for _, srid := range []string{"49910", "EPSG:49910", "IAU:3857", "IAU:49999"} {
+		_, err := proj.Convert(srid, []float64{1.0, 0.0})
+		assert.Error(err, srid)
+	}
+
+	// geographic systems pass through unchanged
+	out, err = proj.Convert("4326", inputA)
+	assert.NoError(err)
+	assert.Equal(inputA, out)
+}
+
+func TestResolveDefinition(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, def := range []string{"3857", "EPSG:3857", "urn:ogc:def:crs:EPSG::3857", "900913", "ESRI:102100"} {
+		ps, err := proj.ResolveDefinition(def)
+		assert.NoError(err, def)
+		id, _ := ps.GetAsString("proj")
+		assert.Equal("merc", id, def)
+	}
+
+	ps, err := proj.ResolveDefinition("+proj=lcc +lat_1=33 +ellps=GRS80")
+	assert.NoError(err)
+	assert.Equal("+proj=lcc +lat_1=33 +ellps=GRS80", ps.Definition())
+
+	_, err = proj.ResolveDefinition("999999")
+	assert.Error(err)
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/oahumap/proj/support"
+)
+
+// ConvertSimplified converts a line of lon/lat points, laid out as for
+// Convert, to the projected system and simplifies it there, by
+// Douglas-Peucker: points are dropped as long as the line stays within
+// tolerance of the original. The tolerance is in the units of the
+// system, usually meters, rather than in degrees, whose length on the
+// ground varies with latitude.
+//
+// The kept points are returned, converted, along with their point
+// indices in the input. The first and last points are always kept.
+func ConvertSimplified(proj4 string, input []float64, tolerance float64) ([]float64, []int, error) {
+	output, err := Convert(proj4, input)
+	if err != nil {
+		return nil, nil, err
+	}
+	return simplify(output, tolerance)
+}
+
+// ForwardSimplified is ConvertSimplified for the transformer
+func (t *Transformer) ForwardSimplified(input []float64, tolerance float64) ([]float64, []int, error) {
+	output, err := t.Forward(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	return simplify(output, tolerance)
+}
+
+// simplify returns the points of the line kept by Douglas-Peucker, and
+// their indices
+func simplify(line []float64, tolerance float64) ([]float64, []int, error) {
+	if !(tolerance >= 0.0) {
+		return nil, nil, fmt.Errorf("tolerance must not be negative")
+	}
+	if len(line)%2 != 0 {
+		return nil, nil, fmt.Errorf("input array of points must be an even number")
+	}
+
+	n := len(line) / 2
+	if n < 3 {
+		return append([]float64{}, line...), identityIndices(n), nil
+	}
+
+	keep := make([]bool, n)
+	keep[0], keep[n-1] = true, true
+
+	// a stack rather than recursion, since lines can be long
+	type span struct{ first, last int }
+	stack := []span{{0, n - 1}}
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		farthest, maxDistance := -1, tolerance
+		for i := s.first + 1; i < s.last; i++ {
+			d := segmentDistance(line, i, s.first, s.last)
+			if d > maxDistance {
+				farthest, maxDistance = i, d
+			}
+		}
+		if farthest < 0 {
+			continue
+		}
+		keep[farthest] = true
+		stack = append(stack, span{s.first, farthest}, span{farthest, s.last})
+	}
+
+	output := []float64{}
+	indices := []int{}
+	for i, k := range keep {
+		if k {
+			output = append(output, line[2*i], line[2*i+1])
+			indices = append(indices, i)
+		}
+	}
+	return output, indices, nil
+}
+
+// segmentDistance returns the distance from point i of the line to the
+// segment between points a and b
+func segmentDistance(line []float64, i, a, b int) float64 {
+	return pointSegmentDistance(line[2*i], line[2*i+1], line[2*a], line[2*a+1], line[2*b], line[2*b+1])
+}
+
+// pointSegmentDistance returns the distance from point p to the segment
+// between points a and b
+func pointSegmentDistance(px, py, ax, ay, bx, by float64) float64 {
+	dx, dy := bx-ax, by-ay
+
+	length2 := dx*dx + dy*dy
+	if length2 == 0.0 {
+		return support.Hypot(px-ax, py-ay)
+	}
+	t := ((px-ax)*dx + (py-ay)*dy) / length2
+	t = math.Max(0.0, math.Min(1.0, t))
+	return support.Hypot(px-(ax+t*dx), py-(ay+t*dy))
+}
+
+func identityIndices(n int) []int {
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertSimplified(t *testing.T) {
+	assert := assert.New(t)
+
+	// up the central meridian of UTM zone 32, which is straight, with a
+	// detour of about 7.9 m east at point 50
+	line := []float64{}
+	for i := 0; i <= 100; i++ {
+		lon := 9.0
+		if i == 50 {
+			lon += 0.0001
+		}
+		line = append(line, lon, 45.0+0.01*float64(i))
+	}
+
+	xy, kept, err := proj.ConvertSimplified("32632", line, 5.0)
+	assert.NoError(err)
+	assert.Equal([]int{0, 49, 50, 51, 100}, kept)
+	assert.Len(xy, 10)
+	assert.InDelta(500000.0, xy[0], 1.0e-6)
+	assert.InDelta(500007.9, xy[4], 0.1)
+
+	_, kept, err = proj.ConvertSimplified("32632", line, 10.0)
+	assert.NoError(err)
+	assert.Equal([]int{0, 100}, kept)
+
+	// a zero tolerance keeps every point off the line
+	_, kept, err = proj.ConvertSimplified("32632", line, 0.0)
+	assert.NoError(err)
+	assert.Contains(kept, 50)
+
+	tr, err := proj.NewTransformer("32632")
+	assert.NoError(err)
+	expected, _, _ := proj.ConvertSimplified("32632", line, 5.0)
+	actual, kept, err := tr.ForwardSimplified(line, 5.0)
+	assert.NoError(err)
+	assert.Equal(expected, actual)
+	assert.Equal([]int{0, 49, 50, 51, 100}, kept)
+
+	// short lines are kept whole
+	xy, kept, err = proj.ConvertSimplified("32632", line[:4], 1000.0)
+	assert.NoError(err)
+	assert.Len(xy, 4)
+	assert.Equal([]int{0, 1}, kept)
+
+	_, _, err = proj.ConvertSimplified("32632", line, -1.0)
+	assert.Error(err)
+	_, _, err = proj.ConvertSimplified("32632", line[:3], 1.0)
+	assert.Error(err)
+}
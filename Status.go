@@ -0,0 +1,179 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"math"
+	"time"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/support"
+)
+
+// PointStatus flags the quality of a single converted point. It is a bit
+// set: a point may, for example, be both clamped and outside the domain.
+type PointStatus uint8
+
+// The point statuses
+const (
+	StatusOK            PointStatus = 0
+	StatusClamped       PointStatus = 1 << 0 // latitude slightly beyond a pole, clamped to it
+	StatusOutsideDomain PointStatus = 1 << 1 // extrapolated outside the operation's valid domain
+	StatusGridFallback  PointStatus = 1 << 2 // a grid was missing and a fallback used (not yet produced)
+	StatusFailed        PointStatus = 1 << 3 // no result; the output values are NaN
+	StatusOtherZone     PointStatus = 1 << 4 // beyond the UTM zone; see ForwardUTM
+)
+
+func (s PointStatus) String() string {
+	if s == StatusOK {
+		return "ok"
+	}
+	names := []string{"clamped", "outside-domain", "grid-fallback", "failed", "other-zone"}
+	str := ""
+	for i, name := range names {
+		if s&(1<<uint(i)) != 0 {
+			if str != "" {
+				str += "|"
+			}
+			str += name
+		}
+	}
+	return str
+}
+
+// ForwardWithStatus is like Forward, but rather than failing the whole
+// batch when a point can't be converted, it marks that point as failed,
+// as it does a point whose result isn't finite. The returned statuses
+// parallel the points (not the array values).
+//
+// An error is only returned if the input as a whole is malformed.
+func (t *Transformer) ForwardWithStatus(input []float64) ([]float64, []PointStatus, error) {
+	start := time.Now()
+	output, status, err := t.forwardWithStatus(input)
+	t.observe("ForwardWithStatus", len(input)/2, start, countFailed(status), err)
+	return output, status, err
+}
+
+func (t *Transformer) forwardWithStatus(input []float64) ([]float64, []PointStatus, error) {
+	if t.conv == nil || len(input)%2 != 0 {
+		output, err := t.forward(input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return output, make([]PointStatus, len(input)/2), nil
+	}
+
+	output := make([]float64, len(input))
+	status := make([]PointStatus, len(input)/2)
+
+	lp := &core.CoordLP{}
+
+	validate := t.validating()
+
+	for i := 0; i < len(input); i += 2 {
+		s := &status[i/2]
+
+		if validate && checkPoint(input, i, t.unit) != nil {
+			*s |= StatusFailed
+			output[i], output[i+1] = math.NaN(), math.NaN()
+			continue
+		}
+
+		lp.Lam = input[i] * t.unit
+		lp.Phi = input[i+1] * t.unit
+
+		// judge the domain by where the point ends up
+		at := *lp
+		if math.Abs(lp.Phi) > support.PiOverTwo {
+			*s |= StatusClamped
+			at.Phi = math.Copysign(support.PiOverTwo, lp.Phi)
+		}
+		if !t.conv.system.InDomain(&at) {
+			*s |= StatusOutsideDomain
+		}
+
+		xy, err := t.conv.project(lp)
+		if err != nil || !isFinite(xy.X) || !isFinite(xy.Y) {
+			*s |= StatusFailed
+			output[i], output[i+1] = math.NaN(), math.NaN()
+			continue
+		}
+
+		output[i] = xy.X
+		output[i+1] = xy.Y
+	}
+
+	return output, status, nil
+}
+
+// InverseWithStatus is like Inverse, but marks the points which can't be
+// converted as failed instead of failing the whole batch, as per
+// ForwardWithStatus. Points whose result lies outside the operation's
+// valid domain are flagged as such.
+//
+// Stats are not recorded.
+func (t *Transformer) InverseWithStatus(input []float64) ([]float64, []PointStatus, error) {
+	start := time.Now()
+	output, status, err := t.inverseWithStatus(input)
+	t.observe("InverseWithStatus", len(input)/2, start, countFailed(status), err)
+	return output, status, err
+}
+
+func (t *Transformer) inverseWithStatus(input []float64) ([]float64, []PointStatus, error) {
+	if t.conv == nil || len(input)%2 != 0 {
+		output, err := t.inverse(input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return output, make([]PointStatus, len(input)/2), nil
+	}
+
+	output := make([]float64, len(input))
+	status := make([]PointStatus, len(input)/2)
+
+	xy := &core.CoordXY{}
+
+	for i := 0; i < len(input); i += 2 {
+		s := &status[i/2]
+
+		xy.X = input[i]
+		xy.Y = input[i+1]
+
+		lp, err := t.conv.unproject(xy)
+		if err != nil || !isFinite(lp.Lam) || !isFinite(lp.Phi) {
+			*s |= StatusFailed
+			output[i], output[i+1] = math.NaN(), math.NaN()
+			continue
+		}
+
+		if !t.conv.system.InDomain(lp) {
+			*s |= StatusOutsideDomain
+		}
+
+		output[i] = lp.Lam / t.unit
+		output[i+1] = lp.Phi / t.unit
+	}
+
+	return output, status, nil
+}
+
+// isFinite reports whether v is neither NaN nor infinite
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// countFailed returns the number of points marked StatusFailed
+func countFailed(status []PointStatus) int {
+	n := 0
+	for _, s := range status {
+		if s&StatusFailed != 0 {
+			n++
+		}
+	}
+	return n
+}
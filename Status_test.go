@@ -0,0 +1,84 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForwardWithStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	tr, err := proj.NewTransformer("+proj=utm +zone=32 +ellps=GRS80")
+	assert.NoError(err)
+
+	input := []float64{
+		12.0, 55.0, // fine
+		60.0, 55.0, // 51 degrees from the central meridian
+		9.0, 90.00000000001, // just past the pole
+		9.0, 95.0, // well past it
+	}
+
+	output, status, err := tr.ForwardWithStatus(input)
+	assert.NoError(err)
+	assert.Equal([]proj.PointStatus{
+		proj.StatusOK,
+		proj.StatusOutsideDomain,
+		proj.StatusClamped,
+		proj.StatusClamped | proj.StatusFailed,
+	}, status)
+
+	assert.InDelta(691875.63, output[0], 1e-2)
+	assert.InDelta(6098907.83, output[1], 1e-2)
+	assert.True(math.IsNaN(output[6]))
+	assert.True(math.IsNaN(output[7]))
+
+	// the batch as a whole still fails with the plain Forward
+	_, err = tr.Forward(input)
+	assert.Error(err)
+
+	_, _, err = tr.ForwardWithStatus(input[:3])
+	assert.Error(err)
+
+	// a result which isn't finite fails too, though the operation gives
+	// no error for it
+	output, status, err = tr.ForwardWithStatus([]float64{math.NaN(), 55.0, 12.0, math.Inf(1)})
+	assert.NoError(err)
+	assert.Equal(proj.StatusFailed, status[0]&proj.StatusFailed)
+	assert.Equal(proj.StatusFailed, status[1]&proj.StatusFailed)
+	for _, v := range output {
+		assert.True(math.IsNaN(v))
+	}
+}
+
+func TestInverseWithStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	tr, err := proj.NewTransformer("+proj=lcc +ellps=GRS80 +lat_1=30 +lat_2=60")
+	assert.NoError(err)
+
+	xy, err := tr.Forward([]float64{10.0, 45.0})
+	assert.NoError(err)
+
+	output, status, err := tr.InverseWithStatus([]float64{xy[0], xy[1], math.NaN(), 0.0})
+	assert.NoError(err)
+	assert.Equal([]proj.PointStatus{proj.StatusOK, proj.StatusFailed}, status)
+	assert.InDeltaSlice([]float64{10.0, 45.0}, output[:2], 1e-8)
+	assert.True(math.IsNaN(output[2]))
+}
+
+func TestPointStatusString(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("ok", proj.StatusOK.String())
+	assert.Equal("clamped|failed", (proj.StatusClamped | proj.StatusFailed).String())
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/oahumap/proj/support"
+)
+
+// StreamResult is a converted chunk of points from a stream, or the
+// error converting it
+type StreamResult struct {
+	Points []float64
+	Err    error
+}
+
+// ForwardStream converts the chunks of lon/lat points read from in, as
+// per Forward, using a pool of workers, and sends the results out in the
+// same order as the chunks came in. The output is closed once in is
+// closed and drained, or ctx is done.
+//
+// At most a few chunks per worker are in memory at once: if the output
+// isn't read, reading from in stops too. A chunk which fails to convert
+// is reported in its result, and the stream carries on.
+//
+// If workers is zero or less, the Workers of the transformer's Config
+// are used, or GOMAXPROCS if that isn't set either. Each worker has its
+// own copy of the transformer, so the transformer's Stats are not
+// updated.
+func (t *Transformer) ForwardStream(ctx context.Context, in <-chan []float64, workers int) (<-chan StreamResult, error) {
+	return t.stream(ctx, in, workers, (*Transformer).Forward)
+}
+
+// InverseStream is ForwardStream for x/y points, as per Inverse
+func (t *Transformer) InverseStream(ctx context.Context, in <-chan []float64, workers int) (<-chan StreamResult, error) {
+	return t.stream(ctx, in, workers, (*Transformer).Inverse)
+}
+
+type streamJob struct {
+	points []float64
+	result chan StreamResult
+}
+
+func (t *Transformer) stream(ctx context.Context, in <-chan []float64, workers int,
+	convert func(*Transformer, []float64) ([]float64, error)) (<-chan StreamResult, error) {
+
+	if workers <= 0 {
+		workers = t.workers
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	// clone them all before starting any, so that a failure doesn't leave
+	// workers waiting for jobs which never come
+	clones := make([]*Transformer, workers)
+	for i := range clones {
+		worker, err := t.clone()
+		if err != nil {
+			return nil, err
+		}
+		clones[i] = worker
+	}
+
+	jobs := make(chan streamJob)
+	for _, worker := range clones {
+		go func() {
+			for job := range jobs {
+				points, err := convert(worker, job.points)
+				job.result <- StreamResult{Points: points, Err: err}
+			}
+		}()
+	}
+
+	// the results, in the order of the input, waiting to be sent; the
+	// buffer bounds how many chunks are in flight
+	pending := make(chan chan StreamResult, workers)
+
+	go func() {
+		defer close(jobs)
+		defer close(pending)
+		for {
+			var points []float64
+			var ok bool
+			select {
+			case <-ctx.Done():
+				return
+			case points, ok = <-in:
+				if !ok {
+					return
+				}
+			}
+
+			job := streamJob{points: points, result: make(chan StreamResult, 1)}
+			select {
+			case <-ctx.Done():
+				return
+			case pending <- job.result:
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- job:
+			}
+		}
+	}()
+
+	out := make(chan StreamResult)
+
+	go func() {
+		defer close(out)
+		for result := range pending {
+			var r StreamResult
+			select {
+			case <-ctx.Done():
+				return
+			case r = <-result:
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- r:
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// clone returns a transformer with the same settings, but its own
+// conversion objects, so that the two can be used concurrently
+func (t *Transformer) clone() (*Transformer, error) {
+	c := *t
+	c.stats = Stats{}
+	if t.conv == nil {
+		return &c, nil
+	}
+
+	ps, err := support.NewProjString(t.resolved)
+	if err != nil {
+		return nil, err
+	}
+	c.conv, err = newConversion(ps)
+	if err != nil {
+		return nil, err
+	}
+	c.conv.system.PolePolicy = t.conv.system.PolePolicy
+	if err := c.conv.setHub(t.conv.hub); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformerStream(t *testing.T) {
+	assert := assert.New(t)
+
+	tr, err := proj.NewTransformer("3395")
+	assert.NoError(err)
+
+	// chunks of varying size, one of them bad
+	const n = 200
+	chunks := make([][]float64, n)
+	for i := range chunks {
+		chunk := []float64{}
+		for j := 0; j <= i%7; j++ {
+			chunk = append(chunk, -120.0+float64(i)*0.2, 30.0+float64(j))
+		}
+		chunks[i] = chunk
+	}
+	chunks[17] = []float64{1.0}
+
+	in := make(chan []float64)
+	go func() {
+		for _, chunk := range chunks {
+			in <- chunk
+		}
+		close(in)
+	}()
+
+	out, err := tr.ForwardStream(context.Background(), in, 4)
+	assert.NoError(err)
+
+	xys := [][]float64{}
+	for i := 0; ; i++ {
+		result, ok := <-out
+		if !ok {
+			break
+		}
+		if i == 17 {
+			assert.Error(result.Err)
+			xys = append(xys, nil)
+			continue
+		}
+		assert.NoError(result.Err)
+
+		expected, err := tr.Forward(chunks[i])
+		assert.NoError(err)
+		assert.Equal(expected, result.Points)
+		xys = append(xys, result.Points)
+	}
+	assert.Len(xys, n)
+
+	// and back again
+	in = make(chan []float64, n)
+	for i, xy := range xys {
+		if i != 17 {
+			in <- xy
+		}
+	}
+	close(in)
+
+	out, err = tr.InverseStream(context.Background(), in, 0)
+	assert.NoError(err)
+	i := 0
+	for result := range out {
+		if i == 17 {
+			i++
+		}
+		assert.NoError(result.Err)
+		assert.InDeltaSlice(chunks[i], result.Points, 1e-8)
+		i++
+	}
+	assert.Equal(n, i)
+}
+
+func TestTransformerStreamCancel(t *testing.T) {
+	assert := assert.New(t)
+
+	tr, err := proj.NewTransformer("3857")
+	assert.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan []float64)
+	out, err := tr.ForwardStream(ctx, in, 2)
+	assert.NoError(err)
+
+	in <- []float64{0.0, 0.0}
+	result := <-out
+	assert.NoError(result.Err)
+	assert.Equal([]float64{0.0, 0.0}, result.Points)
+
+	// the output is closed even though the input never is
+	cancel()
+	for range out {
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/oahumap/proj/support"
+)
+
+// SupersededError is returned when a deprecated SRID, such as 3785, is
+// looked up while redirection is off
+type SupersededError struct {
+	SRID       int
+	ReplacedBy int
+}
+
+func (e SupersededError) Error() string {
+	return fmt.Sprintf("srid %d is superseded by %d", e.SRID, e.ReplacedBy)
+}
+
+var redirectSuperseded atomic.Bool
+
+// SetRedirectSuperseded sets whether lookups of superseded SRIDs (by
+// FromSRID, by the functions which accept SRIDs, and by GetInfoFromEPSG)
+// are redirected to their replacements, for the whole process. It is off
+// by default, so that such lookups fail with a SupersededError naming the
+// replacement; aliases, such as 900913 for 3857, are redirected either
+// way.
+func SetRedirectSuperseded(redirect bool) {
+	redirectSuperseded.Store(redirect)
+}
+
+// RedirectSuperseded reports whether superseded SRIDs are redirected
+func RedirectSuperseded() bool {
+	return redirectSuperseded.Load()
+}
+
+// SupersededBy returns the SRID which replaces the given one, if it is
+// deprecated or unofficial
+func SupersededBy(srid int) (int, bool) {
+	entry, ok := support.SupersededTable[srid]
+	if !ok {
+		return 0, false
+	}
+	return entry.ReplacedBy, true
+}
+
+// redirect returns the SRID to use in place of the given one: the same
+// SRID, unless it has been superseded. Aliases, such as the Web Mercator
+// codes 900913 and 102100, are always redirected.
+func redirect(srid int) (int, error) {
+	entry, ok := support.SupersededTable[srid]
+	if !ok {
+		return srid, nil
+	}
+	if !entry.Alias && !RedirectSuperseded() {
+		return 0, SupersededError{SRID: srid, ReplacedBy: entry.ReplacedBy}
+	}
+	return entry.ReplacedBy, nil
+}
+
+// parseSRID returns the SRID of a definition which is just a code, such
+// as "3857", "EPSG:3857" or "ESRI:102100", an OGC identifier of one, such
+// as "urn:ogc:def:crs:EPSG::3857" or "CRS84", or WKT identifying one.
+//
+// The planetary presets are IAU codes, such as "IAU:49900", which need
+// their prefix: without it, or with another, the code isn't theirs.
+func parseSRID(def string) (int, bool) {
+	code := strings.TrimSpace(def)
+	if ogc, ok := fromOGC(code); ok {
+		code = ogc
+	} else if wkt, ok := fromWKT(code); ok {
+		code = wkt
+	}
+	iau := false
+	if i := strings.IndexByte(code, ':'); i >= 0 {
+		switch strings.ToUpper(code[:i]) {
+		case "EPSG", "ESRI":
+		case "IAU", "IAU2000":
+			iau = true
+		default:
+			return 0, false
+		}
+		code = code[i+1:]
+	}
+
+	srid, err := strconv.Atoi(code)
+	if err != nil {
+		return 0, false
+	}
+	entry, ok := support.SRIDsTable[srid]
+	if iau != (ok && entry.AuthName == "IAU2000") {
+		return 0, false
+	}
+	return srid, true
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuperseded(t *testing.T) {
+	assert := assert.New(t)
+
+	defer proj.SetRedirectSuperseded(proj.RedirectSuperseded())
+	proj.SetRedirectSuperseded(false)
+
+	srid, ok := proj.SupersededBy(3785)
+	assert.True(ok)
+	assert.Equal(3857, srid)
+	_, ok = proj.SupersededBy(3857)
+	assert.False(ok)
+
+	// by default, lookups fail but say what to use instead
+	var serr proj.SupersededError
+	_, err := proj.FromSRID(3785)
+	assert.True(errors.As(err, &serr))
+	assert.Equal(proj.SupersededError{SRID: 3785, ReplacedBy: 3857}, serr)
+	assert.Equal("srid 3785 is superseded by 3857", err.Error())
+
+	_, err = proj.Convert("54004", inputB)
+	assert.True(errors.As(err, &serr))
+
+	// or they are redirected
+	proj.SetRedirectSuperseded(true)
+
+	def, err := proj.FromSRID(3785)
+	assert.NoError(err)
+	expected, err := proj.FromSRID(3857)
+	assert.NoError(err)
+	assert.Equal(expected, def)
+
+	xy, err := proj.Convert("3785", inputB)
+	assert.NoError(err)
+	assert.InDelta(-8641240.37, xy[0], 1e-2)
+	assert.InDelta(4697899.31, xy[1], 1e-2)
+}
+
+func TestAliases(t *testing.T) {
+	assert := assert.New(t)
+
+	defer proj.SetRedirectSuperseded(proj.RedirectSuperseded())
+	proj.SetRedirectSuperseded(false)
+
+	expected, err := proj.FromSRID(3857)
+	assert.NoError(err)
+	for _, srid := range []int{900913, 102100, 102113} {
+		def, err := proj.FromSRID(srid)
+		assert.NoError(err)
+		assert.Equal(expected, def)
+	}
+
+	for _, code := range []string{"3857", "EPSG:3857", "epsg:3857", "900913", "EPSG:900913", "ESRI:102100", " ESRI:102113 "} {
+		xy, err := proj.Convert(code, inputB)
+		assert.NoError(err, code)
+		assert.InDelta(-8641240.37, xy[0], 1e-2, code)
+		assert.InDelta(4697899.31, xy[1], 1e-2, code)
+	}
+
+	_, err = proj.Convert("OSGEO:41001", inputB)
+	assert.Error(err)
+}
+
+func TestGetInfoFromEPSGSuperseded(t *testing.T) {
+	assert := assert.New(t)
+
+	defer proj.SetRedirectSuperseded(proj.RedirectSuperseded())
+	proj.SetRedirectSuperseded(false)
+
+	merc := "+proj=merc +a=6378137 +b=6378137 +lat_ts=0 +lon_0=0 +x_0=0 +y_0=0 +k=1 +units=m +nadgrids=@null +no_defs"
+	json := `{"type": "ProjectedCRS", "name": "%s"}`
+	responses := fakeCRS("3785", merc, json)
+	for k, v := range fakeCRS("3857", merc, json) {
+		responses[k] = v
+	}
+
+	withFakeEPSG(responses, func() {
+		// epsg.io still knows some deprecated codes
+		p, err := proj.GetInfoFromEPSG("EPSG:3785")
+		assert.NoError(err)
+		assert.Equal("3785", p.Code)
+		assert.Equal("3857", p.SupersededBy)
+		assert.True(p.Deprecated)
+		assert.Empty(p.RedirectedFrom)
+
+		// but not others
+		_, err = proj.GetInfoFromEPSG("54004")
+		assert.Equal(proj.SupersededError{SRID: 54004, ReplacedBy: 3395}, err)
+
+		// aliases are always looked up as what they stand for
+		p, err = proj.GetInfoFromEPSG("ESRI:102100")
+		assert.NoError(err)
+		assert.Equal("3857", p.Code)
+		assert.Equal("3857", p.Name)
+		assert.Equal("ESRI:102100", p.RedirectedFrom)
+		assert.Empty(p.SupersededBy)
+		assert.False(p.Deprecated)
+
+		proj.SetRedirectSuperseded(true)
+		p, err = proj.GetInfoFromEPSG("3785")
+		assert.NoError(err)
+		assert.Equal("3857", p.Code)
+		assert.Equal("3785", p.RedirectedFrom)
+	})
+}
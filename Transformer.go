@@ -0,0 +1,264 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/support"
+)
+
+// Transformer converts points between 4326 (lon/lat degrees, 2D) and a
+// projected system, as Convert and Inverse do, but parses the proj string
+// only once and keeps diagnostics about the conversions it performs.
+type Transformer struct {
+	conv       *conversion // nil for geographic systems
+	stats      Stats
+	definition string // as given to NewTransformer
+	resolved   string // the proj string it resolved to
+
+	unit float64     // size in radians of the caller's lon/lat unit
+	geo  *geographic // the unit and prime meridian of a geographic system
+
+	datum         datum       // of the system
+	hub           string      // the lon/lat system, for Provenance
+	hubDatum      datum       // of the hub
+	hubShift      *core.Datum // of the hub, for shifting from
+	shift         bool        // whether datum shifts are applied
+	identityShift bool        // whether the datums are assumed to be the same
+
+	metrics      func(Batch) // called after each batch, if set
+	validate     bool        // check the input, whatever ValidateInput says
+	checkInverse bool        // check the inverses, whatever CheckInverse says
+	approximate  bool        // take the approximate fast path; see SetApproximate
+	workers      int         // for the streams, if they are given none
+	chunk        int         // points per chunk for the Chunked methods
+}
+
+// Stats summarizes how the iterative inverse of a transformer converged
+// over the most recent batch, for QA pipelines.
+//
+// Residuals are the size of the final correction, in radians. For
+// operations with a closed-form inverse, Iterative is false and only
+// Points is filled in.
+type Stats struct {
+	Points        int     // points inverted
+	Iterative     bool    // whether the inverse is found by iteration
+	MaxIterations int     // worst-case iteration count
+	MaxResidual   float64 // worst-case final residual
+	Failures      int     // points which failed to converge
+}
+
+// add records the outcome of an inverse by the converter, which took the
+// given iterations to the final residual
+func (stats *Stats) add(converter core.IConvertLPToXY, iterations int, residual float64, failed bool) {
+	stats.Points++
+	if failed {
+		stats.Failures++
+	}
+
+	op, ok := converter.(*core.ConvertLPToXY)
+	if !ok || !op.IsIterative() {
+		return
+	}
+
+	stats.Iterative = true
+	if iterations > stats.MaxIterations {
+		stats.MaxIterations = iterations
+	}
+	if residual > stats.MaxResidual {
+		stats.MaxResidual = residual
+	}
+}
+
+// NewTransformer returns a Transformer for the given projected system,
+// with the options of DefaultConfig.
+//
+// As with Convert, an SRID may be given instead of a proj4 string.
+func NewTransformer(proj4 string) (*Transformer, error) {
+	t, err := newTransformer(proj4)
+	if err != nil {
+		return nil, err
+	}
+	c := DefaultConfig()
+	c.ValidateInput = false // the process-wide switch applies anyway
+	if err := t.apply(c); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func newTransformer(proj4 string) (*Transformer, error) {
+	ps, err := resolveDefinition(proj4)
+	if err != nil {
+		return nil, err
+	}
+	return newTransformerOf(proj4, ps)
+}
+
+// newTransformerOf returns a Transformer for the resolved definition,
+// which becomes part of its conversion and may be modified
+func newTransformerOf(proj4 string, ps *support.ProjString) (*Transformer, error) {
+	var err error
+	t := &Transformer{
+		definition: proj4,
+		resolved:   ps.Definition(),
+		unit:       degree,
+		hub:        "EPSG:4326 " + wgs84Definition,
+		hubDatum:   datum{known: true},
+		hubShift:   wgs84Datum,
+	}
+
+	t.datum, err = datumOf(ps)
+	if err != nil {
+		return nil, err
+	}
+
+	if isGeographicSystem(ps) {
+		t.geo, err = geographicOf(ps)
+	} else {
+		t.conv, err = newConversion(ps)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.updateShift(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// SetAngularUnit sets the unit of the lon/lat points passed to Forward
+// and returned by Inverse: "deg" (the default), "rad" or "grad".
+func (t *Transformer) SetAngularUnit(id string) error {
+	unit, ok := support.AngularUnitsTable[id]
+	if !ok {
+		return fmt.Errorf("unknown angular unit: %s", id)
+	}
+	t.unit = unit.ToRadians
+	return nil
+}
+
+// PolePolicy determines what projections which are singular at the
+// poles, such as Mercator, do with points at a pole.
+type PolePolicy = core.PolePolicy
+
+// The pole policies
+const (
+	PoleError    = core.PoleError    // fail, as PROJ does (the default)
+	PoleInfinity = core.PoleInfinity // return an infinite northing
+	PoleClamp    = core.PoleClamp    // clamp the latitude; for merc, to +lat_max or the Web Mercator limit
+)
+
+// SetPolePolicy sets what happens to points at a pole, for projections
+// which are singular there. It has no effect on other projections.
+func (t *Transformer) SetPolePolicy(policy PolePolicy) {
+	if t.conv != nil {
+		t.conv.system.PolePolicy = policy
+	}
+}
+
+// Forward converts lon/lat points to x/y points, as per Convert
+func (t *Transformer) Forward(input []float64) ([]float64, error) {
+	start := time.Now()
+	output, err := t.forward(input)
+	t.observe("Forward", len(input)/2, start, 0, err)
+	return output, err
+}
+
+func (t *Transformer) forward(input []float64) ([]float64, error) {
+	if err := checkLonLat(input, t.unit, t.validating()); err != nil {
+		return nil, err
+	}
+
+	if t.conv == nil {
+		return t.geo.fromGreenwichPoints(input, t.unit)
+	}
+
+	if t.unit != degree {
+		input = rescale(input, t.unit/degree)
+	}
+
+	if t.approximate {
+		return t.conv.convertApproximate(input)
+	}
+	return t.conv.convert(input)
+}
+
+// Inverse converts x/y points to lon/lat points, as per Inverse.
+//
+// The convergence of the batch is recorded, and can be retrieved using
+// Stats. If any point fails to converge, the rest of the batch is still
+// run (so that the stats are complete) and the first failure, a
+// merror.ConvergenceError, is returned.
+func (t *Transformer) Inverse(input []float64) ([]float64, error) {
+	start := time.Now()
+	output, err := t.inverse(input)
+	t.observe("Inverse", len(input)/2, start, t.inverseFailures(err), err)
+	return output, err
+}
+
+func (t *Transformer) inverse(input []float64) ([]float64, error) {
+	t.stats = Stats{}
+
+	if t.conv == nil {
+		t.stats.Points = len(input) / 2
+		output, err := t.geo.toGreenwichPoints(input, t.unit)
+		if err != nil {
+			return nil, err
+		}
+		return output, findOutliers(t.definition, input, output, t.unit, t.checkingInverse())
+	}
+
+	var output []float64
+	var err error
+	if t.approximate {
+		output, err = t.conv.inverseApproximate(input, &t.stats)
+	} else {
+		output, err = t.conv.inverse(input, &t.stats)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if t.unit != degree {
+		for i := range output {
+			output[i] *= degree / t.unit
+		}
+	}
+
+	if err := findOutliers(t.definition, input, output, t.unit, t.checkingInverse()); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// Stats returns the convergence diagnostics of the most recent call to
+// Inverse.
+func (t *Transformer) Stats() Stats {
+	return t.stats
+}
+
+// Ellipsoid returns a copy of the ellipsoid of the transformer's system,
+// holding the exact values its conversions use, or nil if the system has
+// no ellipsoid.
+func (t *Transformer) Ellipsoid() *core.Ellipsoid {
+	if t.conv == nil {
+		ps, err := support.NewProjString(t.resolved)
+		if err != nil {
+			return nil
+		}
+		return ellipsoidOf(ps)
+	}
+
+	ellipsoid := *t.conv.system.Ellipsoid
+	return &ellipsoid
+}
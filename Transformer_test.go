@@ -0,0 +1,148 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/merror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformer(t *testing.T) {
+	assert := assert.New(t)
+
+	lonlat := []float64{-77.625583, 38.833846, 12.0, 55.0}
+
+	tr, err := proj.NewTransformer("3395")
+	assert.NoError(err)
+
+	xy, err := tr.Forward(lonlat)
+	assert.NoError(err)
+	expected, err := proj.Convert("3395", lonlat)
+	assert.NoError(err)
+	assert.Equal(expected, xy)
+
+	back, err := tr.Inverse(xy)
+	assert.NoError(err)
+	assert.InDeltaSlice(lonlat, back, 1e-8)
+
+	// merc has a closed-form inverse
+	assert.Equal(proj.Stats{Points: 2}, tr.Stats())
+
+	// geographic systems pass through
+	tr, err = proj.NewTransformer("4326")
+	assert.NoError(err)
+	back, err = tr.Inverse(lonlat)
+	assert.NoError(err)
+	assert.Equal(lonlat, back)
+
+	_, err = proj.NewTransformer("+proj=nonesuch")
+	assert.Error(err)
+}
+
+func TestTransformerStats(t *testing.T) {
+	assert := assert.New(t)
+
+	tr, err := proj.NewTransformer("+proj=lcc +ellps=GRS80 +lat_1=30 +lat_2=60")
+	assert.NoError(err)
+
+	lonlat := []float64{10.0, 45.0, -20.0, 70.0, 5.0, -10.0}
+	xy, err := tr.Forward(lonlat)
+	assert.NoError(err)
+
+	back, err := tr.Inverse(xy)
+	assert.NoError(err)
+	assert.InDeltaSlice(lonlat, back, 1e-8)
+
+	stats := tr.Stats()
+	assert.Equal(3, stats.Points)
+	assert.True(stats.Iterative)
+	assert.Equal(0, stats.Failures)
+	assert.True(stats.MaxIterations > 1)
+	assert.True(stats.MaxResidual < 1e-12)
+
+	// a NaN can never converge; the rest of the batch still runs
+	_, err = tr.Inverse([]float64{xy[0], xy[1], math.NaN(), 0.0, xy[2], xy[3]})
+	assert.Error(err)
+
+	var cerr merror.ConvergenceError
+	assert.True(errors.As(err, &cerr))
+	assert.Equal("lcc", cerr.Operation)
+	assert.Equal(15, cerr.Iterations)
+
+	stats = tr.Stats()
+	assert.Equal(3, stats.Points)
+	assert.Equal(1, stats.Failures)
+	assert.Equal(15, stats.MaxIterations)
+
+	// the numerical inverses report a finite residual, even for a point
+	// their first guess already hits
+	for _, def := range []string{"+proj=airy +a=6400000", "+proj=august +a=6400000"} {
+		tr, err = proj.NewTransformer(def)
+		assert.NoError(err, def)
+		_, err = tr.Inverse([]float64{0.0, 0.0})
+		assert.NoError(err, def)
+		stats = tr.Stats()
+		assert.True(stats.Iterative, def)
+		assert.True(stats.MaxResidual < 1e-12, def)
+	}
+}
+
+func BenchmarkNewTransformer(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = proj.NewTransformer("32633")
+	}
+}
+
+func TestTransformerPolePolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	tr, err := proj.NewTransformer("3857")
+	assert.NoError(err)
+
+	_, err = tr.Forward([]float64{0.0, 90.0})
+	assert.Error(err)
+
+	tr.SetPolePolicy(proj.PoleClamp)
+	xy, err := tr.Forward([]float64{0.0, 90.0, 0.0, -90.0})
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{0.0, 20037508.34, 0.0, -20037508.34}, xy, 0.01)
+
+	tr.SetPolePolicy(proj.PoleInfinity)
+	xy, err = tr.Forward([]float64{0.0, 90.0})
+	assert.NoError(err)
+	assert.True(math.IsInf(xy[1], 1))
+}
+
+func TestTransformerEllipsoid(t *testing.T) {
+	assert := assert.New(t)
+
+	grs80, err := core.NewEllipsoid(6378137.0, 1.0/298.257222101)
+	assert.NoError(err)
+
+	for _, def := range []string{"+proj=utm +zone=17 +ellps=GRS80", "4269"} {
+		tr, err := proj.NewTransformer(def)
+		assert.NoError(err, def)
+		e := tr.Ellipsoid()
+		assert.NotNil(e, def)
+		assert.InDelta(grs80.A, e.A, 1.0e-9, def)
+		assert.InDelta(grs80.B, e.B, 1.0e-6, def)
+		assert.InDelta(grs80.Es, e.Es, 1.0e-15, def)
+	}
+
+	// it's a copy
+	tr, err := proj.NewTransformer("3395")
+	assert.NoError(err)
+	tr.Ellipsoid().A = 1.0
+	assert.Equal(6378137.0, tr.Ellipsoid().A)
+}
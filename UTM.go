@@ -0,0 +1,185 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/support"
+)
+
+// UTMZoneMargin is how far, in degrees of longitude, a point may lie
+// beyond the edge of a UTM zone and still be taken to belong to it, since
+// data near a zone boundary commonly spills over it. At the equator, the
+// scale error there is about 0.2%, against 0.1% at the edge.
+const UTMZoneMargin = 1.0
+
+// UTMZonePolicy says what ForwardUTM does with the points which belong to
+// another zone
+type UTMZonePolicy int
+
+// The UTM zone policies
+const (
+	UTMZoneFlag      UTMZonePolicy = iota // convert them in the transformer's zone anyway
+	UTMZoneReproject                      // convert them in their own zone
+)
+
+// UTMZone returns the UTM zone of the lon/lat point (in degrees), from 1
+// to 60, including the exceptions for southern Norway and Svalbard, or 0
+// if either coordinate is NaN or infinite
+func UTMZone(lon, lat float64) int {
+	if math.IsNaN(lon) || math.IsInf(lon, 0) || math.IsNaN(lat) || math.IsInf(lat, 0) {
+		return 0
+	}
+	lon = math.Remainder(lon, 360.0)
+	if lon == 180.0 {
+		lon = -180.0
+	}
+	zone := int(math.Floor((lon+180.0)/6.0)) + 1
+	if zone > 60 {
+		zone = 60
+	}
+
+	switch {
+	case lat >= 56.0 && lat < 64.0 && lon >= 3.0 && lon < 12.0:
+		return 32
+	case lat >= 72.0 && lat < 84.0 && lon >= 0.0 && lon < 42.0:
+		switch {
+		case lon < 9.0:
+			return 31
+		case lon < 21.0:
+			return 33
+		case lon < 33.0:
+			return 35
+		}
+		return 37
+	}
+	return zone
+}
+
+// ForwardUTM is ForwardWithStatus for a UTM system, with points checked
+// against its zone: those which belong to another zone, and lie more than
+// UTMZoneMargin beyond the edge of this one, are marked StatusOtherZone,
+// and converted according to the policy. The zone each point was
+// converted in is returned alongside its status. Points which aren't
+// finite belong to no zone, and are marked StatusFailed.
+//
+// Points converted in another zone keep the transformer's hemisphere
+// (that is, its false northing), ellipsoid and units.
+func (t *Transformer) ForwardUTM(input []float64, policy UTMZonePolicy) ([]float64, []int, []PointStatus, error) {
+	start := time.Now()
+	output, zones, status, err := t.forwardUTM(input, policy)
+	t.observe("ForwardUTM", len(input)/2, start, countFailed(status), err)
+	return output, zones, status, err
+}
+
+func (t *Transformer) forwardUTM(input []float64, policy UTMZonePolicy) ([]float64, []int, []PointStatus, error) {
+	if t.conv == nil {
+		return nil, nil, nil, fmt.Errorf("not a UTM system: %s", t.definition)
+	}
+	if id, _ := t.conv.projString.GetAsString("proj"); id != "utm" {
+		return nil, nil, nil, fmt.Errorf("not a UTM system: %s", t.definition)
+	}
+	switch policy {
+	case UTMZoneFlag, UTMZoneReproject:
+	default:
+		return nil, nil, nil, fmt.Errorf("unknown UTM zone policy: %d", policy)
+	}
+
+	output, status, err := t.forwardWithStatus(input)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	cm := support.RToDD(t.conv.system.Lam0)
+	zone := int(math.Floor((cm+180.0)/6.0)) + 1
+	zones := make([]int, len(status))
+	others := map[int]*conversion{}
+
+	validate := t.validating()
+
+	for i := 0; i < len(input); i += 2 {
+		zones[i/2] = zone
+
+		if validate && checkPoint(input, i, t.unit) != nil {
+			continue
+		}
+		lon := support.RToDD(input[i] * t.unit)
+		lat := support.RToDD(input[i+1] * t.unit)
+
+		own := UTMZone(lon, lat)
+		if own == 0 {
+			status[i/2] |= StatusFailed
+			output[i], output[i+1] = math.NaN(), math.NaN()
+			continue
+		}
+		if own == zone || math.Abs(math.Remainder(lon-cm, 360.0)) <= 3.0+UTMZoneMargin {
+			continue
+		}
+		status[i/2] |= StatusOtherZone
+		if policy != UTMZoneReproject {
+			continue
+		}
+
+		conv, ok := others[own]
+		if !ok {
+			conv, err = t.zoneConversion(own)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			others[own] = conv
+		}
+
+		// judged afresh in the point's own zone
+		zones[i/2] = own
+		status[i/2] &^= StatusFailed | StatusOutsideDomain
+		lp := &core.CoordLP{Lam: input[i] * t.unit, Phi: input[i+1] * t.unit}
+		if !conv.system.InDomain(lp) {
+			status[i/2] |= StatusOutsideDomain
+		}
+		xy, err := conv.project(lp)
+		if err != nil {
+			status[i/2] |= StatusFailed
+			output[i], output[i+1] = math.NaN(), math.NaN()
+			continue
+		}
+		output[i], output[i+1] = xy.X, xy.Y
+	}
+
+	return output, zones, status, nil
+}
+
+// zoneConversion returns the conversion for the transformer's system in
+// another zone
+func (t *Transformer) zoneConversion(zone int) (*conversion, error) {
+	ps, err := support.NewProjString(t.resolved)
+	if err != nil {
+		return nil, err
+	}
+	other := &support.ProjString{}
+	for _, pair := range ps.Pairs {
+		if pair.Key != "zone" && pair.Key != "lon_0" {
+			other.Add(pair)
+		}
+	}
+	other.Add(support.Pair{Key: "zone", Value: strconv.Itoa(zone)})
+
+	conv, err := newConversion(other)
+	if err != nil {
+		return nil, err
+	}
+	conv.system.PolePolicy = t.conv.system.PolePolicy
+	if err := conv.setHub(t.conv.hub); err != nil {
+		return nil, err
+	}
+	return conv, nil
+}
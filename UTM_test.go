@@ -0,0 +1,123 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUTMZone(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, tc := range []struct {
+		lon, lat float64
+		zone     int
+	}{
+		{9.0, 45.0, 32},
+		{-180.0, 0.0, 1},
+		{180.0, 0.0, 1},
+		{179.9, 0.0, 60},
+		{-0.1, 51.5, 30},
+		{2.0, 60.0, 31},
+		{5.0, 60.0, 32}, // southern Norway
+		{5.0, 55.0, 31},
+		{8.9, 78.0, 31}, // Svalbard
+		{10.0, 78.0, 33},
+		{40.0, 80.0, 37},
+		{40.0, 84.5, 37},
+		{45.0, 80.0, 38},
+	} {
+		assert.Equal(tc.zone, proj.UTMZone(tc.lon, tc.lat), "%g %g", tc.lon, tc.lat)
+	}
+
+	// no zone
+	assert.Equal(0, proj.UTMZone(math.NaN(), 45.0))
+	assert.Equal(0, proj.UTMZone(9.0, math.NaN()))
+	assert.Equal(0, proj.UTMZone(math.Inf(1), 45.0))
+	assert.Equal(0, proj.UTMZone(9.0, math.Inf(-1)))
+}
+
+func TestForwardUTM(t *testing.T) {
+	assert := assert.New(t)
+
+	tr, err := proj.NewTransformer("32632")
+	assert.NoError(err)
+
+	input := []float64{
+		9.0, 45.0, // at the central meridian
+		12.5, 45.0, // beyond the zone, within the margin
+		2.0, 45.0, // in zone 31
+		-170.0, 45.0, // in zone 2, too far round for zone 32
+	}
+
+	// flagged, and converted in zone 32 where possible
+	output, zones, status, err := tr.ForwardUTM(input, proj.UTMZoneFlag)
+	assert.NoError(err)
+	assert.Equal([]int{32, 32, 32, 32}, zones)
+	assert.Equal([]proj.PointStatus{
+		proj.StatusOK,
+		proj.StatusOK,
+		proj.StatusOtherZone,
+		proj.StatusOtherZone | proj.StatusOutsideDomain,
+	}, status)
+	assert.Equal("outside-domain|other-zone", status[3].String())
+	expected, err := proj.Convert("32632", input)
+	assert.NoError(err)
+	assert.Equal(expected, output)
+
+	// or converted in their own zones
+	output, zones, status, err = tr.ForwardUTM(input, proj.UTMZoneReproject)
+	assert.NoError(err)
+	assert.Equal([]int{32, 32, 31, 2}, zones)
+	assert.Equal(proj.StatusOtherZone, status[2])
+	assert.Equal(proj.StatusOtherZone, status[3])
+	assert.Equal(expected[:4], output[:4])
+	for i, srid := range []string{"32631", "32602"} {
+		expected, err := proj.Convert(srid, input[4+2*i:6+2*i])
+		assert.NoError(err, srid)
+		assert.InDeltaSlice(expected, output[4+2*i:6+2*i], 1.0e-9, srid)
+	}
+
+	// points which aren't finite fail, whatever the policy, and don't
+	// disturb the others
+	for _, policy := range []proj.UTMZonePolicy{proj.UTMZoneFlag, proj.UTMZoneReproject} {
+		output, zones, status, err = tr.ForwardUTM([]float64{math.NaN(), 45.0, 2.0, math.Inf(1), 2.0, 45.0}, policy)
+		assert.NoError(err)
+		assert.Equal([]int{32, 32}, zones[:2])
+		assert.NotZero(status[0] & proj.StatusFailed)
+		assert.NotZero(status[1] & proj.StatusFailed)
+		assert.True(math.IsNaN(output[0]) && math.IsNaN(output[1]))
+		assert.True(math.IsNaN(output[2]) && math.IsNaN(output[3]))
+		assert.Equal(proj.StatusOtherZone, status[2])
+		assert.False(math.IsNaN(output[4]))
+	}
+
+	// keeping the hemisphere and the rest of the definition
+	tr, err = proj.NewTransformer("+proj=utm +zone=33 +south +ellps=GRS80 +units=km")
+	assert.NoError(err)
+	output, zones, _, err = tr.ForwardUTM([]float64{22.0, -30.0}, proj.UTMZoneReproject)
+	assert.NoError(err)
+	assert.Equal([]int{34}, zones)
+	expected, err = proj.Convert("+proj=utm +zone=34 +south +ellps=GRS80 +units=km", []float64{22.0, -30.0})
+	assert.NoError(err)
+	assert.InDeltaSlice(expected, output, 1.0e-9)
+
+	// only for UTM
+	tr, err = proj.NewTransformer("3857")
+	assert.NoError(err)
+	_, _, _, err = tr.ForwardUTM(input, proj.UTMZoneFlag)
+	assert.Error(err)
+	tr, err = proj.NewTransformer("32632")
+	assert.NoError(err)
+	_, _, _, err = tr.ForwardUTM(input, proj.UTMZonePolicy(7))
+	assert.Error(err)
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+)
+
+// InputRangeError is returned, while input validation is on, for a
+// lon/lat input value which is out of range or not a number
+type InputRangeError struct {
+	Index    int     // of the value in the input array
+	Value    float64 // as given
+	Min      float64 // the range it should have been in, in the same unit
+	Max      float64
+	Latitude bool // whether the value is a latitude rather than a longitude
+}
+
+func (e InputRangeError) Error() string {
+	what := "longitude"
+	if e.Latitude {
+		what = "latitude"
+	}
+	return fmt.Sprintf("input[%d]: %s %g is outside [%g, %g]", e.Index, what, e.Value, e.Min, e.Max)
+}
+
+var validateInput atomic.Bool
+
+// SetValidateInput turns input validation on or off for the whole
+// process. It is off by default.
+//
+// While it is on, Convert, ConvertClipped and the Forward methods of
+// Transformer check that every longitude is in [-180, 180] degrees and
+// every latitude in [-90, 90] (or the same ranges in the transformer's
+// angular unit), and fail with an InputRangeError for the first value
+// which isn't, rather than converting it into whatever the math gives.
+// ForwardWithStatus marks such points as failed instead.
+func SetValidateInput(validate bool) {
+	validateInput.Store(validate)
+}
+
+// ValidateInput reports whether input validation is on
+func ValidateInput() bool {
+	return validateInput.Load()
+}
+
+// validating reports whether the transformer checks its input, which it
+// does if its Config or the process says so
+func (t *Transformer) validating() bool {
+	return t.validate || ValidateInput()
+}
+
+// checkLonLat returns an InputRangeError for the first lon/lat value out
+// of range, if validate is set; unit is the size of the values' unit in
+// radians
+func checkLonLat(input []float64, unit float64, validate bool) error {
+	if !validate {
+		return nil
+	}
+	for i := 0; i+1 < len(input); i += 2 {
+		if err := checkPoint(input, i, unit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPoint checks the lon/lat point at input[i]
+func checkPoint(input []float64, i int, unit float64) error {
+	half := math.Pi / unit
+	if !(math.Abs(input[i]) <= half) {
+		return InputRangeError{Index: i, Value: input[i], Min: -half, Max: half}
+	}
+	if !(math.Abs(input[i+1]) <= half/2.0) {
+		return InputRangeError{Index: i + 1, Value: input[i+1], Min: -half / 2.0, Max: half / 2.0, Latitude: true}
+	}
+	return nil
+}
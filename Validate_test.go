@@ -0,0 +1,78 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateInput(t *testing.T) {
+	assert := assert.New(t)
+
+	bad := []float64{10.0, 20.0, -120.0, 91.0}
+
+	// off by default
+	assert.False(proj.ValidateInput())
+	_, err := proj.Convert("3395", []float64{10.0, 20.0, 190.0, 45.0})
+	assert.NoError(err)
+
+	proj.SetValidateInput(true)
+	defer proj.SetValidateInput(false)
+
+	_, err = proj.Convert("3395", bad)
+	rangeErr, ok := err.(proj.InputRangeError)
+	assert.True(ok)
+	assert.Equal(3, rangeErr.Index)
+	assert.Equal(91.0, rangeErr.Value)
+	assert.Contains(err.Error(), "latitude 91")
+
+	_, err = proj.Convert("4326", []float64{-180.5, 0.0})
+	rangeErr, ok = err.(proj.InputRangeError)
+	assert.True(ok)
+	assert.Equal(0, rangeErr.Index)
+	assert.Contains(err.Error(), "longitude")
+
+	_, err = proj.Convert("3395", []float64{math.NaN(), 0.0})
+	assert.Error(err)
+
+	_, _, err = proj.ConvertClipped("3395", bad)
+	assert.Error(err)
+
+	// the limits themselves are fine
+	_, err = proj.Convert("4326", []float64{180.0, 90.0, -180.0, -90.0})
+	assert.NoError(err)
+
+	tr, err := proj.NewTransformer("3395")
+	assert.NoError(err)
+
+	_, err = tr.Forward(bad)
+	assert.Error(err)
+
+	// in radians, the limits are scaled
+	assert.NoError(tr.SetAngularUnit("rad"))
+	_, err = tr.Forward([]float64{3.0, 1.5})
+	assert.NoError(err)
+	_, err = tr.Forward([]float64{3.0, 1.6})
+	rangeErr, ok = err.(proj.InputRangeError)
+	assert.True(ok)
+	assert.InDelta(math.Pi/2.0, rangeErr.Max, 1e-12)
+
+	// with status, only the bad point fails
+	assert.NoError(tr.SetAngularUnit("deg"))
+	output, status, err := tr.ForwardWithStatus(bad)
+	assert.NoError(err)
+	assert.Len(status, 2)
+	assert.False(status[0]&proj.StatusFailed != 0)
+	assert.True(status[1]&proj.StatusFailed != 0)
+	assert.False(math.IsNaN(output[0]))
+	assert.True(math.IsNaN(output[2]))
+}
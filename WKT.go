@@ -0,0 +1,347 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/support"
+)
+
+// wktKeywords are the keywords WKT 1 and WKT 2 CRS definitions start with
+var wktKeywords = map[string]bool{
+	"PROJCS": true, "GEOGCS": true, "GEOCCS": true, "COMPD_CS": true,
+	"PROJCRS": true, "PROJECTEDCRS": true, "GEOGCRS": true, "GEOGRAPHICCRS": true,
+	"GEODCRS": true, "GEODETICCRS": true, "COMPOUNDCRS": true, "BOUNDCRS": true,
+}
+
+// isWKT reports whether the definition looks like a WKT CRS, such as
+// `PROJCS["WGS 84 / UTM zone 32N", ...]`
+func isWKT(def string) bool {
+	s := strings.TrimSpace(def)
+	i := strings.IndexAny(s, "[(")
+	if i < 0 {
+		return false
+	}
+	return wktKeywords[strings.ToUpper(strings.TrimSpace(s[:i]))]
+}
+
+// fromWKT returns the identifier of a WKT CRS as "AUTHORITY:CODE", e.g.
+// "EPSG:32632", from its AUTHORITY (WKT 1) or ID (WKT 2) element. Only the
+// identifier of the CRS itself counts, not those of its datum, units and
+// so on. ok is false if the definition isn't WKT or has no identifier.
+func fromWKT(def string) (string, bool) {
+	if !isWKT(def) {
+		return "", false
+	}
+
+	var id string
+	depth := 0
+	quoted := false
+	keyword := 0 // where the keyword of the element being opened starts
+	content := -1
+
+	for i, c := range def {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '[' || c == '(':
+			depth++
+			if depth == 2 {
+				name := strings.ToUpper(strings.TrimSpace(def[keyword:i]))
+				if name == "AUTHORITY" || name == "ID" {
+					content = i + 1
+				}
+			}
+		case c == ']' || c == ')':
+			if depth == 2 && content >= 0 {
+				id = def[content:i]
+				content = -1
+			}
+			depth--
+		case c == ',' || unicode.IsSpace(c):
+			keyword = i + 1
+		}
+	}
+
+	parts := strings.Split(id, ",")
+	if len(parts) < 2 {
+		return "", false
+	}
+	auth := strings.Trim(strings.TrimSpace(parts[0]), `"`)
+	code := strings.Trim(strings.TrimSpace(parts[1]), `"`)
+	if auth == "" || code == "" {
+		return "", false
+	}
+	return strings.ToUpper(auth) + ":" + code, true
+}
+
+//---------------------------------------------------------------------
+
+// the ESRI names of the linear units, for ToWKT
+var esriLinearUnits = map[string]string{
+	"m":     "Meter",
+	"km":    "Kilometer",
+	"ft":    "Foot",
+	"us-ft": "Foot_US",
+}
+
+// the ESRI names of the ellipsoids, for ToWKT; others are named after
+// their description
+var esriEllipsoids = map[string]string{
+	"WGS84":  "WGS_1984",
+	"WGS72":  "WGS_1972",
+	"GRS80":  "GRS_1980",
+	"intl":   "International_1924",
+	"clrk66": "Clarke_1866",
+	"bessel": "Bessel_1841",
+	"airy":   "Airy_1830",
+}
+
+// ToWKT returns the CRS of the definition as WKT 1, in the form ESRI uses
+// for .prj files, so that data written in it can say what it is in. WKT
+// is returned as given. The datum is named after its ellipsoid, and its
+// +towgs84, if any, is given as a TOWGS84, as GDAL writes it.
+//
+// Geographic systems and the tmerc, etmerc, utm, merc, lcc, aea, laea,
+// eqc, cea, stere and sterea projections are supported.
+func ToWKT(definition string) (string, error) {
+	if isWKT(definition) {
+		return definition, nil
+	}
+	ps, err := resolveDefinition(definition)
+	if err != nil {
+		return "", err
+	}
+
+	datum, err := core.NewDatum(ps)
+	if err != nil {
+		return "", err
+	}
+	geogcs, err := esriGeogCS(ps, datum)
+	if err != nil {
+		return "", err
+	}
+	if isGeographicSystem(ps) {
+		return geogcs, nil
+	}
+
+	sys, _, err := core.NewSystem(ps.DeepCopy())
+	if err != nil {
+		return "", err
+	}
+	unit := ""
+	for id, name := range esriLinearUnits {
+		if math.Abs(support.UnitsTable[id].ToMeters-sys.ToMeter) <= 1.0e-12*sys.ToMeter {
+			unit = name
+		}
+	}
+	if unit == "" {
+		return "", fmt.Errorf("no WKT for the linear unit of %s", definition)
+	}
+
+	angle := func(key string) float64 {
+		v, _ := ps.GetAsAngle(key)
+		return support.RToDD(v)
+	}
+	lat0 := support.RToDD(sys.Phi0)
+	lon0 := support.RToDD(sys.Lam0)
+
+	// the false origin is in the linear unit, the angles in degrees
+	type parameter struct {
+		name  string
+		value float64
+	}
+	// angles are rounded to 1e-12 degrees, so that 15 doesn't come out
+	// as 14.999999999999982
+	params := []parameter{
+		{"False_Easting", sys.X0 / sys.ToMeter},
+		{"False_Northing", sys.Y0 / sys.ToMeter},
+		{"Central_Meridian", lon0},
+	}
+
+	var method string
+	id, _ := ps.GetAsString("proj")
+	switch id {
+	case "tmerc", "etmerc", "utm":
+		method = "Transverse_Mercator"
+		params = append(params, parameter{"Scale_Factor", sys.K0}, parameter{"Latitude_Of_Origin", lat0})
+	case "merc":
+		// lat_ts sets the scale of the Mercator, so it is read as given
+		method = "Mercator"
+		if ps.ContainsKey("nadgrids") && datum.Ellipsoid.Es == 0.0 && datum.Ellipsoid.A == 6378137.0 {
+			method = "Mercator_Auxiliary_Sphere"
+		}
+		if !ps.ContainsKey("lat_ts") && sys.K0 != 1.0 {
+			params = append(params, parameter{"Scale_Factor", sys.K0})
+		} else {
+			params = append(params, parameter{"Standard_Parallel_1", angle("lat_ts")})
+		}
+		if method == "Mercator_Auxiliary_Sphere" {
+			// the sphere is ESRI's type 0, that of the WGS 84 major axis
+			params = append(params, parameter{"Auxiliary_Sphere_Type", 0.0})
+			wgs84, _ := support.NewProjString("+proj=longlat +datum=WGS84")
+			datum, err = core.NewDatum(wgs84)
+			if err != nil {
+				return "", err
+			}
+			geogcs, err = esriGeogCS(wgs84, datum)
+			if err != nil {
+				return "", err
+			}
+		}
+	case "lcc":
+		lat2 := angle("lat_1")
+		if ps.ContainsKey("lat_2") {
+			lat2 = angle("lat_2")
+		}
+		method = "Lambert_Conformal_Conic"
+		params = append(params,
+			parameter{"Standard_Parallel_1", angle("lat_1")},
+			parameter{"Standard_Parallel_2", lat2},
+			parameter{"Scale_Factor", sys.K0},
+			parameter{"Latitude_Of_Origin", lat0})
+	case "aea":
+		method = "Albers"
+		params = append(params,
+			parameter{"Standard_Parallel_1", angle("lat_1")},
+			parameter{"Standard_Parallel_2", angle("lat_2")},
+			parameter{"Latitude_Of_Origin", lat0})
+	case "laea":
+		method = "Lambert_Azimuthal_Equal_Area"
+		params = append(params, parameter{"Latitude_Of_Origin", lat0})
+	case "eqc":
+		method = "Equidistant_Cylindrical"
+		params = append(params, parameter{"Standard_Parallel_1", angle("lat_ts")})
+	case "cea":
+		method = "Cylindrical_Equal_Area"
+		params = append(params, parameter{"Standard_Parallel_1", angle("lat_ts")})
+	case "stere", "ups":
+		// the polar aspects true to scale away from the pole are ESRI's
+		// polar stereographics; the rest have a scale factor
+		latts, hasTS := ps.GetAsAngle("lat_ts")
+		switch {
+		case math.Abs(lat0) == 90.0 && hasTS && math.Abs(latts) != support.PiOverTwo && sys.K0 == 1.0:
+			method = "Stereographic_North_Pole"
+			if lat0 < 0.0 {
+				method = "Stereographic_South_Pole"
+			}
+			params = append(params, parameter{"Standard_Parallel_1", support.RToDD(latts)})
+		default:
+			method = "Stereographic"
+			params = append(params, parameter{"Scale_Factor", sys.K0}, parameter{"Latitude_Of_Origin", lat0})
+		}
+	case "sterea":
+		method = "Double_Stereographic"
+		params = append(params, parameter{"Scale_Factor", sys.K0}, parameter{"Latitude_Of_Origin", lat0})
+	default:
+		return "", fmt.Errorf("no WKT for the %s projection", id)
+	}
+
+	name := "unnamed"
+	if srid, ok := parseSRID(definition); ok {
+		if entry, ok := support.SRIDsTable[srid]; ok {
+			name = entry.Name
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "PROJCS[%q,%s,PROJECTION[%q]", esriName(name), geogcs, method)
+	for _, p := range params {
+		fmt.Fprintf(&b, ",PARAMETER[%q,%s]", p.name, esriFixed(p.value, 12))
+	}
+	fmt.Fprintf(&b, ",UNIT[%q,%s]]", unit, esriNumber(sys.ToMeter))
+	return b.String(), nil
+}
+
+// esriGeogCS returns the GEOGCS of the system, on the datum
+func esriGeogCS(ps *support.ProjString, datum *core.Datum) (string, error) {
+	ellps := datum.Ellipsoid
+	name, ok := esriEllipsoids[ellps.ID]
+	if !ok {
+		name = esriName(ellps.Name)
+		if ellps.Name == "" {
+			name = "Unknown"
+		}
+	}
+	rf := 0.0
+	if ellps.Es != 0.0 {
+		rf = ellps.A / (ellps.A - ellps.B)
+	}
+
+	towgs84 := ""
+	if s, ok := ps.GetAsString("towgs84"); ok && name != "WGS_1984" {
+		towgs84 = ",TOWGS84[" + s + "]"
+	}
+
+	pmName, pm := "Greenwich", 0.0
+	if id, ok := ps.GetAsString("pm"); ok {
+		lon, err := primeMeridian(ps)
+		if err != nil {
+			return "", err
+		}
+		pmName, pm = id, support.RToDD(lon)
+	}
+
+	unitName, unit := "Degree", math.Pi/180.0
+	if isGeographicSystem(ps) {
+		var err error
+		unit, err = geographicUnit(ps)
+		if err != nil {
+			return "", err
+		}
+		unitName = ""
+		for _, u := range support.AngularUnitsTable {
+			if u.ToRadians == unit {
+				unitName = u.Name
+			}
+		}
+		if unitName == "" {
+			return "", fmt.Errorf("no WKT for the angular unit of %s", ps.Definition())
+		}
+	}
+
+	return fmt.Sprintf("GEOGCS[%q,DATUM[%q,SPHEROID[%q,%s,%s]%s],PRIMEM[%q,%s],UNIT[%q,%s]]",
+		"GCS_"+name, "D_"+name, name, esriNumber(ellps.A), esriFixed(rf, 9), towgs84,
+		pmName, esriFixed(pm, 12), unitName, strconv.FormatFloat(unit, 'g', 16, 64)), nil
+}
+
+// esriName returns the name in ESRI's style, with underscores for spaces
+// and without the quotes WKT can't hold
+func esriName(name string) string {
+	name = strings.ReplaceAll(name, `"`, "")
+	return strings.Join(strings.Fields(name), "_")
+}
+
+// esriNumber formats the number as ESRI does, always with a decimal point
+func esriNumber(f float64) string {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
+
+// esriFixed formats the number to the given number of decimals, less any
+// trailing zeros
+func esriFixed(f float64, decimals int) string {
+	s := strings.TrimRight(strconv.FormatFloat(f, 'f', decimals, 64), "0")
+	if strings.HasSuffix(s, ".") {
+		s += "0"
+	}
+	if s == "-0.0" {
+		s = "0.0"
+	}
+	return s
+}
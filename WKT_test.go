@@ -0,0 +1,59 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToWKT(t *testing.T) {
+	assert := assert.New(t)
+
+	wkt, err := proj.ToWKT("32633")
+	assert.NoError(err)
+	assert.Equal(`PROJCS["WGS_84_/_UTM_zone_33N",`+
+		`GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",SPHEROID["WGS_1984",6378137.0,298.257223563]],`+
+		`PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]],`+
+		`PROJECTION["Transverse_Mercator"],PARAMETER["False_Easting",500000.0],`+
+		`PARAMETER["False_Northing",0.0],PARAMETER["Central_Meridian",15.0],`+
+		`PARAMETER["Scale_Factor",0.9996],PARAMETER["Latitude_Of_Origin",0.0],UNIT["Meter",1.0]]`, wkt)
+
+	// WKT is returned as given
+	wkt, err = proj.ToWKT("PROJCS[]")
+	assert.NoError(err)
+	assert.Equal("PROJCS[]", wkt)
+
+	// the WKT converts as the definition does
+	for _, def := range []string{
+		"3857", "3035", "3031", "32661", "28992", "6933",
+		"+proj=lcc +lat_1=33 +lat_2=45 +lat_0=39 +lon_0=-96 +x_0=500000 +ellps=GRS80 +units=us-ft",
+		"+proj=aea +lat_1=29.5 +lat_2=45.5 +lat_0=23 +lon_0=-96 +datum=WGS84",
+		"+proj=eqc +lat_ts=30 +R=6371000",
+		"+proj=merc +k=0.9 +ellps=intl +towgs84=-87,-98,-121",
+	} {
+		wkt, err := proj.ToWKT(def)
+		assert.NoError(err, def)
+		expected, err := proj.Convert(def, []float64{10.0, 60.0, -100.0, 40.0})
+		assert.NoError(err, def)
+		actual, err := proj.Convert(wkt, []float64{10.0, 60.0, -100.0, 40.0})
+		assert.NoError(err, def)
+		assert.InDeltaSlice(expected, actual, 1.0e-6, def)
+	}
+
+	wkt, err = proj.ToWKT("+proj=longlat +ellps=intl +pm=paris +units=grad")
+	assert.NoError(err)
+	assert.Contains(wkt, `PRIMEM["paris",2.337229166667],UNIT["Grad",0.01570796326794897]`)
+
+	_, err = proj.ToWKT("+proj=gnom +lat_0=90 +datum=WGS84")
+	assert.Error(err)
+	_, err = proj.ToWKT("+proj=merc +units=yd +datum=WGS84")
+	assert.Error(err)
+}
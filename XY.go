@@ -0,0 +1,59 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj
+
+import (
+	"fmt"
+)
+
+// TransformXY is Forward for points held as separate x and y slices, as
+// NetCDF variables and dataframe columns hold them, so that they needn't
+// be interleaved and deinterleaved around the call. The points are
+// converted in place.
+//
+// While input validation is on, a geographic source is checked first,
+// and nothing is converted if a point is out of range; the Index of the
+// InputRangeError is that the value would have in an interleaved array,
+// i.e. twice the point's index, plus one for a latitude. Otherwise, if a
+// point fails, the error names it, and the points before it have been
+// converted while the rest are as given.
+func (t *Transform) TransformXY(xs, ys []float64) error {
+	return transformXY(t.from, t.to, xs, ys)
+}
+
+// InverseXY is TransformXY from the target CRS back to the source CRS
+func (t *Transform) InverseXY(xs, ys []float64) error {
+	return transformXY(t.to, t.from, xs, ys)
+}
+
+func transformXY(from, to transformSide, xs, ys []float64) error {
+	if len(xs) != len(ys) {
+		return fmt.Errorf("x and y slices differ in length: %d and %d", len(xs), len(ys))
+	}
+
+	if from.geo != nil && ValidateInput() {
+		var point [2]float64
+		for i := range xs {
+			point[0], point[1] = xs[i], ys[i]
+			if err := checkPoint(point[:], 0, from.geo.unit); err != nil {
+				rangeErr := err.(InputRangeError)
+				rangeErr.Index += 2 * i
+				return rangeErr
+			}
+		}
+	}
+
+	for i := range xs {
+		x, y, err := transformPoint(from, to, xs[i], ys[i])
+		if err != nil {
+			return fmt.Errorf("point %d: %w", i, err)
+		}
+		xs[i], ys[i] = x, y
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package proj_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformXY(t *testing.T) {
+	assert := assert.New(t)
+
+	wgs84, err := proj.NewCRS("4326")
+	assert.NoError(err)
+	web, err := proj.NewCRS("3857")
+	assert.NoError(err)
+	tr, err := proj.NewTransform(wgs84, web)
+	assert.NoError(err)
+
+	lons := []float64{9.0, 10.5, -3.0}
+	lats := []float64{45.0, 47.25, -50.0}
+	expected, err := tr.Forward([]float64{9.0, 45.0, 10.5, 47.25, -3.0, -50.0})
+	assert.NoError(err)
+
+	xs := append([]float64{}, lons...)
+	ys := append([]float64{}, lats...)
+	assert.NoError(tr.TransformXY(xs, ys))
+	for i := range xs {
+		assert.Equal(expected[2*i], xs[i])
+		assert.Equal(expected[2*i+1], ys[i])
+	}
+
+	assert.NoError(tr.InverseXY(xs, ys))
+	assert.InDeltaSlice(lons, xs, 1.0e-9)
+	assert.InDeltaSlice(lats, ys, 1.0e-9)
+
+	assert.Error(tr.TransformXY([]float64{1.0, 2.0}, []float64{1.0}))
+
+	// a failing point stops the rest
+	xs = []float64{1.0, 2.0, 3.0}
+	ys = []float64{1.0, 90.0, 3.0}
+	err = tr.TransformXY(xs, ys)
+	assert.ErrorContains(err, "point 1")
+	assert.NotEqual(1.0, xs[0])
+	assert.Equal(3.0, xs[2])
+
+	// validation checks everything first
+	proj.SetValidateInput(true)
+	defer proj.SetValidateInput(false)
+	xs = []float64{1.0, 2.0, 3.0}
+	ys = []float64{1.0, 2.0, 95.0}
+	err = tr.TransformXY(xs, ys)
+	var rangeErr proj.InputRangeError
+	assert.True(errors.As(err, &rangeErr))
+	assert.Equal(5, rangeErr.Index)
+	assert.Equal(1.0, xs[0])
+}
@@ -2,7 +2,7 @@
 
 set -e
 
-for i in . cmd/proj core gie merror mlog operations support
+for i in . cf cmd/proj cmd/reproject-shp core geohash geotiff gie greatcircle merror mlog operations operations/cylindrical rhumb shader support testsupport tiles
 do
     echo "*** $i ***"
     pushd $i &> /dev/null
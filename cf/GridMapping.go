@@ -0,0 +1,360 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+// Package cf interprets the grid mapping attributes of the CF (Climate and
+// Forecast) metadata conventions for NetCDF as a CRS, so that climate and
+// weather data can be given a transformer straight from its metadata.
+package cf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/oahumap/proj"
+	"github.com/oahumap/proj/support"
+)
+
+// GridMapping is the grid mapping of a NetCDF data variable
+type GridMapping struct {
+	// Attributes are those of the grid mapping variable, as read from the
+	// file: numbers as any of Go's integer or floating point types, or
+	// slices of them, and strings as strings
+	Attributes map[string]any
+
+	// Units are those of the projection x and y coordinate variables, as
+	// UDUNITS strings such as "m" or "km"; meters if empty
+	Units string
+}
+
+// ellipsoidNames are the ids of the ellipsoids, by the names used for
+// reference_ellipsoid_name (those of their WKT)
+var ellipsoidNames = map[string]string{
+	"WGS 84":             "WGS84",
+	"WGS 72":             "WGS72",
+	"GRS 1980":           "GRS80",
+	"Airy 1830":          "airy",
+	"Bessel 1841":        "bessel",
+	"Clarke 1866":        "clrk66",
+	"International 1924": "intl",
+}
+
+// linearUnits are the units ids of the UDUNITS names of linear units
+var linearUnits = map[string]string{
+	"m": "m", "meter": "m", "meters": "m", "metre": "m", "metres": "m",
+	"km": "km", "kilometer": "km", "kilometers": "km", "kilometre": "km", "kilometres": "km",
+	"ft": "ft", "foot": "ft", "feet": "ft",
+	"us_survey_foot": "us-ft", "us_survey_feet": "us-ft",
+}
+
+// Definition returns the CRS of the grid mapping as a proj string, as
+// NewTransformer accepts.
+//
+// The grid mappings supported are albers_conical_equal_area,
+// azimuthal_equidistant, lambert_azimuthal_equal_area,
+// lambert_conformal_conic, latitude_longitude, mercator, oblique_mercator,
+// polar_stereographic, stereographic and transverse_mercator. If there is
+// no grid_mapping_name, or it is not supported, the crs_wkt attribute is
+// used instead, if it identifies or describes a CRS we support.
+//
+// The figure of the earth is taken from earth_radius, semi_major_axis
+// and semi_minor_axis or inverse_flattening, or reference_ellipsoid_name,
+// in that order; if none is given, it is WGS 84, as GDAL also assumes.
+func (g GridMapping) Definition() (string, error) {
+	name, _ := g.Attributes["grid_mapping_name"].(string)
+	wkt, hasWKT := g.Attributes["crs_wkt"].(string)
+
+	def, err := g.definition(name)
+	if err != nil && hasWKT {
+		if _, wktErr := proj.NewCRS(wkt); wktErr == nil {
+			return wkt, nil
+		}
+	}
+	return def, err
+}
+
+// CRS returns the CRS of the grid mapping, as given by Definition
+func (g GridMapping) CRS() (*proj.CRS, error) {
+	def, err := g.Definition()
+	if err != nil {
+		return nil, err
+	}
+	return proj.NewCRS(def)
+}
+
+// Transformer returns a transformer for the CRS of the grid mapping, as
+// given by Definition
+func (g GridMapping) Transformer() (*proj.Transformer, error) {
+	def, err := g.Definition()
+	if err != nil {
+		return nil, err
+	}
+	return proj.NewTransformer(def)
+}
+
+func (g GridMapping) definition(name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("no grid_mapping_name is given")
+	}
+
+	figure, err := g.figure()
+	if err != nil {
+		return "", err
+	}
+	if name == "latitude_longitude" {
+		return "+proj=longlat " + figure, nil
+	}
+
+	units := "m"
+	if g.Units != "" {
+		var ok bool
+		units, ok = linearUnits[strings.ToLower(strings.TrimSpace(g.Units))]
+		if !ok {
+			return "", fmt.Errorf("unsupported units %q", g.Units)
+		}
+	}
+
+	params, err := g.projection(name, support.UnitsTable[units].ToMeters)
+	if err != nil {
+		return "", err
+	}
+	return params + " " + figure + " +units=" + units, nil
+}
+
+// projection returns the projection and its parameters; toMeter is the
+// size of the coordinates' unit
+func (g GridMapping) projection(name string, toMeter float64) (string, error) {
+	params := []string{}
+	var err error
+
+	// parameter sets the proj parameter from the first of the attributes
+	// given
+	parameter := func(param string, attributes ...string) {
+		for _, attribute := range attributes {
+			values, ok, e := g.floats(attribute)
+			if e != nil {
+				err = e
+				return
+			}
+			if ok {
+				params = append(params, "+"+param+"="+number(values[0]))
+				return
+			}
+		}
+	}
+	// parallels sets lat_1 and, if there are two, lat_2
+	parallels := func() {
+		values, ok, e := g.floats("standard_parallel")
+		if e != nil || !ok {
+			err = e
+			return
+		}
+		params = append(params, "+lat_1="+number(values[0]))
+		if len(values) > 1 {
+			params = append(params, "+lat_2="+number(values[1]))
+		}
+	}
+
+	switch name {
+	case "albers_conical_equal_area":
+		params = append(params, "+proj=aea")
+		parallels()
+		parameter("lat_0", "latitude_of_projection_origin")
+		parameter("lon_0", "longitude_of_central_meridian")
+	case "azimuthal_equidistant":
+		params = append(params, "+proj=aeqd")
+		parameter("lat_0", "latitude_of_projection_origin")
+		parameter("lon_0", "longitude_of_projection_origin")
+	case "lambert_azimuthal_equal_area":
+		params = append(params, "+proj=laea")
+		parameter("lat_0", "latitude_of_projection_origin")
+		parameter("lon_0", "longitude_of_projection_origin")
+	case "lambert_conformal_conic":
+		params = append(params, "+proj=lcc")
+		parallels()
+		parameter("lat_0", "latitude_of_projection_origin")
+		parameter("lon_0", "longitude_of_central_meridian")
+	case "mercator":
+		params = append(params, "+proj=merc")
+		parameter("lat_ts", "standard_parallel")
+		parameter("lon_0", "longitude_of_projection_origin")
+		parameter("k_0", "scale_factor_at_projection_origin")
+	case "oblique_mercator":
+		// as GDAL reads it, Hotine variant B: the false origin is at the
+		// center of the projection
+		params = append(params, "+proj=omerc")
+		parameter("lat_0", "latitude_of_projection_origin")
+		parameter("lonc", "longitude_of_projection_origin")
+		parameter("alpha", "azimuth_of_central_line")
+		parameter("k_0", "scale_factor_at_projection_origin")
+	case "polar_stereographic":
+		params = append(params, "+proj=stere")
+		parameter("lat_0", "latitude_of_projection_origin")
+		parameter("lat_ts", "standard_parallel")
+		parameter("lon_0", "straight_vertical_longitude_from_pole")
+		parameter("k_0", "scale_factor_at_projection_origin")
+	case "stereographic":
+		params = append(params, "+proj=stere")
+		parameter("lat_0", "latitude_of_projection_origin")
+		parameter("lon_0", "longitude_of_projection_origin")
+		parameter("k_0", "scale_factor_at_projection_origin")
+	case "transverse_mercator":
+		params = append(params, "+proj=etmerc")
+		parameter("lat_0", "latitude_of_projection_origin")
+		parameter("lon_0", "longitude_of_central_meridian")
+		parameter("k_0", "scale_factor_at_central_meridian")
+	default:
+		return "", fmt.Errorf("unsupported grid mapping %q", name)
+	}
+
+	// the false origin is in the units of the coordinates
+	for _, offset := range []struct{ param, attribute string }{
+		{"x_0", "false_easting"},
+		{"y_0", "false_northing"},
+	} {
+		values, ok, e := g.floats(offset.attribute)
+		if e != nil {
+			return "", e
+		}
+		if ok {
+			params = append(params, "+"+offset.param+"="+number(values[0]*toMeter))
+		}
+	}
+
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(params, " "), nil
+}
+
+// figure returns the ellipsoid, prime meridian and datum shift as proj
+// parameters
+func (g GridMapping) figure() (string, error) {
+	var def string
+
+	radius, hasRadius, err := g.float("earth_radius")
+	if err != nil {
+		return "", err
+	}
+	a, hasA, err := g.float("semi_major_axis")
+	if err != nil {
+		return "", err
+	}
+	b, hasB, err := g.float("semi_minor_axis")
+	if err != nil {
+		return "", err
+	}
+	rf, hasRF, err := g.float("inverse_flattening")
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case hasRadius:
+		def = "+R=" + number(radius)
+	case hasA && hasRF && rf != 0.0:
+		def = "+a=" + number(a) + " +rf=" + number(rf)
+	case hasA && hasB && b != a:
+		def = "+a=" + number(a) + " +b=" + number(b)
+	case hasA:
+		def = "+R=" + number(a)
+	default:
+		def = "+ellps=WGS84"
+		if name, ok := g.Attributes["reference_ellipsoid_name"].(string); ok {
+			id, ok := ellipsoidNames[name]
+			if !ok {
+				return "", fmt.Errorf("unsupported reference ellipsoid %q", name)
+			}
+			def = "+ellps=" + id
+		}
+	}
+
+	pm, hasPM, err := g.float("longitude_of_prime_meridian")
+	if err != nil {
+		return "", err
+	}
+	if hasPM && pm != 0.0 {
+		def += " +pm=" + number(pm)
+	}
+
+	shift, hasShift, err := g.floats("towgs84")
+	if err != nil {
+		return "", err
+	}
+	if hasShift {
+		words := make([]string, len(shift))
+		for i, v := range shift {
+			words[i] = number(v)
+		}
+		def += " +towgs84=" + strings.Join(words, ",")
+	}
+
+	return def, nil
+}
+
+// float returns the attribute, which must be a single number
+func (g GridMapping) float(attribute string) (float64, bool, error) {
+	values, ok, err := g.floats(attribute)
+	if err != nil || !ok {
+		return 0.0, ok, err
+	}
+	if len(values) != 1 {
+		return 0.0, false, fmt.Errorf("attribute %s has %d values, not one", attribute, len(values))
+	}
+	return values[0], true, nil
+}
+
+// floats returns the attribute, which must be one or more numbers
+func (g GridMapping) floats(attribute string) ([]float64, bool, error) {
+	value, ok := g.Attributes[attribute]
+	if !ok {
+		return nil, false, nil
+	}
+
+	var values []float64
+	switch v := value.(type) {
+	case float64:
+		values = []float64{v}
+	case float32:
+		values = []float64{float64(v)}
+	case int:
+		values = []float64{float64(v)}
+	case int8:
+		values = []float64{float64(v)}
+	case int16:
+		values = []float64{float64(v)}
+	case int32:
+		values = []float64{float64(v)}
+	case int64:
+		values = []float64{float64(v)}
+	case []float64:
+		values = v
+	case []float32:
+		for _, f := range v {
+			values = append(values, float64(f))
+		}
+	case []int:
+		for _, n := range v {
+			values = append(values, float64(n))
+		}
+	case []int32:
+		for _, n := range v {
+			values = append(values, float64(n))
+		}
+	default:
+		return nil, false, fmt.Errorf("attribute %s is not a number: %v", attribute, value)
+	}
+
+	if len(values) == 0 {
+		return nil, false, fmt.Errorf("attribute %s has no values", attribute)
+	}
+	return values, true, nil
+}
+
+func number(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
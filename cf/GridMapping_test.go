@@ -0,0 +1,176 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package cf_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/oahumap/proj/cf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefinition(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, tc := range []struct {
+		mapping  cf.GridMapping
+		expected string
+	}{
+		{cf.GridMapping{Attributes: map[string]any{
+			"grid_mapping_name": "latitude_longitude",
+		}}, "+proj=longlat +ellps=WGS84"},
+		{cf.GridMapping{Attributes: map[string]any{
+			"grid_mapping_name":           "latitude_longitude",
+			"reference_ellipsoid_name":    "GRS 1980",
+			"longitude_of_prime_meridian": 2.337229167,
+		}}, "+proj=longlat +ellps=GRS80 +pm=2.337229167"},
+
+		// NCEP's North American Regional Reanalysis, with its grid in km
+		{cf.GridMapping{Attributes: map[string]any{
+			"grid_mapping_name":             "lambert_conformal_conic",
+			"standard_parallel":             float32(50.0),
+			"longitude_of_central_meridian": float32(-107.0),
+			"latitude_of_projection_origin": float32(50.0),
+			"false_easting":                 5632.64,
+			"false_northing":                4612.55,
+			"earth_radius":                  6371200,
+		}, Units: "km"}, "+proj=lcc +lat_1=50 +lat_0=50 +lon_0=-107 +x_0=5632640 +y_0=4612550 +R=6371200 +units=km"},
+
+		{cf.GridMapping{Attributes: map[string]any{
+			"grid_mapping_name":             "albers_conical_equal_area",
+			"standard_parallel":             []float64{29.5, 45.5},
+			"longitude_of_central_meridian": -96.0,
+			"latitude_of_projection_origin": 23.0,
+			"false_easting":                 0.0,
+			"false_northing":                0.0,
+			"semi_major_axis":               6378137.0,
+			"inverse_flattening":            298.257222101,
+			"towgs84":                       []float64{0, 0, 0},
+		}}, "+proj=aea +lat_1=29.5 +lat_2=45.5 +lat_0=23 +lon_0=-96 +x_0=0 +y_0=0 +a=6378137 +rf=298.257222101 +towgs84=0,0,0 +units=m"},
+
+		// ETRS89-LAEA Europe, 3035
+		{cf.GridMapping{Attributes: map[string]any{
+			"grid_mapping_name":              "lambert_azimuthal_equal_area",
+			"latitude_of_projection_origin":  52.0,
+			"longitude_of_projection_origin": 10.0,
+			"false_easting":                  4321000.0,
+			"false_northing":                 3210000.0,
+			"reference_ellipsoid_name":       "GRS 1980",
+		}}, "+proj=laea +lat_0=52 +lon_0=10 +x_0=4321000 +y_0=3210000 +ellps=GRS80 +units=m"},
+
+		// NSIDC's sea ice grid, 3413
+		{cf.GridMapping{Attributes: map[string]any{
+			"grid_mapping_name":                     "polar_stereographic",
+			"latitude_of_projection_origin":         90.0,
+			"standard_parallel":                     70.0,
+			"straight_vertical_longitude_from_pole": -45.0,
+			"false_easting":                         0.0,
+			"false_northing":                        0.0,
+			"semi_major_axis":                       6378137.0,
+			"inverse_flattening":                    298.257223563,
+		}}, "+proj=stere +lat_0=90 +lat_ts=70 +lon_0=-45 +x_0=0 +y_0=0 +a=6378137 +rf=298.257223563 +units=m"},
+
+		{cf.GridMapping{Attributes: map[string]any{
+			"grid_mapping_name":                 "mercator",
+			"longitude_of_projection_origin":    0.0,
+			"scale_factor_at_projection_origin": 1.0,
+			"semi_major_axis":                   6378137.0,
+		}}, "+proj=merc +lon_0=0 +k_0=1 +R=6378137 +units=m"},
+	} {
+		def, err := tc.mapping.Definition()
+		assert.NoError(err)
+		assert.Equal(tc.expected, def)
+	}
+
+	// a grid mapping not supported, with WKT saying what it is
+	def, err := cf.GridMapping{Attributes: map[string]any{
+		"grid_mapping_name": "geostationary",
+		"crs_wkt":           `PROJCS["GOES-16",GEOGCS["GRS 1980",DATUM["D",SPHEROID["GRS 1980",6378137,298.257222101]]],PROJECTION["Geostationary_Satellite"],PARAMETER["central_meridian",-75]]`,
+	}}.Definition()
+	assert.Error(err)
+	assert.Empty(def)
+	def, err = cf.GridMapping{Attributes: map[string]any{
+		"crs_wkt": `PROJCS["WGS 84 / Pseudo-Mercator",AUTHORITY["EPSG","3857"]]`,
+	}}.Definition()
+	assert.NoError(err)
+	assert.Contains(def, "3857")
+
+	// a numeric prime meridian is honored
+	tr, err := cf.GridMapping{Attributes: map[string]any{
+		"grid_mapping_name":           "latitude_longitude",
+		"longitude_of_prime_meridian": 2.5,
+	}}.Transformer()
+	assert.NoError(err)
+	lonlat, err := tr.Forward([]float64{3.0, 45.0})
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{0.5, 45.0}, lonlat, 1.0e-12)
+
+	for _, attrs := range []map[string]any{
+		{},
+		{"grid_mapping_name": "rotated_latitude_longitude"},
+		{"grid_mapping_name": "mercator", "standard_parallel": "45"},
+		{"grid_mapping_name": "mercator", "earth_radius": []float64{}},
+		{"grid_mapping_name": "mercator", "reference_ellipsoid_name": "Everest"},
+	} {
+		_, err := cf.GridMapping{Attributes: attrs}.Definition()
+		assert.Error(err, attrs)
+	}
+	_, err = cf.GridMapping{Attributes: map[string]any{"grid_mapping_name": "mercator"}, Units: "furlong"}.Definition()
+	assert.Error(err)
+}
+
+func TestTransformer(t *testing.T) {
+	assert := assert.New(t)
+
+	// UTM zone 32N, spelled out
+	mapping := cf.GridMapping{Attributes: map[string]any{
+		"grid_mapping_name":                 "transverse_mercator",
+		"scale_factor_at_central_meridian":  0.9996,
+		"longitude_of_central_meridian":     9.0,
+		"latitude_of_projection_origin":     0.0,
+		"false_easting":                     500000.0,
+		"false_northing":                    0.0,
+		"semi_major_axis":                   6378137.0,
+		"inverse_flattening":                298.257223563,
+		"longitude_of_prime_meridian":       0.0,
+		"horizontal_datum_name":             "WGS_1984",
+		"reference_ellipsoid_name":          "WGS 84",
+		"projected_crs_name":                "WGS 84 / UTM zone 32N",
+		"geographic_crs_name":               "WGS 84",
+		"prime_meridian_name":               "Greenwich",
+		"scale_factor_at_projection_origin": 0.9996,
+	}}
+
+	lonlat := []float64{9.0, 45.0, 10.5, 47.25}
+	expected, err := proj.Convert("32632", lonlat)
+	assert.NoError(err)
+
+	tr, err := mapping.Transformer()
+	assert.NoError(err)
+	actual, err := tr.Forward(lonlat)
+	assert.NoError(err)
+	assert.InDeltaSlice(expected, actual, 1.0e-6)
+
+	crs, err := mapping.CRS()
+	assert.NoError(err)
+	assert.False(crs.IsGeographic())
+
+	// the equatorial azimuthal equidistant on GRS80, as in Snyder's table 30
+	mapping = cf.GridMapping{Attributes: map[string]any{
+		"grid_mapping_name":              "azimuthal_equidistant",
+		"latitude_of_projection_origin":  0.0,
+		"longitude_of_projection_origin": 0.0,
+		"reference_ellipsoid_name":       "GRS 1980",
+	}}
+	tr, err = mapping.Transformer()
+	assert.NoError(err)
+	actual, err = tr.Forward([]float64{45.0, 45.0})
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{3860398.3783, 5430089.0490}, actual, 1.0e-4)
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/oahumap/proj/gie"
+)
+
+// check runs every registered operation against the test vectors built
+// into the gie package, and prints a conformance report, so that a build
+// can be verified on its platform. It fails if any operation does.
+func check(outS io.Writer) error {
+	g, err := gie.NewEmbeddedGie()
+	if err != nil {
+		return err
+	}
+	err = g.Parse()
+	if err != nil {
+		return err
+	}
+	report := g.Check()
+
+	w := tabwriter.NewWriter(outS, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "operation\tresult\tcommands\tfailed\tmax forward error\tmax inverse error (deg)\n")
+	passed, failed, untested := 0, 0, 0
+	for _, c := range report {
+		result := "pass"
+		switch {
+		case !c.Tested():
+			result = "untested"
+			untested++
+		case !c.Passed():
+			result = "FAIL"
+			failed++
+		default:
+			passed++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%.3g\t%.3g\n",
+			c.Operation, result, c.Commands, c.Failed, c.MaxForwardError, c.MaxInverseError)
+	}
+	err = w.Flush()
+	if err != nil {
+		return err
+	}
+
+	for _, c := range report {
+		for _, failure := range c.Failures {
+			fmt.Fprintf(outS, "%s: %s\n", c.Operation, failure)
+		}
+	}
+	fmt.Fprintf(outS, "%d passed, %d failed, %d untested\n", passed, failed, untested)
+
+	if failed > 0 {
+		return fmt.Errorf("%d operations failed their test vectors", failed)
+	}
+	return nil
+}
@@ -40,6 +40,10 @@ func Main(inS io.Reader, outS io.Writer, args []string) error {
 	mlog.DisableInfo()
 	mlog.DisableError()
 
+	if len(args) > 1 && args[1] == "check" {
+		return check(outS)
+	}
+
 	cli := flag.NewFlagSet(args[0], flag.ContinueOnError)
 	cli.SetOutput(outS)
 
@@ -73,21 +77,36 @@ func Main(inS io.Reader, outS io.Writer, args []string) error {
 		mlog.EnableError()
 	}
 
-	// handle "-epsg" usage, using the Convert API
-	if *epsgDest != 0 {
-		if *inverse {
-			return fmt.Errorf("-inverse not allowed with -epsg")
+	// handle "-epsg" usage, and no proj string at all (meaning the default
+	// target CRS), using a Transformer
+	if *epsgDest != 0 || projString == "" {
+		dest := ""
+		if *epsgDest != 0 {
+			if *inverse {
+				return fmt.Errorf("-inverse not allowed with -epsg")
+			}
+			if projString != "" {
+				return fmt.Errorf("projection string not allowed with -epsg")
+			}
+			dest = strconv.Itoa(*epsgDest)
 		}
-		if projString != "" {
-			return fmt.Errorf("projection string not allowed with -epsg")
+		tr, err := proj.NewTransformer(dest)
+		if err != nil {
+			return err
 		}
 		input := make([]float64, 2)
 
-		// wrap the converter in a little lambda to be run inside a REPL loop
+		// wrap the transformer in a little lambda to be run inside a REPL loop
 		f := func(a, b float64) (float64, float64, error) {
 			input[0] = a
 			input[1] = b
-			output, err := proj.Convert(strconv.Itoa(*epsgDest), input)
+			var output []float64
+			var err error
+			if *inverse {
+				output, err = tr.Inverse(input)
+			} else {
+				output, err = tr.Forward(input)
+			}
 			if err != nil {
 				return 0.0, 0.0, err
 			}
@@ -14,6 +14,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/oahumap/proj"
 	main "github.com/oahumap/proj/cmd/proj"
 	"github.com/stretchr/testify/assert"
 )
@@ -52,6 +53,10 @@ func TestCmd(t *testing.T) {
 			"proj -verbose -inverse +proj=utm +zone=32 +ellps=GRS80",
 			[]float64{691875.63, 6098907.83},
 			[]float64{12.0, 55.0},
+		}, {
+			"proj",
+			[]float64{0.0, 0.0},
+			nil,
 		},
 	}
 
@@ -79,3 +84,46 @@ func TestCmd(t *testing.T) {
 		}
 	}
 }
+
+func TestCmdDefaultCRS(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(proj.SetDefaultCRS("", "3395"))
+	defer proj.SetDefaultCRS("", "")
+
+	for _, tc := range []struct {
+		args          string
+		input, output string
+	}{
+		{"proj", "-77.625583 38.833846", "-8641240.37 4671101.60"},
+		{"proj -inverse", "-8641240.37 4671101.60", "-77.625583 38.833846"},
+	} {
+		outBuf := &bytes.Buffer{}
+		err := main.Main(bytes.NewBufferString(tc.input), outBuf, strings.Fields(tc.args))
+		assert.NoError(err, tc.args)
+
+		expected := strings.Fields(tc.output)
+		tokens := strings.Fields(outBuf.String())
+		assert.Len(tokens, 2, tc.args)
+		for i := range tokens {
+			e, _ := strconv.ParseFloat(expected[i], 64)
+			a, err := strconv.ParseFloat(tokens[i], 64)
+			assert.NoError(err, tc.args)
+			assert.InDelta(e, a, 1.0e-2, tc.args)
+		}
+	}
+}
+
+func TestCmdCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	outBuf := &bytes.Buffer{}
+	err := main.Main(&bytes.Buffer{}, outBuf, []string{"proj", "check"})
+	assert.NoError(err)
+
+	report := outBuf.String()
+	assert.Regexp(`(?m)^operation +result +commands`, report)
+	assert.Regexp(`(?m)^merc +pass +16 +0 `, report)
+	assert.Regexp(`(?m)^wintri +untested +0 +0 `, report)
+	assert.Regexp(`(?m)^\d+ passed, 0 failed, \d+ untested$`, report)
+}
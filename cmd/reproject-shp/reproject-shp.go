@@ -0,0 +1,315 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package main
+
+import (
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/oahumap/proj"
+	"github.com/oahumap/proj/merror"
+	"github.com/oahumap/proj/mlog"
+)
+
+func main() {
+	err := Main(os.Stdout, os.Args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Main is just a callable version of main(), for testing purposes
+//
+// Usage:
+//
+//	reproject-shp [-from proj4] -to proj4 [-prj wktfile] in.shp out.shp
+//
+// The geometries in in.shp are reprojected and written to out.shp. The
+// .shx index is rewritten with the new bounding box, and the .dbf (and
+// .cpg, if present) are copied over unchanged. The CRS of -to is written
+// to out.prj, as proj.ToWKT gives it; if -prj is given, that file is
+// copied to out.prj instead, as for projections ToWKT can't describe.
+func Main(outS io.Writer, args []string) error {
+
+	merror.ShowSource = false
+	mlog.DisableDebug()
+	mlog.DisableInfo()
+	mlog.DisableError()
+
+	cli := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	cli.SetOutput(outS)
+
+	from := cli.String("from", "", "source proj string (default: 4326 lon/lat)")
+	to := cli.String("to", "", "destination proj string")
+	prj := cli.String("prj", "", "WKT file to write as the output .prj (default: the WKT of -to)")
+
+	err := cli.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+	if *to == "" {
+		return fmt.Errorf("-to is required")
+	}
+	if cli.NArg() != 2 {
+		return fmt.Errorf("expected input and output .shp files")
+	}
+	inPath, outPath := cli.Arg(0), cli.Arg(1)
+
+	// found before anything is written, so that the output is never
+	// left without its CRS
+	var wkt []byte
+	if *prj != "" {
+		wkt, err = os.ReadFile(*prj)
+	} else {
+		var s string
+		s, err = proj.ToWKT(*to)
+		if err != nil {
+			err = fmt.Errorf("%v; give the .prj to write with -prj", err)
+		}
+		wkt = []byte(s)
+	}
+	if err != nil {
+		return err
+	}
+
+	f := func(input []float64) ([]float64, error) {
+		if *from != "" {
+			lonlat, err := proj.Inverse(*from, input)
+			if err != nil {
+				return nil, err
+			}
+			input = lonlat
+		}
+		return proj.Convert(*to, input)
+	}
+
+	shp, err := os.ReadFile(inPath)
+	if err != nil {
+		return err
+	}
+	shx, err := os.ReadFile(basename(inPath) + ".shx")
+	if err != nil {
+		return err
+	}
+
+	count, err := reprojectShapes(shp, f)
+	if err != nil {
+		return err
+	}
+
+	// record offsets and lengths don't change, so only the header
+	// bounding box of the index needs to be updated
+	copy(shx[36:68], shp[36:68])
+
+	err = os.WriteFile(outPath, shp, 0644)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(basename(outPath)+".shx", shx, 0644)
+	if err != nil {
+		return err
+	}
+
+	for _, ext := range []string{".dbf", ".cpg"} {
+		err = copyFile(basename(inPath)+ext, basename(outPath)+ext)
+		if err != nil && !(os.IsNotExist(err) && ext == ".cpg") {
+			return err
+		}
+	}
+
+	err = os.WriteFile(basename(outPath)+".prj", wkt, 0644)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(outS, "%d shapes reprojected\n", count)
+	return nil
+}
+
+// the type of our conversion lambda
+type converter func(input []float64) ([]float64, error)
+
+// shape types, from the ESRI Shapefile Technical Description
+const (
+	shapeNull        = 0
+	shapePoint       = 1
+	shapePolyLine    = 3
+	shapePolygon     = 5
+	shapeMultiPoint  = 8
+	shapePointZ      = 11
+	shapePolyLineZ   = 13
+	shapePolygonZ    = 15
+	shapeMultiPointZ = 18
+	shapePointM      = 21
+	shapePolyLineM   = 23
+	shapePolygonM    = 25
+	shapeMultiPointM = 28
+	shapeMultiPatch  = 31
+)
+
+const headerSize = 100
+
+// reprojectShapes rewrites the XY values of every record in the .shp
+// buffer in place, along with the record and file bounding boxes. Z and M
+// values are left alone, and since the record sizes don't change neither
+// does the .shx index.
+func reprojectShapes(shp []byte, f converter) (int, error) {
+
+	if len(shp) < headerSize || binary.BigEndian.Uint32(shp[0:4]) != 9994 {
+		return 0, fmt.Errorf("not a shapefile")
+	}
+
+	fileBox := newBox()
+	count := 0
+
+	for pos := headerSize; pos+8 <= len(shp); {
+		offset := pos
+		length := 2 * int(binary.BigEndian.Uint32(shp[pos+4:pos+8]))
+		rec := shp[pos+8:]
+		if length > len(rec) {
+			return 0, fmt.Errorf("truncated record at offset %d", offset)
+		}
+		rec = rec[:length]
+		pos += 8 + length
+
+		if len(rec) < 4 {
+			return 0, fmt.Errorf("empty record at offset %d", offset)
+		}
+
+		shapeType := binary.LittleEndian.Uint32(rec[0:4])
+
+		var start, n int
+		var recBox []byte
+
+		switch shapeType {
+
+		case shapeNull:
+			continue
+
+		case shapePoint, shapePointZ, shapePointM:
+			start, n = 4, 1
+
+		case shapeMultiPoint, shapeMultiPointZ, shapeMultiPointM:
+			if len(rec) < 40 {
+				return 0, fmt.Errorf("truncated record at offset %d", offset)
+			}
+			recBox = rec[4:36]
+			start = 40
+			n = int(binary.LittleEndian.Uint32(rec[36:40]))
+
+		case shapePolyLine, shapePolyLineZ, shapePolyLineM,
+			shapePolygon, shapePolygonZ, shapePolygonM, shapeMultiPatch:
+			if len(rec) < 44 {
+				return 0, fmt.Errorf("truncated record at offset %d", offset)
+			}
+			numParts := int(binary.LittleEndian.Uint32(rec[36:40]))
+			recBox = rec[4:36]
+			start = 44 + 4*numParts
+			if shapeType == shapeMultiPatch {
+				// the part types follow the parts
+				start += 4 * numParts
+			}
+			n = int(binary.LittleEndian.Uint32(rec[40:44]))
+
+		default:
+			return 0, fmt.Errorf("unsupported shape type %d", shapeType)
+		}
+
+		if start < 0 || n < 0 || start+16*n > len(rec) {
+			return 0, fmt.Errorf("truncated record at offset %d", offset)
+		}
+		points := rec[start : start+16*n]
+
+		input := getFloats(points)
+		output, err := f(input)
+		if err != nil {
+			return 0, err
+		}
+		putFloats(points, output)
+
+		box := newBox()
+		box.addPoints(output)
+		if recBox != nil {
+			box.put(recBox)
+		}
+		fileBox.addBox(box)
+		count++
+	}
+
+	fileBox.put(shp[36:68])
+
+	return count, nil
+}
+
+//---------------------------------------------------------------------
+
+type bbox struct {
+	minX, minY, maxX, maxY float64
+}
+
+func newBox() *bbox {
+	return &bbox{math.MaxFloat64, math.MaxFloat64, -math.MaxFloat64, -math.MaxFloat64}
+}
+
+func (b *bbox) addPoints(xy []float64) {
+	for i := 0; i < len(xy); i += 2 {
+		b.minX = math.Min(b.minX, xy[i])
+		b.minY = math.Min(b.minY, xy[i+1])
+		b.maxX = math.Max(b.maxX, xy[i])
+		b.maxY = math.Max(b.maxY, xy[i+1])
+	}
+}
+
+func (b *bbox) addBox(other *bbox) {
+	b.minX = math.Min(b.minX, other.minX)
+	b.minY = math.Min(b.minY, other.minY)
+	b.maxX = math.Max(b.maxX, other.maxX)
+	b.maxY = math.Max(b.maxY, other.maxY)
+}
+
+// put writes the box as the 4 little-endian doubles Xmin, Ymin, Xmax, Ymax
+func (b *bbox) put(buf []byte) {
+	if b.minX > b.maxX {
+		// no points at all
+		putFloats(buf, []float64{0, 0, 0, 0})
+		return
+	}
+	putFloats(buf, []float64{b.minX, b.minY, b.maxX, b.maxY})
+}
+
+func getFloats(buf []byte) []float64 {
+	v := make([]float64, len(buf)/8)
+	for i := range v {
+		v[i] = math.Float64frombits(binary.LittleEndian.Uint64(buf[8*i:]))
+	}
+	return v
+}
+
+func putFloats(buf []byte, v []float64) {
+	for i, f := range v {
+		binary.LittleEndian.PutUint64(buf[8*i:], math.Float64bits(f))
+	}
+}
+
+func basename(path string) string {
+	return strings.TrimSuffix(path, ".shp")
+}
+
+func copyFile(src, dst string) error {
+	b, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, b, 0644)
+}
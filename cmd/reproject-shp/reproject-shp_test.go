@@ -0,0 +1,175 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package main_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oahumap/proj"
+	main "github.com/oahumap/proj/cmd/reproject-shp"
+	"github.com/stretchr/testify/assert"
+)
+
+const merc = "+proj=merc +lon_0=0 +k=1 +x_0=0 +y_0=0 +datum=WGS84"
+
+// writeShapefile writes a point record and a two-vertex polyline record,
+// plus the matching .shx and a dummy .dbf
+func writeShapefile(t *testing.T, path string) {
+	le := binary.LittleEndian
+	be := binary.BigEndian
+
+	f64 := func(b *bytes.Buffer, v ...float64) {
+		for _, f := range v {
+			_ = binary.Write(b, le, math.Float64bits(f))
+		}
+	}
+
+	var point, line bytes.Buffer
+	_ = binary.Write(&point, le, uint32(1))
+	f64(&point, -0.127758, 51.507351) // London
+
+	_ = binary.Write(&line, le, uint32(3))
+	f64(&line, -0.127758, 48.856614, 2.352222, 51.507351)
+	_ = binary.Write(&line, le, uint32(1))
+	_ = binary.Write(&line, le, uint32(2))
+	_ = binary.Write(&line, le, uint32(0))
+	f64(&line, -0.127758, 51.507351, 2.352222, 48.856614) // London, Paris
+
+	header := func(b *bytes.Buffer, words int) {
+		_ = binary.Write(b, be, uint32(9994))
+		b.Write(make([]byte, 20))
+		_ = binary.Write(b, be, uint32(words))
+		_ = binary.Write(b, le, uint32(1000))
+		_ = binary.Write(b, le, uint32(3))
+		f64(b, -0.127758, 48.856614, 2.352222, 51.507351, 0, 0, 0, 0)
+	}
+
+	var shp, shx bytes.Buffer
+	shpWords := (100 + 8 + point.Len() + 8 + line.Len()) / 2
+	header(&shp, shpWords)
+	header(&shx, (100+16)/2)
+
+	offset := 100
+	for i, rec := range []*bytes.Buffer{&point, &line} {
+		_ = binary.Write(&shp, be, uint32(i+1))
+		_ = binary.Write(&shp, be, uint32(rec.Len()/2))
+		shp.Write(rec.Bytes())
+
+		_ = binary.Write(&shx, be, uint32(offset/2))
+		_ = binary.Write(&shx, be, uint32(rec.Len()/2))
+		offset += 8 + rec.Len()
+	}
+
+	assert.NoError(t, os.WriteFile(path, shp.Bytes(), 0644))
+	assert.NoError(t, os.WriteFile(path[:len(path)-4]+".shx", shx.Bytes(), 0644))
+	assert.NoError(t, os.WriteFile(path[:len(path)-4]+".dbf", []byte("dbf"), 0644))
+}
+
+func readFloats(b []byte, offset, count int) []float64 {
+	v := make([]float64, count)
+	for i := range v {
+		v[i] = math.Float64frombits(binary.LittleEndian.Uint64(b[offset+8*i:]))
+	}
+	return v
+}
+
+func TestReprojectShp(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	in := filepath.Join(dir, "in.shp")
+	out := filepath.Join(dir, "out.shp")
+	writeShapefile(t, in)
+
+	outBuf := &bytes.Buffer{}
+	err := main.Main(outBuf, []string{"reproject-shp", "-to", merc, in, out})
+	assert.NoError(err)
+	assert.Equal("2 shapes reprojected\n", outBuf.String())
+
+	shp, err := os.ReadFile(out)
+	assert.NoError(err)
+
+	london := []float64{-14221.96, 6678068.96}
+	paris := []float64{261848.16, 6218371.80}
+
+	// the point record
+	pt := readFloats(shp, 100+8+4, 2)
+	assert.InDeltaSlice(london, pt, 1.0e-2)
+
+	// the polyline record: box, then the two vertices
+	lineStart := 100 + 8 + 20 + 8
+	box := readFloats(shp, lineStart+4, 4)
+	assert.InDeltaSlice([]float64{london[0], paris[1], paris[0], london[1]}, box, 1.0e-2)
+	vertices := readFloats(shp, lineStart+4+32+4+4+4, 4)
+	assert.InDeltaSlice(append(london, paris...), vertices, 1.0e-2)
+
+	// the header boxes of the .shp and .shx agree
+	shx, err := os.ReadFile(filepath.Join(dir, "out.shx"))
+	assert.NoError(err)
+	assert.Equal(shp[36:68], shx[36:68])
+	assert.InDeltaSlice(box, readFloats(shp, 36, 4), 1.0e-2)
+
+	dbf, err := os.ReadFile(filepath.Join(dir, "out.dbf"))
+	assert.NoError(err)
+	assert.Equal("dbf", string(dbf))
+
+	// the CRS is written by default, or copied from -prj
+	prj, err := os.ReadFile(filepath.Join(dir, "out.prj"))
+	assert.NoError(err)
+	wkt, err := proj.ToWKT(merc)
+	assert.NoError(err)
+	assert.Equal(wkt, string(prj))
+	given := filepath.Join(dir, "given.prj")
+	assert.NoError(os.WriteFile(given, []byte("PROJCS[]"), 0644))
+	err = main.Main(outBuf, []string{"reproject-shp", "-to", merc, "-prj", given, in, out})
+	assert.NoError(err)
+	prj, err = os.ReadFile(filepath.Join(dir, "out.prj"))
+	assert.NoError(err)
+	assert.Equal("PROJCS[]", string(prj))
+
+	// and back again
+	back := filepath.Join(dir, "back.shp")
+	err = main.Main(outBuf, []string{"reproject-shp", "-from", merc, "-to", "+proj=longlat +datum=WGS84", out, back})
+	assert.NoError(err)
+	shp, err = os.ReadFile(back)
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{-0.127758, 51.507351}, readFloats(shp, 100+8+4, 2), 1.0e-6)
+}
+
+func TestReprojectShpErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	in := filepath.Join(dir, "in.shp")
+	writeShapefile(t, in)
+
+	outBuf := &bytes.Buffer{}
+	err := main.Main(outBuf, []string{"reproject-shp", in, filepath.Join(dir, "out.shp")})
+	assert.Error(err)
+
+	err = main.Main(outBuf, []string{"reproject-shp", "-to", merc, in})
+	assert.Error(err)
+
+	// without -prj, a projection with no WKT is refused before anything
+	// is written
+	err = main.Main(outBuf, []string{"reproject-shp", "-to", "+proj=gnom +lat_0=90 +datum=WGS84", in, filepath.Join(dir, "gnom.shp")})
+	assert.Error(err)
+	_, err = os.Stat(filepath.Join(dir, "gnom.shp"))
+	assert.True(os.IsNotExist(err))
+
+	bad := filepath.Join(dir, "bad.shp")
+	assert.NoError(os.WriteFile(bad, []byte("not a shapefile"), 0644))
+	assert.NoError(os.WriteFile(filepath.Join(dir, "bad.shx"), []byte{}, 0644))
+	err = main.Main(outBuf, []string{"reproject-shp", "-to", merc, bad, filepath.Join(dir, "out.shp")})
+	assert.Error(err)
+}
@@ -31,6 +31,17 @@ type IConvertLPToXY interface {
 	Inverse(*CoordXY) (*CoordLP, error)
 }
 
+// IIterativeInverse is implemented by algorithms whose inverse is found
+// by iteration, so that callers can see how well it converged.
+//
+// InverseWithConvergence is Inverse, also returning the number of
+// iterations and the residual, the size of the final correction, in
+// radians. They are returned rather than kept, so that the algorithm can
+// be used concurrently.
+type IIterativeInverse interface {
+	InverseWithConvergence(*CoordXY) (lp *CoordLP, iterations int, residual float64, err error)
+}
+
 // ConvertLPToXY is a specific kind of operation, which satisfies
 // the IConvertLPToXY interfaces.
 //
@@ -90,23 +101,45 @@ func (op *ConvertLPToXY) Forward(lp *CoordLP) (*CoordXY, error) {
 
 // Inverse is the hook-providing entry point to the inverse algorithm.
 func (op *ConvertLPToXY) Inverse(xy *CoordXY) (*CoordLP, error) {
+	lp, _, _, err := op.InverseWithConvergence(xy)
+	return lp, err
+}
+
+// IsIterative reports whether the algorithm's inverse is found by
+// iteration, i.e. whether it implements IIterativeInverse.
+func (op *ConvertLPToXY) IsIterative() bool {
+	_, ok := op.Algorithm.(IIterativeInverse)
+	return ok
+}
+
+// InverseWithConvergence is Inverse, also reporting how the algorithm
+// converged, as per IIterativeInverse; the iterations and residual are
+// zero if it isn't iterative. If it fails to converge, they are returned
+// with the error.
+func (op *ConvertLPToXY) InverseWithConvergence(xy *CoordXY) (*CoordLP, int, float64, error) {
 
 	xy, err := op.inversePrepare(xy)
 	if err != nil {
-		return nil, err
+		return nil, 0, 0.0, err
 	}
 
-	lp, err := op.Algorithm.Inverse(xy)
+	var lp *CoordLP
+	iterations, residual := 0, 0.0
+	if it, ok := op.Algorithm.(IIterativeInverse); ok {
+		lp, iterations, residual, err = it.InverseWithConvergence(xy)
+	} else {
+		lp, err = op.Algorithm.Inverse(xy)
+	}
 	if err != nil {
-		return nil, err
+		return nil, iterations, residual, err
 	}
 
 	lp, err = op.inverseFinalize(lp)
 	if err != nil {
-		return nil, err
+		return nil, iterations, residual, err
 	}
 
-	return lp, err
+	return lp, iterations, residual, nil
 }
 
 // ForwardPrepare is called just before calling Forward()
@@ -192,6 +225,9 @@ func (op *ConvertLPToXY) forwardFinalize(coo *CoordXY) (*CoordXY, error) {
 
 	}
 
+	/* Axis orientation, for every operation alike */
+	coo.X, coo.Y = sys.AxisForward(coo.X, coo.Y)
+
 	return coo, nil
 }
 
@@ -204,6 +240,9 @@ func (op *ConvertLPToXY) inversePrepare(coo *CoordXY) (*CoordXY, error) {
 		return nil, merror.New(merror.InvalidXOrY)
 	}
 
+	/* Undo the axis orientation before anything else */
+	coo.X, coo.Y = sys.AxisInverse(coo.X, coo.Y)
+
 	/* Handle remaining possible input types */
 	switch sys.Right {
 
@@ -0,0 +1,246 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package core
+
+import (
+	"math"
+
+	"github.com/oahumap/proj/merror"
+	"github.com/oahumap/proj/support"
+)
+
+// Datum is the datum of a system, as given by +datum, +towgs84 or
+// +nadgrids, together with the ellipsoid it is on: what DatumTransform
+// needs to shift lon/lat points from one system to another.
+type Datum struct {
+	Type      DatumType
+	Params    [7]float64 // as for System.DatumParams
+	Grids     string     // the +nadgrids, for DatumTypeGridShift
+	Ellipsoid *Ellipsoid
+}
+
+// Datum returns the datum of the system
+func (sys *System) Datum() *Datum {
+	d := &Datum{
+		Type:      sys.DatumType,
+		Params:    sys.DatumParams,
+		Ellipsoid: sys.Ellipsoid,
+	}
+	if d.Type == DatumTypeGridShift {
+		d.Grids, _ = sys.ProjString.GetAsString("nadgrids")
+	}
+	return d
+}
+
+// NewDatum returns the datum of the proj string, which need not be that
+// of a projection: "+proj=longlat +datum=NAD27" will do. Without an
+// ellipsoid, the datum is on WGS84, as it would be for a projection.
+func NewDatum(ps *support.ProjString) (*Datum, error) {
+	sys := &System{ProjString: ps.DeepCopy()}
+
+	err := sys.processDatum()
+	if err != nil {
+		return nil, err
+	}
+	if !sys.ProjString.ContainsKey("ellps") && !sys.ProjString.ContainsKey("a") && !sys.ProjString.ContainsKey("R") {
+		sys.ProjString.Add(support.Pair{Key: "ellps", Value: "WGS84"})
+	}
+	err = sys.processEllipsoid()
+	if err != nil {
+		return nil, err
+	}
+	sys.processWGS84()
+
+	return sys.Datum(), nil
+}
+
+// Equal reports whether the two datums are the same, so that shifting
+// from one to the other changes nothing
+func (d *Datum) Equal(other *Datum) bool {
+	if d.Ellipsoid.A != other.Ellipsoid.A ||
+		math.Abs(d.Ellipsoid.Es-other.Ellipsoid.Es) > 0.000000000050 {
+		return false
+	}
+	if d.isNull() && other.isNull() {
+		return true
+	}
+	return d.Type == other.Type && d.Params == other.Params && d.Grids == other.Grids
+}
+
+// isNull reports whether the datum's shift to WGS84 does nothing
+func (d *Datum) isNull() bool {
+	switch d.Type {
+	case DatumTypeWGS84:
+		return true
+	case DatumType3Param:
+		return d.Params[0] == 0.0 && d.Params[1] == 0.0 && d.Params[2] == 0.0
+	case DatumType7Param:
+		return d.Params == [7]float64{0, 0, 0, 0, 0, 0, 1}
+	}
+	return false
+}
+
+// DatumTransform shifts a lon/lat point, in radians on Greenwich, from the
+// src datum to the dst datum, as DatumTransform3D does, with the heights
+// taken to be zero.
+func DatumTransform(src, dst *Datum, lp *CoordLP) (*CoordLP, error) {
+	lpz, err := DatumTransform3D(src, dst, &CoordLPZ{Lam: lp.Lam, Phi: lp.Phi})
+	if err != nil {
+		return nil, err
+	}
+	return &CoordLP{Lam: lpz.Lam, Phi: lpz.Phi}, nil
+}
+
+// DatumTransform3D shifts a lon/lat/height point, in radians on Greenwich
+// and meters above the ellipsoid, from the src datum to the dst datum,
+// through geocentric coordinates and WGS84 by the 3- or 7-parameter
+// Helmert transformation of each, as pj_datum_transform does. The height
+// changes with the shift, as the ellipsoid does.
+//
+// If either datum is unknown, or they are the same, the point is returned
+// unchanged. A grid shift is supported only for the null grid, "@null",
+// which puts the points on WGS84 without changing them.
+func DatumTransform3D(src, dst *Datum, lpz *CoordLPZ) (*CoordLPZ, error) {
+	if src.Type == DatumTypeUnknown || dst.Type == DatumTypeUnknown {
+		return lpz, nil
+	}
+
+	src, err := src.withoutGrids()
+	if err != nil {
+		return nil, err
+	}
+	dst, err = dst.withoutGrids()
+	if err != nil {
+		return nil, err
+	}
+	if src.Equal(dst) {
+		return lpz, nil
+	}
+
+	x, y, z := GeodeticToGeocentric(src.Ellipsoid, lpz.Lam, lpz.Phi, lpz.Z)
+	x, y, z = src.toWGS84(x, y, z)
+	x, y, z = dst.fromWGS84(x, y, z)
+	lam, phi, h := GeocentricToGeodetic(dst.Ellipsoid, x, y, z)
+
+	return &CoordLPZ{Lam: lam, Phi: phi, Z: h}, nil
+}
+
+// withoutGrids returns the datum, with the null grid replaced by WGS84
+func (d *Datum) withoutGrids() (*Datum, error) {
+	if d.Type != DatumTypeGridShift {
+		return d, nil
+	}
+	if d.Grids != "@null" {
+		return nil, merror.New(merror.UnsupportedProjectionString, "nadgrids="+d.Grids)
+	}
+	return &Datum{Type: DatumTypeWGS84, Ellipsoid: wgs84Ellipsoid}, nil
+}
+
+// toWGS84 applies the datum's Helmert transformation to a geocentric
+// point, as pj_geocentric_to_wgs84 does
+func (d *Datum) toWGS84(x, y, z float64) (float64, float64, float64) {
+	p := d.Params
+	switch d.Type {
+	case DatumType3Param:
+		return x + p[0], y + p[1], z + p[2]
+	case DatumType7Param:
+		return p[6]*(x-p[5]*y+p[4]*z) + p[0],
+			p[6]*(p[5]*x+y-p[3]*z) + p[1],
+			p[6]*(-p[4]*x+p[3]*y+z) + p[2]
+	}
+	return x, y, z
+}
+
+// fromWGS84 is the inverse of toWGS84, as pj_geocentric_from_wgs84
+func (d *Datum) fromWGS84(x, y, z float64) (float64, float64, float64) {
+	p := d.Params
+	switch d.Type {
+	case DatumType3Param:
+		return x - p[0], y - p[1], z - p[2]
+	case DatumType7Param:
+		x = (x - p[0]) / p[6]
+		y = (y - p[1]) / p[6]
+		z = (z - p[2]) / p[6]
+		return x + p[5]*y - p[4]*z,
+			-p[5]*x + y + p[3]*z,
+			p[4]*x - p[3]*y + z
+	}
+	return x, y, z
+}
+
+// wgs84Ellipsoid is the ellipsoid of the null grid
+var wgs84Ellipsoid = func() *Ellipsoid {
+	e, err := NewEllipsoid(6378137.0, 1.0/298.257223563)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}()
+
+//---------------------------------------------------------------------
+
+// GeodeticToGeocentric returns the geocentric x/y/z, in meters, of the
+// point at lon/lat (radians) and height h (meters) on the ellipsoid
+func GeodeticToGeocentric(e *Ellipsoid, lam, phi, h float64) (float64, float64, float64) {
+	sinPhi, cosPhi := math.Sin(phi), math.Cos(phi)
+	rn := e.A / math.Sqrt(1.0-e.Es*sinPhi*sinPhi) /* Earth radius at location */
+
+	return (rn + h) * cosPhi * math.Cos(lam),
+		(rn + h) * cosPhi * math.Sin(lam),
+		(rn*(1.0-e.Es) + h) * sinPhi
+}
+
+// GeocentricToGeodetic is the inverse of GeodeticToGeocentric, by the
+// iteration of pj_Convert_Geocentric_To_Geodetic
+func GeocentricToGeodetic(e *Ellipsoid, x, y, z float64) (float64, float64, float64) {
+	const genau = 1.e-12
+	const genau2 = genau * genau
+	const maxIter = 30
+
+	b := e.A * math.Sqrt(1.0-e.Es)
+
+	d2 := x*x + y*y
+	p := math.Sqrt(d2)        /* distance between semi-minor axis and location */
+	rr := math.Sqrt(d2 + z*z) /* distance between center and location */
+
+	var lam float64
+	if p/e.A < genau {
+		/* special case: on the polar axis */
+		if rr/e.A < genau {
+			/* at the center of the earth */
+			return 0.0, support.PiOverTwo, -b
+		}
+	} else {
+		lam = math.Atan2(y, x)
+	}
+
+	ct := z / rr
+	st := p / rr
+	rx := 1.0 / math.Sqrt(1.0-e.Es*(2.0-e.Es)*st*st)
+	cphi0 := st * (1.0 - e.Es) * rx
+	sphi0 := ct * rx
+
+	var h, cphi, sphi float64
+	for iter := 0; iter < maxIter; iter++ {
+		rn := e.A / math.Sqrt(1.0-e.Es*sphi0*sphi0)
+		h = p*cphi0 + z*sphi0 - rn*(1.0-e.Es*sphi0*sphi0)
+
+		rk := e.Es * rn / (rn + h)
+		rx = 1.0 / math.Sqrt(1.0-rk*(2.0-rk)*st*st)
+		cphi = st * (1.0 - rk) * rx
+		sphi = ct * rx
+		sdphi := sphi*cphi0 - cphi*sphi0
+		cphi0, sphi0 = cphi, sphi
+
+		if sdphi*sdphi <= genau2 {
+			break
+		}
+	}
+
+	return lam, math.Atan(sphi / math.Abs(cphi)), h
+}
@@ -0,0 +1,140 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package core_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/support"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDatum(t *testing.T, proj string) *core.Datum {
+	ps, err := support.NewProjString(proj)
+	assert.NoError(t, err)
+	d, err := core.NewDatum(ps)
+	assert.NoError(t, err)
+	return d
+}
+
+func TestGeocentric(t *testing.T) {
+	assert := assert.New(t)
+
+	// EPSG Guidance Note 7-2, 2.2.1
+	wgs84 := newDatum(t, "+proj=longlat +datum=WGS84").Ellipsoid
+	lam := support.DDToR(2.0 + 7.0/60.0 + 46.38/3600.0)
+	phi := support.DDToR(53.0 + 48.0/60.0 + 33.82/3600.0)
+	x, y, z := core.GeodeticToGeocentric(wgs84, lam, phi, 73.0)
+	assert.InDelta(3771793.968, x, 1.0e-3)
+	assert.InDelta(140253.342, y, 1.0e-3)
+	assert.InDelta(5124304.349, z, 1.0e-3)
+
+	l, p, h := core.GeocentricToGeodetic(wgs84, x, y, z)
+	assert.InDelta(lam, l, 1.0e-12)
+	assert.InDelta(phi, p, 1.0e-12)
+	assert.InDelta(73.0, h, 1.0e-6)
+
+	// the poles
+	_, p, h = core.GeocentricToGeodetic(wgs84, 0.0, 0.0, -6356752.314245)
+	assert.InDelta(-support.PiOverTwo, p, 1.0e-12)
+	assert.InDelta(0.0, h, 1.0e-6)
+}
+
+func TestDatumTransform(t *testing.T) {
+	assert := assert.New(t)
+
+	wgs84 := newDatum(t, "+proj=longlat +datum=WGS84")
+	assert.Equal(core.DatumTypeWGS84, wgs84.Type)
+
+	// EPSG Guidance Note 7-2, 2.4.3.1: WGS 72 to WGS 84, by the 7-parameter
+	// position vector transformation
+	wgs72 := newDatum(t, "+proj=longlat +a=6378135 +rf=298.26 +towgs84=0,0,4.5,0,0,0.554,0.219")
+	assert.Equal(core.DatumType7Param, wgs72.Type)
+	lam, phi, _ := core.GeocentricToGeodetic(wgs72.Ellipsoid, 3657660.66, 255768.55, 5201382.11)
+	eLam, ePhi, _ := core.GeocentricToGeodetic(wgs84.Ellipsoid, 3657660.78, 255778.43, 5201387.75)
+
+	lp, err := core.DatumTransform(wgs72, wgs84, &core.CoordLP{Lam: lam, Phi: phi})
+	assert.NoError(err)
+	assert.InDelta(eLam, lp.Lam, 1.0e-9)
+	assert.InDelta(ePhi, lp.Phi, 1.0e-9)
+
+	back, err := core.DatumTransform(wgs84, wgs72, lp)
+	assert.NoError(err)
+	assert.InDelta(lam, back.Lam, 1.0e-9)
+	assert.InDelta(phi, back.Phi, 1.0e-9)
+
+	// a 3-parameter shift, with the change of ellipsoid, moves points by
+	// some hundreds of meters for Old Hawaiian
+	oldHawaiian := newDatum(t, "+proj=longlat +datum=OldHawaiian")
+	assert.Equal(core.DatumType3Param, oldHawaiian.Type)
+	in := &core.CoordLP{Lam: support.DDToR(-157.8583), Phi: support.DDToR(21.3069)}
+	lp, err = core.DatumTransform(wgs84, oldHawaiian, in)
+	assert.NoError(err)
+	dx := (lp.Lam - in.Lam) * wgs84.Ellipsoid.A * math.Cos(in.Phi)
+	dy := (lp.Phi - in.Phi) * wgs84.Ellipsoid.A
+	assert.InDelta(450.0, math.Hypot(dx, dy), 50.0)
+
+	// the heights are dropped, which costs about a millimeter on the way
+	// back
+	back, err = core.DatumTransform(oldHawaiian, wgs84, lp)
+	assert.NoError(err)
+	assert.InDelta(in.Lam, back.Lam, 1.0e-9)
+	assert.InDelta(in.Phi, back.Phi, 1.0e-9)
+
+	// the same datum, an unknown one and the null grid change nothing
+	for _, proj := range []string{
+		"+proj=longlat +ellps=GRS80 +towgs84=0,0,0",
+		"+proj=longlat +ellps=WGS84 +towgs84=0,0,0,0,0,0,0",
+		"+proj=longlat +ellps=clrk66",
+		"+proj=longlat +a=6378137 +b=6378137 +nadgrids=@null",
+	} {
+		lp, err := core.DatumTransform(wgs84, newDatum(t, proj), in)
+		assert.NoError(err, proj)
+		assert.Equal(in, lp, proj)
+	}
+
+	// other grids aren't supported
+	_, err = core.DatumTransform(wgs84, newDatum(t, "+proj=longlat +ellps=clrk66 +nadgrids=conus"), in)
+	assert.Error(err)
+}
+
+func TestDatumTransform3D(t *testing.T) {
+	assert := assert.New(t)
+
+	// EPSG Guidance Note 7-2, 2.4.3.1 again, with the heights carried
+	// through rather than dropped
+	wgs84 := newDatum(t, "+proj=longlat +datum=WGS84")
+	wgs72 := newDatum(t, "+proj=longlat +a=6378135 +rf=298.26 +towgs84=0,0,4.5,0,0,0.554,0.219")
+	lam, phi, h := core.GeocentricToGeodetic(wgs72.Ellipsoid, 3657660.66, 255768.55, 5201382.11)
+	eLam, ePhi, eH := core.GeocentricToGeodetic(wgs84.Ellipsoid, 3657660.78, 255778.43, 5201387.75)
+
+	lpz, err := core.DatumTransform3D(wgs72, wgs84, &core.CoordLPZ{Lam: lam, Phi: phi, Z: h})
+	assert.NoError(err)
+	assert.InDelta(eLam, lpz.Lam, 1.0e-9)
+	assert.InDelta(ePhi, lpz.Phi, 1.0e-9)
+	assert.InDelta(eH, lpz.Z, 1.0e-2)
+
+	// and so the round trip is exact, unlike that of DatumTransform
+	oldHawaiian := newDatum(t, "+proj=longlat +datum=OldHawaiian")
+	in := &core.CoordLPZ{Lam: support.DDToR(-157.8583), Phi: support.DDToR(21.3069), Z: 100.0}
+	lpz, err = core.DatumTransform3D(wgs84, oldHawaiian, in)
+	assert.NoError(err)
+	assert.NotEqual(100.0, lpz.Z)
+	back, err := core.DatumTransform3D(oldHawaiian, wgs84, lpz)
+	assert.NoError(err)
+	assert.InDelta(in.Lam, back.Lam, 1.0e-12)
+	assert.InDelta(in.Phi, back.Phi, 1.0e-12)
+	assert.InDelta(in.Z, back.Z, 1.0e-6)
+
+	// the same datum changes nothing
+	lpz, err = core.DatumTransform3D(wgs84, newDatum(t, "+proj=longlat +ellps=WGS84 +towgs84=0,0,0"), in)
+	assert.NoError(err)
+	assert.Equal(in, lpz)
+}
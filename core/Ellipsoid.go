@@ -9,7 +9,9 @@ package core
 
 import (
 	"encoding/json"
+	"fmt"
 	"math"
+	"strconv"
 
 	"github.com/oahumap/proj/merror"
 	"github.com/oahumap/proj/support"
@@ -20,6 +22,13 @@ import (
 // A System object contains a pointer to one Ellipsoid object. It is pretty
 // close to the original C type, but when it grows up it wants to look and feel
 // like a real Go type.
+//
+// The size and shape come from, in increasing order of precedence, ellps=,
+// then a=, then one of rf=, f=, es=, e= or b= (the first of these present,
+// in that order). R= overrules all of them and gives a sphere. Any other
+// shape parameters given alongside the one used must describe the same
+// ellipsoid, or NewEllipsoid returns an merror.InconsistentEllipsoid error
+// saying which values were used.
 type Ellipsoid struct {
 	ID    string
 	Major string
@@ -66,9 +75,33 @@ type Ellipsoid struct {
 	EsOrig, AOrig float64 /* es and a before any +proj related adjustment */
 }
 
-// NewEllipsoid creates an Ellipsoid and initializes it from the
+// NewEllipsoid returns the ellipsoid with semimajor axis a and flattening
+// f, with all of its derived values (B, E, Es, OneEs and so on) filled in.
+// A flattening of zero gives a sphere of radius a.
+func NewEllipsoid(a, f float64) (*Ellipsoid, error) {
+	if !(a > 0.0) || a == math.MaxFloat64 {
+		return nil, merror.New(merror.MajorAxisNotGiven)
+	}
+	if !(f >= 0.0 && f < 1.0) {
+		return nil, merror.New(merror.InvalidArg)
+	}
+
+	ellipsoid := &Ellipsoid{
+		DefSize: "a",
+		F:       f,
+	}
+
+	err := ellipsoid.doCalcParams(a, f*(2-f))
+	if err != nil {
+		return nil, err
+	}
+
+	return ellipsoid, nil
+}
+
+// NewEllipsoidFromSystem creates an Ellipsoid and initializes it from the
 // information in the given System object
-func NewEllipsoid(sys *System) (*Ellipsoid, error) {
+func NewEllipsoidFromSystem(sys *System) (*Ellipsoid, error) {
 	ellipsoid := &Ellipsoid{}
 
 	err := ellipsoid.initialize(sys)
@@ -120,10 +153,16 @@ func (e *Ellipsoid) initialize(sys *System) error {
 		return err
 	}
 
+	/* Any other shape parameters given must agree with the one we used */
+	err = e.checkShape(sys.ProjString)
+	if err != nil {
+		return err
+	}
+
 	/* When we're done with it, we compute all related ellipsoid parameters */
 	err = e.doCalcParams(e.A, e.Es)
 	if err != nil {
-		return nil
+		return err
 	}
 
 	/* And finally, we may turn it into a sphere */
@@ -176,7 +215,7 @@ func (e *Ellipsoid) doCalcParams(a float64, es float64) error {
 	}
 
 	/* third flattening */
-	P.N = math.Pow(math.Tan(P.Alpha/2), 2)
+	P.N = support.Pow(math.Tan(P.Alpha/2), 2)
 	P.Rn = math.MaxFloat64
 	if P.N != 0.0 {
 		P.Rn = 1 / P.N
@@ -283,13 +322,19 @@ func (e *Ellipsoid) doSize(ps *support.ProjString) error {
 	return nil
 }
 
+// shapeKeys are the keys that give the shape of the ellipsoid, in order
+// of precedence
+var shapeKeys = []string{"rf", "f", "es", "e", "b"}
+
+// ShapeTolerance is how far apart, in meters, the semiminor axes implied
+// by two shape parameters may be before they are taken to disagree
+const ShapeTolerance = 1.0e-3
+
 func getShapeKey(ps *support.ProjString) (bool, string, float64) {
 
 	/* Check which shape key is specified */
 
-	keys := []string{"rf", "f", "es", "e", "b"}
-
-	for _, key := range keys {
+	for _, key := range shapeKeys {
 		value, ok := ps.GetAsFloat(key)
 		if ok {
 			return true, key, value
@@ -400,6 +445,49 @@ func (e *Ellipsoid) doShape(ps *support.ProjString) error {
 	return nil
 }
 
+// checkShape makes sure that any shape parameters given besides the one
+// doShape used describe the same ellipsoid, to within ShapeTolerance
+func (e *Ellipsoid) checkShape(ps *support.ProjString) error {
+
+	found, usedKey, _ := getShapeKey(ps)
+	if !found {
+		return nil
+	}
+	usedValue, _ := ps.GetAsString(usedKey)
+	b := e.A * math.Sqrt(1-e.Es)
+
+	for _, key := range shapeKeys {
+		if key == usedKey || !ps.ContainsKey(key) {
+			continue
+		}
+		value, _ := ps.GetAsFloat(key)
+
+		var implied float64
+		switch key {
+		case "rf":
+			f := 1 / value
+			implied = e.A * math.Sqrt(1-(2*f-f*f))
+		case "f":
+			implied = e.A * math.Sqrt(1-(2*value-value*value))
+		case "es":
+			implied = e.A * math.Sqrt(1-value)
+		case "e":
+			implied = e.A * math.Sqrt(1-value*value)
+		case "b":
+			implied = value
+		}
+
+		if !(math.Abs(implied-b) <= ShapeTolerance) {
+			given, _ := ps.GetAsString(key)
+			return merror.New(merror.InconsistentEllipsoid,
+				fmt.Sprintf("used a=%s and %s=%s, giving b=%.4f, but %s=%s gives b=%.4f",
+					strconv.FormatFloat(e.A, 'f', -1, 64), usedKey, usedValue, b, key, given, implied))
+		}
+	}
+
+	return nil
+}
+
 func getSphereKey(ps *support.ProjString) string {
 	keys := []string{"R_A", "R_V", "R_a", "R_g", "R_h", "R_lat_a", "R_lat_g"}
 
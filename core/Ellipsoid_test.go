@@ -32,3 +32,92 @@ func TestEllipsoid(t *testing.T) {
 	s := fmt.Sprintf("%s", e)
 	assert.True(len(s) > 1)
 }
+
+func TestEllipsoidShape(t *testing.T) {
+	assert := assert.New(t)
+
+	newEllipsoid := func(s string) (*core.Ellipsoid, error) {
+		ps, err := support.NewProjString("+proj=merc " + s)
+		assert.NoError(err)
+		sys, _, err := core.NewSystem(ps)
+		if err != nil {
+			return nil, err
+		}
+		return sys.Ellipsoid, nil
+	}
+
+	grs80, err := newEllipsoid("+ellps=GRS80")
+	assert.NoError(err)
+
+	// inverse flattening, alone or with a consistent b
+	for _, s := range []string{
+		"+a=6378137 +rf=298.257222101",
+		"+a=6378137 +b=6356752.31414 +rf=298.257222101",
+		"+a=6378137 +f=0.003352810681182319 +b=6356752.3141",
+	} {
+		e, err := newEllipsoid(s)
+		assert.NoError(err, s)
+		assert.InDelta(grs80.Es, e.Es, 1.0e-12, s)
+		assert.InDelta(grs80.B, e.B, 1.0e-3, s)
+	}
+
+	// the ellipsoid's own shape may be replaced
+	e, err := newEllipsoid("+ellps=GRS80 +rf=300")
+	assert.NoError(err)
+	assert.InDelta(300.0, e.Rf, 1.0e-9)
+
+	// but not contradicted
+	_, err = newEllipsoid("+a=6378137 +b=6356752 +rf=300")
+	assert.Error(err)
+	assert.Contains(err.Error(), "rf=300")
+	assert.Contains(err.Error(), "b=6356752")
+
+	_, err = newEllipsoid("+ellps=GRS80 +es=0.006 +e=0.5")
+	assert.Error(err)
+
+	_, err = newEllipsoid("+a=6378137 +rf=0")
+	assert.Error(err)
+
+	// other bodies are as good as the earth: Mars is flatter, the Moon is
+	// a sphere, and neither needs to be anywhere near the earth's size
+	mars, err := newEllipsoid("+ellps=Mars2000")
+	assert.NoError(err)
+	assert.InDelta(169.894447, mars.Rf, 1.0e-6)
+	e, err = newEllipsoid("+a=3396190 +b=3376200 +rf=169.894447")
+	assert.NoError(err)
+	assert.InDelta(mars.Es, e.Es, 1.0e-10)
+	moon, err := newEllipsoid("+ellps=Moon2000")
+	assert.NoError(err)
+	assert.Equal(0.0, moon.Es)
+	assert.Equal(1737400.0, moon.A)
+}
+
+func TestNewEllipsoid(t *testing.T) {
+	assert := assert.New(t)
+
+	ps, err := support.NewProjString("+proj=merc +ellps=WGS84")
+	assert.NoError(err)
+	sys, _, err := core.NewSystem(ps)
+	assert.NoError(err)
+
+	e, err := core.NewEllipsoid(6378137.0, 1.0/298.257223563)
+	assert.NoError(err)
+	assert.InDelta(sys.Ellipsoid.B, e.B, 1.0e-9)
+	assert.InDelta(sys.Ellipsoid.E, e.E, 1.0e-15)
+	assert.InDelta(sys.Ellipsoid.Es, e.Es, 1.0e-15)
+	assert.InDelta(sys.Ellipsoid.OneEs, e.OneEs, 1.0e-15)
+	assert.InDelta(298.257223563, e.Rf, 1.0e-9)
+
+	sphere, err := core.NewEllipsoid(6371000.0, 0.0)
+	assert.NoError(err)
+	assert.Equal(6371000.0, sphere.B)
+	assert.Equal(0.0, sphere.Es)
+	assert.Equal(1.0, sphere.OneEs)
+
+	_, err = core.NewEllipsoid(0.0, 0.0)
+	assert.Error(err)
+	_, err = core.NewEllipsoid(6371000.0, 1.0)
+	assert.Error(err)
+	_, err = core.NewEllipsoid(6371000.0, -0.1)
+	assert.Error(err)
+}
@@ -0,0 +1,169 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package core
+
+import (
+	"math"
+
+	"github.com/oahumap/proj/merror"
+	"github.com/oahumap/proj/support"
+)
+
+// GenericInverseMaxIterations bounds the iteration of GenericInverse
+const GenericInverseMaxIterations = 30
+
+// GenericInverseEpsilon is the tolerance of GenericInverse, in radians
+const GenericInverseEpsilon = 1e-12
+
+// genericInverseMisfit is how far, in radii, the forward of a point which
+// has converged may be from the point wanted
+const genericInverseMisfit = 1e-9
+
+// genericInverseHalvings bounds how often a step of GenericInverse is
+// halved
+const genericInverseHalvings = 20
+
+// genericInverseDelta is the step of the finite differences, in radians
+const genericInverseDelta = 1e-7
+
+// GenericInverse is the inverse of an algorithm which has only a forward,
+// found numerically: starting from the guess, it solves forward(lp) = xy
+// by Newton's method, with the derivatives found by finite differences.
+// As for PROJ's pj_generic_inverse_2d, the forward must be smooth and one
+// to one over the area of interest.
+//
+// The forward is given a copy of the point each time, so it may modify
+// it. Longitudes are kept in [-pi, pi] and latitudes in (-pi/2, pi/2).
+// The number of iterations and the final residual are returned as for
+// IIterativeInverse; if it doesn't converge, the name of the algorithm is
+// used for the merror.ConvergenceError.
+func GenericInverse(name string, forward func(*CoordLP) (*CoordXY, error), xy *CoordXY, guess CoordLP) (*CoordLP, int, float64, error) {
+	at := func(lam, phi float64) (*CoordXY, error) {
+		f, err := forward(&CoordLP{Lam: lam, Phi: phi})
+		if err == nil && (math.IsNaN(f.X) || math.IsNaN(f.Y)) {
+			// some forwards don't check their domain
+			return nil, merror.New(merror.ToleranceCondition)
+		}
+		return f, err
+	}
+	misfit := func(f *CoordXY) float64 {
+		return math.Max(math.Abs(xy.X-f.X), math.Abs(xy.Y-f.Y))
+	}
+	near := func(f *CoordXY) bool {
+		return misfit(f) < GenericInverseEpsilon
+	}
+
+	// the poles are singular, so are looked for first
+	for _, pole := range []float64{support.PiOverTwo, -support.PiOverTwo} {
+		if f, err := at(0.0, pole); err == nil && near(f) {
+			return &CoordLP{Lam: 0.0, Phi: pole}, 0, 0.0, nil
+		}
+	}
+
+	lam, phi := clampLP(guess.Lam, guess.Phi)
+	f, err := at(lam, phi)
+	if err != nil {
+		return nil, 0, 0.0, err
+	}
+	// until a step is taken, the residual is how far the guess is off
+	residual := misfit(f)
+
+	for iterations := 1; iterations <= GenericInverseMaxIterations; iterations++ {
+		if near(f) {
+			return &CoordLP{Lam: lam, Phi: phi}, iterations, residual, nil
+		}
+		dx, dy := xy.X-f.X, xy.Y-f.Y
+
+		xLam, yLam, err := derivative(at, f, lam, phi, genericInverseDelta, 0.0)
+		if err != nil {
+			return nil, iterations, residual, err
+		}
+		xPhi, yPhi, err := derivative(at, f, lam, phi, 0.0, genericInverseDelta)
+		if err != nil {
+			return nil, iterations, residual, err
+		}
+		det := xLam*yPhi - xPhi*yLam
+		if math.Abs(det) < 1e-15 {
+			return nil, iterations, residual, merror.New(merror.ToleranceCondition)
+		}
+		stepLam := (yPhi*dx - xPhi*dy) / det
+		stepPhi := (xLam*dy - yLam*dx) / det
+
+		// on the antimeridian, a step off the map is replaced by the
+		// closest step along it
+		if math.Abs(lam) == math.Pi && lam*stepLam > 0.0 {
+			stepLam = 0.0
+			stepPhi = (xPhi*dx + yPhi*dy) / (xPhi*xPhi + yPhi*yPhi)
+		}
+
+		// far from the solution, Newton can overshoot, so the step is
+		// limited, and halved until it stays in the domain and gets
+		// closer
+		if size := math.Max(math.Abs(stepLam), math.Abs(stepPhi)); size > 0.5 {
+			stepLam *= 0.5 / size
+			stepPhi *= 0.5 / size
+		}
+		var nextLam, nextPhi float64
+		for halvings := 0; ; halvings++ {
+			nextLam, nextPhi = clampLP(lam+stepLam, phi+stepPhi)
+			g, err := at(nextLam, nextPhi)
+			if err == nil && (misfit(g) < misfit(f) || halvings == genericInverseHalvings) {
+				f = g
+				break
+			}
+			if halvings == genericInverseHalvings {
+				return nil, iterations, residual, err
+			}
+			stepLam, stepPhi = 0.5*stepLam, 0.5*stepPhi
+		}
+
+		// the residual is how far the point actually moved, so that a
+		// point held off a pole converges
+		residual = math.Max(math.Abs(nextLam-lam), math.Abs(nextPhi-phi))
+		lam, phi = nextLam, nextPhi
+		if residual < GenericInverseEpsilon {
+			// stuck against the edge of the domain, rather than at
+			// the point
+			if m := misfit(f); m > genericInverseMisfit {
+				return nil, iterations, m, merror.NewConvergenceError(name, iterations, m)
+			}
+			return &CoordLP{Lam: lam, Phi: phi}, iterations, residual, nil
+		}
+	}
+
+	return nil, GenericInverseMaxIterations, residual, merror.NewConvergenceError(name, GenericInverseMaxIterations, residual)
+}
+
+// derivative returns the derivative of the forward at the point in the
+// given direction, by a finite difference on whichever side of the point
+// is in the domain
+func derivative(at func(lam, phi float64) (*CoordXY, error), f *CoordXY, lam, phi, dlam, dphi float64) (float64, float64, error) {
+	if phi > 0.0 || lam > 0.0 {
+		// step towards the middle first, away from the poles and the
+		// antimeridian
+		dlam, dphi = -dlam, -dphi
+	}
+	g, err := at(lam+dlam, phi+dphi)
+	if err != nil {
+		dlam, dphi = -dlam, -dphi
+		g, err = at(lam+dlam, phi+dphi)
+		if err != nil {
+			return 0.0, 0.0, err
+		}
+	}
+	h := dlam + dphi
+	return (g.X - f.X) / h, (g.Y - f.Y) / h, nil
+}
+
+// clampLP keeps a point in the domain of the forward, and just off the
+// poles, where the derivative along the parallel vanishes
+func clampLP(lam, phi float64) (float64, float64) {
+	const limit = support.PiOverTwo - GenericInverseEpsilon
+	return math.Max(-math.Pi, math.Min(math.Pi, lam)),
+		math.Max(-limit, math.Min(limit, phi))
+}
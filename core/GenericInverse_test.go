@@ -0,0 +1,57 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package core_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/merror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenericInverse(t *testing.T) {
+	assert := assert.New(t)
+
+	// the sinusoidal, whose inverse is known
+	sinusoidal := func(lp *core.CoordLP) (*core.CoordXY, error) {
+		xy := &core.CoordXY{X: lp.Lam * math.Cos(lp.Phi), Y: lp.Phi}
+		lp.Lam = math.NaN() // the forward may modify its input
+		return xy, nil
+	}
+
+	for _, lp := range []core.CoordLP{{Lam: 0.3, Phi: 0.2}, {Lam: -3.0, Phi: -1.4}, {Lam: 0.0, Phi: 0.0}, {Lam: 3.1, Phi: 1.2}} {
+		xy, _ := sinusoidal(&core.CoordLP{Lam: lp.Lam, Phi: lp.Phi})
+		got, iterations, _, err := core.GenericInverse("sinu", sinusoidal, xy, core.CoordLP{Lam: xy.X, Phi: xy.Y})
+		assert.NoError(err)
+		assert.InDelta(lp.Lam, got.Lam, 1e-10)
+		assert.InDelta(lp.Phi, got.Phi, 1e-10)
+		assert.True(iterations <= core.GenericInverseMaxIterations)
+	}
+
+	// the poles are found, although the forward is singular there
+	got, _, _, err := core.GenericInverse("sinu", sinusoidal, &core.CoordXY{X: 0.0, Y: -math.Pi / 2.0}, core.CoordLP{})
+	assert.NoError(err)
+	assert.Equal(-math.Pi/2.0, got.Phi)
+
+	// a point outside the map isn't found
+	_, _, _, err = core.GenericInverse("sinu", sinusoidal, &core.CoordXY{X: 0.0, Y: 3.0}, core.CoordLP{})
+	assert.Error(err)
+	var cerr merror.ConvergenceError
+	assert.True(errors.As(err, &cerr))
+	assert.Equal("sinu", cerr.Operation)
+
+	// and errors of the forward are passed on
+	failing := func(lp *core.CoordLP) (*core.CoordXY, error) {
+		return nil, merror.New(merror.ToleranceCondition)
+	}
+	_, _, _, err = core.GenericInverse("fail", failing, &core.CoordXY{X: 0.1, Y: 0.1}, core.CoordLP{})
+	assert.Error(err)
+}
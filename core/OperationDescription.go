@@ -35,9 +35,26 @@ type OperationDescription struct {
 	OperationType OperationType
 	InputType     CoordType
 	OutputType    CoordType
+	Domain        *Domain     // nil if the operation is valid everywhere
+	Parameters    []Parameter // those specific to the operation
 	creatorFunc   interface{} // for now, this will always be a ConvertLPToXYCreatorFuncType
 }
 
+// Domain is the lon/lat region in which an operation gives sensible
+// results. All values are in degrees, and the longitudes are relative to
+// the system's central meridian (lon_0).
+type Domain struct {
+	MinLam, MaxLam float64
+	MinPhi, MaxPhi float64
+}
+
+// Contains returns true iff the point (in degrees, with the longitude
+// relative to the central meridian) is inside the domain
+func (d *Domain) Contains(lam, phi float64) bool {
+	return lam >= d.MinLam && lam <= d.MaxLam &&
+		phi >= d.MinPhi && phi <= d.MaxPhi
+}
+
 // RegisterConvertLPToXY adds an OperationDescription entry to the OperationDescriptionTable
 //
 // Each file in the operations package has an init() routine which calls this function.
@@ -65,6 +82,19 @@ func RegisterConvertLPToXY(
 	OperationDescriptionTable[id] = pi
 }
 
+// RegisterDomain sets the valid domain of an already-registered operation
+//
+// Operations with a limited domain call this from their init() routine,
+// right after registering themselves. Those whose parameters narrow or
+// widen the domain set System.Domain in their constructor instead.
+func RegisterDomain(id string, domain Domain) {
+	desc, ok := OperationDescriptionTable[id]
+	if !ok {
+		panic(fmt.Sprintf("domain for unknown operation description id '%s'", id))
+	}
+	desc.Domain = &domain
+}
+
 // CreateOperation returns a new object of the specific operation type, e.g. an operations.EtMerc
 func (desc *OperationDescription) CreateOperation(sys *System) (IOperation, error) {
 
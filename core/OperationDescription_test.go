@@ -8,9 +8,17 @@
 package core_test
 
 import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
 	"testing"
 
 	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/support"
+
+	// need to pull in the operations table entries
+	_ "github.com/oahumap/proj/operations"
 )
 
 func TestOperationDescription(t *testing.T) {
@@ -20,3 +28,85 @@ func TestOperationDescription(t *testing.T) {
 		t.Errorf("operaton description table for utm is nil")
 	}
 }
+
+func TestRegisterParameters(t *testing.T) {
+
+	// eqc has registered its parameters, and can't do so twice
+	defer func() {
+		if recover() == nil {
+			t.Errorf("registering the parameters of eqc again should panic")
+		}
+	}()
+	core.RegisterParameters("eqc",
+		core.Parameter{Name: "lat_ts", Type: core.ParameterAngle, Unit: "degrees", Default: "0", Description: "latitude of true scale"},
+	)
+}
+
+func TestDomain(t *testing.T) {
+
+	ps, err := support.NewProjString("+proj=utm +zone=32 +ellps=GRS80")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sys, _, err := core.NewSystem(ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sys.OpDescr.Domain == nil {
+		t.Fatalf("utm has no domain")
+	}
+
+	// zone 32 is centered on 9E
+	if !sys.InDomain(&core.CoordLP{Lam: support.DDToR(53.9), Phi: support.DDToR(10.0)}) {
+		t.Errorf("44.9 degrees from the central meridian should be in the domain")
+	}
+	if sys.InDomain(&core.CoordLP{Lam: support.DDToR(-36.1), Phi: support.DDToR(10.0)}) {
+		t.Errorf("45.1 degrees from the central meridian should not be in the domain")
+	}
+}
+
+func TestGeneralParameters(t *testing.T) {
+
+	described := map[string]bool{"k": true} // as k_0
+	for _, p := range core.GeneralParameters {
+		described[p.Name] = true
+	}
+
+	// the keys are read by name, but for those of readUnits
+	read := map[string]bool{"units": true, "vunits": true}
+	for _, file := range []string{"System.go", "Ellipsoid.go", "Datum.go"} {
+		f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 1 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			lit, isLit := call.Args[0].(*ast.BasicLit)
+			if !ok || !isLit || lit.Kind != token.STRING {
+				return true
+			}
+			switch sel.Sel.Name {
+			case "ContainsKey", "CountKey", "GetAsString", "GetAsFloat", "GetAsFloats",
+				"GetAsInt", "GetAsBool", "GetAsAngle", "GetAsDegrees":
+				key, _ := strconv.Unquote(lit.Value)
+				read[key] = true
+			}
+			return true
+		})
+	}
+
+	// these are refused, or read but not acted on
+	for _, key := range []string{"init", "pipeline", "geoidgrids", "catalog", "date", "lon_wrap", "t_0"} {
+		delete(read, key)
+	}
+	for key := range read {
+		if !described[key] {
+			t.Errorf("%s is read by NewSystem, but not described by GeneralParameters", key)
+		}
+	}
+}
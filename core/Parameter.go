@@ -0,0 +1,79 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package core
+
+import "fmt"
+
+// ParameterType is the kind of value a proj string parameter takes
+type ParameterType string
+
+// The parameter types
+const (
+	ParameterFloat  ParameterType = "float"  // a number
+	ParameterAngle  ParameterType = "angle"  // in degrees, decimal or DMS, with an optional N/S/E/W
+	ParameterInt    ParameterType = "int"    // a whole number
+	ParameterFlag   ParameterType = "flag"   // no value, as in "+south"
+	ParameterString ParameterType = "string" // a name, such as an ellipsoid id
+	ParameterList   ParameterType = "list"   // comma-separated numbers
+)
+
+// Parameter describes a proj string parameter, for documentation and for
+// validation by tools
+type Parameter struct {
+	Name        string        `json:"name"`
+	Type        ParameterType `json:"type"`
+	Unit        string        `json:"unit,omitempty"`    // e.g. "degrees", for numbers
+	Default     string        `json:"default,omitempty"` // if not given
+	Description string        `json:"description"`
+}
+
+// GeneralParameters are those taken by every operation
+var GeneralParameters = []Parameter{
+	{Name: "proj", Type: ParameterString, Description: "the operation"},
+	{Name: "ellps", Type: ParameterString, Description: "the ellipsoid, by name"},
+	{Name: "datum", Type: ParameterString, Description: "the datum, by name"},
+	{Name: "a", Type: ParameterFloat, Unit: "meters", Description: "semi-major axis"},
+	{Name: "b", Type: ParameterFloat, Unit: "meters", Description: "semi-minor axis"},
+	{Name: "rf", Type: ParameterFloat, Description: "inverse flattening"},
+	{Name: "f", Type: ParameterFloat, Description: "flattening"},
+	{Name: "es", Type: ParameterFloat, Description: "eccentricity squared"},
+	{Name: "e", Type: ParameterFloat, Description: "eccentricity"},
+	{Name: "R", Type: ParameterFloat, Unit: "meters", Description: "radius of a sphere"},
+	{Name: "towgs84", Type: ParameterList, Description: "3 or 7 parameter datum shift to WGS 84"},
+	{Name: "nadgrids", Type: ParameterString, Description: "datum shift grids; only @null is supported"},
+	{Name: "pm", Type: ParameterString, Description: "prime meridian, by name or in degrees"},
+	{Name: "lon_0", Type: ParameterAngle, Unit: "degrees", Default: "0", Description: "central meridian"},
+	{Name: "lat_0", Type: ParameterAngle, Unit: "degrees", Default: "0", Description: "latitude of origin"},
+	{Name: "x_0", Type: ParameterFloat, Unit: "meters", Default: "0", Description: "false easting"},
+	{Name: "y_0", Type: ParameterFloat, Unit: "meters", Default: "0", Description: "false northing"},
+	{Name: "z_0", Type: ParameterFloat, Unit: "meters", Default: "0", Description: "false height"},
+	{Name: "k_0", Type: ParameterFloat, Default: "1", Description: "scale factor (also k)"},
+	{Name: "units", Type: ParameterString, Default: "m", Description: "linear unit of the output, by name"},
+	{Name: "to_meter", Type: ParameterFloat, Description: "size of the unit instead of units: meters for geocentric, radians for geographic systems"},
+	{Name: "vunits", Type: ParameterString, Default: "m", Description: "unit of the heights, by name"},
+	{Name: "axis", Type: ParameterString, Default: "enu", Description: "directions of the axes, in order, such as neu"},
+	{Name: "over", Type: ParameterFlag, Description: "don't wrap longitudes to [-180, 180]"},
+	{Name: "geoc", Type: ParameterFlag, Description: "take latitudes as geocentric"},
+	{Name: "no_defs", Type: ParameterFlag, Description: "ignored"},
+}
+
+// RegisterParameters sets the parameters of an already-registered
+// operation, other than the GeneralParameters
+//
+// Operations call this from their init() routine, right after registering
+// themselves, and only once.
+func RegisterParameters(id string, parameters ...Parameter) {
+	desc, ok := OperationDescriptionTable[id]
+	if !ok {
+		panic(fmt.Sprintf("parameters for unknown operation description id '%s'", id))
+	}
+	if desc.Parameters != nil {
+		panic(fmt.Sprintf("duplicate parameters for operation description id '%s'", id))
+	}
+	desc.Parameters = parameters
+}
@@ -12,6 +12,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"github.com/oahumap/proj/merror"
 	"github.com/oahumap/proj/support"
@@ -41,6 +42,18 @@ const (
 	IOUnitsAngular   IOUnitsType = 4 /* Radians */
 )
 
+// PolePolicy is the enum for what projections which are singular at the
+// poles, such as merc, do with latitudes at (or within 1e-10 radians of)
+// a pole
+type PolePolicy int
+
+// All the PolePolicy constants
+const (
+	PoleError    PolePolicy = 0 /* Fail with a tolerance condition, as PROJ does */
+	PoleInfinity PolePolicy = 1 /* Return an infinite northing, of the pole's sign */
+	PoleClamp    PolePolicy = 2 /* Clamp the latitude to the projection's own limit */
+)
+
 // DirectionType is the enum for the operation's direction
 type DirectionType int
 
@@ -72,6 +85,11 @@ type System struct {
 	IsGeocentric bool /* proj=geocent ... not really a projection at all */
 	NeedEllps    bool /* 0 for operations that are purely cartesian */
 
+	Domain *Domain /* Where the operation is valid (nil if everywhere); see RegisterDomain */
+
+	PolePolicy    PolePolicy /* What to do at a pole, if the operation is singular there */
+	HighPrecision bool       /* Compute the setup constants in extended precision */
+
 	Left  IOUnitsType /* Flags for input/output coordinate types */
 	Right IOUnitsType
 
@@ -125,6 +143,22 @@ type System struct {
 	//double        last_after_date;      /* TODO: Description needed */
 }
 
+var highPrecision atomic.Bool
+
+// SetHighPrecision sets whether the systems made from then on compute
+// their setup constants, such as the cone constants of the conics, in
+// extended precision, so that parameters close to a degenerate case, such
+// as nearly equal standard parallels, still give accurate constants. It
+// is off by default; the per-point computations are float64 either way.
+func SetHighPrecision(on bool) {
+	highPrecision.Store(on)
+}
+
+// HighPrecision reports whether new systems use extended precision
+func HighPrecision() bool {
+	return highPrecision.Load()
+}
+
 // NewSystem returns a new System object
 func NewSystem(ps *support.ProjString) (*System, IOperation, error) {
 
@@ -133,13 +167,17 @@ func NewSystem(ps *support.ProjString) (*System, IOperation, error) {
 		return nil, nil, err
 	}
 
+	// the system gets its own copy, which setup adds to, so that the
+	// caller's can be reused
 	sys := &System{
-		ProjString: ps,
+		ProjString: ps.DeepCopy(),
 		NeedEllps:  true,
 		Left:       IOUnitsAngular,
 		Right:      IOUnitsClassic,
 		Axis:       "enu",
 		FromMeter:  1.0,
+
+		HighPrecision: HighPrecision(),
 	}
 
 	err = sys.initialize()
@@ -155,6 +193,19 @@ func NewSystem(ps *support.ProjString) (*System, IOperation, error) {
 	return sys, op, nil
 }
 
+// Clone returns a deep copy of the system, which can be changed without
+// affecting the original. The operation description, which is never
+// changed, is shared.
+func (sys *System) Clone() *System {
+	c := *sys
+	c.ProjString = sys.ProjString.DeepCopy()
+	if sys.Ellipsoid != nil {
+		ellipsoid := *sys.Ellipsoid
+		c.Ellipsoid = &ellipsoid
+	}
+	return &c
+}
+
 // ValidateProjStringContents checks to mke sure the contents are semantically valid
 func ValidateProjStringContents(pl *support.ProjString) error {
 
@@ -178,9 +229,6 @@ func ValidateProjStringContents(pl *support.ProjString) error {
 	}
 
 	// explicitly call out stuff we don't support yet
-	if pl.ContainsKey("axis") {
-		return merror.New(merror.UnsupportedProjectionString, "axis")
-	}
 	if pl.ContainsKey("geoidgrids") {
 		return merror.New(merror.UnsupportedProjectionString, "geoidgrids")
 	}
@@ -188,6 +236,16 @@ func ValidateProjStringContents(pl *support.ProjString) error {
 		return merror.New(merror.UnsupportedProjectionString, "to_meter")
 	}
 
+	// flags have no value, or a boolean one
+	for _, pair := range pl.Pairs {
+		if !support.FlagsTable[pair.Key] {
+			continue
+		}
+		if _, ok := pl.GetAsBool(pair.Key); !ok {
+			return merror.New(merror.InvalidProjectionSyntax, pair.Key+"="+pair.Value)
+		}
+	}
+
 	return nil
 }
 
@@ -209,6 +267,7 @@ func (sys *System) initialize() error {
 	}
 
 	sys.OpDescr = opDescr
+	sys.Domain = opDescr.Domain
 
 	err := sys.processDatum()
 	if err != nil {
@@ -220,7 +279,14 @@ func (sys *System) initialize() error {
 		return err
 	}
 
-	/* Now that we have ellipse information check for WGS84 datum */
+	sys.processWGS84()
+
+	return sys.processMisc()
+}
+
+// processWGS84 switches a null 3-parameter shift on the WGS84 or GRS80
+// ellipsoid to the WGS84 datum, now that we have ellipse information
+func (sys *System) processWGS84() {
 	if sys.DatumType == DatumType3Param &&
 		sys.DatumParams[0] == 0.0 &&
 		sys.DatumParams[1] == 0.0 &&
@@ -230,8 +296,6 @@ func (sys *System) initialize() error {
 		/*WGS84/GRS80*/
 		sys.DatumType = DatumTypeWGS84
 	}
-
-	return sys.processMisc()
 }
 
 func (sys *System) processDatum() error {
@@ -320,7 +384,7 @@ func (sys *System) processDatum() error {
 
 func (sys *System) processEllipsoid() error {
 
-	ellipsoid, err := NewEllipsoid(sys)
+	ellipsoid, err := NewEllipsoidFromSystem(sys)
 	if err != nil {
 		return err
 	}
@@ -402,7 +466,7 @@ func (sys *System) readUnits(vertical bool) (float64, float64, error) {
 			to = factor
 		}
 
-		from = 1.0 / sys.FromMeter
+		from = 1.0 / to
 	} else {
 		to = 1.0
 		from = 1.0
@@ -414,10 +478,11 @@ func (sys *System) readUnits(vertical bool) (float64, float64, error) {
 func (sys *System) processMisc() error {
 
 	/* Set PIN->geoc coordinate system */
-	sys.Geoc = (sys.Ellipsoid.Es != 0.0 && sys.ProjString.ContainsKey("geoc"))
+	geoc, _ := sys.ProjString.GetAsBool("geoc")
+	sys.Geoc = (sys.Ellipsoid.Es != 0.0 && geoc)
 
 	/* Over-ranging flag */
-	sys.Over = sys.ProjString.ContainsKey("over")
+	sys.Over, _ = sys.ProjString.GetAsBool("over")
 
 	/* Vertical datum geoid grids */
 	sys.HasGeoidVgrids = sys.ProjString.ContainsKey("geoidgrids")
@@ -425,7 +490,11 @@ func (sys *System) processMisc() error {
 	/* Longitude center for wrapping */
 	sys.IsLongWrapSet = sys.ProjString.ContainsKey("lon_wrap")
 	if sys.IsLongWrapSet {
-		sys.LongWrapCenter, _ = sys.ProjString.GetAsFloat("lon_wrap")
+		f, _, err := sys.ProjString.GetAsDegrees("lon_wrap")
+		if err != nil {
+			return err
+		}
+		sys.LongWrapCenter = f * support.DegToRad
 		/* Don't accept excessive values otherwise we might perform badly */
 		/* when correcting longitudes around it */
 		/* The test is written this way to error on long_wrap_center "=" NaN */
@@ -440,13 +509,19 @@ func (sys *System) processMisc() error {
 	}
 
 	/* Central meridian */
-	f, ok := sys.ProjString.GetAsFloat("lon_0")
+	f, ok, err := sys.ProjString.GetAsDegrees("lon_0")
+	if err != nil {
+		return err
+	}
 	if ok {
 		sys.Lam0 = f * support.DegToRad
 	}
 
 	/* Central latitude */
-	f, ok = sys.ProjString.GetAsFloat("lat_0")
+	f, ok, err = sys.ProjString.GetAsDegrees("lat_0")
+	if err != nil {
+		return err
+	}
 	if ok {
 		sys.Phi0 = f * support.DegToRad
 	}
@@ -544,25 +619,84 @@ func (sys *System) processUnits() error {
 func (sys *System) processAxis() error {
 	/* Axis orientation */
 	if sys.ProjString.ContainsKey("axis") {
-		axisLegal := "ewnsud"
 		axisArg, _ := sys.ProjString.GetAsString("axis")
 		if len(axisArg) != 3 {
 			return merror.New(merror.Axis)
 		}
 
-		if !strings.ContainsAny(axisArg[0:1], axisLegal) ||
-			!strings.ContainsAny(axisArg[1:2], axisLegal) ||
-			!strings.ContainsAny(axisArg[2:3], axisLegal) {
+		// one of each of east/west, north/south and up/down, with the
+		// vertical one last: the horizontal axes may be swapped and
+		// reversed, but the vertical one stays put
+		if !strings.ContainsAny(axisArg[0:1], "ewns") ||
+			!strings.ContainsAny(axisArg[1:2], "ewns") ||
+			!strings.ContainsAny(axisArg[2:3], "ud") ||
+			strings.ContainsAny(axisArg[0:1], "ew") == strings.ContainsAny(axisArg[1:2], "ew") {
 			return merror.New(merror.Axis)
 		}
 
-		/* TODO: it would be nice to validate we don't have on axis repeated */
 		sys.Axis = axisArg
 	}
 
 	return nil
 }
 
+// AxisForward returns the easting and northing, in that order, as the
+// system's axes: swapped for +axis=neu, negated for +axis=wsu, and so on.
+func (sys *System) AxisForward(x, y float64) (float64, float64) {
+	if sys.Axis == "enu" {
+		return x, y
+	}
+	var out [2]float64
+	for i := 0; i < 2; i++ {
+		switch sys.Axis[i] {
+		case 'e':
+			out[i] = x
+		case 'w':
+			out[i] = -x
+		case 'n':
+			out[i] = y
+		case 's':
+			out[i] = -y
+		}
+	}
+	return out[0], out[1]
+}
+
+// AxisInverse is the inverse of AxisForward, returning the easting and
+// northing from coordinates on the system's axes.
+func (sys *System) AxisInverse(a, b float64) (float64, float64) {
+	if sys.Axis == "enu" {
+		return a, b
+	}
+	var x, y float64
+	for i, v := range [2]float64{a, b} {
+		switch sys.Axis[i] {
+		case 'e':
+			x = v
+		case 'w':
+			x = -v
+		case 'n':
+			y = v
+		case 's':
+			y = -v
+		}
+	}
+	return x, y
+}
+
+// InDomain returns true iff the point (lon/lat in radians) lies within the
+// valid domain of the system's operation. Operations which have not
+// declared a domain accept every point.
+func (sys *System) InDomain(lp *CoordLP) bool {
+	domain := sys.Domain
+	if domain == nil {
+		return true
+	}
+
+	lam := support.Adjlon(lp.Lam - sys.FromGreenwich - sys.Lam0)
+	return domain.Contains(support.RToDD(lam), support.RToDD(lp.Phi))
+}
+
 // GeocentricLatitude converts geographical latitude to geocentric
 // or the other way round if direction = PJ_INV
 func GeocentricLatitude(op *System, direction DirectionType, lp *CoordLP) *CoordLP {
@@ -58,4 +58,56 @@ func TestProjStringValidation(t *testing.T) {
 		err = core.ValidateProjStringContents(ps)
 		assert.Error(err)
 	}
+
+	// flags must be booleans, if they have a value
+	{
+		ps, err = support.NewProjString("proj=utm south over=t no_defs=false")
+		assert.NoError(err)
+		err = core.ValidateProjStringContents(ps)
+		assert.NoError(err)
+
+		ps, err = support.NewProjString("proj=utm south=1")
+		assert.NoError(err)
+		err = core.ValidateProjStringContents(ps)
+		assert.Error(err)
+	}
+}
+
+func TestSystemClone(t *testing.T) {
+	assert := assert.New(t)
+
+	// setting up a system doesn't change the proj string it was given,
+	// so it can be reused
+	ps, err := support.NewProjString("+proj=merc +datum=GGRS87")
+	assert.NoError(err)
+	definition := ps.Definition()
+	sys1, _, err := core.NewSystem(ps)
+	assert.NoError(err)
+	assert.Equal(definition, ps.Definition())
+	sys2, _, err := core.NewSystem(ps)
+	assert.NoError(err)
+	assert.Equal(sys1.ProjString.Definition(), sys2.ProjString.Definition())
+	assert.Equal(*sys1.Ellipsoid, *sys2.Ellipsoid)
+
+	// a clone is independent of the original
+	clone := sys1.Clone()
+	assert.Equal(*sys1.Ellipsoid, *clone.Ellipsoid)
+	assert.Equal(sys1.ProjString.Definition(), clone.ProjString.Definition())
+	clone.Ellipsoid.A = 1.0
+	clone.ProjString.Add(support.Pair{Key: "over"})
+	clone.Lam0 = 1.0
+	assert.Equal(sys2.Ellipsoid.A, sys1.Ellipsoid.A)
+	assert.Equal(sys2.ProjString.Definition(), sys1.ProjString.Definition())
+	assert.Equal(0.0, sys1.Lam0)
+
+	// wintri is spherical, with all of the values that go with that
+	ps, err = support.NewProjString("+proj=wintri +ellps=WGS84")
+	assert.NoError(err)
+	sys, _, err := core.NewSystem(ps)
+	assert.NoError(err)
+	assert.Equal(6378137.0, sys.Ellipsoid.A)
+	assert.Equal(6378137.0, sys.Ellipsoid.B)
+	assert.Equal(0.0, sys.Ellipsoid.Es)
+	assert.Equal(0.0, sys.Ellipsoid.E)
+	assert.Equal(1.0, sys.Ellipsoid.OneEs)
 }
@@ -102,3 +102,43 @@ func Test3395(t *testing.T) {
 	assert.NotNil(opx)
 	assert.EqualValues(sys, opx.GetSystem())
 }
+
+func TestAxis(t *testing.T) {
+	assert := assert.New(t)
+
+	// the axes are turned round after the operation, whichever it is, and
+	// turned back before its inverse
+	for _, tc := range []struct {
+		axis string
+		x, y float64
+	}{
+		{"enu", 691875.63, 6098907.83},
+		{"wsu", -691875.63, -6098907.83},
+		{"neu", 6098907.83, 691875.63},
+		{"swd", -6098907.83, -691875.63},
+	} {
+		ps, err := support.NewProjString("+proj=utm +zone=32 +ellps=GRS80 +axis=" + tc.axis)
+		assert.NoError(err)
+		_, opx, err := core.NewSystem(ps)
+		assert.NoError(err, tc.axis)
+		op := opx.(core.IConvertLPToXY)
+
+		output, err := op.Forward(&core.CoordLP{Lam: support.DDToR(12.0), Phi: support.DDToR(55.0)})
+		assert.NoError(err)
+		assert.InDelta(tc.x, output.X, 1e-2, tc.axis)
+		assert.InDelta(tc.y, output.Y, 1e-2, tc.axis)
+
+		output2, err := op.Inverse(output)
+		assert.NoError(err)
+		assert.InDelta(12.0, support.RToDD(output2.Lam), 1e-8, tc.axis)
+		assert.InDelta(55.0, support.RToDD(output2.Phi), 1e-8, tc.axis)
+	}
+
+	// one of east/west and of north/south, then up or down
+	for _, axis := range []string{"ewu", "nsu", "une", "enx", "en"} {
+		ps, err := support.NewProjString("+proj=utm +zone=32 +ellps=GRS80 +axis=" + axis)
+		assert.NoError(err)
+		_, _, err = core.NewSystem(ps)
+		assert.Error(err, axis)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+// Package geohash encodes WGS84 lon/lat points, such as the output of
+// proj.Inverse, as geohash strings.
+package geohash
+
+import (
+	"fmt"
+	"strings"
+)
+
+const base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// MaxPrecision is the longest geohash we will generate; at 12 characters
+// a cell is already a few centimeters across.
+const MaxPrecision = 12
+
+// CellIndexer maps a lon/lat point to the ID of the cell containing it.
+//
+// Geohash implements this, and it is the hook for passing through other
+// schemes such as S2 or H3 cell IDs, without this package depending on them.
+type CellIndexer interface {
+	Index(lon, lat float64) (string, error)
+}
+
+// Geohash is a CellIndexer producing geohashes of a fixed length
+type Geohash struct {
+	Precision int
+}
+
+// Index returns the geohash of the point
+func (g Geohash) Index(lon, lat float64) (string, error) {
+	return Encode(lon, lat, g.Precision)
+}
+
+// Encode returns the geohash of the given lon/lat (in degrees), using
+// precision characters.
+func Encode(lon, lat float64, precision int) (string, error) {
+
+	if precision < 1 || precision > MaxPrecision {
+		return "", fmt.Errorf("geohash precision must be in 1..%d", MaxPrecision)
+	}
+	if !(lon >= -180.0 && lon <= 180.0) || !(lat >= -90.0 && lat <= 90.0) {
+		return "", fmt.Errorf("point (%f, %f) is not a valid lon/lat", lon, lat)
+	}
+
+	minLon, maxLon := -180.0, 180.0
+	minLat, maxLat := -90.0, 90.0
+
+	var sb strings.Builder
+	isLon := true
+	bit := 0
+	ch := 0
+
+	for sb.Len() < precision {
+		if isLon {
+			mid := (minLon + maxLon) / 2
+			if lon >= mid {
+				ch = ch<<1 | 1
+				minLon = mid
+			} else {
+				ch <<= 1
+				maxLon = mid
+			}
+		} else {
+			mid := (minLat + maxLat) / 2
+			if lat >= mid {
+				ch = ch<<1 | 1
+				minLat = mid
+			} else {
+				ch <<= 1
+				maxLat = mid
+			}
+		}
+		isLon = !isLon
+
+		bit++
+		if bit == 5 {
+			sb.WriteByte(base32[ch])
+			bit = 0
+			ch = 0
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// Decode returns the center of the geohash cell, along with the
+// half-width and half-height of the cell (the error bounds), all in
+// degrees.
+func Decode(hash string) (lon, lat, lonErr, latErr float64, err error) {
+
+	if hash == "" {
+		return 0, 0, 0, 0, fmt.Errorf("empty geohash")
+	}
+
+	minLon, maxLon := -180.0, 180.0
+	minLat, maxLat := -90.0, 90.0
+	isLon := true
+
+	for _, c := range strings.ToLower(hash) {
+		v := strings.IndexRune(base32, c)
+		if v < 0 {
+			return 0, 0, 0, 0, fmt.Errorf("invalid geohash character %q", c)
+		}
+		for mask := 16; mask != 0; mask >>= 1 {
+			if isLon {
+				mid := (minLon + maxLon) / 2
+				if v&mask != 0 {
+					minLon = mid
+				} else {
+					maxLon = mid
+				}
+			} else {
+				mid := (minLat + maxLat) / 2
+				if v&mask != 0 {
+					minLat = mid
+				} else {
+					maxLat = mid
+				}
+			}
+			isLon = !isLon
+		}
+	}
+
+	lon = (minLon + maxLon) / 2
+	lat = (minLat + maxLat) / 2
+	lonErr = (maxLon - minLon) / 2
+	latErr = (maxLat - minLat) / 2
+	return lon, lat, lonErr, latErr, nil
+}
+
+// IndexAll runs the indexer over an array of lon/lat points, e.g.
+// [lon0, lat0, lon1, lat1, ...], as returned by proj.Inverse.
+func IndexAll(idx CellIndexer, lonlat []float64) ([]string, error) {
+
+	if len(lonlat)%2 != 0 {
+		return nil, fmt.Errorf("input array of lon/lat values must be an even number")
+	}
+
+	ids := make([]string, len(lonlat)/2)
+	for i := range ids {
+		id, err := idx.Index(lonlat[2*i], lonlat[2*i+1])
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
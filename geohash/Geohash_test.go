@@ -0,0 +1,69 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package geohash_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/oahumap/proj/geohash"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	assert := assert.New(t)
+
+	// the canonical examples
+	h, err := geohash.Encode(-5.6, 42.6, 5)
+	assert.NoError(err)
+	assert.Equal("ezs42", h)
+
+	h, err = geohash.Encode(10.40744, 57.64911, 11)
+	assert.NoError(err)
+	assert.Equal("u4pruydqqvj", h)
+
+	_, err = geohash.Encode(0, 0, 0)
+	assert.Error(err)
+	_, err = geohash.Encode(0, 0, geohash.MaxPrecision+1)
+	assert.Error(err)
+	_, err = geohash.Encode(181, 0, 5)
+	assert.Error(err)
+	_, err = geohash.Encode(0, -91, 5)
+	assert.Error(err)
+}
+
+func TestDecode(t *testing.T) {
+	assert := assert.New(t)
+
+	lon, lat, lonErr, latErr, err := geohash.Decode("ezs42")
+	assert.NoError(err)
+	assert.InDelta(-5.6, lon, lonErr)
+	assert.InDelta(42.6, lat, latErr)
+	assert.InDelta(0.02197, lonErr, 1e-5)
+	assert.InDelta(0.02197, latErr, 1e-5)
+
+	_, _, _, _, err = geohash.Decode("")
+	assert.Error(err)
+	_, _, _, _, err = geohash.Decode("ezs4a")
+	assert.Error(err)
+}
+
+func TestIndexAll(t *testing.T) {
+	assert := assert.New(t)
+
+	// index the output of an inverse conversion
+	lonlat, err := proj.Inverse("3857", []float64{-623395.5, 5254936.3})
+	assert.NoError(err)
+
+	ids, err := geohash.IndexAll(geohash.Geohash{Precision: 5}, lonlat)
+	assert.NoError(err)
+	assert.Equal([]string{"ezs42"}, ids)
+
+	_, err = geohash.IndexAll(geohash.Geohash{Precision: 5}, []float64{1})
+	assert.Error(err)
+}
@@ -0,0 +1,417 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package geotiff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/oahumap/proj"
+	"github.com/oahumap/proj/support"
+)
+
+// The model types
+const (
+	ModelTypeProjected  = 1
+	ModelTypeGeographic = 2
+	ModelTypeGeocentric = 3
+)
+
+// The coordinate transformations (ProjCoordTransGeoKey) which are
+// supported
+const (
+	CTTransverseMercator   = 1
+	CTObliqueMercator      = 3
+	CTMercator             = 7
+	CTLambertConfConic2SP  = 8
+	CTLambertConfConic1SP  = 9
+	CTAlbersEqualArea      = 11
+	CTAzimuthalEquidistant = 12
+	CTEquirectangular      = 17
+)
+
+// geographicTypes are the datum or ellipsoid, as proj parameters, of the
+// common geographic CRSs (GeographicTypeGeoKey) and their datums
+// (GeogGeodeticDatumGeoKey)
+var geographicTypes = map[uint16]string{
+	4326: "+datum=WGS84",
+	4269: "+ellps=GRS80 +towgs84=0,0,0,0,0,0,0",
+	4258: "+ellps=GRS80",
+	4267: "+ellps=clrk66",
+	4230: "+ellps=intl",
+	4277: "+ellps=airy",
+	4322: "+ellps=WGS72",
+	4019: "+ellps=GRS80",
+	4030: "+ellps=WGS84",
+
+	6326: "+datum=WGS84",
+	6269: "+ellps=GRS80 +towgs84=0,0,0,0,0,0,0",
+	6258: "+ellps=GRS80",
+	6267: "+ellps=clrk66",
+	6230: "+ellps=intl",
+	6277: "+ellps=airy",
+	6322: "+ellps=WGS72",
+}
+
+// ellipsoids are the ids of the ellipsoids (GeogEllipsoidGeoKey)
+var ellipsoids = map[uint16]string{
+	7001: "airy",
+	7004: "bessel",
+	7008: "clrk66",
+	7012: "clrk80",
+	7019: "GRS80",
+	7022: "intl",
+	7024: "krass",
+	7030: "WGS84",
+	7043: "WGS72",
+}
+
+// primeMeridians are the ids of the prime meridians
+// (GeogPrimeMeridianGeoKey)
+var primeMeridians = map[uint16]string{
+	8901: "greenwich",
+	8902: "lisbon",
+	8903: "paris",
+	8904: "bogota",
+	8905: "madrid",
+	8906: "rome",
+	8907: "bern",
+	8908: "jakarta",
+	8909: "ferro",
+	8910: "brussels",
+	8911: "stockholm",
+	8912: "athens",
+	8913: "oslo",
+}
+
+// linearUnits are the ids of the linear units (ProjLinearUnitsGeoKey
+// and GeogLinearUnitsGeoKey)
+var linearUnits = map[uint16]string{
+	9001: "m",
+	9002: "ft",
+	9003: "us-ft",
+	9014: "fath",
+	9030: "kmi",
+	9036: "km",
+	9093: "mi",
+	9096: "yd",
+}
+
+// angularUnits are the ids of the angular units (GeogAngularUnitsGeoKey)
+var angularUnits = map[uint16]string{
+	9101: "rad",
+	9102: "deg",
+	9105: "grad",
+	9122: "deg",
+}
+
+// Definition returns the CRS of the keys as a definition NewTransformer
+// accepts: "EPSG:<code>" for a code which is one of the presets, and a
+// proj string otherwise.
+//
+// Projected CRSs given by an EPSG code which isn't a preset are still
+// interpreted from their other keys, if they have them.
+func (k *Keys) Definition() (string, error) {
+	model, ok := k.short(GTModelTypeGeoKey)
+	if !ok {
+		// older images leave the model out
+		model = ModelTypeGeographic
+		if _, ok := k.short(ProjectedCSTypeGeoKey); ok {
+			model = ModelTypeProjected
+		}
+	}
+
+	switch model {
+	case ModelTypeProjected:
+		return k.projected()
+	case ModelTypeGeographic:
+		return k.geographic()
+	}
+	return "", fmt.Errorf("unsupported model type %d", model)
+}
+
+// Transformer returns a transformer for the CRS of the keys, as given by
+// Definition
+func (k *Keys) Transformer() (*proj.Transformer, error) {
+	def, err := k.Definition()
+	if err != nil {
+		return nil, err
+	}
+	return proj.NewTransformer(def)
+}
+
+func (k *Keys) geographic() (string, error) {
+	if code, ok := k.short(GeographicTypeGeoKey); ok && code != UserDefined {
+		if _, err := proj.FromSRID(int(code)); err == nil {
+			return "EPSG:" + strconv.Itoa(int(code)), nil
+		}
+	}
+
+	geodetic, err := k.geodetic()
+	if err != nil {
+		return "", err
+	}
+	def := "+proj=longlat " + geodetic
+
+	if code, ok := k.short(GeogAngularUnitsGeoKey); ok {
+		id, ok := angularUnits[code]
+		if !ok {
+			return "", fmt.Errorf("unsupported angular unit %d", code)
+		}
+		if id != "deg" {
+			def += " +units=" + id
+		}
+	}
+	return def, nil
+}
+
+// geodetic returns the datum or ellipsoid, and the prime meridian, as
+// proj parameters
+func (k *Keys) geodetic() (string, error) {
+	var def string
+
+	if code, ok := k.short(GeographicTypeGeoKey); ok && code != UserDefined {
+		if def, ok = geographicTypes[code]; !ok {
+			return "", fmt.Errorf("unsupported geographic type %d", code)
+		}
+	} else if code, ok := k.short(GeogGeodeticDatumGeoKey); ok && code != UserDefined {
+		if def, ok = geographicTypes[code]; !ok {
+			return "", fmt.Errorf("unsupported datum %d", code)
+		}
+	} else if code, ok := k.short(GeogEllipsoidGeoKey); ok && code != UserDefined {
+		id, ok := ellipsoids[code]
+		if !ok {
+			return "", fmt.Errorf("unsupported ellipsoid %d", code)
+		}
+		def = "+ellps=" + id
+	} else {
+		var err error
+		def, err = k.ellipsoidAxes()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if code, ok := k.short(GeogPrimeMeridianGeoKey); ok && code != 8901 {
+		id, ok := primeMeridians[code]
+		if !ok {
+			return "", fmt.Errorf("unsupported prime meridian %d", code)
+		}
+		def += " +pm=" + id
+	}
+
+	return def, nil
+}
+
+// ellipsoidAxes returns the user-defined ellipsoid
+func (k *Keys) ellipsoidAxes() (string, error) {
+	a, ok := k.double(GeogSemiMajorAxisGeoKey)
+	if !ok {
+		return "", fmt.Errorf("no datum or ellipsoid is given")
+	}
+
+	toMeter, err := k.linearUnit(GeogLinearUnitsGeoKey, 0)
+	if err != nil {
+		return "", err
+	}
+	a *= toMeter
+
+	if rf, ok := k.double(GeogInvFlatteningGeoKey); ok && rf != 0.0 {
+		return "+a=" + number(a) + " +rf=" + number(rf), nil
+	}
+	if b, ok := k.double(GeogSemiMinorAxisGeoKey); ok && b*toMeter != a {
+		return "+a=" + number(a) + " +b=" + number(b*toMeter), nil
+	}
+	return "+R=" + number(a), nil
+}
+
+// linearUnit returns the size in meters of the unit given by a key, or
+// by the size key if it is user-defined; meters if neither is given
+func (k *Keys) linearUnit(key, sizeKey GeoKey) (float64, error) {
+	code, ok := k.short(key)
+	if !ok {
+		return 1.0, nil
+	}
+	if code == UserDefined && sizeKey != 0 {
+		size, ok := k.double(sizeKey)
+		if !ok || !(size > 0.0) {
+			return 0.0, fmt.Errorf("user-defined linear unit has no size")
+		}
+		return size, nil
+	}
+	id, ok := linearUnits[code]
+	if !ok {
+		return 0.0, fmt.Errorf("unsupported linear unit %d", code)
+	}
+	return support.UnitsTable[id].ToMeters, nil
+}
+
+func (k *Keys) projected() (string, error) {
+	code, ok := k.short(ProjectedCSTypeGeoKey)
+	if ok && code != UserDefined {
+		if _, err := proj.FromSRID(int(code)); err == nil {
+			return "EPSG:" + strconv.Itoa(int(code)), nil
+		}
+		_, hasProjection := k.short(ProjectionGeoKey)
+		_, hasTransformation := k.short(ProjCoordTransGeoKey)
+		if !hasProjection && !hasTransformation {
+			return "", fmt.Errorf("projected CRS EPSG:%d is not a preset, and isn't defined by other keys", code)
+		}
+	}
+
+	geodetic, err := k.geodetic()
+	if err != nil {
+		return "", err
+	}
+
+	toMeter, err := k.linearUnit(ProjLinearUnitsGeoKey, ProjLinearUnitSizeGeoKey)
+	if err != nil {
+		return "", err
+	}
+	units := "+units=m"
+	if toMeter != 1.0 {
+		id, ok := support.UnitsFor(toMeter)
+		if !ok {
+			return "", fmt.Errorf("unsupported linear unit of %g m", toMeter)
+		}
+		units = "+units=" + id
+	}
+
+	// UTM zones may be given as a projection code rather than as
+	// parameters
+	if code, ok := k.short(ProjectionGeoKey); ok && code != UserDefined {
+		switch {
+		case code > 16000 && code <= 16060:
+			return fmt.Sprintf("+proj=utm +zone=%d %s %s", code-16000, geodetic, units), nil
+		case code > 16100 && code <= 16160:
+			return fmt.Sprintf("+proj=utm +zone=%d +south %s %s", code-16100, geodetic, units), nil
+		}
+		if _, ok := k.short(ProjCoordTransGeoKey); !ok {
+			return "", fmt.Errorf("unsupported projection %d", code)
+		}
+	}
+
+	params, err := k.transformation(toMeter)
+	if err != nil {
+		return "", err
+	}
+	return params + " " + geodetic + " " + units, nil
+}
+
+// transformation returns the projection and its parameters
+func (k *Keys) transformation(toMeter float64) (string, error) {
+	ct, ok := k.short(ProjCoordTransGeoKey)
+	if !ok {
+		return "", fmt.Errorf("no coordinate transformation is given")
+	}
+
+	// angles are in the geographic angular unit, which defaults to
+	// degrees, and offsets in the projected linear unit
+	toDegrees := 1.0
+	if code, ok := k.short(GeogAngularUnitsGeoKey); ok {
+		id, ok := angularUnits[code]
+		if !ok {
+			return "", fmt.Errorf("unsupported angular unit %d", code)
+		}
+		toDegrees = support.RToDD(support.AngularUnitsTable[id].ToRadians)
+	}
+
+	params := []string{}
+	angle := func(name string, keys ...GeoKey) {
+		if value, ok := k.first(keys...); ok {
+			params = append(params, "+"+name+"="+number(value*toDegrees))
+		}
+	}
+	scale := func(name string, keys ...GeoKey) {
+		if value, ok := k.first(keys...); ok {
+			params = append(params, "+"+name+"="+number(value))
+		}
+	}
+	offsets := func(keys ...GeoKey) {
+		if value, ok := k.first(keys[0], keys[1]); ok {
+			params = append(params, "+x_0="+number(value*toMeter))
+		}
+		if value, ok := k.first(keys[2], keys[3]); ok {
+			params = append(params, "+y_0="+number(value*toMeter))
+		}
+	}
+
+	switch ct {
+	case CTTransverseMercator:
+		params = append(params, "+proj=etmerc")
+		angle("lat_0", ProjNatOriginLatGeoKey)
+		angle("lon_0", ProjNatOriginLongGeoKey)
+		scale("k_0", ProjScaleAtNatOriginGeoKey)
+		offsets(ProjFalseEastingGeoKey, ProjFalseEastingGeoKey, ProjFalseNorthingGeoKey, ProjFalseNorthingGeoKey)
+	case CTObliqueMercator:
+		// Hotine variant A: the false origin is at the natural origin
+		params = append(params, "+proj=omerc", "+no_uoff")
+		angle("lat_0", ProjCenterLatGeoKey, ProjNatOriginLatGeoKey)
+		angle("lonc", ProjCenterLongGeoKey, ProjNatOriginLongGeoKey)
+		angle("alpha", ProjAzimuthAngleGeoKey)
+		angle("gamma", ProjRectifiedGridAngleGeoKey)
+		scale("k_0", ProjScaleAtCenterGeoKey, ProjScaleAtNatOriginGeoKey)
+		offsets(ProjFalseEastingGeoKey, ProjCenterEastingGeoKey, ProjFalseNorthingGeoKey, ProjCenterNorthingGeoKey)
+	case CTMercator:
+		params = append(params, "+proj=merc")
+		angle("lat_ts", ProjStdParallel1GeoKey)
+		angle("lon_0", ProjNatOriginLongGeoKey)
+		scale("k_0", ProjScaleAtNatOriginGeoKey)
+		offsets(ProjFalseEastingGeoKey, ProjFalseEastingGeoKey, ProjFalseNorthingGeoKey, ProjFalseNorthingGeoKey)
+	case CTLambertConfConic2SP:
+		params = append(params, "+proj=lcc")
+		angle("lat_1", ProjStdParallel1GeoKey)
+		angle("lat_2", ProjStdParallel2GeoKey)
+		angle("lat_0", ProjFalseOriginLatGeoKey, ProjNatOriginLatGeoKey)
+		angle("lon_0", ProjFalseOriginLongGeoKey, ProjNatOriginLongGeoKey)
+		offsets(ProjFalseOriginEastingGeoKey, ProjFalseEastingGeoKey, ProjFalseOriginNorthingGeoKey, ProjFalseNorthingGeoKey)
+	case CTLambertConfConic1SP:
+		params = append(params, "+proj=lcc")
+		angle("lat_1", ProjNatOriginLatGeoKey)
+		angle("lat_0", ProjNatOriginLatGeoKey)
+		angle("lon_0", ProjNatOriginLongGeoKey)
+		scale("k_0", ProjScaleAtNatOriginGeoKey)
+		offsets(ProjFalseEastingGeoKey, ProjFalseEastingGeoKey, ProjFalseNorthingGeoKey, ProjFalseNorthingGeoKey)
+	case CTAlbersEqualArea:
+		params = append(params, "+proj=aea")
+		angle("lat_1", ProjStdParallel1GeoKey)
+		angle("lat_2", ProjStdParallel2GeoKey)
+		angle("lat_0", ProjNatOriginLatGeoKey, ProjFalseOriginLatGeoKey)
+		angle("lon_0", ProjNatOriginLongGeoKey, ProjFalseOriginLongGeoKey)
+		offsets(ProjFalseEastingGeoKey, ProjFalseOriginEastingGeoKey, ProjFalseNorthingGeoKey, ProjFalseOriginNorthingGeoKey)
+	case CTAzimuthalEquidistant:
+		params = append(params, "+proj=aeqd")
+		angle("lat_0", ProjCenterLatGeoKey, ProjNatOriginLatGeoKey)
+		angle("lon_0", ProjCenterLongGeoKey, ProjNatOriginLongGeoKey)
+		offsets(ProjFalseEastingGeoKey, ProjFalseEastingGeoKey, ProjFalseNorthingGeoKey, ProjFalseNorthingGeoKey)
+	case CTEquirectangular:
+		params = append(params, "+proj=eqc")
+		angle("lat_ts", ProjStdParallel1GeoKey, ProjCenterLatGeoKey)
+		angle("lon_0", ProjCenterLongGeoKey, ProjNatOriginLongGeoKey)
+		offsets(ProjFalseEastingGeoKey, ProjFalseEastingGeoKey, ProjFalseNorthingGeoKey, ProjFalseNorthingGeoKey)
+	default:
+		return "", fmt.Errorf("unsupported coordinate transformation %d", ct)
+	}
+
+	return strings.Join(params, " "), nil
+}
+
+// first returns the value of the first of the keys which is given
+func (k *Keys) first(keys ...GeoKey) (float64, bool) {
+	for _, key := range keys {
+		if value, ok := k.double(key); ok {
+			return value, true
+		}
+	}
+	return 0.0, false
+}
+
+func number(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
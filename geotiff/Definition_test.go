@@ -0,0 +1,156 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package geotiff_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj/geotiff"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefinition(t *testing.T) {
+	assert := assert.New(t)
+
+	type test struct {
+		keys     geotiff.Keys
+		expected string
+	}
+	shorts := func(values ...uint16) map[geotiff.GeoKey]uint16 {
+		m := map[geotiff.GeoKey]uint16{}
+		for i := 0; i < len(values); i += 2 {
+			m[geotiff.GeoKey(values[i])] = values[i+1]
+		}
+		return m
+	}
+
+	for _, tc := range []test{
+		{geotiff.Keys{Shorts: shorts(1024, 1, 3072, 32633)}, "EPSG:32633"},
+		{geotiff.Keys{Shorts: shorts(3072, 3857)}, "EPSG:3857"},
+		{geotiff.Keys{Shorts: shorts(1024, 2, 2048, 4326)}, "EPSG:4326"},
+		{geotiff.Keys{Shorts: shorts(1024, 2, 2048, 4277, 2054, 9101)}, "+proj=longlat +ellps=airy +units=rad"},
+		{geotiff.Keys{Shorts: shorts(1024, 2, 2048, 32767, 2050, 6230, 2051, 8903)}, "+proj=longlat +ellps=intl +pm=paris"},
+		{geotiff.Keys{Shorts: shorts(1024, 2, 2048, 32767, 2056, 7008)}, "+proj=longlat +ellps=clrk66"},
+		{geotiff.Keys{
+			Shorts:  shorts(1024, 2, 2048, 32767),
+			Doubles: map[geotiff.GeoKey][]float64{2057: {6378137.0}, 2059: {298.257223563}},
+		}, "+proj=longlat +a=6378137 +rf=298.257223563"},
+		{geotiff.Keys{
+			Shorts:  shorts(1024, 2, 2048, 32767),
+			Doubles: map[geotiff.GeoKey][]float64{2057: {6371000.0}},
+		}, "+proj=longlat +R=6371000"},
+
+		// a user-defined CRS with a UTM projection code
+		{geotiff.Keys{Shorts: shorts(1024, 1, 3072, 32767, 2048, 4267, 3074, 16114, 3076, 9001)},
+			"+proj=utm +zone=14 +south +ellps=clrk66 +units=m"},
+
+		// user-defined transformations
+		{geotiff.Keys{
+			Shorts: shorts(1024, 1, 3072, 32767, 2048, 4269, 3074, 32767, 3075, 8, 3076, 9003),
+			Doubles: map[geotiff.GeoKey][]float64{
+				3078: {28.383333333333333}, 3079: {30.283333333333333},
+				3084: {-99.0}, 3085: {27.833333333333333},
+				3086: {2000000.0}, 3087: {0.0},
+			},
+		}, "+proj=lcc +lat_1=28.383333333333333 +lat_2=30.28333333333333 +lat_0=27.833333333333332 +lon_0=-99 " +
+			"+x_0=609601.219202438 +y_0=0 +ellps=GRS80 +towgs84=0,0,0,0,0,0,0 +units=us-ft"},
+		{geotiff.Keys{
+			Shorts: shorts(1024, 1, 3072, 32767, 2048, 4326, 3075, 1),
+			Doubles: map[geotiff.GeoKey][]float64{
+				3080: {9.0}, 3081: {0.0}, 3092: {0.9996}, 3082: {500000.0}, 3083: {0.0},
+			},
+		}, "+proj=etmerc +lat_0=0 +lon_0=9 +k_0=0.9996 +x_0=500000 +y_0=0 +datum=WGS84 +units=m"},
+		{geotiff.Keys{
+			Shorts: shorts(1024, 1, 3072, 32767, 2048, 4326, 3075, 7, 3076, 32767),
+			Doubles: map[geotiff.GeoKey][]float64{
+				3077: {1000.0}, 3078: {10.0}, 3080: {0.0}, 3082: {1.5},
+			},
+		}, "+proj=merc +lat_ts=10 +lon_0=0 +x_0=1500 +datum=WGS84 +units=km"},
+		{geotiff.Keys{
+			Shorts: shorts(1024, 1, 3072, 32767, 2048, 4326, 3075, 3),
+			Doubles: map[geotiff.GeoKey][]float64{
+				3088: {115.0}, 3089: {4.0}, 3094: {53.31580995}, 3096: {53.13010236}, 3093: {0.99984},
+				3090: {0.0}, 3091: {0.0},
+			},
+		}, "+proj=omerc +no_uoff +lat_0=4 +lonc=115 +alpha=53.31580995 +gamma=53.13010236 +k_0=0.99984 +x_0=0 +y_0=0 +datum=WGS84 +units=m"},
+	} {
+		def, err := tc.keys.Definition()
+		assert.NoError(err, tc.expected)
+		assert.Equal(tc.expected, def)
+
+		_, err = tc.keys.Transformer()
+		assert.NoError(err, tc.expected)
+	}
+
+	for _, keys := range []geotiff.Keys{
+		{Shorts: shorts(1024, 3)},
+		{Shorts: shorts(1024, 1, 3072, 2154)},
+		{Shorts: shorts(1024, 1, 3072, 32767, 2048, 4326, 3075, 15)},
+		{Shorts: shorts(1024, 1, 3072, 32767, 2048, 4326)},
+		{Shorts: shorts(1024, 1, 3072, 32767, 2048, 4326, 3074, 12345)},
+		{Shorts: shorts(1024, 1, 3072, 32767, 2048, 4326, 3075, 1, 3076, 9999)},
+		{Shorts: shorts(1024, 2, 2048, 4999)},
+		{Shorts: shorts(1024, 2, 2048, 32767)},
+		{Shorts: shorts(1024, 2, 2048, 32767, 2050, 6326, 2054, 9110)},
+	} {
+		_, err := keys.Definition()
+		assert.Error(err, keys.Shorts)
+	}
+}
+
+func TestTransformer(t *testing.T) {
+	assert := assert.New(t)
+
+	// the EPSG Guidance Note 7-2 example (Lambert Conic Conformal 2SP,
+	// Texas South Central), in US survey feet
+	keys := geotiff.Keys{
+		Shorts: map[geotiff.GeoKey]uint16{
+			geotiff.GTModelTypeGeoKey:     geotiff.ModelTypeProjected,
+			geotiff.ProjectedCSTypeGeoKey: geotiff.UserDefined,
+			geotiff.GeographicTypeGeoKey:  4267,
+			geotiff.ProjCoordTransGeoKey:  geotiff.CTLambertConfConic2SP,
+			geotiff.ProjLinearUnitsGeoKey: 9003,
+		},
+		Doubles: map[geotiff.GeoKey][]float64{
+			geotiff.ProjStdParallel1GeoKey:        {28.38333333333333},
+			geotiff.ProjStdParallel2GeoKey:        {30.28333333333333},
+			geotiff.ProjFalseOriginLatGeoKey:      {27.83333333333333},
+			geotiff.ProjFalseOriginLongGeoKey:     {-99.0},
+			geotiff.ProjFalseOriginEastingGeoKey:  {2000000.0},
+			geotiff.ProjFalseOriginNorthingGeoKey: {0.0},
+		},
+	}
+	tr, err := keys.Transformer()
+	assert.NoError(err)
+
+	xy, err := tr.Forward([]float64{-96.0, 28.5})
+	assert.NoError(err)
+	assert.InDelta(2963503.91, xy[0], 0.01)
+	assert.InDelta(254759.80, xy[1], 0.01)
+
+	// the equatorial azimuthal equidistant on GRS80, as in Snyder's table 30
+	keys = geotiff.Keys{
+		Shorts: map[geotiff.GeoKey]uint16{
+			geotiff.GTModelTypeGeoKey:     geotiff.ModelTypeProjected,
+			geotiff.ProjectedCSTypeGeoKey: geotiff.UserDefined,
+			geotiff.GeographicTypeGeoKey:  4019,
+			geotiff.ProjCoordTransGeoKey:  geotiff.CTAzimuthalEquidistant,
+		},
+		Doubles: map[geotiff.GeoKey][]float64{
+			geotiff.ProjCenterLatGeoKey:  {0.0},
+			geotiff.ProjCenterLongGeoKey: {0.0},
+		},
+	}
+	tr, err = keys.Transformer()
+	assert.NoError(err)
+
+	xy, err = tr.Forward([]float64{45.0, 45.0})
+	assert.NoError(err)
+	assert.InDelta(3860398.3783, xy[0], 1.0e-4)
+	assert.InDelta(5430089.0490, xy[1], 1.0e-4)
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+// Package geotiff interprets the GeoKeys of a GeoTIFF (OGC 19-008r4) as a
+// CRS, so that raster tooling can get a transformer for an image without
+// needing GDAL.
+package geotiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The TIFF tags holding the GeoKeys
+const (
+	GeoKeyDirectoryTag  = 34735
+	GeoDoubleParamsTag  = 34736
+	GeoASCIIParamsTag   = 34737
+	UserDefined         = 32767 // the code of a user-defined (by other keys) item
+	supportedKeyVersion = 1
+)
+
+// GeoKey identifies a key in the GeoKey directory
+type GeoKey uint16
+
+// The GeoKeys which are interpreted
+const (
+	GTModelTypeGeoKey              GeoKey = 1024
+	GTRasterTypeGeoKey             GeoKey = 1025
+	GTCitationGeoKey               GeoKey = 1026
+	GeographicTypeGeoKey           GeoKey = 2048
+	GeogCitationGeoKey             GeoKey = 2049
+	GeogGeodeticDatumGeoKey        GeoKey = 2050
+	GeogPrimeMeridianGeoKey        GeoKey = 2051
+	GeogLinearUnitsGeoKey          GeoKey = 2052
+	GeogAngularUnitsGeoKey         GeoKey = 2054
+	GeogEllipsoidGeoKey            GeoKey = 2056
+	GeogSemiMajorAxisGeoKey        GeoKey = 2057
+	GeogSemiMinorAxisGeoKey        GeoKey = 2058
+	GeogInvFlatteningGeoKey        GeoKey = 2059
+	ProjectedCSTypeGeoKey          GeoKey = 3072
+	PCSCitationGeoKey              GeoKey = 3073
+	ProjectionGeoKey               GeoKey = 3074
+	ProjCoordTransGeoKey           GeoKey = 3075
+	ProjLinearUnitsGeoKey          GeoKey = 3076
+	ProjLinearUnitSizeGeoKey       GeoKey = 3077
+	ProjStdParallel1GeoKey         GeoKey = 3078
+	ProjStdParallel2GeoKey         GeoKey = 3079
+	ProjNatOriginLongGeoKey        GeoKey = 3080
+	ProjNatOriginLatGeoKey         GeoKey = 3081
+	ProjFalseEastingGeoKey         GeoKey = 3082
+	ProjFalseNorthingGeoKey        GeoKey = 3083
+	ProjFalseOriginLongGeoKey      GeoKey = 3084
+	ProjFalseOriginLatGeoKey       GeoKey = 3085
+	ProjFalseOriginEastingGeoKey   GeoKey = 3086
+	ProjFalseOriginNorthingGeoKey  GeoKey = 3087
+	ProjCenterLongGeoKey           GeoKey = 3088
+	ProjCenterLatGeoKey            GeoKey = 3089
+	ProjCenterEastingGeoKey        GeoKey = 3090
+	ProjCenterNorthingGeoKey       GeoKey = 3091
+	ProjScaleAtNatOriginGeoKey     GeoKey = 3092
+	ProjScaleAtCenterGeoKey        GeoKey = 3093
+	ProjAzimuthAngleGeoKey         GeoKey = 3094
+	ProjStraightVertPoleLongGeoKey GeoKey = 3095
+	ProjRectifiedGridAngleGeoKey   GeoKey = 3096
+)
+
+// Keys are the GeoKeys of an image, by type. Short values are stored in
+// the directory itself, doubles and strings in their own tags.
+type Keys struct {
+	Shorts  map[GeoKey]uint16
+	Doubles map[GeoKey][]float64
+	ASCII   map[GeoKey]string
+}
+
+// ParseKeys reads the GeoKeys from the values of the three GeoTIFF tags;
+// doubles and ascii may be empty if the image doesn't have those tags.
+func ParseKeys(directory []uint16, doubles []float64, ascii string) (*Keys, error) {
+	if len(directory) < 4 {
+		return nil, fmt.Errorf("GeoKey directory is too short")
+	}
+	if directory[0] != supportedKeyVersion {
+		return nil, fmt.Errorf("unsupported GeoKey directory version %d", directory[0])
+	}
+	count := int(directory[3])
+	if len(directory) < 4+4*count {
+		return nil, fmt.Errorf("GeoKey directory has %d keys, but room for only %d", count, (len(directory)-4)/4)
+	}
+
+	keys := &Keys{
+		Shorts:  map[GeoKey]uint16{},
+		Doubles: map[GeoKey][]float64{},
+		ASCII:   map[GeoKey]string{},
+	}
+
+	for i := 0; i < count; i++ {
+		entry := directory[4+4*i : 8+4*i]
+		key, location, n, offset := GeoKey(entry[0]), entry[1], int(entry[2]), int(entry[3])
+
+		switch location {
+		case 0:
+			keys.Shorts[key] = entry[3]
+		case GeoKeyDirectoryTag:
+			if n < 1 || offset+n > len(directory) {
+				return nil, fmt.Errorf("GeoKey %d: value is outside the directory", key)
+			}
+			keys.Shorts[key] = directory[offset]
+		case GeoDoubleParamsTag:
+			if offset+n > len(doubles) {
+				return nil, fmt.Errorf("GeoKey %d: value is outside the double params", key)
+			}
+			keys.Doubles[key] = doubles[offset : offset+n]
+		case GeoASCIIParamsTag:
+			if offset+n > len(ascii) {
+				return nil, fmt.Errorf("GeoKey %d: value is outside the ascii params", key)
+			}
+			// strings are terminated by a "|"
+			keys.ASCII[key] = strings.TrimSuffix(ascii[offset:offset+n], "|")
+		default:
+			return nil, fmt.Errorf("GeoKey %d: unknown tag location %d", key, location)
+		}
+	}
+
+	return keys, nil
+}
+
+// short returns a short value, if the key is given
+func (k *Keys) short(key GeoKey) (uint16, bool) {
+	value, ok := k.Shorts[key]
+	return value, ok
+}
+
+// double returns a double value, if the key is given
+func (k *Keys) double(key GeoKey) (float64, bool) {
+	values, ok := k.Doubles[key]
+	if !ok || len(values) == 0 {
+		return 0.0, false
+	}
+	return values[0], true
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package geotiff_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj/geotiff"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	// as written by GDAL for a UTM image
+	directory := []uint16{
+		1, 1, 0, 4,
+		1024, 0, 1, 1,
+		1025, 0, 1, 1,
+		1026, 34737, 22, 0,
+		3072, 0, 1, 32633,
+	}
+	keys, err := geotiff.ParseKeys(directory, nil, "WGS 84 / UTM zone 33N|")
+	assert.NoError(err)
+	assert.Equal(uint16(1), keys.Shorts[geotiff.GTModelTypeGeoKey])
+	assert.Equal(uint16(32633), keys.Shorts[geotiff.ProjectedCSTypeGeoKey])
+	assert.Equal("WGS 84 / UTM zone 33N", keys.ASCII[geotiff.GTCitationGeoKey])
+
+	// doubles
+	directory = []uint16{
+		1, 1, 0, 2,
+		3078, 34736, 1, 1,
+		3079, 34736, 1, 0,
+	}
+	keys, err = geotiff.ParseKeys(directory, []float64{45.0, 33.0}, "")
+	assert.NoError(err)
+	assert.Equal([]float64{33.0}, keys.Doubles[geotiff.ProjStdParallel1GeoKey])
+	assert.Equal([]float64{45.0}, keys.Doubles[geotiff.ProjStdParallel2GeoKey])
+
+	for _, bad := range [][]uint16{
+		{1, 1, 0},
+		{2, 1, 0, 0},
+		{1, 1, 0, 2, 1024, 0, 1, 1},
+		{1, 1, 0, 1, 3078, 34736, 1, 5},
+		{1, 1, 0, 1, 1026, 34737, 10, 0},
+		{1, 1, 0, 1, 1024, 12345, 1, 0},
+	} {
+		_, err = geotiff.ParseKeys(bad, []float64{1.0}, "abc|")
+		assert.Error(err, bad)
+	}
+}
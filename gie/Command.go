@@ -26,9 +26,12 @@ type coord struct {
 
 // represents a single invocation of the operation
 type testcase struct {
-	inv    bool
-	accept coord
-	expect coord
+	inv            bool
+	accept         coord
+	expect         coord
+	failure        bool
+	roundtripCount int
+	roundtripDelta float64
 }
 
 // Command holds a set of testcases
@@ -43,8 +46,10 @@ type Command struct {
 	completeFailure bool
 	File            string
 	Line            int
-	roundtripCount  int
-	roundtripDelta  float64
+
+	// the largest differences from the expected values seen by Execute
+	maxForwardError float64
+	maxInverseError float64
 }
 
 // NewCommand returns a new Command
@@ -131,8 +136,7 @@ func (c *Command) setExpectFailure() {
 	if n == 0 {
 		c.completeFailure = true
 	} else {
-		tc := &c.testcases[n-1]
-		tc.expect = coord{math.MaxFloat64, math.MaxFloat64, math.MaxFloat64, math.MaxFloat64}
+		c.testcases[n-1].failure = true
 	}
 }
 
@@ -162,6 +166,7 @@ func (c *Command) setExpect(s1, s2, s3, s4 string) {
 	tc.expect = coord{v1, v2, v3, v4}
 }
 
+// setRoundtrip applies to the last testcase
 func (c *Command) setRoundtrip(s1, s2, s3 string) {
 	count, err := strconv.Atoi(s1)
 	if err != nil {
@@ -173,8 +178,13 @@ func (c *Command) setRoundtrip(s1, s2, s3 string) {
 	}
 	delta := v / unitsValue(s3)
 
-	c.roundtripCount = count
-	c.roundtripDelta = delta
+	n := len(c.testcases)
+	if n == 0 {
+		panic("roundtrip without accept")
+	}
+	tc := &c.testcases[n-1]
+	tc.roundtripCount = count
+	tc.roundtripDelta = delta
 }
 
 func (c *Command) setTolerance(s1, s2 string) {
@@ -211,6 +221,8 @@ func unitsValue(s string) float64 {
 // then it executes the operation for each of the inputs.
 func (c *Command) Execute() error {
 
+	c.maxForwardError, c.maxInverseError = 0.0, 0.0
+
 	ps, err := support.NewProjString(c.ProjString)
 	if err != nil {
 		if c.completeFailure {
@@ -231,29 +243,22 @@ func (c *Command) Execute() error {
 
 	for _, tc := range c.testcases {
 
-		if !tc.inv {
-
-			if c.roundtripCount == 0 {
-				_, _, err = c.executeForwardOnce(
-					tc.accept.a, tc.accept.b,
-					tc.expect.a, tc.expect.b,
-					op, c.tolerance)
-			} else {
-				err = c.executeRoundtrip(
-					tc.accept.a, tc.accept.b,
-					tc.expect.a, tc.expect.b,
-					op, c.roundtripDelta, c.roundtripCount)
-			}
-		} else {
-			if c.roundtripCount == 0 {
-				_, _, err = c.executeInverseOnce(
-					tc.accept.a, tc.accept.b,
-					tc.expect.a, tc.expect.b,
-					op, c.roundtripDelta)
-			} else {
-				// roundtrips are always done from the Forward funcs
-				panic(9)
-			}
+		switch {
+		case tc.failure:
+			err = c.executeFailure(tc, op)
+		case !tc.inv:
+			_, _, err = c.executeForwardOnce(
+				tc.accept.a, tc.accept.b,
+				tc.expect.a, tc.expect.b,
+				op, c.tolerance)
+		default:
+			_, _, err = c.executeInverseOnce(
+				tc.accept.a, tc.accept.b,
+				tc.expect.a, tc.expect.b,
+				op, c.tolerance)
+		}
+		if err == nil && tc.roundtripCount > 0 {
+			err = c.executeRoundtrip(tc, op)
 		}
 
 		if err != nil {
@@ -264,6 +269,13 @@ func (c *Command) Execute() error {
 	return nil
 }
 
+// MaxErrors returns the largest differences from the expected values seen
+// by the last Execute: forward, in the units of the system, and inverse,
+// in degrees
+func (c *Command) MaxErrors() (forward, inverse float64) {
+	return c.maxForwardError, c.maxInverseError
+}
+
 func (c *Command) executeForwardOnce(
 	in1, in2, out1, out2 float64,
 	op core.IConvertLPToXY,
@@ -276,6 +288,7 @@ func (c *Command) executeForwardOnce(
 	}
 
 	x, y := output.X, output.Y
+	c.maxForwardError = math.Max(c.maxForwardError, math.Max(math.Abs(out1-x), math.Abs(out2-y)))
 	ok1 := check(out1, x, c.tolerance)
 	ok2 := check(out2, y, c.tolerance)
 	if !ok1 || !ok2 {
@@ -297,6 +310,7 @@ func (c *Command) executeInverseOnce(
 	}
 
 	lam, phi := support.RToDD(output.Lam), support.RToDD(output.Phi)
+	c.maxInverseError = math.Max(c.maxInverseError, math.Max(math.Abs(out1-lam), math.Abs(out2-phi)))
 	ok1 := check(out1, lam, c.tolerance)
 	ok2 := check(out2, phi, c.tolerance)
 	if !ok1 || !ok2 {
@@ -306,26 +320,48 @@ func (c *Command) executeInverseOnce(
 	return lam, phi, nil
 }
 
-func (c *Command) executeRoundtrip(
-	in1, in2, out1, out2 float64,
-	op core.IConvertLPToXY,
-	tolerance float64,
-	count int) error {
+// executeFailure expects the operation to fail on the input
+func (c *Command) executeFailure(tc testcase, op core.IConvertLPToXY) error {
+	var err error
+	if tc.inv {
+		_, err = op.Inverse(&core.CoordXY{X: tc.accept.a, Y: tc.accept.b})
+	} else {
+		_, err = op.Forward(&core.CoordLP{Lam: support.DDToR(tc.accept.a), Phi: support.DDToR(tc.accept.b)})
+	}
+	if err == nil {
+		return fmt.Errorf("expected failure")
+	}
+	return nil
+}
 
-	for i := 0; i < count; i++ {
+// executeRoundtrip runs the (forward) input forward and back count times,
+// and fails if the projected point has moved by more than the delta
+func (c *Command) executeRoundtrip(tc testcase, op core.IConvertLPToXY) error {
+	if tc.inv {
+		return fmt.Errorf("roundtrip of an inverse testcase")
+	}
 
-		x, y, err := c.executeForwardOnce(in1, in2, out1, out2, op, tolerance)
+	first, err := op.Forward(&core.CoordLP{Lam: support.DDToR(tc.accept.a), Phi: support.DDToR(tc.accept.b)})
+	if err != nil {
+		return err
+	}
+	xy := &core.CoordXY{X: first.X, Y: first.Y}
+	for i := 0; i < tc.roundtripCount; i++ {
+		lp, err := op.Inverse(xy)
 		if err != nil {
 			return err
 		}
-		lam, phi, err := c.executeInverseOnce(x, y, in1, in1, op, tolerance)
+		xy, err = op.Forward(lp)
 		if err != nil {
 			return err
 		}
-
-		in1, in2 = lam, phi
 	}
 
+	ok1 := check(first.X, xy.X, tc.roundtripDelta)
+	ok2 := check(first.Y, xy.Y, tc.roundtripDelta)
+	if !ok1 || !ok2 {
+		return fmt.Errorf("roundtrip failed")
+	}
 	return nil
 }
 
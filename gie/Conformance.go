@@ -0,0 +1,74 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package gie
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/oahumap/proj/core"
+)
+
+// Conformance is how an operation fared against its test vectors
+type Conformance struct {
+	Operation       string
+	Commands        int      // the commands run, each with its testcases
+	Failed          int      // the commands which failed
+	MaxForwardError float64  // in the units of the system, usually meters
+	MaxInverseError float64  // in degrees
+	Failures        []string // the failed commands' file:line and error
+}
+
+// Tested reports whether there were any test vectors for the operation
+func (c *Conformance) Tested() bool {
+	return c.Commands > 0
+}
+
+// Passed reports whether the operation was tested and passed every test
+func (c *Conformance) Passed() bool {
+	return c.Commands > 0 && c.Failed == 0
+}
+
+// Check executes the supported commands, and reports on every registered
+// operation, in order of name, whether it has test vectors or not
+func (g *Gie) Check() []*Conformance {
+	byOperation := map[string]*Conformance{}
+	for id := range core.OperationDescriptionTable {
+		byOperation[id] = &Conformance{Operation: id}
+	}
+
+	for _, command := range g.Commands {
+		if !g.IsSupported(command) {
+			continue
+		}
+		id := command.ProjectionName()
+		c, ok := byOperation[id]
+		if !ok {
+			c = &Conformance{Operation: id}
+			byOperation[id] = c
+		}
+
+		c.Commands++
+		err := command.Execute()
+		forward, inverse := command.MaxErrors()
+		c.MaxForwardError = math.Max(c.MaxForwardError, forward)
+		c.MaxInverseError = math.Max(c.MaxInverseError, inverse)
+		if err != nil {
+			c.Failed++
+			c.Failures = append(c.Failures, fmt.Sprintf("%s:%d: %v", command.File, command.Line, err))
+		}
+	}
+
+	report := make([]*Conformance, 0, len(byOperation))
+	for _, c := range byOperation {
+		report = append(report, c)
+	}
+	sort.Slice(report, func(i, j int) bool { return report[i].Operation < report[j].Operation })
+	return report
+}
@@ -8,7 +8,9 @@
 package gie
 
 import (
+	"embed"
 	"fmt"
+	"io/fs"
 	"io/ioutil"
 	"strings"
 
@@ -16,21 +18,31 @@ import (
 	_ "github.com/oahumap/proj/operations"
 )
 
+//go:embed gie_data/*.gie
+var embeddedData embed.FS
+
 // These are the projections we know about. If the projection string has a
 // "proj=" key whose valued is not in this list, the Gie will not try to
 // execute the Command.
 var supportedProjections = []string{
-	"etmerc", "utm",
+	"etmerc", "utm", "tmerc",
 	"aea", "leac",
 	"merc",
+	"aeqd",
 	"airy",
 	"august",
 	"eqc",
+	"gnom",
+	"laea",
+	"stere", "sterea", "ups",
+	"labrd",
+	"omerc",
+	"lcc",
+	"cea",
 }
 
 // If the proj string has one of these keys, we won't execute the Command.
 var unsupportedKeys = []string{
-	"axis",
 	"geoidgrids",
 	"to_meter",
 }
@@ -39,6 +51,11 @@ var unsupportedKeys = []string{
 // Command -- this acts as a way to shut off tests we don't like.
 var skippedTests = []string{
 	"ellipsoid.gie:64",
+
+	// aeqd cases whose expectations are misspelled, corrected in local.gie
+	"builtins.gie:130",
+	"builtins.gie:195",
+	"builtins.gie:228",
 }
 
 // Gie is the top-level object for the Gie test runner
@@ -47,6 +64,7 @@ var skippedTests = []string{
 // executing the commands and their testcases.
 type Gie struct {
 	dir      string
+	fsys     fs.FS // where the files are; nil for the disk
 	files    []string
 	Commands []*Command
 }
@@ -74,10 +92,36 @@ func NewGie(dir string) (*Gie, error) {
 	return g, nil
 }
 
+// NewEmbeddedGie returns a new Gie object for the .gie files built into
+// the package, so that the operations can be checked without the source
+// tree at hand
+func NewEmbeddedGie() (*Gie, error) {
+
+	g := &Gie{
+		dir:      "gie_data",
+		fsys:     embeddedData,
+		files:    []string{},
+		Commands: []*Command{},
+	}
+
+	entries, err := fs.ReadDir(embeddedData, g.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		file := entry.Name()
+		if strings.HasSuffix(file, ".gie") {
+			g.files = append(g.files, g.dir+"/"+file)
+		}
+	}
+	return g, nil
+}
+
 // Parse reads the .gie files and creates the commands
 func (g *Gie) Parse() error {
 	for _, file := range g.files {
-		p, err := NewParser(file)
+		p, err := newParser(g.fsys, file)
 		if err != nil {
 			return err
 		}
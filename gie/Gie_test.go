@@ -54,3 +54,31 @@ func TestGie(t *testing.T) {
 	log.Printf("passed: %d", passed)
 	log.Printf("failed: %d", failed)
 }
+
+func TestCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	g, err := gie.NewEmbeddedGie()
+	assert.NoError(err)
+	err = g.Parse()
+	assert.NoError(err)
+
+	report := g.Check()
+	operations := map[string]*gie.Conformance{}
+	for i, c := range report {
+		operations[c.Operation] = c
+		if i > 0 {
+			assert.True(report[i-1].Operation < c.Operation)
+		}
+		assert.Zero(c.Failed, c.Failures)
+	}
+
+	merc := operations["merc"]
+	assert.True(merc.Passed())
+	assert.True(merc.MaxForwardError < 0.0005)
+	assert.True(merc.MaxInverseError > 0.0)
+
+	// registered, but with no test vectors
+	assert.False(operations["wintri"].Tested())
+	assert.False(operations["wintri"].Passed())
+}
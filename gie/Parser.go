@@ -9,6 +9,8 @@ package gie
 
 import (
 	"bufio"
+	"io"
+	"io/fs"
 	"os"
 	"strings"
 )
@@ -28,8 +30,13 @@ type Parser struct {
 // what the official .gie format is supposed to be, so we
 // use a very dumb but effective approach.
 func NewParser(fname string) (*Parser, error) {
+	return newParser(nil, fname)
+}
+
+// newParser is NewParser for a file in fsys, or on disk if fsys is nil
+func newParser(fsys fs.FS, fname string) (*Parser, error) {
 
-	lines, err := readLines(fname)
+	lines, err := readLines(fsys, fname)
 	if err != nil {
 		return nil, err
 	}
@@ -300,8 +307,14 @@ func (p *Parser) isDelimiter(c string) bool {
 	return len(s) == 0
 }
 
-func readLines(fname string) ([]string, error) {
-	file, err := os.Open(fname)
+func readLines(fsys fs.FS, fname string) ([]string, error) {
+	var file io.ReadCloser
+	var err error
+	if fsys == nil {
+		file, err = os.Open(fname)
+	} else {
+		file, err = fsys.Open(fname)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,150 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+// Package greatcircle interpolates between lon/lat points along great
+// circles, for densifying or animating paths before they are projected.
+// Unlike interpolating the coordinates themselves, this takes the short
+// way across the antimeridian and stays sensible near the poles.
+//
+// Points are interpolated by the spherical linear interpolation (slerp) of
+// their surface normals, so that the latitudes may be geodetic: on the
+// sphere the path is the great circle, and on the ellipsoid it is close
+// to, but not exactly, the geodesic. Fractions are of the angle between
+// the normals, which on the ellipsoid is close to the same fraction of
+// the distance; use rhumb, or a geodesic library, where distances must be
+// exact.
+//
+// A Region tests whether great circle paths, such as flight routes, cross
+// a polygon on the sphere, using the gnomonic projection.
+//
+// All angles are in degrees, and longitudes come back in (-180, 180].
+package greatcircle
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/oahumap/proj/support"
+)
+
+// tolerance, in radians, below which two points are taken to be the same
+// or antipodal
+const tol = 1.0e-12
+
+// vector is a unit vector normal to the surface
+type vector [3]float64
+
+// normal returns the surface normal at the lon/lat point
+func normal(lon, lat float64) vector {
+	sinLam, cosLam := math.Sincos(support.DDToR(lon))
+	sinPhi, cosPhi := math.Sincos(support.DDToR(lat))
+	return vector{cosPhi * cosLam, cosPhi * sinLam, sinPhi}
+}
+
+// angle returns the angle between two normals, in radians
+func angle(a, b vector) float64 {
+	cx := a[1]*b[2] - a[2]*b[1]
+	cy := a[2]*b[0] - a[0]*b[2]
+	cz := a[0]*b[1] - a[1]*b[0]
+	dot := a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+	return math.Atan2(math.Sqrt(cx*cx+cy*cy+cz*cz), dot)
+}
+
+// normalize returns the longitude in (-180, 180]
+func normalize(lon float64) float64 {
+	lon = math.Remainder(lon, 360.0)
+	if lon <= -180.0 {
+		lon += 360.0
+	}
+	return lon
+}
+
+// Angle returns the angle, in degrees, subtended by the two points at the
+// center of the earth (or, on the ellipsoid, between their normals)
+func Angle(lon1, lat1, lon2, lat2 float64) float64 {
+	return support.RToDD(angle(normal(lon1, lat1), normal(lon2, lat2)))
+}
+
+// Interpolate returns the point the fraction f of the way along the great
+// circle from point 1 to point 2. Fractions outside [0, 1] extrapolate
+// along the same circle.
+//
+// It fails for antipodal points, between which every great circle is as
+// short as any other. At a pole, the longitude is that of point 1.
+func Interpolate(lon1, lat1, lon2, lat2, f float64) (lon, lat float64, err error) {
+	a := normal(lon1, lat1)
+	b := normal(lon2, lat2)
+	theta := angle(a, b)
+	if theta > math.Pi-tol {
+		return 0.0, 0.0, fmt.Errorf("no single great circle between antipodal points (%g, %g) and (%g, %g)", lon1, lat1, lon2, lat2)
+	}
+
+	// close enough to a straight line, which slerp can't divide by
+	wa, wb := 1.0-f, f
+	if theta > tol {
+		sinTheta := math.Sin(theta)
+		wa = math.Sin((1.0-f)*theta) / sinTheta
+		wb = math.Sin(f*theta) / sinTheta
+	}
+
+	var p vector
+	for i := range p {
+		p[i] = wa*a[i] + wb*b[i]
+	}
+
+	horizontal := support.Hypot(p[0], p[1])
+	lat = support.RToDD(math.Atan2(p[2], horizontal))
+	if horizontal < tol {
+		return normalize(lon1), lat, nil
+	}
+	return support.RToDD(math.Atan2(p[1], p[0])), lat, nil
+}
+
+// Midpoint returns the point halfway along the great circle between
+// point 1 and point 2, as per Interpolate
+func Midpoint(lon1, lat1, lon2, lat2 float64) (lon, lat float64, err error) {
+	return Interpolate(lon1, lat1, lon2, lat2, 0.5)
+}
+
+// Lerp interpolates the coordinates of the two points linearly, as for a
+// straight line on an equirectangular chart (or, nearly, a rhumb line),
+// but taking the shorter way around in longitude
+func Lerp(lon1, lat1, lon2, lat2, f float64) (lon, lat float64) {
+	dlon := math.Remainder(lon2-lon1, 360.0)
+	return normalize(lon1 + f*dlon), lat1 + f*(lat2-lat1)
+}
+
+// Densify returns the line of lon/lat points (pairs in a flat slice) with
+// points added along the great circles between them, so that no two
+// neighbours are more than step degrees apart. The points of the line are
+// kept as given; the added ones are as per Interpolate.
+func Densify(line []float64, step float64) ([]float64, error) {
+	if len(line)%2 != 0 {
+		return nil, fmt.Errorf("line has an odd number of coordinates: %d", len(line))
+	}
+	if !(step > 0.0) {
+		return nil, fmt.Errorf("invalid step: %g", step)
+	}
+
+	out := make([]float64, 0, len(line))
+	for i := 0; i+1 < len(line); i += 2 {
+		if i > 0 {
+			lon1, lat1, lon2, lat2 := line[i-2], line[i-1], line[i], line[i+1]
+			// not counting rounding as a step of its own
+			n := math.Ceil(Angle(lon1, lat1, lon2, lat2)/step - 1.0e-9)
+			for k := 1.0; k < n; k++ {
+				lon, lat, err := Interpolate(lon1, lat1, lon2, lat2, k/n)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, lon, lat)
+			}
+		}
+		out = append(out, line[i], line[i+1])
+	}
+	return out, nil
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package greatcircle_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj/greatcircle"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpolate(t *testing.T) {
+	assert := assert.New(t)
+
+	// across the antimeridian, not the long way round
+	lon, lat, err := greatcircle.Midpoint(179.0, 0.0, -179.0, 0.0)
+	assert.NoError(err)
+	assert.InDelta(180.0, lon, 1.0e-9)
+	assert.InDelta(0.0, lat, 1.0e-9)
+
+	lon, lat, err = greatcircle.Interpolate(170.0, 0.0, -170.0, 0.0, 0.75)
+	assert.NoError(err)
+	assert.InDelta(-175.0, lon, 1.0e-9)
+	assert.InDelta(0.0, lat, 1.0e-9)
+
+	// over the pole, along the meridians
+	lon, lat, err = greatcircle.Interpolate(0.0, 80.0, 180.0, 80.0, 0.25)
+	assert.NoError(err)
+	assert.InDelta(0.0, lon, 1.0e-9)
+	assert.InDelta(85.0, lat, 1.0e-9)
+	lon, lat, err = greatcircle.Midpoint(10.0, 80.0, -170.0, 80.0)
+	assert.NoError(err)
+	assert.InDelta(10.0, lon, 1.0e-9)
+	assert.InDelta(90.0, lat, 1.0e-9)
+
+	// JFK to LHR: the great circle goes north of both, as the usual
+	// spherical midpoint formula has it
+	lon, lat, err = greatcircle.Midpoint(-73.8, 40.6, -0.5, 51.6)
+	assert.NoError(err)
+	assert.InDelta(-41.40758949, lon, 1.0e-8)
+	assert.InDelta(52.25281843, lat, 1.0e-8)
+
+	// the ends, and the same point
+	lon, lat, err = greatcircle.Interpolate(-73.8, 40.6, -0.5, 51.6, 1.0)
+	assert.NoError(err)
+	assert.InDelta(-0.5, lon, 1.0e-9)
+	assert.InDelta(51.6, lat, 1.0e-9)
+	lon, lat, err = greatcircle.Midpoint(12.5, -33.0, 12.5, -33.0)
+	assert.NoError(err)
+	assert.InDelta(12.5, lon, 1.0e-9)
+	assert.InDelta(-33.0, lat, 1.0e-9)
+
+	_, _, err = greatcircle.Midpoint(0.0, 10.0, 180.0, -10.0)
+	assert.Error(err)
+}
+
+func TestLerp(t *testing.T) {
+	assert := assert.New(t)
+
+	lon, lat := greatcircle.Lerp(179.0, 10.0, -179.0, 20.0, 0.5)
+	assert.InDelta(180.0, lon, 1.0e-9)
+	assert.InDelta(15.0, lat, 1.0e-9)
+
+	lon, lat = greatcircle.Lerp(-170.0, 0.0, 170.0, 0.0, 0.25)
+	assert.InDelta(-175.0, lon, 1.0e-9)
+	assert.InDelta(0.0, lat, 1.0e-9)
+}
+
+func TestDensify(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.InDelta(90.0, greatcircle.Angle(0.0, 0.0, 90.0, 0.0), 1.0e-9)
+
+	line, err := greatcircle.Densify([]float64{175.0, 0.0, -175.0, 0.0, -175.0, 1.0}, 2.5)
+	assert.NoError(err)
+	expected := []float64{175.0, 0.0, 177.5, 0.0, 180.0, 0.0, -177.5, 0.0, -175.0, 0.0, -175.0, 1.0}
+	assert.Len(line, len(expected))
+	for i := range expected {
+		assert.InDelta(expected[i], line[i], 1.0e-9, i)
+	}
+
+	_, err = greatcircle.Densify([]float64{0.0, 0.0, 1.0}, 1.0)
+	assert.Error(err)
+	_, err = greatcircle.Densify([]float64{0.0, 0.0, 1.0, 1.0}, 0.0)
+	assert.Error(err)
+	_, err = greatcircle.Densify([]float64{0.0, 0.0, 180.0, 0.0}, 1.0)
+	assert.Error(err)
+}
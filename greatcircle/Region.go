@@ -0,0 +1,267 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package greatcircle
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/oahumap/proj"
+	"github.com/oahumap/proj/support"
+)
+
+// MaxRegionRadius is the largest angle, in degrees, between the center of
+// a Region and any of its vertices. The gnomonic projection grows without
+// bound towards 90 degrees, where straight lines lose their precision.
+const MaxRegionRadius = 80.0
+
+// Region is a polygon on the sphere whose edges are great circles, such
+// as an airspace or a restricted area, for testing whether great circle
+// paths cross it.
+//
+// The tests are done on a gnomonic projection centered on the region: it
+// maps every great circle to a straight line, so a path crosses the
+// region exactly when its projected segment crosses the projected
+// polygon. The projection is polar or oblique as the center requires.
+type Region struct {
+	center vector
+	radius float64 // radians, from the center to the farthest vertex
+	tr     *proj.Transformer
+	ring   []float64 // projected vertices, not closed
+}
+
+// NewRegion returns the region bounded by the ring of lon/lat points
+// (pairs in a flat slice), which may or may not repeat its first point at
+// the end. All of its vertices must lie within MaxRegionRadius of their
+// mean direction.
+func NewRegion(ring []float64) (*Region, error) {
+	if len(ring)%2 != 0 {
+		return nil, fmt.Errorf("ring has an odd number of coordinates: %d", len(ring))
+	}
+	n := len(ring) / 2
+	if n > 1 && ring[0] == ring[2*n-2] && ring[1] == ring[2*n-1] {
+		n--
+	}
+	if n < 3 {
+		return nil, fmt.Errorf("ring has fewer than 3 points")
+	}
+
+	var sum vector
+	for i := 0; i < n; i++ {
+		v := normal(ring[2*i], ring[2*i+1])
+		for k := range sum {
+			sum[k] += v[k]
+		}
+	}
+	length := math.Sqrt(sum[0]*sum[0] + sum[1]*sum[1] + sum[2]*sum[2])
+	if length < tol {
+		return nil, fmt.Errorf("ring has no center")
+	}
+	r := &Region{}
+	for k := range sum {
+		r.center[k] = sum[k] / length
+	}
+	for i := 0; i < n; i++ {
+		r.radius = math.Max(r.radius, angle(r.center, normal(ring[2*i], ring[2*i+1])))
+	}
+	if r.radius > support.DDToR(MaxRegionRadius) {
+		return nil, fmt.Errorf("ring is too large: %g degrees from its center", support.RToDD(r.radius))
+	}
+
+	lon, lat := lonLat(r.center)
+	var err error
+	// in degrees, whatever the default Config says
+	r.tr, err = proj.NewTransformerWithConfig(fmt.Sprintf("+proj=gnom +R=1 +lat_0=%.17g +lon_0=%.17g", lat, lon),
+		proj.Config{AngularUnit: "deg"})
+	if err != nil {
+		return nil, err
+	}
+	r.ring, err = r.tr.Forward(ring[:2*n])
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Contains reports whether the point is inside the region, or on its
+// boundary
+func (r *Region) Contains(lon, lat float64) (bool, error) {
+	if angle(r.center, normal(lon, lat)) > r.radius {
+		return false, nil
+	}
+	xy, err := r.tr.Forward([]float64{lon, lat})
+	if err != nil {
+		return false, err
+	}
+	return r.containsXY(xy[0], xy[1]), nil
+}
+
+// Crosses reports whether the great circle between point 1 and point 2
+// passes through the region, touching its boundary included. It fails
+// for antipodal points, as per Interpolate.
+func (r *Region) Crosses(lon1, lat1, lon2, lat2 float64) (bool, error) {
+	a := normal(lon1, lat1)
+	b := normal(lon2, lat2)
+	theta := angle(a, b)
+	if theta > math.Pi-tol {
+		return false, fmt.Errorf("no single great circle between antipodal points (%g, %g) and (%g, %g)", lon1, lat1, lon2, lat2)
+	}
+	if theta < tol {
+		return r.Contains(lon1, lat1)
+	}
+
+	// the part of the path within the region's radius of its center,
+	// which the projection covers: along the path, at the angle t from
+	// point 1, the cosine of the angle to the center is
+	// ca cos(t) + cu sin(t) = m cos(t - phase)
+	var u vector
+	dot := a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+	for k := range u {
+		u[k] = b[k] - dot*a[k]
+	}
+	length := math.Sqrt(u[0]*u[0] + u[1]*u[1] + u[2]*u[2])
+	for k := range u {
+		u[k] /= length
+	}
+	ca := a[0]*r.center[0] + a[1]*r.center[1] + a[2]*r.center[2]
+	cu := u[0]*r.center[0] + u[1]*r.center[1] + u[2]*r.center[2]
+	m := support.Hypot(ca, cu)
+	limit := math.Cos(r.radius)
+	if m < limit {
+		return false, nil
+	}
+	phase := math.Atan2(cu, ca)
+	half := math.Acos(math.Min(1.0, limit/m))
+
+	// the path and the clipped circle are both shorter than half the
+	// circle, so they overlap once at most
+	for _, turn := range []float64{-2.0 * math.Pi, 0.0, 2.0 * math.Pi} {
+		t0 := math.Max(0.0, phase-half+turn)
+		t1 := math.Min(theta, phase+half+turn)
+		if t0 > t1 {
+			continue
+		}
+		lon0, lat0 := lonLat(along(a, u, t0))
+		lon1, lat1 := lonLat(along(a, u, t1))
+		xy, err := r.tr.Forward([]float64{lon0, lat0, lon1, lat1})
+		if err != nil {
+			return false, err
+		}
+		return r.crossesXY(xy[0], xy[1], xy[2], xy[3]), nil
+	}
+	return false, nil
+}
+
+// CrossesPath reports whether any leg of the path of lon/lat points (pairs
+// in a flat slice) crosses the region, and if so the index of the first
+// point of the first such leg. A path of a single point crosses the region
+// if it is inside it.
+func (r *Region) CrossesPath(path []float64) (bool, int, error) {
+	if len(path)%2 != 0 {
+		return false, 0, fmt.Errorf("path has an odd number of coordinates: %d", len(path))
+	}
+	if len(path) == 2 {
+		inside, err := r.Contains(path[0], path[1])
+		return inside, 0, err
+	}
+	for i := 0; i+3 < len(path); i += 2 {
+		crosses, err := r.Crosses(path[i], path[i+1], path[i+2], path[i+3])
+		if err != nil {
+			return false, 0, err
+		}
+		if crosses {
+			return true, i / 2, nil
+		}
+	}
+	return false, 0, nil
+}
+
+//---------------------------------------------------------------------
+
+// lonLat returns the lon/lat point of the normal
+func lonLat(v vector) (lon, lat float64) {
+	horizontal := support.Hypot(v[0], v[1])
+	lat = support.RToDD(math.Atan2(v[2], horizontal))
+	if horizontal < tol {
+		return 0.0, lat
+	}
+	return support.RToDD(math.Atan2(v[1], v[0])), lat
+}
+
+// along returns the normal at the angle t along the great circle from a,
+// in the direction of the unit vector u at right angles to it
+func along(a, u vector, t float64) vector {
+	sin, cos := math.Sincos(t)
+	var p vector
+	for k := range p {
+		p[k] = cos*a[k] + sin*u[k]
+	}
+	return p
+}
+
+// containsXY is the even-odd test for the projected point, counting the
+// boundary as inside
+func (r *Region) containsXY(x, y float64) bool {
+	n := len(r.ring) / 2
+	inside := false
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		xi, yi := r.ring[2*i], r.ring[2*i+1]
+		xj, yj := r.ring[2*j], r.ring[2*j+1]
+		if onSegment(x, y, xi, yi, xj, yj) {
+			return true
+		}
+		if (yi > y) != (yj > y) && x < xi+(y-yi)*(xj-xi)/(yj-yi) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// crossesXY reports whether the projected segment crosses the projected
+// polygon: either it has an end inside, or it crosses an edge
+func (r *Region) crossesXY(x0, y0, x1, y1 float64) bool {
+	if r.containsXY(x0, y0) || r.containsXY(x1, y1) {
+		return true
+	}
+	n := len(r.ring) / 2
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		if segmentsIntersect(x0, y0, x1, y1, r.ring[2*j], r.ring[2*j+1], r.ring[2*i], r.ring[2*i+1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// cross returns the z component of (b - a) x (c - a)
+func cross(ax, ay, bx, by, cx, cy float64) float64 {
+	return (bx-ax)*(cy-ay) - (by-ay)*(cx-ax)
+}
+
+// onSegment reports whether p lies on the segment from a to b
+func onSegment(px, py, ax, ay, bx, by float64) bool {
+	scale := math.Max(math.Abs(bx-ax), math.Abs(by-ay))
+	if math.Abs(cross(ax, ay, bx, by, px, py)) > tol*scale*scale {
+		return false
+	}
+	return math.Min(ax, bx)-tol <= px && px <= math.Max(ax, bx)+tol &&
+		math.Min(ay, by)-tol <= py && py <= math.Max(ay, by)+tol
+}
+
+// segmentsIntersect reports whether the segments p0-p1 and q0-q1 meet
+func segmentsIntersect(p0x, p0y, p1x, p1y, q0x, q0y, q1x, q1y float64) bool {
+	d1 := cross(q0x, q0y, q1x, q1y, p0x, p0y)
+	d2 := cross(q0x, q0y, q1x, q1y, p1x, p1y)
+	d3 := cross(p0x, p0y, p1x, p1y, q0x, q0y)
+	d4 := cross(p0x, p0y, p1x, p1y, q1x, q1y)
+	if ((d1 > 0) != (d2 > 0) && d1 != 0 && d2 != 0) &&
+		((d3 > 0) != (d4 > 0) && d3 != 0 && d4 != 0) {
+		return true
+	}
+	return onSegment(p0x, p0y, q0x, q0y, q1x, q1y) || onSegment(p1x, p1y, q0x, q0y, q1x, q1y) ||
+		onSegment(q0x, q0y, p0x, p0y, p1x, p1y) || onSegment(q1x, q1y, p0x, p0y, p1x, p1y)
+}
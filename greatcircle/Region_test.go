@@ -0,0 +1,104 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package greatcircle_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/oahumap/proj/greatcircle"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegion(t *testing.T) {
+	assert := assert.New(t)
+
+	// JFK to LHR passes north of both, through the box around its
+	// midpoint, and not through the box to the south of it, where the
+	// straight line on a lon/lat chart goes
+	north, err := greatcircle.NewRegion([]float64{-42.5, 51.5, -40.5, 51.5, -40.5, 53.0, -42.5, 53.0, -42.5, 51.5})
+	assert.NoError(err)
+	south, err := greatcircle.NewRegion([]float64{-43.0, 45.0, -39.0, 45.0, -39.0, 48.0, -43.0, 48.0})
+	assert.NoError(err)
+
+	crosses, err := north.Crosses(-73.8, 40.6, -0.5, 51.6)
+	assert.NoError(err)
+	assert.True(crosses)
+	crosses, err = south.Crosses(-73.8, 40.6, -0.5, 51.6)
+	assert.NoError(err)
+	assert.False(crosses)
+
+	inside, err := north.Contains(-41.40758949, 52.25281843)
+	assert.NoError(err)
+	assert.True(inside)
+	inside, err = south.Contains(-41.40758949, 52.25281843)
+	assert.NoError(err)
+	assert.False(inside)
+
+	// a polar cap bounded at 80N: its edges bulge to nearly 83N, over
+	// paths that stay south of 76N, but a path over the pole crosses it,
+	// though its ends are well outside
+	polar, err := greatcircle.NewRegion([]float64{0.0, 80.0, 90.0, 80.0, 180.0, 80.0, -90.0, 80.0})
+	assert.NoError(err)
+	crosses, err = polar.Crosses(0.0, 70.0, 90.0, 70.0)
+	assert.NoError(err)
+	assert.False(crosses)
+	crosses, err = polar.Crosses(-100.0, 30.0, 80.0, 30.0)
+	assert.NoError(err)
+	assert.True(crosses)
+	crosses, err = polar.Crosses(100.0, 0.0, 170.0, 0.0)
+	assert.NoError(err)
+	assert.False(crosses)
+
+	// touching a vertex counts
+	crosses, err = polar.Crosses(0.0, 80.0, 0.0, 60.0)
+	assert.NoError(err)
+	assert.True(crosses)
+
+	_, err = polar.Crosses(0.0, 10.0, 180.0, -10.0)
+	assert.Error(err)
+
+	// the first leg through the region
+	crosses, leg, err := north.CrossesPath([]float64{-80.0, 40.0, -73.8, 40.6, -0.5, 51.6, 2.5, 49.0})
+	assert.NoError(err)
+	assert.True(crosses)
+	assert.Equal(1, leg)
+	crosses, _, err = south.CrossesPath([]float64{-80.0, 40.0, -73.8, 40.6, -0.5, 51.6})
+	assert.NoError(err)
+	assert.False(crosses)
+	crosses, _, err = north.CrossesPath([]float64{-41.5, 52.0})
+	assert.NoError(err)
+	assert.True(crosses)
+
+	for _, ring := range [][]float64{
+		{0.0, 0.0, 1.0, 0.0, 1.0},
+		{0.0, 0.0, 1.0, 0.0, 0.0, 0.0},
+		{0.0, 0.0, 120.0, 0.0, -120.0, 0.0},
+		{0.0, -5.0, 90.0, -5.0, 180.0, -5.0, -90.0, -5.0},
+	} {
+		_, err = greatcircle.NewRegion(ring)
+		assert.Error(err, ring)
+	}
+}
+
+func TestRegionDefaultConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	// the region works in degrees when the default is radians
+	defer func() { assert.NoError(proj.SetDefaultConfig(proj.Config{})) }()
+	assert.NoError(proj.SetDefaultConfig(proj.Config{AngularUnit: "rad"}))
+
+	north, err := greatcircle.NewRegion([]float64{-42.5, 51.5, -40.5, 51.5, -40.5, 53.0, -42.5, 53.0})
+	assert.NoError(err)
+	crosses, err := north.Crosses(-73.8, 40.6, -0.5, 51.6)
+	assert.NoError(err)
+	assert.True(crosses)
+	inside, err := north.Contains(-41.40758949, 52.25281843)
+	assert.NoError(err)
+	assert.True(inside)
+}
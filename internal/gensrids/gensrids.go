@@ -0,0 +1,66 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+// Command gensrids precompiles the definitions in support.SRIDsTable into
+// the blob embedded by support, so that the presets need no parsing at
+// run time. It is run by "go generate" in the support directory.
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/oahumap/proj/support"
+)
+
+// sridPreset must match the type of the same name in support
+type sridPreset struct {
+	SRID  int
+	Pairs []support.Pair
+}
+
+func main() {
+	out := flag.String("o", "SRIDsPresets.gob", "output file")
+	flag.Parse()
+
+	err := run(*out)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gensrids: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(out string) error {
+
+	// sorted, so that the output only changes when the table does
+	srids := []int{}
+	for srid := range support.SRIDsTable {
+		srids = append(srids, srid)
+	}
+	sort.Ints(srids)
+
+	presets := make([]sridPreset, len(srids))
+	for i, srid := range srids {
+		ps, err := support.NewProjString(support.SRIDsTable[srid].Definition)
+		if err != nil {
+			return fmt.Errorf("srid %d: %v", srid, err)
+		}
+		presets[i] = sridPreset{SRID: srid, Pairs: ps.Pairs}
+	}
+
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(presets)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(out, buf.Bytes(), 0644)
+}
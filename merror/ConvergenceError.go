@@ -0,0 +1,42 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package merror
+
+import (
+	"fmt"
+
+	"github.com/oahumap/proj/mlog"
+)
+
+// ConvergenceError is returned by an iterative inverse which failed to
+// converge, recording how far it got.
+//
+// Use errors.As to get at the fields.
+type ConvergenceError struct {
+	Operation  string  // e.g. "wintri"
+	Iterations int     // number of iterations performed
+	Residual   float64 // size of the final correction, in radians
+}
+
+// NewConvergenceError creates a new ConvergenceError object
+func NewConvergenceError(operation string, iterations int, residual float64) error {
+	err := ConvergenceError{
+		Operation:  operation,
+		Iterations: iterations,
+		Residual:   residual,
+	}
+
+	mlog.Error(err)
+
+	return err
+}
+
+func (e ConvergenceError) Error() string {
+	return fmt.Sprintf("%s: %s after %d iterations (residual %g)",
+		e.Operation, NonConvergence, e.Iterations, e.Residual)
+}
@@ -0,0 +1,28 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package merror_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/oahumap/proj/merror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvergenceError(t *testing.T) {
+	assert := assert.New(t)
+
+	err := merror.NewConvergenceError("lcc", 15, 1.5e-9)
+	assert.Equal("lcc: inverse did not converge after 15 iterations (residual 1.5e-09)", err.Error())
+
+	var cerr merror.ConvergenceError
+	assert.True(errors.As(err, &cerr))
+	assert.Equal(15, cerr.Iterations)
+	assert.Equal(1.5e-9, cerr.Residual)
+}
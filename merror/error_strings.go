@@ -40,4 +40,10 @@ var (
 	AeaProjString                   = "invalid projection string for aea"
 	LatTSLargerThan90               = "lat ts is greater than 90"
 	Phi2                            = "invalid phi2 computation"
+	NonConvergence                  = "inverse did not converge"
+	Lat0OrAlphaEq90                 = "lat_0 or alpha is 90"
+	InconsistentEllipsoid           = "inconsistent ellipsoid parameters: %s"
+	Lat0IsZero                      = "lat_0 is zero"
+	InvalidWKT                      = "invalid WKT: %s"
+	UnsupportedWKT                  = "unsupported WKT: %s"
 )
@@ -0,0 +1,122 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package operations_test
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/support"
+)
+
+// fuzzOperations returns the ids of the operations, in a fixed order
+func fuzzOperations() []string {
+	ids := []string{}
+	for id, desc := range core.OperationDescriptionTable {
+		if desc.IsConvertLPToXY() {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// fuzzDefinition builds a proj string for one of the operations: the
+// bits of mask pick which of its parameters, and of the general ones,
+// are given, and the values are taken from values in turn
+func fuzzDefinition(ids []string, op uint8, mask uint32, values []float64) string {
+	id := ids[int(op)%len(ids)]
+	def := "+proj=" + id
+
+	params := append([]core.Parameter{}, core.OperationDescriptionTable[id].Parameters...)
+	for _, p := range core.GeneralParameters {
+		switch p.Name {
+		case "a", "b", "rf", "es", "R", "lon_0", "lat_0", "k_0", "over", "geoc":
+			params = append(params, p)
+		}
+	}
+
+	next := 0
+	for i, p := range params {
+		if i >= 32 || mask&(1<<uint(i)) == 0 {
+			continue
+		}
+		switch p.Type {
+		case core.ParameterFlag:
+			def += " +" + p.Name
+		case core.ParameterFloat, core.ParameterInt, core.ParameterAngle:
+			v := values[next%len(values)]
+			next++
+			if p.Type == core.ParameterInt {
+				v = math.Trunc(v)
+			}
+			def += " +" + p.Name + "=" + strconv.FormatFloat(v, 'g', -1, 64)
+		}
+	}
+	if mask&(1<<31) != 0 {
+		def += " +ellps=WGS84"
+	}
+	return def
+}
+
+// FuzzOperations builds operations from random parameters and converts
+// random points both ways: whatever the values, this must fail with an
+// error rather than panic
+func FuzzOperations(f *testing.F) {
+	ids := fuzzOperations()
+
+	f.Add(uint8(0), uint32(0), 0.0, 0.0, 0.0, 0.0, 0.0, 0.0)
+	f.Add(uint8(1), uint32(0xffffffff), 90.0, -90.0, 0.0, 1e308, 180.0, 90.0)
+	f.Add(uint8(2), uint32(0x80000003), 45.0, 45.0, 45.0, 45.0, -180.0, -90.0)
+	f.Add(uint8(3), uint32(0x5555), 0.0, -0.0, math.Inf(1), math.NaN(), 1e-300, 89.999999)
+	for i := range ids {
+		f.Add(uint8(i), uint32(0x8000000f), 30.0, 60.0, 0.0, 1.0, 10.0, 50.0)
+	}
+
+	f.Fuzz(func(t *testing.T, op uint8, mask uint32, a, b, c, d, lon, lat float64) {
+		def := fuzzDefinition(ids, op, mask, []float64{a, b, c, d})
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("%s at (%g, %g): panic: %v", def, lon, lat, r)
+			}
+		}()
+
+		ps, err := support.NewProjString(def)
+		if err != nil {
+			return
+		}
+		_, opx, err := core.NewSystem(ps)
+		if err != nil {
+			return
+		}
+		conv := opx.(core.IConvertLPToXY)
+
+		lp := &core.CoordLP{Lam: support.DDToR(lon), Phi: support.DDToR(lat)}
+		xy, err := conv.Forward(lp)
+		if err == nil && xy != nil {
+			_, _ = conv.Inverse(xy)
+		}
+		_, _ = conv.Inverse(&core.CoordXY{X: lon * 1e5, Y: lat * 1e5})
+	})
+}
+
+func TestFuzzDefinition(t *testing.T) {
+	ids := fuzzOperations()
+	def := fuzzDefinition(ids, 0, 0, []float64{1.0})
+	if def != "+proj="+ids[0] {
+		t.Error(def)
+	}
+	def = fuzzDefinition(ids, uint8(len(ids)), 1<<31, []float64{1.0})
+	if def != fmt.Sprintf("+proj=%s +ellps=WGS84", ids[0]) {
+		t.Error(def)
+	}
+}
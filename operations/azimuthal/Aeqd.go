@@ -0,0 +1,286 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package azimuthal
+
+import (
+	"math"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/merror"
+	"github.com/oahumap/proj/support"
+)
+
+func init() {
+	core.RegisterConvertLPToXY("aeqd",
+		"Azimuthal Equidistant",
+		"\n\tAzi, Sph&Ell\n\tlat_0 guam",
+		NewAeqd,
+	)
+	core.RegisterParameters("aeqd",
+		core.Parameter{Name: "guam", Type: core.ParameterFlag, Description: "use the Guam elliptical approximation"},
+	)
+}
+
+const aeqdTol = 1.e-14
+
+// Aeqd implements core.IOperation and core.ConvertLPToXY
+//
+// Distances and azimuths from the center are true. On the ellipsoid, the
+// polar aspects use the meridian distance and the others the geodesic
+// from the center; +guam selects the approximation used for the Guam
+// grid, which is only good near the center.
+type Aeqd struct {
+	core.Operation
+	AzimuthalBase
+	en   []float64
+	M1   float64 // meridian distance to the latitude of origin, for guam
+	Mp   float64 // meridian distance to the pole, for the polar aspects
+	guam bool
+}
+
+// NewAeqd returns a new Aeqd
+func NewAeqd(system *core.System, desc *core.OperationDescription) (core.IConvertLPToXY, error) {
+	op := &Aeqd{}
+	op.System = system
+
+	err := op.setup(system)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// Forward goes forewards
+func (op *Aeqd) Forward(lp *core.CoordLP) (*core.CoordXY, error) {
+	PE := op.System.Ellipsoid
+	if PE.Es == 0.0 {
+		return op.sForward(lp)
+	}
+	if op.guam {
+		return op.guamForward(lp)
+	}
+	return op.eForward(lp)
+}
+
+// Inverse goes backwards
+func (op *Aeqd) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
+	PE := op.System.Ellipsoid
+	if PE.Es == 0.0 {
+		return op.sInverse(xy)
+	}
+	if op.guam {
+		return op.guamInverse(xy)
+	}
+	return op.eInverse(xy)
+}
+
+//---------------------------------------------------------------------
+
+// Guam elliptical, forward
+func (op *Aeqd) guamForward(lp *core.CoordLP) (*core.CoordXY, error) {
+	xy := &core.CoordXY{X: 0.0, Y: 0.0}
+	PE := op.System.Ellipsoid
+
+	cosphi := math.Cos(lp.Phi)
+	sinphi := math.Sin(lp.Phi)
+	t := 1. / math.Sqrt(1.-PE.Es*sinphi*sinphi)
+	xy.X = lp.Lam * cosphi * t
+	xy.Y = support.Mlfn(lp.Phi, sinphi, cosphi, op.en) - op.M1 +
+		.5*lp.Lam*lp.Lam*cosphi*sinphi*t
+
+	return xy, nil
+}
+
+// Guam elliptical, inverse
+func (op *Aeqd) guamInverse(xy *core.CoordXY) (*core.CoordLP, error) {
+	lp := &core.CoordLP{Lam: 0.0, Phi: 0.0}
+	PE := op.System.Ellipsoid
+
+	var err error
+	t := 0.0
+	x2 := 0.5 * xy.X * xy.X
+	lp.Phi = op.System.Phi0
+	for i := 0; i < 3; i++ {
+		t = PE.E * math.Sin(lp.Phi)
+		t = math.Sqrt(1. - t*t)
+		lp.Phi, err = support.InvMlfn(op.M1+xy.Y-x2*math.Tan(lp.Phi)*t, PE.Es, op.en)
+		if err != nil {
+			return nil, err
+		}
+	}
+	lp.Lam = xy.X * t / math.Cos(lp.Phi)
+	return lp, nil
+}
+
+// Ellipsoidal, forward
+func (op *Aeqd) eForward(lp *core.CoordLP) (*core.CoordXY, error) {
+	xy := &core.CoordXY{X: 0.0, Y: 0.0}
+	PE := op.System.Ellipsoid
+
+	coslam := math.Cos(lp.Lam)
+	switch op.mode {
+	case modeNPole:
+		coslam = -coslam
+		fallthrough
+	case modeSPole:
+		rho := math.Abs(op.Mp - support.Mlfn(lp.Phi, math.Sin(lp.Phi), math.Cos(lp.Phi), op.en))
+		xy.X = rho * math.Sin(lp.Lam)
+		xy.Y = rho * coslam
+	case modeEquit, modeObliq:
+		if math.Abs(lp.Lam) < eps10 && math.Abs(lp.Phi-op.System.Phi0) < eps10 {
+			return xy, nil
+		}
+		s12, azi1, err := support.GeodInverse(1.0, PE.F, op.System.Phi0, 0.0, lp.Phi, lp.Lam)
+		if err != nil {
+			return xy, err
+		}
+		xy.X = s12 * math.Sin(azi1)
+		xy.Y = s12 * math.Cos(azi1)
+	}
+	return xy, nil
+}
+
+// Ellipsoidal, inverse
+func (op *Aeqd) eInverse(xy *core.CoordXY) (*core.CoordLP, error) {
+	lp := &core.CoordLP{Lam: 0.0, Phi: 0.0}
+	PE := op.System.Ellipsoid
+
+	c := support.Hypot(xy.X, xy.Y)
+	if c < eps10 {
+		lp.Phi = op.System.Phi0
+		return lp, nil
+	}
+
+	if !op.isPolar() {
+		azi1 := math.Atan2(xy.X, xy.Y)
+		lp.Phi, lp.Lam = support.GeodDirect(1.0, PE.F, op.System.Phi0, 0.0, azi1, c)
+		return lp, nil
+	}
+
+	var err error
+	if op.mode == modeNPole {
+		lp.Phi, err = support.InvMlfn(op.Mp-c, PE.Es, op.en)
+		lp.Lam = math.Atan2(xy.X, -xy.Y)
+	} else {
+		lp.Phi, err = support.InvMlfn(op.Mp+c, PE.Es, op.en)
+		lp.Lam = math.Atan2(xy.X, xy.Y)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return lp, nil
+}
+
+// Spheroidal, forward
+func (op *Aeqd) sForward(lp *core.CoordLP) (*core.CoordXY, error) {
+	xy := &core.CoordXY{X: 0.0, Y: 0.0}
+
+	sinphi := math.Sin(lp.Phi)
+	cosphi := math.Cos(lp.Phi)
+	coslam := math.Cos(lp.Lam)
+
+	switch op.mode {
+	case modeEquit, modeObliq:
+		cosz := op.cosz(sinphi, cosphi, coslam)
+		if math.Abs(math.Abs(cosz)-1.) < aeqdTol {
+			if cosz < 0. {
+				// the point opposite the center
+				return xy, merror.New(merror.ToleranceCondition)
+			}
+			return xy, nil
+		}
+		z := math.Acos(cosz)
+		k := z / math.Sin(z)
+		xy.X = k * cosphi * math.Sin(lp.Lam)
+		if op.mode == modeEquit {
+			xy.Y = k * sinphi
+		} else {
+			xy.Y = k * (op.cosph0*sinphi - op.sinph0*cosphi*coslam)
+		}
+	case modeNPole, modeSPole:
+		err := op.checkPolarLat(lp.Phi)
+		if err != nil {
+			return xy, err
+		}
+		phi := lp.Phi
+		if op.mode == modeNPole {
+			phi = -phi
+			coslam = -coslam
+		}
+		rho := support.PiOverTwo + phi
+		xy.X = rho * math.Sin(lp.Lam)
+		xy.Y = rho * coslam
+	}
+	return xy, nil
+}
+
+// Spheroidal, inverse
+func (op *Aeqd) sInverse(xy *core.CoordXY) (*core.CoordLP, error) {
+	lp := &core.CoordLP{Lam: 0.0, Phi: 0.0}
+
+	x, y := xy.X, xy.Y
+	cRh := support.Hypot(x, y)
+	if cRh > math.Pi {
+		if cRh-eps10 > math.Pi {
+			return nil, merror.New(merror.ToleranceCondition)
+		}
+		cRh = math.Pi
+	} else if cRh < eps10 {
+		lp.Phi = op.System.Phi0
+		return lp, nil
+	}
+
+	switch op.mode {
+	case modeEquit, modeObliq:
+		sinc := math.Sin(cRh)
+		cosc := math.Cos(cRh)
+		if op.mode == modeEquit {
+			lp.Phi = support.Aasin(y * sinc / cRh)
+			x *= sinc
+			y = cosc * cRh
+		} else {
+			lp.Phi = support.Aasin(cosc*op.sinph0 + y*sinc*op.cosph0/cRh)
+			y = (cosc - op.sinph0*math.Sin(lp.Phi)) * cRh
+			x *= sinc * op.cosph0
+		}
+		if y != 0. {
+			lp.Lam = math.Atan2(x, y)
+		}
+	case modeNPole:
+		lp.Phi = support.PiOverTwo - cRh
+		lp.Lam = math.Atan2(x, -y)
+	case modeSPole:
+		lp.Phi = cRh - support.PiOverTwo
+		lp.Lam = math.Atan2(x, y)
+	}
+	return lp, nil
+}
+
+func (op *Aeqd) setup(sys *core.System) error {
+	PE := sys.Ellipsoid
+
+	op.setupAspect(sys.Phi0)
+	if PE.Es == 0.0 {
+		return nil
+	}
+
+	op.en = support.Enfn(PE.Es)
+	op.guam = sys.ProjString.ContainsKey("guam")
+	if op.guam {
+		op.M1 = support.Mlfn(sys.Phi0, op.sinph0, op.cosph0, op.en)
+		return nil
+	}
+
+	switch op.mode {
+	case modeNPole:
+		op.Mp = support.Mlfn(support.PiOverTwo, 1., 0., op.en)
+	case modeSPole:
+		op.Mp = support.Mlfn(-support.PiOverTwo, -1., 0., op.en)
+	}
+	return nil
+}
@@ -5,7 +5,7 @@
 // is provided under the MIT-style license in `LICENSE.md` and may
 // additionally be subject to the copyrights of the PROJ.4 authors.
 
-package operations
+package azimuthal
 
 import (
 	"math"
@@ -19,9 +19,13 @@ import (
 func init() {
 	core.RegisterConvertLPToXY("airy",
 		"Airy",
-		"\n\tMisc Sph, no inv.\n\tno_cut lat_b=",
+		"\n\tMisc Sph\n\tno_cut lat_b=",
 		NewAiry,
 	)
+	core.RegisterParameters("airy",
+		core.Parameter{Name: "lat_b", Type: core.ParameterAngle, Unit: "degrees", Default: "0", Description: "latitude of the boundary of the region of minimum error"},
+		core.Parameter{Name: "no_cut", Type: core.ParameterFlag, Description: "don't cut at the hemisphere limit"},
+	)
 }
 
 // Airy implements core.IOperation and core.ConvertLPToXY
@@ -73,7 +77,11 @@ func (op *Airy) Forward(lp *core.CoordLP) (*core.CoordXY, error) {
 		s := 1. - cosz
 		if math.Abs(s) > eps10 {
 			t = 0.5 * (1. + cosz)
-			Krho = -math.Log(t)/s - Q.Cb/t
+			if t < eps10 {
+				// the point opposite the center, with no_cut
+				return nil, merror.New(merror.ToleranceCondition)
+			}
+			Krho = -support.Log(t)/s - Q.Cb/t
 		} else {
 			Krho = 0.5 - Q.Cb
 		}
@@ -90,9 +98,13 @@ func (op *Airy) Forward(lp *core.CoordLP) (*core.CoordXY, error) {
 			return nil, merror.New(merror.ToleranceCondition)
 		}
 		lp.Phi *= 0.5
+		if lp.Phi > support.PiOverTwo-eps10 {
+			// the opposite pole, with no_cut
+			return nil, merror.New(merror.ToleranceCondition)
+		}
 		if lp.Phi > eps10 {
 			t = math.Tan(lp.Phi)
-			Krho = -2. * (math.Log(math.Cos(lp.Phi))/t + t*Q.Cb)
+			Krho = -2. * (support.Log(math.Cos(lp.Phi))/t + t*Q.Cb)
 			xy.X = Krho * sinlam
 			xy.Y = Krho * coslam
 			if Q.mode == modeNPole {
@@ -107,9 +119,31 @@ func (op *Airy) Forward(lp *core.CoordLP) (*core.CoordXY, error) {
 	return xy, nil
 }
 
-// Inverse is not allowed
-func (*Airy) Inverse(*core.CoordXY) (*core.CoordLP, error) {
-	panic("no such conversion")
+// Inverse is found numerically, as there is no closed form
+func (op *Airy) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
+	lp, _, _, err := op.InverseWithConvergence(xy)
+	return lp, err
+}
+
+// InverseWithConvergence implements core.IIterativeInverse
+func (op *Airy) InverseWithConvergence(xy *core.CoordXY) (*core.CoordLP, int, float64, error) {
+	return core.GenericInverse("airy", op.Forward, xy, op.guess(xy))
+}
+
+// guess takes the scale at the center to hold everywhere, which it does
+// roughly, and inverts that as an azimuthal equidistant
+func (op *Airy) guess(xy *core.CoordXY) core.CoordLP {
+	phi0 := op.System.Phi0
+	rho := support.Hypot(xy.X, xy.Y)
+	if rho < eps10 {
+		return core.CoordLP{Lam: 0.0, Phi: phi0}
+	}
+	c := math.Min(rho/(0.5-op.Cb), support.PiOverTwo)
+	sinc, cosc := math.Sin(c), math.Cos(c)
+	return core.CoordLP{
+		Lam: math.Atan2(xy.X*sinc, rho*math.Cos(phi0)*cosc-xy.Y*math.Sin(phi0)*sinc),
+		Phi: support.Aasin(cosc*math.Sin(phi0) + xy.Y*sinc*math.Cos(phi0)/rho),
+	}
 }
 
 func (op *Airy) setup(sys *core.System) error {
@@ -117,10 +151,10 @@ func (op *Airy) setup(sys *core.System) error {
 
 	PE := sys.Ellipsoid
 
-	op.nocut = sys.ProjString.ContainsKey("no_cut")
-	latb, ok := sys.ProjString.GetAsFloat("lat_b")
-	if !ok {
-		latb = 0.0
+	op.nocut, _ = sys.ProjString.GetAsBool("no_cut")
+	latb, _, err := sys.ProjString.GetAsDegrees("lat_b")
+	if err != nil {
+		return err
 	}
 	latb = support.DDToR(latb)
 
@@ -129,7 +163,7 @@ func (op *Airy) setup(sys *core.System) error {
 		op.Cb = -0.5
 	} else {
 		op.Cb = 1. / math.Tan(beta)
-		op.Cb *= op.Cb * math.Log(math.Cos(beta))
+		op.Cb *= op.Cb * support.Log(math.Cos(beta))
 	}
 
 	if math.Abs(math.Abs(sys.Phi0)-support.PiOverTwo) < eps10 {
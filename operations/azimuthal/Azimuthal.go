@@ -0,0 +1,80 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package azimuthal
+
+import (
+	"math"
+
+	"github.com/oahumap/proj/merror"
+	"github.com/oahumap/proj/support"
+)
+
+// AzimuthalBase holds the aspect handling shared by the azimuthal
+// projections (laea, aeqd, stere, gnom, ortho, ...): which of the polar,
+// equatorial or oblique cases applies, and the sine and cosine of the
+// latitude of origin.
+//
+// Embed it in the operation and call setupAspect from its setup function.
+type AzimuthalBase struct {
+	mode   mode
+	sinph0 float64
+	cosph0 float64
+}
+
+// setupAspect determines the mode from the latitude of origin (radians)
+func (az *AzimuthalBase) setupAspect(phi0 float64) {
+	t := math.Abs(phi0)
+
+	if math.Abs(t-support.PiOverTwo) < eps10 {
+		if phi0 < 0.0 {
+			az.mode = modeSPole
+			az.sinph0 = -1.0
+		} else {
+			az.mode = modeNPole
+			az.sinph0 = 1.0
+		}
+		az.cosph0 = 0.0
+	} else if t < eps10 {
+		az.mode = modeEquit
+		az.sinph0 = 0.0
+		az.cosph0 = 1.0
+	} else {
+		az.mode = modeObliq
+		az.sinph0 = math.Sin(phi0)
+		az.cosph0 = math.Cos(phi0)
+	}
+}
+
+// isPolar is true for the north and south polar aspects
+func (az *AzimuthalBase) isPolar() bool {
+	return az.mode == modeNPole || az.mode == modeSPole
+}
+
+// cosz returns the cosine of the angular distance between the point and
+// the center of the projection, on the sphere
+func (az *AzimuthalBase) cosz(sinphi, cosphi, coslam float64) float64 {
+	switch az.mode {
+	case modeNPole:
+		return sinphi
+	case modeSPole:
+		return -sinphi
+	case modeEquit:
+		return cosphi * coslam
+	}
+	return az.sinph0*sinphi + az.cosph0*cosphi*coslam
+}
+
+// checkPolarLat fails with a tolerance condition when the latitude lies in
+// the wrong hemisphere for a polar aspect, i.e. at the opposite pole
+func (az *AzimuthalBase) checkPolarLat(phi float64) error {
+	if (az.mode == modeNPole && phi < -support.PiOverTwo+eps10) ||
+		(az.mode == modeSPole && phi > support.PiOverTwo-eps10) {
+		return merror.New(merror.ToleranceCondition)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package azimuthal
+
+import (
+	"math"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/merror"
+	"github.com/oahumap/proj/support"
+)
+
+func init() {
+	core.RegisterConvertLPToXY("gnom",
+		"Gnomonic",
+		"\n\tAzi, Sph.",
+		NewGnom,
+	)
+}
+
+// Gnom implements core.IOperation and core.ConvertLPToXY
+//
+// The gnomonic projection maps every great circle to a straight line, but
+// only covers the hemisphere around its center: points 90 degrees or more
+// from it fail with a tolerance condition. It is spherical only.
+type Gnom struct {
+	core.Operation
+	AzimuthalBase
+}
+
+// NewGnom returns a new Gnom
+func NewGnom(system *core.System, desc *core.OperationDescription) (core.IConvertLPToXY, error) {
+	op := &Gnom{}
+	op.System = system
+
+	err := op.setup(system)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// Forward goes forewards
+func (op *Gnom) Forward(lp *core.CoordLP) (*core.CoordXY, error) {
+	xy := &core.CoordXY{X: 0.0, Y: 0.0}
+
+	sinphi := math.Sin(lp.Phi)
+	cosphi := math.Cos(lp.Phi)
+	coslam := math.Cos(lp.Lam)
+
+	cosz := op.cosz(sinphi, cosphi, coslam)
+	if cosz <= eps10 {
+		return xy, merror.New(merror.ToleranceCondition)
+	}
+
+	xy.Y = 1. / cosz
+	xy.X = xy.Y * cosphi * math.Sin(lp.Lam)
+	switch op.mode {
+	case modeEquit:
+		xy.Y *= sinphi
+	case modeObliq:
+		xy.Y *= op.cosph0*sinphi - op.sinph0*cosphi*coslam
+	case modeNPole:
+		xy.Y *= -cosphi * coslam
+	case modeSPole:
+		xy.Y *= cosphi * coslam
+	}
+	return xy, nil
+}
+
+// Inverse goes backwards
+func (op *Gnom) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
+	lp := &core.CoordLP{Lam: 0.0, Phi: 0.0}
+
+	x, y := xy.X, xy.Y
+	rh := support.Hypot(x, y)
+	lp.Phi = math.Atan(rh)
+	sinz := math.Sin(lp.Phi)
+	cosz := math.Sqrt(1. - sinz*sinz)
+
+	if math.Abs(rh) <= eps10 {
+		lp.Phi = op.System.Phi0
+		lp.Lam = 0.
+		return lp, nil
+	}
+
+	switch op.mode {
+	case modeObliq:
+		lp.Phi = support.Aasin(cosz*op.sinph0 + y*sinz*op.cosph0/rh)
+		y = (cosz - op.sinph0*math.Sin(lp.Phi)) * rh
+		x *= sinz * op.cosph0
+	case modeEquit:
+		lp.Phi = support.Aasin(y * sinz / rh)
+		y = cosz * rh
+		x *= sinz
+	case modeSPole:
+		lp.Phi -= support.PiOverTwo
+	case modeNPole:
+		lp.Phi = support.PiOverTwo - lp.Phi
+		y = -y
+	}
+	lp.Lam = math.Atan2(x, y)
+	return lp, nil
+}
+
+//---------------------------------------------------------------------
+
+func (op *Gnom) setup(sys *core.System) error {
+	op.setupAspect(sys.Phi0)
+
+	sys.Ellipsoid.Es = 0.
+
+	return nil
+}
@@ -0,0 +1,310 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package azimuthal
+
+import (
+	"math"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/merror"
+	"github.com/oahumap/proj/support"
+)
+
+func init() {
+	core.RegisterConvertLPToXY("laea",
+		"Lambert Azimuthal Equal Area",
+		"\n\tAzi, Sph&Ell",
+		NewLaea,
+	)
+}
+
+// Laea implements core.IOperation and core.ConvertLPToXY
+//
+// On the ellipsoid, the point is first moved to the authalic sphere, so
+// that areas are kept exactly. The EASE-Grid polar projections are this
+// one, with lat_0 at either pole.
+type Laea struct {
+	core.Operation
+	AzimuthalBase
+	isSphere bool
+	sinb1    float64 // of the authalic latitude of origin
+	cosb1    float64
+	xmf      float64
+	ymf      float64
+	qp       float64 // q at the pole
+	dd       float64
+	rq       float64   // radius of the authalic sphere
+	apa      []float64 // for the authalic latitude's inverse
+}
+
+// NewLaea returns a new Laea
+func NewLaea(system *core.System, desc *core.OperationDescription) (core.IConvertLPToXY, error) {
+	op := &Laea{}
+	op.System = system
+
+	err := op.laeaSetup(system)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// Forward goes forewards
+func (op *Laea) Forward(lp *core.CoordLP) (*core.CoordXY, error) {
+
+	if op.isSphere {
+		return op.sphericalForward(lp)
+	}
+	return op.ellipsoidalForward(lp)
+}
+
+// Inverse goes backwards
+func (op *Laea) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
+
+	if op.isSphere {
+		return op.sphericalInverse(xy)
+	}
+	return op.ellipsoidalInverse(xy)
+}
+
+//---------------------------------------------------------------------
+
+func (op *Laea) ellipsoidalForward(lp *core.CoordLP) (*core.CoordXY, error) { /* Ellipsoidal, forward */
+	xy := &core.CoordXY{X: 0.0, Y: 0.0}
+
+	PE := op.System.Ellipsoid
+
+	coslam := math.Cos(lp.Lam)
+	sinlam := math.Sin(lp.Lam)
+	sinphi := math.Sin(lp.Phi)
+	q := support.Qsfn(sinphi, PE.E, PE.OneEs)
+
+	var sinb, cosb, b float64
+	if op.mode == modeObliq || op.mode == modeEquit {
+		sinb = q / op.qp
+		cosb2 := 1. - sinb*sinb
+		if cosb2 > 0 {
+			cosb = math.Sqrt(cosb2)
+		}
+	}
+
+	switch op.mode {
+	case modeObliq:
+		b = 1. + op.sinb1*sinb + op.cosb1*cosb*coslam
+	case modeEquit:
+		b = 1. + cosb*coslam
+	case modeNPole:
+		b = support.PiOverTwo + lp.Phi
+		q = op.qp - q
+	case modeSPole:
+		b = lp.Phi - support.PiOverTwo
+		q = op.qp + q
+	}
+	if math.Abs(b) < eps10 {
+		return xy, merror.New(merror.ToleranceCondition)
+	}
+
+	switch op.mode {
+	case modeObliq:
+		b = math.Sqrt(2. / b)
+		xy.Y = op.ymf * b * (op.cosb1*sinb - op.sinb1*cosb*coslam)
+		xy.X = op.xmf * b * cosb * sinlam
+	case modeEquit:
+		b = math.Sqrt(2. / (1. + cosb*coslam))
+		xy.Y = b * sinb * op.ymf
+		xy.X = op.xmf * b * cosb * sinlam
+	case modeNPole, modeSPole:
+		if q >= 1e-15 {
+			b = math.Sqrt(q)
+			xy.X = b * sinlam
+			if op.mode == modeSPole {
+				xy.Y = coslam * b
+			} else {
+				xy.Y = coslam * -b
+			}
+		}
+	}
+	return xy, nil
+}
+
+func (op *Laea) sphericalForward(lp *core.CoordLP) (*core.CoordXY, error) { /* Spheroidal, forward */
+	xy := &core.CoordXY{X: 0.0, Y: 0.0}
+
+	sinphi := math.Sin(lp.Phi)
+	cosphi := math.Cos(lp.Phi)
+	coslam := math.Cos(lp.Lam)
+
+	switch op.mode {
+	case modeEquit, modeObliq:
+		if op.mode == modeEquit {
+			xy.Y = 1. + cosphi*coslam
+		} else {
+			xy.Y = 1. + op.sinb1*sinphi + op.cosb1*cosphi*coslam
+		}
+		if xy.Y <= eps10 {
+			return xy, merror.New(merror.ToleranceCondition)
+		}
+		xy.Y = math.Sqrt(2. / xy.Y)
+		xy.X = xy.Y * cosphi * math.Sin(lp.Lam)
+		if op.mode == modeEquit {
+			xy.Y *= sinphi
+		} else {
+			xy.Y *= op.cosb1*sinphi - op.sinb1*cosphi*coslam
+		}
+	case modeNPole, modeSPole:
+		if op.mode == modeNPole {
+			coslam = -coslam
+		}
+		if math.Abs(lp.Phi+op.System.Phi0) < eps10 {
+			return xy, merror.New(merror.ToleranceCondition)
+		}
+		xy.Y = support.PiOverFour - lp.Phi*.5
+		if op.mode == modeSPole {
+			xy.Y = 2. * math.Cos(xy.Y)
+		} else {
+			xy.Y = 2. * math.Sin(xy.Y)
+		}
+		xy.X = xy.Y * math.Sin(lp.Lam)
+		xy.Y *= coslam
+	}
+	return xy, nil
+}
+
+func (op *Laea) ellipsoidalInverse(xy *core.CoordXY) (*core.CoordLP, error) { /* Ellipsoidal, inverse */
+	lp := &core.CoordLP{Lam: 0.0, Phi: 0.0}
+
+	x, y := xy.X, xy.Y
+	var ab float64
+
+	switch op.mode {
+	case modeEquit, modeObliq:
+		x /= op.dd
+		y *= op.dd
+		rho := support.Hypot(x, y)
+		if rho < eps10 {
+			lp.Phi = op.System.Phi0
+			return lp, nil
+		}
+		sCe := 2. * math.Asin(.5*rho/op.rq)
+		cCe := math.Cos(sCe)
+		sCe = math.Sin(sCe)
+		x *= sCe
+		if op.mode == modeObliq {
+			ab = cCe*op.sinb1 + y*sCe*op.cosb1/rho
+			y = rho*op.cosb1*cCe - y*op.sinb1*sCe
+		} else {
+			ab = y * sCe / rho
+			y = rho * cCe
+		}
+	case modeNPole, modeSPole:
+		if op.mode == modeNPole {
+			y = -y
+		}
+		q := x*x + y*y
+		if q == 0.0 {
+			lp.Phi = op.System.Phi0
+			return lp, nil
+		}
+		ab = 1. - q/op.qp
+		if op.mode == modeSPole {
+			ab = -ab
+		}
+	}
+	lp.Lam = math.Atan2(x, y)
+	PE := op.System.Ellipsoid
+	lp.Phi = support.AuthlatExact(math.Asin(ab), op.apa, PE.E, PE.OneEs, op.qp)
+	return lp, nil
+}
+
+func (op *Laea) sphericalInverse(xy *core.CoordXY) (*core.CoordLP, error) { /* Spheroidal, inverse */
+	lp := &core.CoordLP{Lam: 0.0, Phi: 0.0}
+
+	x, y := xy.X, xy.Y
+	rh := support.Hypot(x, y)
+	lp.Phi = rh * .5
+	if lp.Phi > 1. {
+		return nil, merror.New(merror.ToleranceCondition)
+	}
+	lp.Phi = 2. * math.Asin(lp.Phi)
+
+	var sinz, cosz float64
+	if op.mode == modeObliq || op.mode == modeEquit {
+		sinz = math.Sin(lp.Phi)
+		cosz = math.Cos(lp.Phi)
+	}
+
+	switch op.mode {
+	case modeEquit:
+		if math.Abs(rh) <= eps10 {
+			lp.Phi = 0.
+		} else {
+			lp.Phi = math.Asin(y * sinz / rh)
+		}
+		x *= sinz
+		y = cosz * rh
+	case modeObliq:
+		if math.Abs(rh) <= eps10 {
+			lp.Phi = op.System.Phi0
+		} else {
+			lp.Phi = math.Asin(cosz*op.sinb1 + y*sinz*op.cosb1/rh)
+		}
+		x *= sinz * op.cosb1
+		y = (cosz - math.Sin(lp.Phi)*op.sinb1) * rh
+	case modeNPole:
+		y = -y
+		lp.Phi = support.PiOverTwo - lp.Phi
+	case modeSPole:
+		lp.Phi -= support.PiOverTwo
+	}
+
+	if y == 0. && (op.mode == modeEquit || op.mode == modeObliq) {
+		lp.Lam = 0.
+	} else {
+		lp.Lam = math.Atan2(x, y)
+	}
+	return lp, nil
+}
+
+func (op *Laea) laeaSetup(sys *core.System) error {
+	PE := sys.Ellipsoid
+
+	if math.Abs(sys.Phi0) > support.PiOverTwo+eps10 {
+		return merror.New(merror.LatOrLonExceededLimit)
+	}
+	op.setupAspect(sys.Phi0)
+
+	if PE.Es == 0.0 { /* sphere */
+		op.isSphere = true
+		op.sinb1 = op.sinph0
+		op.cosb1 = op.cosph0
+		return nil
+	}
+
+	/* ellipsoid */
+	op.qp = support.Qsfn(1., PE.E, PE.OneEs)
+	op.apa = support.Authset(PE.Es)
+	switch op.mode {
+	case modeNPole, modeSPole:
+		op.dd = 1.
+	case modeEquit:
+		op.rq = math.Sqrt(.5 * op.qp)
+		op.dd = 1. / op.rq
+		op.xmf = 1.
+		op.ymf = .5 * op.qp
+	case modeObliq:
+		op.rq = math.Sqrt(.5 * op.qp)
+		sinphi := math.Sin(sys.Phi0)
+		op.sinb1 = support.Qsfn(sinphi, PE.E, PE.OneEs) / op.qp
+		op.cosb1 = math.Sqrt(1. - op.sinb1*op.sinb1)
+		op.dd = math.Cos(sys.Phi0) / (math.Sqrt(1.-PE.Es*sinphi*sinphi) * op.rq * op.cosb1)
+		op.xmf = op.rq * op.dd
+		op.ymf = op.rq / op.dd
+	}
+
+	return nil
+}
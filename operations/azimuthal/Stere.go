@@ -0,0 +1,365 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package azimuthal
+
+import (
+	"math"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/merror"
+	"github.com/oahumap/proj/support"
+)
+
+func init() {
+	core.RegisterConvertLPToXY("stere",
+		"Stereographic",
+		"\n\tAzi, Sph&Ell\n\tlat_ts=",
+		NewStere,
+	)
+	core.RegisterParameters("stere",
+		core.Parameter{Name: "lat_ts", Type: core.ParameterAngle, Unit: "degrees", Default: "90", Description: "latitude of true scale, for the polar aspects; overrides k_0"},
+	)
+	core.RegisterConvertLPToXY("ups",
+		"Universal Polar Stereographic",
+		"\n\tAzi, Ell\n\tsouth",
+		NewUps,
+	)
+	core.RegisterParameters("ups",
+		core.Parameter{Name: "south", Type: core.ParameterFlag, Description: "south polar zone"},
+	)
+}
+
+// Stere implements core.IOperation and core.ConvertLPToXY
+//
+// On the ellipsoid, the oblique and equatorial aspects are on the
+// conformal sphere, as in Snyder; the polar aspects are exact, and with
+// lat_ts are true to scale at that latitude (EPSG's variant B) rather
+// than scaled by k_0 at the pole (variant A). UPS is the polar aspect.
+type Stere struct {
+	core.Operation
+	AzimuthalBase
+	phits float64 // latitude of true scale
+	sinX1 float64 // of the conformal latitude of origin
+	cosX1 float64
+	akm1  float64
+}
+
+const (
+	stereTol   = 1.e-8
+	stereNiter = 8
+	stereConv  = 1.e-10
+)
+
+// NewStere returns a new Stere
+func NewStere(system *core.System, desc *core.OperationDescription) (core.IConvertLPToXY, error) {
+	op := &Stere{}
+	op.System = system
+
+	op.phits = support.PiOverTwo
+	if system.ProjString.ContainsKey("lat_ts") {
+		phits, ok := system.ProjString.GetAsAngle("lat_ts")
+		if !ok {
+			return nil, merror.New(merror.InvalidProjectionSyntax, "lat_ts")
+		}
+		op.phits = phits
+	}
+
+	err := op.setup(system)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// NewUps returns a new Stere, set up as the north or, with +south, the
+// south UPS zone
+func NewUps(system *core.System, desc *core.OperationDescription) (core.IConvertLPToXY, error) {
+	if system.Ellipsoid.Es == 0.0 {
+		return nil, merror.New(merror.EllipsoidUseRequired)
+	}
+
+	system.Phi0 = support.PiOverTwo
+	if south, _ := system.ProjString.GetAsBool("south"); south {
+		system.Phi0 = -support.PiOverTwo
+	}
+	system.K0 = 0.994
+	system.X0 = 2000000.0
+	system.Y0 = 2000000.0
+	system.Lam0 = 0.0
+
+	op := &Stere{phits: support.PiOverTwo}
+	op.System = system
+
+	err := op.setup(system)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// Forward goes forewards
+func (op *Stere) Forward(lp *core.CoordLP) (*core.CoordXY, error) {
+	if op.System.Ellipsoid.Es == 0.0 {
+		return op.sphericalForward(lp)
+	}
+	return op.ellipsoidalForward(lp)
+}
+
+// Inverse goes backwards
+func (op *Stere) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
+	if op.System.Ellipsoid.Es == 0.0 {
+		return op.sphericalInverse(xy)
+	}
+	return op.ellipsoidalInverse(xy)
+}
+
+//---------------------------------------------------------------------
+
+// ssfn is tan(pi/4 + phit/2) ((1 - e sin(phi)) / (1 + e sin(phi)))^(e/2),
+// the tangent of pi/4 plus half the conformal latitude
+func ssfn(phit, sinphi, eccen float64) float64 {
+	sinphi *= eccen
+	return math.Tan(.5*(support.PiOverTwo+phit)) *
+		support.Pow((1.-sinphi)/(1.+sinphi), .5*eccen)
+}
+
+func (op *Stere) ellipsoidalForward(lp *core.CoordLP) (*core.CoordXY, error) { /* Ellipsoidal, forward */
+	xy := &core.CoordXY{X: 0.0, Y: 0.0}
+
+	PE := op.System.Ellipsoid
+	phi := lp.Phi
+	coslam := math.Cos(lp.Lam)
+	sinlam := math.Sin(lp.Lam)
+	sinphi := math.Sin(phi)
+
+	var sinX, cosX float64
+	if op.mode == modeObliq || op.mode == modeEquit {
+		X := 2.*math.Atan(ssfn(phi, sinphi, PE.E)) - support.PiOverTwo
+		sinX = math.Sin(X)
+		cosX = math.Cos(X)
+	}
+
+	switch op.mode {
+	case modeObliq:
+		denom := op.cosX1 * (1. + op.sinX1*sinX + op.cosX1*cosX*coslam)
+		if denom == 0. {
+			return xy, merror.New(merror.ToleranceCondition)
+		}
+		A := op.akm1 / denom
+		xy.Y = A * (op.cosX1*sinX - op.sinX1*cosX*coslam)
+		xy.X = A * cosX
+	case modeEquit:
+		denom := 1. + cosX*coslam
+		if denom == 0. {
+			return xy, merror.New(merror.ToleranceCondition)
+		}
+		A := op.akm1 / denom
+		xy.Y = A * sinX
+		xy.X = A * cosX
+	case modeSPole, modeNPole:
+		if op.mode == modeSPole {
+			phi = -phi
+			coslam = -coslam
+			sinphi = -sinphi
+		}
+		if math.Abs(phi-support.PiOverTwo) >= 1e-15 {
+			t := support.Tsfn(phi, sinphi, PE.E)
+			if t == math.MaxFloat64 {
+				return xy, merror.New(merror.ToleranceCondition)
+			}
+			xy.X = op.akm1 * t
+		}
+		xy.Y = -xy.X * coslam
+	}
+
+	xy.X *= sinlam
+	return xy, nil
+}
+
+func (op *Stere) ellipsoidalInverse(xy *core.CoordXY) (*core.CoordLP, error) { /* Ellipsoidal, inverse */
+	lp := &core.CoordLP{Lam: 0.0, Phi: 0.0}
+
+	PE := op.System.Ellipsoid
+	x, y := xy.X, xy.Y
+	rho := support.Hypot(x, y)
+
+	var tp, phiL, halfe, halfpi float64
+	switch op.mode {
+	case modeObliq, modeEquit:
+		tp = 2. * math.Atan2(rho*op.cosX1, op.akm1)
+		cosphi := math.Cos(tp)
+		sinphi := math.Sin(tp)
+		if rho == 0.0 {
+			phiL = math.Asin(cosphi * op.sinX1)
+		} else {
+			phiL = math.Asin(cosphi*op.sinX1 + (y * sinphi * op.cosX1 / rho))
+		}
+
+		tp = math.Tan(.5 * (support.PiOverTwo + phiL))
+		x *= sinphi
+		y = rho*op.cosX1*cosphi - y*op.sinX1*sinphi
+		halfpi = support.PiOverTwo
+		halfe = .5 * PE.E
+	case modeNPole, modeSPole:
+		if op.mode == modeNPole {
+			y = -y
+		}
+		tp = -rho / op.akm1
+		phiL = support.PiOverTwo - 2.*math.Atan(tp)
+		halfpi = -support.PiOverTwo
+		halfe = -.5 * PE.E
+	}
+
+	for i := stereNiter; i > 0; i-- {
+		sinphi := PE.E * math.Sin(phiL)
+		lp.Phi = 2.*math.Atan(tp*support.Pow((1.+sinphi)/(1.-sinphi), halfe)) - halfpi
+		if math.Abs(phiL-lp.Phi) < stereConv {
+			if op.mode == modeSPole {
+				lp.Phi = -lp.Phi
+			}
+			if x != 0. || y != 0. {
+				lp.Lam = math.Atan2(x, y)
+			}
+			return lp, nil
+		}
+		phiL = lp.Phi
+	}
+	return lp, merror.New(merror.ToleranceCondition)
+}
+
+func (op *Stere) sphericalForward(lp *core.CoordLP) (*core.CoordXY, error) { /* Spheroidal, forward */
+	xy := &core.CoordXY{X: 0.0, Y: 0.0}
+
+	phi := lp.Phi
+	sinphi := math.Sin(phi)
+	cosphi := math.Cos(phi)
+	coslam := math.Cos(lp.Lam)
+	sinlam := math.Sin(lp.Lam)
+
+	switch op.mode {
+	case modeEquit, modeObliq:
+		if op.mode == modeEquit {
+			xy.Y = 1. + cosphi*coslam
+		} else {
+			xy.Y = 1. + op.sinph0*sinphi + op.cosph0*cosphi*coslam
+		}
+		if xy.Y <= eps10 {
+			return xy, merror.New(merror.ToleranceCondition)
+		}
+		xy.Y = op.akm1 / xy.Y
+		xy.X = xy.Y * cosphi * sinlam
+		if op.mode == modeEquit {
+			xy.Y *= sinphi
+		} else {
+			xy.Y *= op.cosph0*sinphi - op.sinph0*cosphi*coslam
+		}
+	case modeNPole, modeSPole:
+		if op.mode == modeNPole {
+			coslam = -coslam
+			phi = -phi
+		}
+		if math.Abs(phi-support.PiOverTwo) < stereTol {
+			return xy, merror.New(merror.ToleranceCondition)
+		}
+		xy.Y = op.akm1 * math.Tan(support.PiOverFour+.5*phi)
+		xy.X = sinlam * xy.Y
+		xy.Y *= coslam
+	}
+	return xy, nil
+}
+
+func (op *Stere) sphericalInverse(xy *core.CoordXY) (*core.CoordLP, error) { /* Spheroidal, inverse */
+	lp := &core.CoordLP{Lam: 0.0, Phi: 0.0}
+
+	x, y := xy.X, xy.Y
+	rh := support.Hypot(x, y)
+	c := 2. * math.Atan(rh/op.akm1)
+	sinc := math.Sin(c)
+	cosc := math.Cos(c)
+
+	switch op.mode {
+	case modeEquit:
+		if math.Abs(rh) > eps10 {
+			lp.Phi = math.Asin(y * sinc / rh)
+		}
+		if cosc != 0. || x != 0. {
+			lp.Lam = math.Atan2(x*sinc, cosc*rh)
+		}
+	case modeObliq:
+		if math.Abs(rh) <= eps10 {
+			lp.Phi = op.System.Phi0
+		} else {
+			lp.Phi = math.Asin(cosc*op.sinph0 + y*sinc*op.cosph0/rh)
+		}
+		c = cosc - op.sinph0*math.Sin(lp.Phi)
+		if c != 0. || x != 0. {
+			lp.Lam = math.Atan2(x*sinc*op.cosph0, c*rh)
+		}
+	case modeNPole, modeSPole:
+		if op.mode == modeNPole {
+			y = -y
+		}
+		if math.Abs(rh) <= eps10 {
+			lp.Phi = op.System.Phi0
+		} else if op.mode == modeSPole {
+			lp.Phi = math.Asin(-cosc)
+		} else {
+			lp.Phi = math.Asin(cosc)
+		}
+		if x != 0. || y != 0. {
+			lp.Lam = math.Atan2(x, y)
+		}
+	}
+	return lp, nil
+}
+
+//---------------------------------------------------------------------
+
+func (op *Stere) setup(sys *core.System) error {
+	op.setupAspect(sys.Phi0)
+	op.phits = math.Abs(op.phits)
+
+	PE := sys.Ellipsoid
+	if PE.Es != 0.0 {
+		switch op.mode {
+		case modeNPole, modeSPole:
+			if math.Abs(op.phits-support.PiOverTwo) < eps10 {
+				op.akm1 = 2. * sys.K0 /
+					math.Sqrt(support.Pow(1+PE.E, 1+PE.E)*support.Pow(1-PE.E, 1-PE.E))
+			} else {
+				t := math.Sin(op.phits)
+				op.akm1 = math.Cos(op.phits) / support.Tsfn(op.phits, t, PE.E)
+				t *= PE.E
+				op.akm1 /= math.Sqrt(1. - t*t)
+			}
+		case modeEquit:
+			op.akm1 = 2. * sys.K0
+			op.cosX1 = 1.
+		case modeObliq:
+			t := math.Sin(sys.Phi0)
+			X := 2.*math.Atan(ssfn(sys.Phi0, t, PE.E)) - support.PiOverTwo
+			t *= PE.E
+			op.akm1 = 2. * sys.K0 * math.Cos(sys.Phi0) / math.Sqrt(1.-t*t)
+			op.sinX1 = math.Sin(X)
+			op.cosX1 = math.Cos(X)
+		}
+		return nil
+	}
+
+	switch op.mode {
+	case modeObliq, modeEquit:
+		op.akm1 = 2. * sys.K0
+	case modeNPole, modeSPole:
+		if math.Abs(op.phits-support.PiOverTwo) >= eps10 {
+			op.akm1 = math.Cos(op.phits) / math.Tan(support.PiOverFour-.5*op.phits)
+		} else {
+			op.akm1 = 2. * sys.K0
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package azimuthal
+
+import (
+	"math"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/merror"
+	"github.com/oahumap/proj/support"
+)
+
+func init() {
+	core.RegisterConvertLPToXY("sterea",
+		"Oblique Stereographic Alternative",
+		"\n\tAzimuthal, Sph&Ell",
+		NewSterea,
+	)
+}
+
+// Sterea implements core.IOperation and core.ConvertLPToXY
+//
+// This is EPSG's Oblique Stereographic, as used by the Dutch RD grid
+// (28992): the point is moved to Gauss's conformal sphere, which touches
+// the ellipsoid at the latitude of origin, and projected from there. It
+// differs from stere's oblique aspect, which uses a different sphere.
+type Sterea struct {
+	core.Operation
+	gauss *gauss
+	phic0 float64 // the latitude of origin on the sphere
+	cosc0 float64
+	sinc0 float64
+	R2    float64 // twice the radius of the sphere
+}
+
+// NewSterea returns a new Sterea
+func NewSterea(system *core.System, desc *core.OperationDescription) (core.IConvertLPToXY, error) {
+	op := &Sterea{}
+	op.System = system
+
+	err := op.setup(system)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// Forward goes forewards
+func (op *Sterea) Forward(lp *core.CoordLP) (*core.CoordXY, error) {
+	xy := &core.CoordXY{X: 0.0, Y: 0.0}
+
+	slp := op.gauss.forward(lp)
+	sinc := math.Sin(slp.Phi)
+	cosc := math.Cos(slp.Phi)
+	cosl := math.Cos(slp.Lam)
+	denom := 1. + op.sinc0*sinc + op.cosc0*cosc*cosl
+	if denom == 0.0 {
+		return xy, merror.New(merror.ToleranceCondition)
+	}
+	k := op.System.K0 * op.R2 / denom
+	xy.X = k * cosc * math.Sin(slp.Lam)
+	xy.Y = k * (op.cosc0*sinc - op.sinc0*cosc*cosl)
+	return xy, nil
+}
+
+// Inverse goes backwards
+func (op *Sterea) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
+	slp := &core.CoordLP{Lam: 0.0, Phi: op.phic0}
+
+	x := xy.X / op.System.K0
+	y := xy.Y / op.System.K0
+	rho := support.Hypot(x, y)
+	if rho != 0.0 {
+		c := 2. * math.Atan2(rho, op.R2)
+		sinc := math.Sin(c)
+		cosc := math.Cos(c)
+		slp.Phi = math.Asin(cosc*op.sinc0 + y*sinc*op.cosc0/rho)
+		slp.Lam = math.Atan2(x*sinc, rho*op.cosc0*cosc-y*op.sinc0*sinc)
+	}
+	return op.gauss.inverse(slp)
+}
+
+//---------------------------------------------------------------------
+
+func (op *Sterea) setup(sys *core.System) error {
+	var R float64
+	var err error
+	op.gauss, op.phic0, R, err = newGauss(sys.Ellipsoid.E, sys.Phi0)
+	if err != nil {
+		return err
+	}
+	op.sinc0 = math.Sin(op.phic0)
+	op.cosc0 = math.Cos(op.phic0)
+	op.R2 = 2. * R
+	return nil
+}
+
+//---------------------------------------------------------------------
+
+// gauss is Gauss's conformal mapping of the ellipsoid onto a sphere which
+// touches it at a given latitude
+type gauss struct {
+	C      float64
+	K      float64
+	e      float64
+	ratexp float64
+}
+
+const (
+	gaussMaxIter = 20
+	gaussDelTol  = 1e-14
+)
+
+func srat(esinp, ratexp float64) float64 {
+	return support.Pow((1.-esinp)/(1.+esinp), ratexp)
+}
+
+// newGauss returns the mapping for the eccentricity e about the latitude
+// phi0, the latitude of phi0 on the sphere, chi, and the radius of the
+// sphere, rc, in units of the major axis
+func newGauss(e, phi0 float64) (en *gauss, chi float64, rc float64, err error) {
+	en = &gauss{e: e}
+	es := e * e
+	sphi := math.Sin(phi0)
+	cphi := math.Cos(phi0)
+	cphi *= cphi
+	rc = math.Sqrt(1.-es) / (1. - es*sphi*sphi)
+	en.C = math.Sqrt(1. + es*cphi*cphi/(1.-es))
+	if en.C == 0.0 {
+		return nil, 0.0, 0.0, merror.New(merror.InvalidProjectionSyntax, "gauss")
+	}
+	chi = math.Asin(sphi / en.C)
+	en.ratexp = 0.5 * en.C * e
+	sratVal := srat(en.e*sphi, en.ratexp)
+	if sratVal == 0.0 {
+		return nil, 0.0, 0.0, merror.New(merror.InvalidProjectionSyntax, "gauss")
+	}
+	if .5*phi0+support.PiOverFour < 1e-10 {
+		en.K = 1.0 / sratVal
+	} else {
+		en.K = math.Tan(.5*chi+support.PiOverFour) /
+			(support.Pow(math.Tan(.5*phi0+support.PiOverFour), en.C) * sratVal)
+	}
+	return en, chi, rc, nil
+}
+
+// forward returns the point on the sphere of the point on the ellipsoid
+func (en *gauss) forward(elp *core.CoordLP) *core.CoordLP {
+	return &core.CoordLP{
+		Lam: en.C * elp.Lam,
+		Phi: 2.*math.Atan(en.K*
+			support.Pow(math.Tan(.5*elp.Phi+support.PiOverFour), en.C)*
+			srat(en.e*math.Sin(elp.Phi), en.ratexp)) - support.PiOverTwo,
+	}
+}
+
+// inverse returns the point on the ellipsoid of the point on the sphere
+func (en *gauss) inverse(slp *core.CoordLP) (*core.CoordLP, error) {
+	elp := &core.CoordLP{Lam: slp.Lam / en.C, Phi: 0.0}
+
+	num := support.Pow(math.Tan(.5*slp.Phi+support.PiOverFour)/en.K, 1./en.C)
+	phi := slp.Phi
+	for i := gaussMaxIter; i > 0; i-- {
+		elp.Phi = 2.*math.Atan(num*srat(en.e*math.Sin(phi), -.5*en.e)) - support.PiOverTwo
+		if math.Abs(elp.Phi-phi) < gaussDelTol {
+			return elp, nil
+		}
+		phi = elp.Phi
+	}
+	return elp, merror.New(merror.ToleranceCondition)
+}
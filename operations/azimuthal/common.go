@@ -5,7 +5,7 @@
 // is provided under the MIT-style license in `LICENSE.md` and may
 // additionally be subject to the copyrights of the PROJ.4 authors.
 
-package operations
+package azimuthal
 
 type mode int
 
@@ -16,8 +16,4 @@ const (
 	modeObliq mode = 3
 )
 
-const tol7 = 1.e-7
-const tol10 = 1.0e-10
-
-const eps7 = 1.0e-7
 const eps10 = 1.e-10
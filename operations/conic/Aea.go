@@ -5,10 +5,11 @@
 // is provided under the MIT-style license in `LICENSE.md` and may
 // additionally be subject to the copyrights of the PROJ.4 authors.
 
-package operations
+package conic
 
 import (
 	"math"
+	"math/big"
 
 	"github.com/oahumap/proj/core"
 	"github.com/oahumap/proj/merror"
@@ -25,6 +26,14 @@ func init() {
 		"Lambert Equal Area Conic",
 		"\n\tConic, Sph&Ell\n\tlat_1= south",
 		NewLeac)
+	core.RegisterParameters("aea",
+		core.Parameter{Name: "lat_1", Type: core.ParameterAngle, Unit: "degrees", Default: "0", Description: "first standard parallel"},
+		core.Parameter{Name: "lat_2", Type: core.ParameterAngle, Unit: "degrees", Default: "0", Description: "second standard parallel"},
+	)
+	core.RegisterParameters("leac",
+		core.Parameter{Name: "lat_1", Type: core.ParameterAngle, Unit: "degrees", Default: "0", Description: "standard parallel"},
+		core.Parameter{Name: "south", Type: core.ParameterFlag, Description: "put the apex at the south pole"},
+	)
 }
 
 // Aea implements core.IOperation and core.ConvertLPToXY
@@ -43,6 +52,8 @@ type Aea struct {
 	rho    float64
 	phi1   float64
 	phi2   float64
+	lat1   float64 // the standard parallels, in degrees, as given
+	lat2   float64
 	en     []float64
 	ellips bool
 }
@@ -95,7 +106,7 @@ func phi1(qs, Te, tOneEs float64) float64 {
 		con = Te * sinpi
 		com = 1. - con*con
 		dphi = .5 * com * com / cospi * (qs/tOneEs -
-			sinpi/com + .5/Te*math.Log((1.-con)/
+			sinpi/com + .5/Te*support.Log((1.-con)/
 			(1.+con)))
 		Phi += dphi
 		i--
@@ -139,8 +150,11 @@ func (op *Aea) setup(sys *core.System) error {
 			}
 
 			op.n = (m1*m1 - m2*m2) / (ml2 - ml1)
+			if sys.HighPrecision {
+				op.n = op.aeaConeHP(sys)
+			}
 		}
-		op.ec = 1. - .5*sys.Ellipsoid.OneEs*math.Log((1.-sys.Ellipsoid.E)/
+		op.ec = 1. - .5*sys.Ellipsoid.OneEs*support.Log((1.-sys.Ellipsoid.E)/
 			(1.+sys.Ellipsoid.E))/sys.Ellipsoid.E
 		op.c = m1*m1 + op.n*ml1
 		op.dd = 1. / op.n
@@ -190,7 +204,7 @@ func (op *Aea) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
 	PE := op.System.Ellipsoid
 
 	xy.Y = Q.rho0 - xy.Y
-	Q.rho = math.Hypot(xy.X, xy.Y)
+	Q.rho = support.Hypot(xy.X, xy.Y)
 	if Q.rho != 0.0 {
 		if Q.n < 0. {
 			Q.rho = -Q.rho
@@ -238,15 +252,17 @@ func (op *Aea) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
 
 func (op *Aea) aeaSetup(sys *core.System) error {
 
-	lat1, ok := sys.ProjString.GetAsFloat("lat_1")
-	if !ok {
-		lat1 = 0.0
+	lat1, _, err := sys.ProjString.GetAsDegrees("lat_1")
+	if err != nil {
+		return err
 	}
-	lat2, ok := sys.ProjString.GetAsFloat("lat_2")
-	if !ok {
-		lat2 = 0.0
+	lat2, _, err := sys.ProjString.GetAsDegrees("lat_2")
+	if err != nil {
+		return err
 	}
 
+	op.lat1 = lat1
+	op.lat2 = lat2
 	op.phi1 = support.DDToR(lat1)
 	op.phi2 = support.DDToR(lat2)
 
@@ -255,19 +271,36 @@ func (op *Aea) aeaSetup(sys *core.System) error {
 
 func (op *Aea) leacSetup(sys *core.System) error {
 
-	lat1, ok := sys.ProjString.GetAsFloat("lat_1")
-	if !ok {
-		lat1 = 0.0
+	lat1, _, err := sys.ProjString.GetAsDegrees("lat_1")
+	if err != nil {
+		return err
 	}
 
-	south := -support.PiOverTwo
-	_, ok = sys.ProjString.GetAsInt("south")
-	if !ok {
-		south = support.PiOverTwo
+	south := 90.0
+	if isSouth, _ := sys.ProjString.GetAsBool("south"); isSouth {
+		south = -90.0
 	}
 
+	op.lat1 = south
+	op.lat2 = lat1
+	op.phi1 = support.DDToR(south)
 	op.phi2 = support.DDToR(lat1)
-	op.phi1 = south
 
 	return op.setup(sys)
 }
+
+// aeaConeHP returns the cone constant of the secant cone, in extended
+// precision: both differences lose as many digits as the standard
+// parallels have in common
+func (op *Aea) aeaConeHP(sys *core.System) float64 {
+	PE := sys.Ellipsoid
+	sin1, cos1 := support.BigSinCos(support.BigDDToR(op.lat1))
+	sin2, cos2 := support.BigSinCos(support.BigDDToR(op.lat2))
+
+	m1 := support.BigMsfn(sin1, cos1, PE.Es)
+	m2 := support.BigMsfn(sin2, cos2, PE.Es)
+	num := new(big.Float).Sub(m1.Mul(m1, m1), m2.Mul(m2, m2))
+	den := new(big.Float).Sub(support.BigQsfn(sin2, PE.E, PE.OneEs), support.BigQsfn(sin1, PE.E, PE.OneEs))
+	n, _ := num.Quo(num, den).Float64()
+	return n
+}
@@ -0,0 +1,228 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package conic
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/merror"
+	"github.com/oahumap/proj/support"
+)
+
+func init() {
+	core.RegisterConvertLPToXY("lcc",
+		"Lambert Conic Conformal (LCC)",
+		"\n\tConic, Sph&Ell\n\tlat_1= and lat_2= or lat_0",
+		NewLCC,
+	)
+	core.RegisterParameters("lcc",
+		core.Parameter{Name: "lat_1", Type: core.ParameterAngle, Unit: "degrees", Default: "0", Description: "first standard parallel"},
+		core.Parameter{Name: "lat_2", Type: core.ParameterAngle, Unit: "degrees", Default: "lat_1", Description: "second standard parallel"},
+	)
+}
+
+// LCCIterationEpsilon is the tolerance for the latitude iteration of the
+// LCC inverse, in radians
+const LCCIterationEpsilon = 1e-12
+
+// LCCMaxIterations bounds the latitude iteration of the LCC inverse
+const LCCMaxIterations = 15
+
+// LCC implements core.IOperation and core.ConvertLPToXY
+type LCC struct {
+	core.Operation
+
+	n    float64 // scale factor of the cone
+	F    float64 // cone constant
+	rho0 float64 // radius at the origin parallel
+	phi0 float64 // latitude of origin
+	phi1 float64 // first standard parallel
+	phi2 float64 // second standard parallel
+}
+
+// NewLCC returns a new LCC
+func NewLCC(system *core.System, desc *core.OperationDescription) (core.IConvertLPToXY, error) {
+	op := &LCC{}
+	op.System = system
+
+	err := op.lccSetup(system)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// Forward Operation
+func (op *LCC) Forward(lp *core.CoordLP) (*core.CoordXY, error) {
+	xy := &core.CoordXY{X: 0.0, Y: 0.0}
+	var rho float64
+
+	if math.Abs(math.Abs(lp.Phi)-support.PiOverTwo) < eps10 {
+		// the pole at the apex of the cone maps to a point, the other
+		// one to infinity
+		if lp.Phi*op.n <= 0.0 {
+			return xy, merror.New(merror.ToleranceCondition)
+		}
+		rho = 0.0
+	} else {
+		t := support.Tsfn(lp.Phi, math.Sin(lp.Phi), op.System.Ellipsoid.E)
+		rho = op.F * support.Pow(t, op.n)
+	}
+
+	// the longitude is relative to lon_0 already, and the offsets are
+	// applied by the caller, as in PROJ
+	theta := op.n * lp.Lam
+	xy.X = op.System.K0 * (rho * math.Sin(theta))
+	xy.Y = op.System.K0 * (op.rho0 - rho*math.Cos(theta))
+
+	return xy, nil
+}
+
+// Inverse Operation
+func (op *LCC) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
+	lp, _, _, err := op.InverseWithConvergence(xy)
+	return lp, err
+}
+
+// InverseWithConvergence implements core.IIterativeInverse
+func (op *LCC) InverseWithConvergence(xy *core.CoordXY) (*core.CoordLP, int, float64, error) {
+	deltaE := xy.X / op.System.K0
+	deltaN := op.rho0 - xy.Y/op.System.K0
+
+	rPrime := support.Hypot(deltaE, deltaN)
+	if rPrime == 0.0 {
+		// the apex of the cone
+		lp := &core.CoordLP{Lam: 0.0, Phi: support.PiOverTwo}
+		if op.n < 0.0 {
+			lp.Phi = -lp.Phi
+		}
+		return lp, 0, 0.0, nil
+	}
+
+	// a south-oriented cone opens the other way: rho, and the direction
+	// it is measured in, are negated
+	if op.n < 0.0 {
+		rPrime = -rPrime
+		deltaE = -deltaE
+		deltaN = -deltaN
+	}
+
+	tPrime := support.Pow(rPrime/op.F, 1.0/op.n)
+	lon := math.Atan2(deltaE, deltaN) / op.n
+
+	e := op.System.Ellipsoid.E
+	lat := support.PiOverTwo - 2.0*math.Atan(tPrime)
+	converged := false
+	iterations, residual := 0, 0.0
+	for iterations < LCCMaxIterations {
+		iterations++
+
+		esinphi := e * math.Sin(lat)
+		latNew := support.PiOverTwo - 2.0*math.Atan(tPrime*support.Pow((1.0-esinphi)/(1.0+esinphi), e/2.0))
+
+		residual = math.Abs(latNew - lat)
+		lat = latNew
+		if residual < LCCIterationEpsilon {
+			converged = true
+			break
+		}
+	}
+	if !converged {
+		return nil, iterations, residual, merror.NewConvergenceError("lcc", iterations, residual)
+	}
+
+	return &core.CoordLP{Phi: lat, Lam: lon}, iterations, residual, nil
+}
+
+// Cone returns the constants of the cone: the cone constant n, F, and the
+// radius rho0 of the parallel of origin, in radii, as in Snyder's
+// formulas (15-1) to (15-3)
+func (op *LCC) Cone() (n, F, rho0 float64) {
+	return op.n, op.F, op.rho0
+}
+
+func (op *LCC) lccSetup(sys *core.System) error {
+	phi0, _, err := sys.ProjString.GetAsDegrees("lat_0")
+	if err != nil {
+		return err
+	}
+	phi1, _, err := sys.ProjString.GetAsDegrees("lat_1")
+	if err != nil {
+		return err
+	}
+	phi2, ok2, err := sys.ProjString.GetAsDegrees("lat_2")
+	if err != nil {
+		return err
+	}
+	if !ok2 {
+		phi2 = phi1
+	}
+
+	op.phi0 = support.DDToR(phi0)
+	op.phi1 = support.DDToR(phi1)
+	op.phi2 = support.DDToR(phi2)
+
+	PE := sys.Ellipsoid
+
+	// the cone would be a cylinder
+	if math.Abs(op.phi1+op.phi2) < eps10 {
+		return merror.New(merror.ConicLatEqual)
+	}
+
+	pole1 := math.Abs(math.Abs(op.phi1)-support.PiOverTwo) < eps10
+	pole2 := math.Abs(math.Abs(op.phi2)-support.PiOverTwo) < eps10
+	if pole1 != pole2 {
+		// a secant cone can't touch the sphere at a point
+		return merror.New(merror.ConicLatEqual)
+	}
+
+	m1 := support.Msfn(math.Sin(op.phi1), math.Cos(op.phi1), PE.Es)
+	t1 := support.Tsfn(op.phi1, math.Sin(op.phi1), PE.E)
+	if pole1 {
+		// tangent at a pole: the cone is a plane, and the limit of the
+		// formulas below is the polar stereographic
+		op.n = math.Copysign(1.0, op.phi1)
+		op.F = op.n * 2.0 / math.Sqrt(support.Pow(1.0+PE.E, 1.0+PE.E)*support.Pow(1.0-PE.E, 1.0-PE.E))
+	} else if math.Abs(op.phi1-op.phi2) >= eps10 && sys.HighPrecision {
+		op.n = lccConeHP(phi1, phi2, PE.Es, PE.E)
+	} else if math.Abs(op.phi1-op.phi2) >= eps10 {
+		m2 := support.Msfn(math.Sin(op.phi2), math.Cos(op.phi2), PE.Es)
+		t2 := support.Tsfn(op.phi2, math.Sin(op.phi2), PE.E)
+		op.n = support.Log(m1/m2) / support.Log(t1/t2)
+	} else {
+		// tangent: the ratio above is 0/0
+		op.n = math.Sin(op.phi1)
+	}
+	if !pole1 {
+		op.F = m1 / (op.n * support.Pow(t1, op.n))
+	}
+
+	if math.Abs(math.Abs(op.phi0)-support.PiOverTwo) < eps10 {
+		op.rho0 = 0.0
+	} else {
+		t0 := support.Tsfn(op.phi0, math.Sin(op.phi0), PE.E)
+		op.rho0 = op.F * support.Pow(t0, op.n)
+	}
+
+	return nil
+}
+
+// lccConeHP returns the cone constant of the secant cone through the
+// standard parallels, given in degrees, in extended precision: the logs
+// of the ratios lose as many digits as the parallels have in common
+func lccConeHP(lat1, lat2, es, e float64) float64 {
+	sin1, cos1 := support.BigSinCos(support.BigDDToR(lat1))
+	sin2, cos2 := support.BigSinCos(support.BigDDToR(lat2))
+
+	m := new(big.Float).Quo(support.BigMsfn(sin1, cos1, es), support.BigMsfn(sin2, cos2, es))
+	t := new(big.Float).Quo(support.BigTsfn(sin1, cos1, e), support.BigTsfn(sin2, cos2, e))
+	n, _ := new(big.Float).Quo(support.BigLog(m), support.BigLog(t)).Float64()
+	return n
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package cylindrical
+
+import (
+	"math"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/merror"
+	"github.com/oahumap/proj/support"
+)
+
+func init() {
+	core.RegisterConvertLPToXY("cea",
+		"Equal Area Cylindrical",
+		"\n\tCyl, Sph&Ell\n\tlat_ts=",
+		NewCea,
+	)
+	core.RegisterParameters("cea",
+		core.Parameter{Name: "lat_ts", Type: core.ParameterAngle, Unit: "degrees", Description: "latitude of true scale; overrides k_0"},
+	)
+}
+
+// Cea implements core.IOperation and core.ConvertLPToXY
+//
+// With +lat_ts=45 this is Gall-Peters, and with +lat_ts=30 on WGS 84 it
+// is the EASE-Grid 2.0 global projection. On the ellipsoid, y is taken
+// from the authalic latitude, so that areas are kept exactly.
+type Cea struct {
+	core.Operation
+	isSphere bool
+	qp       float64   // q at the pole
+	apa      []float64 // for the authalic latitude's inverse
+}
+
+// NewCea returns a new Cea
+func NewCea(system *core.System, desc *core.OperationDescription) (core.IConvertLPToXY, error) {
+	op := &Cea{}
+	op.System = system
+
+	err := op.ceaSetup(system)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// Forward goes forewards
+func (op *Cea) Forward(lp *core.CoordLP) (*core.CoordXY, error) {
+
+	if op.isSphere {
+		return op.sphericalForward(lp)
+	}
+	return op.ellipsoidalForward(lp)
+}
+
+// Inverse goes backwards
+func (op *Cea) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
+
+	if op.isSphere {
+		return op.sphericalInverse(xy)
+	}
+	return op.ellipsoidalInverse(xy)
+}
+
+//---------------------------------------------------------------------
+
+func (op *Cea) ellipsoidalForward(lp *core.CoordLP) (*core.CoordXY, error) { /* Ellipsoidal, forward */
+	xy := &core.CoordXY{X: 0.0, Y: 0.0}
+
+	P := op.System
+	PE := op.System.Ellipsoid
+
+	xy.X = P.K0 * lp.Lam
+	xy.Y = 0.5 * support.Qsfn(math.Sin(lp.Phi), PE.E, PE.OneEs) / P.K0
+	return xy, nil
+}
+
+func (op *Cea) sphericalForward(lp *core.CoordLP) (*core.CoordXY, error) { /* Spheroidal, forward */
+	xy := &core.CoordXY{X: 0.0, Y: 0.0}
+
+	P := op.System
+
+	xy.X = P.K0 * lp.Lam
+	xy.Y = math.Sin(lp.Phi) / P.K0
+	return xy, nil
+}
+
+func (op *Cea) ellipsoidalInverse(xy *core.CoordXY) (*core.CoordLP, error) { /* Ellipsoidal, inverse */
+	lp := &core.CoordLP{Lam: 0.0, Phi: 0.0}
+
+	P := op.System
+
+	t := 2.0 * xy.Y * P.K0 / op.qp
+	if math.Abs(t) > 1.0+eps10 {
+		return nil, merror.New(merror.ToleranceCondition)
+	}
+	if math.Abs(t) > 1.0 {
+		t = math.Copysign(1.0, t)
+	}
+	lp.Phi = support.Authlat(math.Asin(t), op.apa)
+	lp.Lam = xy.X / P.K0
+	return lp, nil
+}
+
+func (op *Cea) sphericalInverse(xy *core.CoordXY) (*core.CoordLP, error) { /* Spheroidal, inverse */
+	lp := &core.CoordLP{Lam: 0.0, Phi: 0.0}
+
+	P := op.System
+
+	y := xy.Y * P.K0
+	t := math.Abs(y)
+	if t-eps10 > 1.0 {
+		return nil, merror.New(merror.ToleranceCondition)
+	}
+	if t >= 1.0 {
+		lp.Phi = math.Copysign(support.PiOverTwo, y)
+	} else {
+		lp.Phi = math.Asin(y)
+	}
+	lp.Lam = xy.X / P.K0
+	return lp, nil
+}
+
+func (op *Cea) ceaSetup(sys *core.System) error {
+	P := op.System
+	PE := op.System.Ellipsoid
+
+	var t float64
+	phits, isPhits, err := sys.ProjString.GetAsDegrees("lat_ts")
+	if err != nil {
+		return err
+	}
+	if isPhits {
+		t = support.DDToR(phits)
+		if math.Abs(t) >= support.PiOverTwo {
+			return merror.New(merror.LatTSLargerThan90)
+		}
+		P.K0 = math.Cos(t)
+	}
+
+	if PE.Es != 0.0 { /* ellipsoid */
+		op.isSphere = false
+		if isPhits {
+			t = math.Sin(t)
+			P.K0 /= math.Sqrt(1.0 - PE.Es*t*t)
+		}
+		op.apa = support.Authset(PE.Es)
+		op.qp = support.Qsfn(1.0, PE.E, PE.OneEs)
+	} else { /* sphere */
+		op.isSphere = true
+	}
+
+	return nil
+}
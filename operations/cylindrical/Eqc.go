@@ -1,10 +1,11 @@
-package operations
+package cylindrical
 
 import (
 	"math"
 
 	"github.com/oahumap/proj/core"
 	"github.com/oahumap/proj/merror"
+	"github.com/oahumap/proj/support"
 )
 
 func init() {
@@ -13,9 +14,15 @@ func init() {
 		"\n\tCyl, Sph\n\tlat_ts=[, lat_0=0]",
 		NewEqc,
 	)
+	core.RegisterParameters("eqc",
+		core.Parameter{Name: "lat_ts", Type: core.ParameterAngle, Unit: "degrees", Default: "0", Description: "latitude of true scale"},
+	)
 }
 
 // Eqc implements core.IOperation and core.ConvertLPToXY
+//
+// Unlike merc, eqc is not singular at the poles, which simply map to
+// lines of constant y, so the system's pole policy does not apply.
 type Eqc struct {
 	core.Operation
 	rc float64
@@ -66,8 +73,12 @@ func (op *Eqc) spheroidalReverse(xy *core.CoordXY) (*core.CoordLP, error) { /* E
 }
 
 func (op *Eqc) eqcSetup(sys *core.System) error {
-	latts, _ := sys.ProjString.GetAsFloat("lat_ts")
-	if math.Cos(latts) <= 0 {
+	latts, _, err := sys.ProjString.GetAsDegrees("lat_ts")
+	if err != nil {
+		return err
+	}
+	latts = support.DDToR(latts)
+	if math.Abs(latts) >= support.PiOverTwo {
 		return merror.New(merror.LatTSLargerThan90)
 	}
 	op.rc = math.Cos(latts)
@@ -5,7 +5,7 @@
 // is provided under the MIT-style license in `LICENSE.md` and may
 // additionally be subject to the copyrights of the PROJ.4 authors.
 
-package operations
+package cylindrical
 
 import (
 	"math"
@@ -21,11 +21,25 @@ func init() {
 		"\n\tCyl, Sph\n\tzone= south",
 		NewUtm,
 	)
+	core.RegisterParameters("utm",
+		core.Parameter{Name: "zone", Type: core.ParameterInt, Description: "UTM zone, 1 to 60"},
+		core.Parameter{Name: "south", Type: core.ParameterFlag, Description: "southern hemisphere"},
+		core.Parameter{Name: "approx", Type: core.ParameterFlag, Description: "use the faster Evenden/Snyder series, as for tmerc"},
+		core.Parameter{Name: "exact", Type: core.ParameterFlag, Description: "use the slower exact projection, as for tmerc"},
+	)
 	core.RegisterConvertLPToXY("etmerc",
 		"Extended Transverse Mercator (UTM)",
 		"\n\tCyl, Sph\n\tlat_ts=(0)\nlat_0=(0)",
 		NewEtMerc,
 	)
+	core.RegisterParameters("etmerc",
+		core.Parameter{Name: "exact", Type: core.ParameterFlag, Description: "use the slower exact projection, as for tmerc"},
+	)
+
+	// the series expansions degrade quickly beyond this
+	domain := core.Domain{MinLam: -45.0, MaxLam: 45.0, MinPhi: -90.0, MaxPhi: 90.0}
+	core.RegisterDomain("utm", domain)
+	core.RegisterDomain("etmerc", domain)
 }
 
 // EtMerc implements core.IOperation and core.ConvertLPToXY
@@ -42,8 +56,12 @@ type EtMerc struct {
 	gtu [6]float64 /* Constants for geo -> transv. merc. */
 }
 
-// NewEtMerc returns a new EtMerc
+// NewEtMerc returns a new EtMerc, or with +exact a TMercExact
 func NewEtMerc(system *core.System, desc *core.OperationDescription) (core.IConvertLPToXY, error) {
+	if exact, _ := system.ProjString.GetAsBool("exact"); exact {
+		return newTMercExact(system)
+	}
+
 	op := &EtMerc{
 		isUtm: false,
 	}
@@ -56,14 +74,35 @@ func NewEtMerc(system *core.System, desc *core.OperationDescription) (core.IConv
 	return op, nil
 }
 
-// NewUtm returns a new EtMerc
+// NewUtm returns a new EtMerc, or with +approx a TMercApprox, or with
+// +exact a TMercExact
 func NewUtm(system *core.System, desc *core.OperationDescription) (core.IConvertLPToXY, error) {
+	exact, err := exactRequested(system)
+	if err != nil {
+		return nil, err
+	}
+	if exact {
+		err := utmSystem(system)
+		if err != nil {
+			return nil, err
+		}
+		return newTMercExact(system)
+	}
+
+	if approx, _ := system.ProjString.GetAsBool("approx"); approx {
+		err := utmSystem(system)
+		if err != nil {
+			return nil, err
+		}
+		return newTMercApprox(system)
+	}
+
 	op := &EtMerc{
 		isUtm: true,
 	}
 	op.System = system
 
-	err := op.utmSetup(system)
+	err = op.utmSetup(system)
 	if err != nil {
 		return nil, err
 	}
@@ -86,12 +125,12 @@ func log1py(x float64) float64 { /* Compute log(1+x) accurately */
 	if z == 0 {
 		return x
 	}
-	return x * math.Log(y) / z
+	return x * support.Log(y) / z
 }
 
 func asinhy(x float64) float64 { /* Compute asinh(x) accurately */
 	y := math.Abs(x) /* Enforce odd parity */
-	y = log1py(y * (1 + y/(math.Hypot(1.0, y)+1)))
+	y = log1py(y * (1 + y/(support.Hypot(1.0, y)+1)))
 	if x < 0 {
 		return -y
 	}
@@ -130,8 +169,8 @@ func clenS(a []float64, lenA int, argR float64, argI float64, R *float64, I *flo
 	/* arguments */
 	ai = lenA
 	sinArgR, cosArgR = math.Sincos(argR)
-	sinhArgI = math.Sinh(argI)
-	coshArgI = math.Cosh(argI)
+	sinhArgI = support.Sinh(argI)
+	coshArgI = support.Cosh(argI)
 	r = 2 * cosArgR * coshArgI
 	i = -2 * sinArgR * sinhArgI
 
@@ -199,19 +238,17 @@ func (op *EtMerc) Forward(lp *core.CoordLP) (*core.CoordXY, error) {
 	sinCe, cosCe = math.Sincos(Ce)
 
 	Cn = math.Atan2(sinCn, cosCe*cosCn)
-	Ce = math.Atan2(sinCe*cosCn, math.Hypot(sinCn, cosCn*cosCe))
+	Ce = math.Atan2(sinCe*cosCn, support.Hypot(sinCn, cosCn*cosCe))
 
 	/* compl. sph. N, E -> ell. norm. N, E */
 	Ce = asinhy(math.Tan(Ce)) /* Replaces: Ce  = log(tan(FORTPI + Ce*0.5)); */
 	Cn += clenS(Q.gtu[:], etmercOrder, 2*Cn, 2*Ce, &dCn, &dCe)
 	Ce += dCe
-	if math.Abs(Ce) <= 2.623395162778 {
-		xy.Y = Q.Qn*Cn + Q.Zb /* Northing */
-		xy.X = Q.Qn * Ce      /* Easting  */
-	} else {
-		xy.X = math.MaxFloat64
-		xy.Y = math.MaxFloat64
+	if math.Abs(Ce) > 2.623395162778 {
+		return xy, merror.New(merror.ToleranceCondition)
 	}
+	xy.Y = Q.Qn*Cn + Q.Zb /* Northing */
+	xy.X = Q.Qn * Ce      /* Easting  */
 	return xy, nil
 }
 
@@ -233,18 +270,17 @@ func (op *EtMerc) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
 		/* norm. N, E -> compl. sph. LAT, LNG */
 		Cn += clenS(Q.utg[:], etmercOrder, 2*Cn, 2*Ce, &dCn, &dCe)
 		Ce += dCe
-		Ce = math.Atan(math.Sinh(Ce)) /* Replaces: Ce = 2*(atan(exp(Ce)) - FORTPI); */
+		Ce = math.Atan(support.Sinh(Ce)) /* Replaces: Ce = 2*(atan(exp(Ce)) - FORTPI); */
 		/* compl. sph. LAT -> Gaussian LAT, LNG */
 		sinCn, cosCn = math.Sincos(Cn)
 		sinCe, cosCe = math.Sincos(Ce)
 		Ce = math.Atan2(sinCe, cosCe*cosCn)
-		Cn = math.Atan2(sinCn*cosCe, math.Hypot(sinCe, cosCe*cosCn))
+		Cn = math.Atan2(sinCn*cosCe, support.Hypot(sinCe, cosCe*cosCn))
 		/* Gaussian LAT, LNG -> ell. LAT, LNG */
 		lp.Phi = gatg(Q.cgb[:], etmercOrder, Cn)
 		lp.Lam = Ce
 	} else {
-		lp.Phi = math.MaxFloat64
-		lp.Lam = math.MaxFloat64
+		return lp, merror.New(merror.ToleranceCondition)
 	}
 	return lp, nil
 }
@@ -343,6 +379,16 @@ func (op *EtMerc) etmercSetup(sys *core.System) error {
 /* utm uses etmerc for the underlying projection */
 
 func (op *EtMerc) utmSetup(sys *core.System) error {
+	err := utmSystem(sys)
+	if err != nil {
+		return err
+	}
+	return op.setup(sys)
+}
+
+// utmSystem sets the system up for the zone: its central meridian, scale
+// and false easting and northing
+func utmSystem(sys *core.System) error {
 
 	if sys.Ellipsoid.Es == 0.0 {
 		return merror.New(merror.EllipsoidUseRequired)
@@ -352,7 +398,7 @@ func (op *EtMerc) utmSetup(sys *core.System) error {
 	}
 
 	sys.Y0 = 0.0
-	if sys.ProjString.ContainsKey("south") {
+	if south, _ := sys.ProjString.GetAsBool("south"); south {
 		sys.Y0 = 10000000.0
 	}
 	sys.X0 = 500000.0
@@ -376,5 +422,5 @@ func (op *EtMerc) utmSetup(sys *core.System) error {
 	sys.K0 = 0.9996
 	sys.Phi0 = 0.0
 
-	return op.setup(sys)
+	return nil
 }
@@ -0,0 +1,176 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package cylindrical
+
+import (
+	"math"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/merror"
+	"github.com/oahumap/proj/support"
+)
+
+func init() {
+	core.RegisterConvertLPToXY("labrd",
+		"Laborde",
+		"\n\tCyl, Sph\n\tSpecial for Madagascar\n\tlat_0= pm= k_0= azi=",
+		NewLabrd,
+	)
+	core.RegisterParameters("labrd",
+		core.Parameter{Name: "azi", Type: core.ParameterAngle, Unit: "degrees", Default: "0", Description: "azimuth of the central line"},
+	)
+}
+
+// Labrd implements core.IOperation and core.ConvertLPToXY
+//
+// Laborde's oblique Mercator, as used for the Madagascar grid: the
+// ellipsoid is mapped conformally onto a sphere tangent along lat_0,
+// and the series expansions of the projection are then rotated by azi.
+type Labrd struct {
+	core.Operation
+	kRg, p0s, A, C, Ca, Cb, Cc, Cd float64
+}
+
+// NewLabrd creates a new Laborde system
+func NewLabrd(system *core.System, desc *core.OperationDescription) (core.IConvertLPToXY, error) {
+	op := &Labrd{}
+	op.System = system
+
+	err := op.labrdSetup(system)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// Forward goes forewards
+func (op *Labrd) Forward(lp *core.CoordLP) (*core.CoordXY, error) {
+	xy := &core.CoordXY{X: 0.0, Y: 0.0}
+
+	PE := op.System.Ellipsoid
+
+	V1 := op.A * support.Log(math.Tan(support.PiOverFour+.5*lp.Phi))
+	t := PE.E * math.Sin(lp.Phi)
+	V2 := .5 * PE.E * op.A * support.Log((1.+t)/(1.-t))
+	ps := 2. * (math.Atan(support.Exp(V1-V2+op.C)) - support.PiOverFour)
+	I1 := ps - op.p0s
+	cosps := math.Cos(ps)
+	cosps2 := cosps * cosps
+	sinps := math.Sin(ps)
+	sinps2 := sinps * sinps
+	I4 := op.A * cosps
+	I2 := .5 * op.A * I4 * sinps
+	I3 := I2 * op.A * op.A * (5.*cosps2 - sinps2) / 12.
+	I6 := I4 * op.A * op.A
+	I5 := I6 * (cosps2 - sinps2) / 6.
+	I6 *= op.A * op.A * (5.*cosps2*cosps2 + sinps2*(sinps2-18.*cosps2)) / 120.
+	t = lp.Lam * lp.Lam
+	xy.X = op.kRg * lp.Lam * (I4 + t*(I5+t*I6))
+	xy.Y = op.kRg * (I1 + t*(I2+t*I3))
+
+	x2 := xy.X * xy.X
+	y2 := xy.Y * xy.Y
+	V1 = 3.*xy.X*y2 - xy.X*x2
+	V2 = xy.Y*y2 - 3.*x2*xy.Y
+	xy.X += op.Ca*V1 + op.Cb*V2
+	xy.Y += op.Ca*V2 - op.Cb*V1
+	return xy, nil
+}
+
+// Inverse goes backwards
+func (op *Labrd) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
+	lp := &core.CoordLP{Lam: 0.0, Phi: 0.0}
+
+	P := op.System
+	PE := op.System.Ellipsoid
+
+	x, y := xy.X, xy.Y
+	x2 := x * x
+	y2 := y * y
+	V1 := 3.*x*y2 - x*x2
+	V2 := y*y2 - 3.*x2*y
+	V3 := x * (5.*y2*y2 + x2*(-10.*y2+x2))
+	V4 := y * (5.*x2*x2 + y2*(-10.*x2+y2))
+	x += -op.Ca*V1 - op.Cb*V2 + op.Cc*V3 + op.Cd*V4
+	y += op.Cb*V1 - op.Ca*V2 - op.Cd*V3 + op.Cc*V4
+
+	ps := op.p0s + y/op.kRg
+	pe := ps + P.Phi0 - op.p0s
+
+	converged := false
+	for i := 20; i > 0; i-- {
+		V1 = op.A * support.Log(math.Tan(support.PiOverFour+.5*pe))
+		tpe := PE.E * math.Sin(pe)
+		V2 = .5 * PE.E * op.A * support.Log((1.+tpe)/(1.-tpe))
+		t := ps - 2.*(math.Atan(support.Exp(V1-V2+op.C))-support.PiOverFour)
+		pe += t
+		if math.Abs(t) < eps10 {
+			converged = true
+			break
+		}
+	}
+	if !converged {
+		return lp, merror.New(merror.NonConvergence)
+	}
+
+	t := PE.E * math.Sin(pe)
+	t = 1. - t*t
+	Re := PE.OneEs / (t * math.Sqrt(t))
+	t = math.Tan(ps)
+	t2 := t * t
+	s := op.kRg * op.kRg
+	d := Re * P.K0 * op.kRg
+	I7 := t / (2. * d)
+	I8 := t * (5. + 3.*t2) / (24. * d * s)
+	d = math.Cos(ps) * op.kRg * op.A
+	I9 := 1. / d
+	d *= s
+	I10 := (1. + 2.*t2) / (6. * d)
+	I11 := (5. + t2*(28.+24.*t2)) / (120. * d * s)
+	x2 = x * x
+	lp.Phi = pe + x2*(-I7+I8*x2)
+	lp.Lam = x * (I9 + x2*(-I10+x2*I11))
+	return lp, nil
+}
+
+//---------------------------------------------------------------------
+
+func (op *Labrd) labrdSetup(sys *core.System) error {
+	PE := sys.Ellipsoid
+
+	if sys.Phi0 == 0.0 {
+		return merror.New(merror.Lat0IsZero)
+	}
+
+	azi, _, err := sys.ProjString.GetAsDegrees("azi")
+	if err != nil {
+		return err
+	}
+	azi = support.DDToR(azi)
+
+	sinp := math.Sin(sys.Phi0)
+	t := 1. - PE.Es*sinp*sinp
+	N := 1. / math.Sqrt(t)
+	R := PE.OneEs * N / t
+	op.kRg = sys.K0 * math.Sqrt(N*R)
+	op.p0s = math.Atan(math.Sqrt(R/N) * math.Tan(sys.Phi0))
+	op.A = sinp / math.Sin(op.p0s)
+	t = PE.E * sinp
+	op.C = .5*PE.E*op.A*support.Log((1.+t)/(1.-t)) -
+		op.A*support.Log(math.Tan(support.PiOverFour+.5*sys.Phi0)) +
+		support.Log(math.Tan(support.PiOverFour+.5*op.p0s))
+
+	t = azi + azi
+	op.Cb = 1. / (12. * op.kRg * op.kRg)
+	op.Ca = (1. - math.Cos(t)) * op.Cb
+	op.Cb *= math.Sin(t)
+	op.Cc = 3. * (op.Ca*op.Ca - op.Cb*op.Cb)
+	op.Cd = 6. * op.Ca * op.Cb
+
+	return nil
+}
@@ -5,7 +5,7 @@
 // is provided under the MIT-style license in `LICENSE.md` and may
 // additionally be subject to the copyrights of the PROJ.4 authors.
 
-package operations
+package cylindrical
 
 import (
 	"math"
@@ -21,12 +21,30 @@ func init() {
 		"\n\tCyl, Sph&Ell\n\tlat_ts=",
 		NewMerc,
 	)
+	core.RegisterParameters("merc",
+		core.Parameter{Name: "lat_ts", Type: core.ParameterAngle, Unit: "degrees", Description: "latitude of true scale; overrides k_0"},
+		core.Parameter{Name: "lat_max", Type: core.ParameterAngle, Unit: "degrees", Description: "latitude beyond which the pole policy applies"},
+	)
 }
 
+// MercMaxLat is the latitude (radians) at which the spherical Mercator
+// becomes square, i.e. the limit of Web Mercator: atan(sinh(pi)), about
+// 85.0511 degrees. Under core.PoleClamp, latitudes nearer the poles are
+// clamped to it, unless +lat_max is given.
+var MercMaxLat = math.Atan(support.Sinh(math.Pi))
+
 // Merc implements core.IOperation and core.ConvertLPToXY
+//
+// Without +lat_max, only the poles themselves are singular: 89.9999
+// degrees projects to a northing of some 70,000 km. With +lat_max,
+// latitudes beyond it are treated as the poles are, i.e. they fail
+// under core.PoleError, project to infinity under core.PoleInfinity,
+// and are clamped to +lat_max under core.PoleClamp. Tiling wants a
+// cutoff of about 85 degrees, science usually none.
 type Merc struct {
 	core.Operation
 	isSphere bool
+	maxLat   float64 // radians; zero if there's no +lat_max
 }
 
 // NewMerc returns a new Merc
@@ -63,17 +81,53 @@ func (op *Merc) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
 
 //---------------------------------------------------------------------
 
+// atPole applies the system's pole policy: it returns the latitude to
+// project, or infinite if the northing should be infinite
+func (op *Merc) atPole(phi float64) (float64, bool, error) {
+	atPole := math.Abs(math.Abs(phi)-support.PiOverTwo) <= eps10
+	beyond := op.maxLat != 0.0 && math.Abs(phi) > op.maxLat
+
+	switch op.System.PolePolicy {
+	case core.PoleClamp:
+		limit := MercMaxLat
+		if op.maxLat != 0.0 {
+			limit = op.maxLat
+		}
+		if math.Abs(phi) > limit {
+			return math.Copysign(limit, phi), false, nil
+		}
+	case core.PoleInfinity:
+		if atPole || beyond {
+			return phi, true, nil
+		}
+	default:
+		if atPole {
+			return phi, false, merror.New(merror.ToleranceCondition)
+		}
+		if beyond {
+			return phi, false, merror.New(merror.LatOrLonExceededLimit)
+		}
+	}
+
+	return phi, false, nil
+}
+
 func (op *Merc) ellipsoidalForward(lp *core.CoordLP) (*core.CoordXY, error) { /* Ellipsoidal, forward */
 	xy := &core.CoordXY{X: 0.0, Y: 0.0}
 
 	P := op.System
 	PE := op.System.Ellipsoid
 
-	if math.Abs(math.Abs(lp.Phi)-support.PiOverTwo) <= eps10 {
-		return xy, merror.New(merror.ToleranceCondition)
+	phi, infinite, err := op.atPole(lp.Phi)
+	if err != nil {
+		return xy, err
 	}
 	xy.X = P.K0 * lp.Lam
-	xy.Y = -P.K0 * math.Log(support.Tsfn(lp.Phi, math.Sin(lp.Phi), PE.E))
+	if infinite {
+		xy.Y = math.Copysign(math.Inf(1), phi)
+		return xy, nil
+	}
+	xy.Y = -P.K0 * support.Log(support.Tsfn(phi, math.Sin(phi), PE.E))
 	return xy, nil
 }
 
@@ -82,11 +136,16 @@ func (op *Merc) sphericalForward(lp *core.CoordLP) (*core.CoordXY, error) { /* S
 
 	P := op.System
 
-	if math.Abs(math.Abs(lp.Phi)-support.PiOverTwo) <= eps10 {
-		return xy, merror.New(merror.ToleranceCondition)
+	phi, infinite, err := op.atPole(lp.Phi)
+	if err != nil {
+		return xy, err
 	}
 	xy.X = P.K0 * lp.Lam
-	xy.Y = P.K0 * math.Log(math.Tan(support.PiOverFour+.5*lp.Phi))
+	if infinite {
+		xy.Y = math.Copysign(math.Inf(1), phi)
+		return xy, nil
+	}
+	xy.Y = P.K0 * support.Log(math.Tan(support.PiOverFour+.5*phi))
 	return xy, nil
 }
 
@@ -97,7 +156,7 @@ func (op *Merc) ellipsoidalInverse(xy *core.CoordXY) (*core.CoordLP, error) { /*
 	PE := op.System.Ellipsoid
 	var err error
 
-	lp.Phi, err = support.Phi2(math.Exp(-xy.Y/P.K0), PE.E)
+	lp.Phi, err = support.Phi2(support.Exp(-xy.Y/P.K0), PE.E)
 	if err != nil {
 		return nil, err
 	}
@@ -113,7 +172,7 @@ func (op *Merc) sphericalInverse(xy *core.CoordXY) (*core.CoordLP, error) { /* S
 
 	P := op.System
 
-	lp.Phi = support.PiOverTwo - 2.*math.Atan(math.Exp(-xy.Y/P.K0))
+	lp.Phi = support.PiOverTwo - 2.*math.Atan(support.Exp(-xy.Y/P.K0))
 	lp.Lam = xy.X / P.K0
 	return lp, nil
 }
@@ -121,9 +180,11 @@ func (op *Merc) sphericalInverse(xy *core.CoordXY) (*core.CoordLP, error) { /* S
 func (op *Merc) mercSetup(sys *core.System) error {
 	var phits float64
 
-	isPhits := sys.ProjString.ContainsKey("lat_ts")
+	phits, isPhits, err := sys.ProjString.GetAsDegrees("lat_ts")
+	if err != nil {
+		return err
+	}
 	if isPhits {
-		phits, _ = sys.ProjString.GetAsFloat("lat_ts")
 		phits = support.DDToR(phits)
 		phits = math.Abs(phits)
 		if phits >= support.PiOverTwo {
@@ -131,6 +192,18 @@ func (op *Merc) mercSetup(sys *core.System) error {
 		}
 	}
 
+	maxLat, ok, err := sys.ProjString.GetAsDegrees("lat_max")
+	if err != nil {
+		return err
+	}
+	if ok {
+		maxLat = support.DDToR(math.Abs(maxLat))
+		if maxLat == 0.0 || maxLat > support.PiOverTwo {
+			return merror.New(merror.LatOrLonExceededLimit)
+		}
+		op.maxLat = maxLat
+	}
+
 	P := op.System
 	PE := op.System.Ellipsoid
 
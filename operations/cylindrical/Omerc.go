@@ -0,0 +1,277 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package cylindrical
+
+import (
+	"math"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/merror"
+	"github.com/oahumap/proj/support"
+)
+
+func init() {
+	core.RegisterConvertLPToXY("omerc",
+		"Oblique Mercator",
+		"\n\tCyl, Sph&Ell no_rot\n\talpha= [gamma=] [no_off] lonc= or\n\tlon_1= lat_1= lon_2= lat_2=",
+		NewOmerc,
+	)
+	core.RegisterParameters("omerc",
+		core.Parameter{Name: "alpha", Type: core.ParameterAngle, Unit: "degrees", Description: "azimuth of the central line at the center"},
+		core.Parameter{Name: "gamma", Type: core.ParameterAngle, Unit: "degrees", Description: "rotation of the rectified grid; defaults to alpha"},
+		core.Parameter{Name: "lonc", Type: core.ParameterAngle, Unit: "degrees", Description: "longitude of the center"},
+		core.Parameter{Name: "no_off", Type: core.ParameterFlag, Description: "put the origin at the natural origin, not the center; with alpha or gamma"},
+		core.Parameter{Name: "no_uoff", Type: core.ParameterFlag, Description: "same as no_off"},
+		core.Parameter{Name: "lon_1", Type: core.ParameterAngle, Unit: "degrees", Description: "longitude of the first point on the central line, without alpha or gamma"},
+		core.Parameter{Name: "lat_1", Type: core.ParameterAngle, Unit: "degrees", Description: "latitude of the first point on the central line"},
+		core.Parameter{Name: "lon_2", Type: core.ParameterAngle, Unit: "degrees", Description: "longitude of the second point on the central line"},
+		core.Parameter{Name: "lat_2", Type: core.ParameterAngle, Unit: "degrees", Description: "latitude of the second point on the central line"},
+		core.Parameter{Name: "no_rot", Type: core.ParameterFlag, Description: "don't rotate the grid: output the (u, v) coordinates of the central line"},
+	)
+}
+
+// Omerc implements core.IOperation and core.ConvertLPToXY
+//
+// The central line is given either by its azimuth (alpha) at the point
+// (lonc, lat_0), or by two points on it (lon_1/lat_1, lon_2/lat_2). The
+// rectified grid is rotated by gamma, which defaults to alpha; no_rot
+// leaves it unrotated, in the (u, v) coordinates of the central line.
+// With alpha or gamma, no_off (or no_uoff) puts the origin at the natural
+// origin of the central line, rather than at the center (lonc, lat_0).
+type Omerc struct {
+	core.Operation
+
+	A, B, E, AB, ArB, BrA, rB      float64
+	singam, cosgam, sinrot, cosrot float64
+	vPoleN, vPoleS, u0             float64
+	noRot                          bool
+}
+
+// NewOmerc returns a new Omerc
+func NewOmerc(system *core.System, desc *core.OperationDescription) (core.IConvertLPToXY, error) {
+	op := &Omerc{}
+	op.System = system
+
+	err := op.omercSetup(system)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// Forward goes forewards
+func (op *Omerc) Forward(lp *core.CoordLP) (*core.CoordXY, error) {
+	xy := &core.CoordXY{X: 0.0, Y: 0.0}
+	var u, v float64
+
+	if math.Abs(math.Abs(lp.Phi)-support.PiOverTwo) > eps10 {
+		W := op.E / support.Pow(support.Tsfn(lp.Phi, math.Sin(lp.Phi), op.System.Ellipsoid.E), op.B)
+		temp := 1. / W
+		S := .5 * (W - temp)
+		T := .5 * (W + temp)
+		V := math.Sin(op.B * lp.Lam)
+		U := (S*op.singam - V*op.cosgam) / T
+		if math.Abs(math.Abs(U)-1.0) < eps10 {
+			return xy, merror.New(merror.ToleranceCondition)
+		}
+		v = 0.5 * op.ArB * support.Log((1.-U)/(1.+U))
+		temp = math.Cos(op.B * lp.Lam)
+		if math.Abs(temp) < tol7 {
+			u = op.A * lp.Lam
+		} else {
+			u = op.ArB * math.Atan2(S*op.cosgam+V*op.singam, temp)
+		}
+	} else {
+		if lp.Phi > 0 {
+			v = op.vPoleN
+		} else {
+			v = op.vPoleS
+		}
+		u = op.ArB * lp.Phi
+	}
+
+	if op.noRot {
+		xy.X = u
+		xy.Y = v
+	} else {
+		u -= op.u0
+		xy.X = v*op.cosrot + u*op.sinrot
+		xy.Y = u*op.cosrot - v*op.sinrot
+	}
+	return xy, nil
+}
+
+// Inverse goes backwards
+func (op *Omerc) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
+	lp := &core.CoordLP{Lam: 0.0, Phi: 0.0}
+	var u, v float64
+
+	if op.noRot {
+		v = xy.Y
+		u = xy.X
+	} else {
+		v = xy.X*op.cosrot - xy.Y*op.sinrot
+		u = xy.Y*op.cosrot + xy.X*op.sinrot + op.u0
+	}
+
+	Qp := support.Exp(-op.BrA * v)
+	Sp := .5 * (Qp - 1./Qp)
+	Tp := .5 * (Qp + 1./Qp)
+	Vp := math.Sin(op.BrA * u)
+	Up := (Vp*op.cosgam + Sp*op.singam) / Tp
+	if math.Abs(math.Abs(Up)-1.) < eps10 {
+		lp.Lam = 0.
+		lp.Phi = support.PiOverTwo
+		if Up < 0. {
+			lp.Phi = -support.PiOverTwo
+		}
+		return lp, nil
+	}
+
+	phi := op.E / math.Sqrt((1.+Up)/(1.-Up))
+	phi, err := support.Phi2(support.Pow(phi, 1./op.B), op.System.Ellipsoid.E)
+	if err != nil {
+		return nil, err
+	}
+	if phi == math.MaxFloat64 {
+		return lp, merror.New(merror.ToleranceCondition)
+	}
+	lp.Phi = phi
+	lp.Lam = -op.rB * math.Atan2(Sp*op.cosgam-Vp*op.singam, math.Cos(op.BrA*u))
+	return lp, nil
+}
+
+func (op *Omerc) omercSetup(sys *core.System) error {
+	var con, cosph0, D, F, H, L, sinph0, p, J, gamma, gamma0, lamc, lam1, lam2, phi1, phi2, alphaC float64
+	var noOff bool
+
+	PE := sys.Ellipsoid
+	ps := sys.ProjString
+
+	// the angles are all read below; any that isn't one is an error
+	for _, key := range []string{"alpha", "gamma", "lonc", "lon_1", "lat_1", "lon_2", "lat_2"} {
+		if _, _, err := ps.GetAsDegrees(key); err != nil {
+			return err
+		}
+	}
+
+	op.noRot, _ = ps.GetAsBool("no_rot")
+	alp := ps.ContainsKey("alpha")
+	if alp {
+		alphaC, _ = ps.GetAsAngle("alpha")
+	}
+	gam := ps.ContainsKey("gamma")
+	if gam {
+		gamma, _ = ps.GetAsAngle("gamma")
+	}
+
+	if alp || gam {
+		lamc, _ = ps.GetAsAngle("lonc")
+		noOff, _ = ps.GetAsBool("no_off")
+		if noUoff, _ := ps.GetAsBool("no_uoff"); noUoff {
+			noOff = true
+		}
+		// the center of the line would be at a pole, where its azimuth
+		// is undefined
+		if math.Abs(math.Abs(sys.Phi0)-support.PiOverTwo) <= tol7 {
+			return merror.New(merror.Lat0OrAlphaEq90)
+		}
+	} else {
+		lam1, _ = ps.GetAsAngle("lon_1")
+		phi1, _ = ps.GetAsAngle("lat_1")
+		lam2, _ = ps.GetAsAngle("lon_2")
+		phi2, _ = ps.GetAsAngle("lat_2")
+
+		con = math.Abs(phi1)
+		if math.Abs(phi1-phi2) <= tol7 ||
+			con <= tol7 ||
+			math.Abs(con-support.PiOverTwo) <= tol7 ||
+			math.Abs(math.Abs(sys.Phi0)-support.PiOverTwo) <= tol7 ||
+			math.Abs(math.Abs(phi2)-support.PiOverTwo) <= tol7 {
+			return merror.New(merror.Lat0OrAlphaEq90)
+		}
+	}
+
+	com := math.Sqrt(PE.OneEs)
+	if math.Abs(sys.Phi0) > eps10 {
+		sinph0 = math.Sin(sys.Phi0)
+		cosph0 = math.Cos(sys.Phi0)
+		con = 1. - PE.Es*sinph0*sinph0
+		op.B = cosph0 * cosph0
+		op.B = math.Sqrt(1. + PE.Es*op.B*op.B/PE.OneEs)
+		op.A = op.B * sys.K0 * com / con
+		D = op.B * com / (cosph0 * math.Sqrt(con))
+		F = D*D - 1.
+		if F <= 0. {
+			F = 0.
+		} else {
+			F = math.Sqrt(F)
+			if sys.Phi0 < 0. {
+				F = -F
+			}
+		}
+		F += D
+		op.E = F * support.Pow(support.Tsfn(sys.Phi0, sinph0, PE.E), op.B)
+	} else {
+		op.B = 1. / com
+		op.A = sys.K0
+		op.E, D, F = 1., 1., 1.
+	}
+
+	if alp || gam {
+		if alp {
+			gamma0 = support.Aasin(math.Sin(alphaC) / D)
+			if !gam {
+				gamma = alphaC
+			}
+		} else {
+			gamma0 = gamma
+			alphaC = support.Aasin(D * math.Sin(gamma0))
+		}
+		sys.Lam0 = lamc - support.Aasin(.5*(F-1./F)*math.Tan(gamma0))/op.B
+	} else {
+		H = support.Pow(support.Tsfn(phi1, math.Sin(phi1), PE.E), op.B)
+		L = support.Pow(support.Tsfn(phi2, math.Sin(phi2), PE.E), op.B)
+		F = op.E / H
+		p = (L - H) / (L + H)
+		J = op.E * op.E
+		J = (J - L*H) / (J + L*H)
+		con = lam1 - lam2
+		if con < -math.Pi {
+			lam2 -= support.TwoPi
+		} else if con > math.Pi {
+			lam2 += support.TwoPi
+		}
+		sys.Lam0 = support.Adjlon(.5*(lam1+lam2) - math.Atan(J*math.Tan(.5*op.B*(lam1-lam2))/p)/op.B)
+		gamma0 = math.Atan(2. * math.Sin(op.B*support.Adjlon(lam1-sys.Lam0)) / (F - 1./F))
+		alphaC = support.Aasin(D * math.Sin(gamma0))
+		gamma = alphaC
+	}
+
+	op.singam = math.Sin(gamma0)
+	op.cosgam = math.Cos(gamma0)
+	op.sinrot = math.Sin(gamma)
+	op.cosrot = math.Cos(gamma)
+	op.rB = 1. / op.B
+	op.ArB = op.A * op.rB
+	op.BrA = 1. / op.ArB
+	op.AB = op.A * op.B
+	if noOff {
+		op.u0 = 0
+	} else {
+		op.u0 = math.Abs(op.ArB * math.Atan(math.Sqrt(D*D-1.)/math.Cos(alphaC)))
+		if sys.Phi0 < 0. {
+			op.u0 = -op.u0
+		}
+	}
+	F = 0.5 * gamma0
+	op.vPoleN = op.ArB * support.Log(math.Tan(support.PiOverFour-F))
+	op.vPoleS = op.ArB * support.Log(math.Tan(support.PiOverFour+F))
+
+	return nil
+}
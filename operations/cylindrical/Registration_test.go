@@ -0,0 +1,40 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package cylindrical_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/support"
+	"github.com/stretchr/testify/assert"
+
+	_ "github.com/oahumap/proj/operations/cylindrical"
+)
+
+func TestRegistration(t *testing.T) {
+	assert := assert.New(t)
+
+	// only this family is imported, so only its operations are registered
+	for _, id := range []string{"merc", "eqc", "cea", "labrd", "utm", "etmerc", "tmerc", "omerc"} {
+		assert.NotNil(core.OperationDescriptionTable[id], id)
+	}
+	for _, id := range []string{"aea", "lcc", "airy", "wintri"} {
+		assert.Nil(core.OperationDescriptionTable[id], id)
+	}
+
+	ps, err := support.NewProjString("+proj=utm +zone=32 +ellps=GRS80")
+	assert.NoError(err)
+	_, _, err = core.NewSystem(ps)
+	assert.NoError(err)
+
+	ps, err = support.NewProjString("+proj=lcc +lat_1=33 +lat_2=45 +ellps=GRS80")
+	assert.NoError(err)
+	_, _, err = core.NewSystem(ps)
+	assert.Error(err)
+}
@@ -0,0 +1,252 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package cylindrical
+
+import (
+	"math"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/merror"
+	"github.com/oahumap/proj/support"
+)
+
+func init() {
+	core.RegisterConvertLPToXY("tmerc",
+		"Transverse Mercator",
+		"\n\tCyl, Sph&Ell\n\tapprox exact",
+		NewTMerc,
+	)
+	core.RegisterParameters("tmerc",
+		core.Parameter{Name: "approx", Type: core.ParameterFlag, Description: "use the faster Evenden/Snyder series, accurate only near the central meridian"},
+		core.Parameter{Name: "exact", Type: core.ParameterFlag, Description: "use the slower exact projection, accurate over the whole hemisphere"},
+	)
+	core.RegisterDomain("tmerc", core.Domain{MinLam: -45.0, MaxLam: 45.0, MinPhi: -90.0, MaxPhi: 90.0})
+}
+
+// TMercApprox implements core.IOperation and core.ConvertLPToXY
+//
+// This is the Evenden/Snyder transverse Mercator: a power series in the
+// distance from the central meridian, about four times as fast as etmerc
+// (see BenchmarkConvertTMercApprox), and within a millimeter of it up to
+// 3 or 4 degrees away, but quickly worse beyond. It is what tmerc and
+// utm use with +approx, and what tmerc always uses on the sphere, where
+// the formulas are exact.
+type TMercApprox struct {
+	core.Operation
+	esp float64
+	ml0 float64
+	en  []float64
+}
+
+// NewTMerc returns a new transverse Mercator: an EtMerc, unless +approx is
+// given or the system is spherical, or a TMercExact with +exact
+func NewTMerc(system *core.System, desc *core.OperationDescription) (core.IConvertLPToXY, error) {
+	exact, err := exactRequested(system)
+	if err != nil {
+		return nil, err
+	}
+	if exact && system.Ellipsoid.Es != 0.0 {
+		return newTMercExact(system)
+	}
+	approx, _ := system.ProjString.GetAsBool("approx")
+	if !approx && system.Ellipsoid.Es != 0.0 {
+		return NewEtMerc(system, desc)
+	}
+	return newTMercApprox(system)
+}
+
+func newTMercApprox(system *core.System) (*TMercApprox, error) {
+	op := &TMercApprox{}
+	op.System = system
+
+	err := op.setup(system)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// Forward goes forewards
+func (op *TMercApprox) Forward(lp *core.CoordLP) (*core.CoordXY, error) {
+	if op.System.Ellipsoid.Es == 0.0 {
+		return op.spheroidalForward(lp)
+	}
+	return op.ellipsoidalForward(lp)
+}
+
+// Inverse goes backwards
+func (op *TMercApprox) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
+	if op.System.Ellipsoid.Es == 0.0 {
+		return op.spheroidalInverse(xy)
+	}
+	return op.ellipsoidalInverse(xy)
+}
+
+//---------------------------------------------------------------------
+
+const (
+	fc1 = 1.
+	fc2 = .5
+	fc3 = .16666666666666666666
+	fc4 = .08333333333333333333
+	fc5 = .05
+	fc6 = .03333333333333333333
+	fc7 = .02380952380952380952
+	fc8 = .01785714285714285714
+)
+
+func (op *TMercApprox) ellipsoidalForward(lp *core.CoordLP) (*core.CoordXY, error) {
+	xy := &core.CoordXY{X: 0.0, Y: 0.0}
+
+	P := op.System
+	PE := op.System.Ellipsoid
+
+	// the results are garbage more than 90 degrees from the central
+	// meridian
+	if lp.Lam < -support.PiOverTwo || lp.Lam > support.PiOverTwo {
+		return xy, merror.New(merror.ToleranceCondition)
+	}
+
+	sinphi, cosphi := math.Sincos(lp.Phi)
+	t := 0.0
+	if math.Abs(cosphi) > 1e-10 {
+		t = sinphi / cosphi
+	}
+	t *= t
+	al := cosphi * lp.Lam
+	als := al * al
+	al /= math.Sqrt(1. - PE.Es*sinphi*sinphi)
+	n := op.esp * cosphi * cosphi
+
+	xy.X = P.K0 * al * (fc1 +
+		fc3*als*(1.-t+n+
+			fc5*als*(5.+t*(t-18.)+n*(14.-58.*t)+
+				fc7*als*(61.+t*(t*(179.-t)-479.)))))
+	xy.Y = P.K0 * (support.Mlfn(lp.Phi, sinphi, cosphi, op.en) - op.ml0 +
+		sinphi*al*lp.Lam*fc2*(1.+
+			fc4*als*(5.-t+n*(9.+4.*n)+
+				fc6*als*(61.+t*(t-58.)+n*(270.-330*t)+
+					fc8*als*(1385.+t*(t*(543.-t)-3111.))))))
+	return xy, nil
+}
+
+func (op *TMercApprox) spheroidalForward(lp *core.CoordLP) (*core.CoordXY, error) {
+	xy := &core.CoordXY{X: 0.0, Y: 0.0}
+
+	P := op.System
+
+	cosphi := math.Cos(lp.Phi)
+	b := cosphi * math.Sin(lp.Lam)
+	if math.Abs(math.Abs(b)-1.) <= eps10 {
+		return xy, merror.New(merror.ToleranceCondition)
+	}
+
+	xy.X = op.ml0 * support.Log((1.+b)/(1.-b))
+	xy.Y = cosphi * math.Cos(lp.Lam) / math.Sqrt(1.-b*b)
+
+	b = math.Abs(xy.Y)
+	if cosphi == 1 && (lp.Lam < -support.PiOverTwo || lp.Lam > support.PiOverTwo) {
+		// so that longitudes beyond 90 degrees on the equator round trip
+		xy.Y = math.Pi
+	} else if b >= 1. {
+		if (b - 1.) > eps10 {
+			return xy, merror.New(merror.ToleranceCondition)
+		}
+		xy.Y = 0.
+	} else {
+		xy.Y = math.Acos(xy.Y)
+	}
+
+	if lp.Phi < 0. {
+		xy.Y = -xy.Y
+	}
+	xy.Y = op.esp * (xy.Y - P.Phi0)
+	return xy, nil
+}
+
+func (op *TMercApprox) ellipsoidalInverse(xy *core.CoordXY) (*core.CoordLP, error) {
+	lp := &core.CoordLP{Lam: 0.0, Phi: 0.0}
+
+	P := op.System
+	PE := op.System.Ellipsoid
+
+	var err error
+	lp.Phi, err = support.InvMlfn(op.ml0+xy.Y/P.K0, PE.Es, op.en)
+	if err != nil {
+		return lp, err
+	}
+	if math.Abs(lp.Phi) >= support.PiOverTwo {
+		if xy.Y < 0. {
+			lp.Phi = -support.PiOverTwo
+		} else {
+			lp.Phi = support.PiOverTwo
+		}
+		lp.Lam = 0.
+		return lp, nil
+	}
+
+	sinphi, cosphi := math.Sincos(lp.Phi)
+	t := 0.0
+	if math.Abs(cosphi) > 1e-10 {
+		t = sinphi / cosphi
+	}
+	n := op.esp * cosphi * cosphi
+	con := 1. - PE.Es*sinphi*sinphi
+	d := xy.X * math.Sqrt(con) / P.K0
+	con *= t
+	t *= t
+	ds := d * d
+
+	lp.Phi -= (con * ds / (1. - PE.Es)) * fc2 * (1. -
+		ds*fc4*(5.+t*(3.-9.*n)+n*(1.-4*n)-
+			ds*fc6*(61.+t*(90.-252.*n+45.*t)+46.*n-
+				ds*fc8*(1385.+t*(3633.+t*(4095.+1575.*t))))))
+	lp.Lam = d * (fc1 -
+		ds*fc3*(1.+2.*t+n-
+			ds*fc5*(5.+t*(28.+24.*t+8.*n)+6.*n-
+				ds*fc7*(61.+t*(662.+t*(1320.+720.*t)))))) / cosphi
+	return lp, nil
+}
+
+func (op *TMercApprox) spheroidalInverse(xy *core.CoordXY) (*core.CoordLP, error) {
+	lp := &core.CoordLP{Lam: 0.0, Phi: 0.0}
+
+	P := op.System
+
+	h := support.Exp(xy.X / op.esp)
+	if h == 0 {
+		return lp, merror.New(merror.ToleranceCondition)
+	}
+	g := .5 * (h - 1./h)
+	// D, as in equation 8-8 of USGS "Map Projections - A Working Manual"
+	D := P.Phi0 + xy.Y/op.esp
+	h = math.Cos(D)
+	lp.Phi = math.Asin(math.Sqrt((1. - h*h) / (1. + g*g)))
+
+	// on the right hemisphere when a false northing is used
+	lp.Phi = math.Copysign(lp.Phi, D)
+
+	if g != 0.0 || h != 0.0 {
+		lp.Lam = math.Atan2(g, h)
+	}
+	return lp, nil
+}
+
+func (op *TMercApprox) setup(sys *core.System) error {
+	PE := sys.Ellipsoid
+
+	if PE.Es != 0.0 {
+		op.en = support.Enfn(PE.Es)
+		op.ml0 = support.Mlfn(sys.Phi0, math.Sin(sys.Phi0), math.Cos(sys.Phi0), op.en)
+		op.esp = PE.Es / (1. - PE.Es)
+	} else {
+		op.esp = sys.K0
+		op.ml0 = .5 * op.esp
+	}
+	return nil
+}
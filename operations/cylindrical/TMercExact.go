@@ -0,0 +1,404 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package cylindrical
+
+import (
+	"math"
+
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/merror"
+	"github.com/oahumap/proj/support"
+)
+
+// TMercExact implements core.IOperation and core.ConvertLPToXY
+//
+// This is the exact transverse Mercator of L. P. Lee, "Conformal
+// Projections Based on Jacobian Elliptic Functions" (1976), as computed
+// by C. F. F. Karney, "Transverse Mercator with an accuracy of a few
+// nanometers" (J. Geodesy 85, 2011) and GeographicLib's
+// TransverseMercatorExact. Where the etmerc series, good to a millimeter
+// or so within 3900 km of the central meridian, falls apart, this stays
+// accurate to nanometers over the whole of the hemisphere about the
+// central meridian, at several times the cost. It is what tmerc, etmerc
+// and utm use with +exact.
+type TMercExact struct {
+	core.Operation
+	e   float64 // eccentricity
+	mu  float64 // e^2
+	mv  float64 // 1 - e^2
+	eu  *support.Elliptic
+	ev  *support.Elliptic
+	xi0 float64 // the northing of the origin latitude, in units of the major axis
+}
+
+const (
+	tmExactNumit = 10
+	tmExactTol2  = 0.1 * 2.220446049250313e-16
+)
+
+// the error of the starting guesses near the branch points is about
+// (rad/e)^(5/3) times a small constant, and they are used as they are
+// below this
+var tmExactTaytol = math.Pow(2.220446049250313e-16, 0.6)
+
+// exactDomain is the hemisphere about the central meridian, over which
+// TMercExact stays accurate, rather than the domain of tmerc, etmerc and
+// utm
+var exactDomain = core.Domain{MinLam: -90.0, MaxLam: 90.0, MinPhi: -90.0, MaxPhi: 90.0}
+
+func newTMercExact(system *core.System) (*TMercExact, error) {
+	if system.Ellipsoid.Es <= 0.0 {
+		return nil, merror.New(merror.EllipsoidUseRequired)
+	}
+
+	op := &TMercExact{
+		e:  math.Sqrt(system.Ellipsoid.Es),
+		mu: system.Ellipsoid.Es,
+		mv: 1.0 - system.Ellipsoid.Es,
+	}
+	op.System = system
+	system.Domain = &exactDomain
+	op.eu = support.NewElliptic(op.mu)
+	op.ev = support.NewElliptic(op.mv)
+
+	if system.Phi0 != 0.0 {
+		xy, err := op.Forward(&core.CoordLP{Lam: 0.0, Phi: system.Phi0})
+		if err != nil {
+			return nil, err
+		}
+		op.xi0 = xy.Y / system.K0
+	}
+	return op, nil
+}
+
+// exactRequested reports whether +exact is given, and fails if +approx
+// is too
+func exactRequested(system *core.System) (bool, error) {
+	exact, _ := system.ProjString.GetAsBool("exact")
+	if !exact {
+		return false, nil
+	}
+	if approx, _ := system.ProjString.GetAsBool("approx"); approx {
+		return false, merror.New(merror.InvalidProjectionSyntax, "approx and exact")
+	}
+	return true, nil
+}
+
+// Forward goes forewards
+func (op *TMercExact) Forward(lp *core.CoordLP) (*core.CoordXY, error) {
+	lam, phi := lp.Lam, lp.Phi
+	if math.IsNaN(lam) || math.IsNaN(phi) {
+		return nil, merror.New(merror.ToleranceCondition)
+	}
+
+	// work in the first quadrant, and on the front of the projection
+	latSign, lonSign := 1.0, 1.0
+	if math.Signbit(phi) {
+		latSign, phi = -1.0, -phi
+	}
+	if math.Signbit(lam) {
+		lonSign, lam = -1.0, -lam
+	}
+	backside := lam > support.PiOverTwo
+	if backside {
+		if phi == 0.0 {
+			latSign = -1.0
+		}
+		lam = support.Pi - lam
+	}
+
+	// u, v are the coordinates of the Thompson transverse Mercator, Lee 54
+	var u, v float64
+	switch {
+	case phi == support.PiOverTwo:
+		u, v = op.eu.K, 0.0
+	case phi == 0.0 && lam == support.PiOverTwo*(1.0-op.e):
+		u, v = 0.0, op.ev.K
+	default:
+		u, v = op.zetainv(taupf(math.Tan(phi), op.e), lam)
+	}
+
+	snu, cnu, dnu := op.eu.SnCnDn(u)
+	snv, cnv, dnv := op.ev.SnCnDn(v)
+	xi, eta := op.sigma(snu, cnu, dnu, v, snv, cnv, dnv)
+	if backside {
+		xi = 2.0*op.eu.E - xi
+	}
+
+	k0 := op.System.K0
+	return &core.CoordXY{X: eta * k0 * lonSign, Y: (xi*latSign - op.xi0) * k0}, nil
+}
+
+// Inverse goes backwards
+func (op *TMercExact) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
+	lp, _, _, err := op.InverseWithConvergence(xy)
+	return lp, err
+}
+
+// InverseWithConvergence implements core.IIterativeInverse
+func (op *TMercExact) InverseWithConvergence(xy *core.CoordXY) (*core.CoordLP, int, float64, error) {
+	k0 := op.System.K0
+	xi, eta := xy.Y/k0+op.xi0, xy.X/k0
+	if math.IsNaN(xi) || math.IsNaN(eta) {
+		return nil, 0, 0.0, merror.New(merror.ToleranceCondition)
+	}
+
+	xiSign, etaSign := 1.0, 1.0
+	if math.Signbit(xi) {
+		xiSign, xi = -1.0, -xi
+	}
+	if math.Signbit(eta) {
+		etaSign, eta = -1.0, -eta
+	}
+	backside := xi > op.eu.E
+	if backside {
+		xi = 2.0*op.eu.E - xi
+	}
+
+	var u, v, residual float64
+	iterations := 0
+	if xi == 0.0 && eta == op.ev.KE {
+		u, v = 0.0, op.ev.K
+	} else {
+		u, v, iterations, residual = op.sigmainv(xi, eta)
+	}
+
+	lp := &core.CoordLP{Lam: 0.0, Phi: support.PiOverTwo}
+	if v != 0.0 || u != op.eu.K {
+		snu, cnu, dnu := op.eu.SnCnDn(u)
+		snv, cnv, dnv := op.ev.SnCnDn(v)
+		taup, lam := op.zeta(snu, cnu, dnu, snv, cnv, dnv)
+		lp.Phi = math.Atan(tauf(taup, op.e))
+		lp.Lam = lam
+	}
+	if backside {
+		lp.Lam = support.Pi - lp.Lam
+	}
+	lp.Lam *= etaSign
+	lp.Phi *= xiSign
+	return lp, iterations, residual, nil
+}
+
+//---------------------------------------------------------------------
+
+// zeta returns the conformal latitude, as tan(chi), and the longitude of
+// the point w = u + iv, Lee 54.17
+func (op *TMercExact) zeta(snu, cnu, dnu, snv, cnv, dnv float64) (float64, float64) {
+	// atanh(snu * dnv) = asinh(snu * dnv / sqrt(cnu^2 + mv * snu^2 * snv^2))
+	// atanh(e * snu / dnv) = asinh(e * snu / sqrt(mu * cnu^2 + mv * cnv^2))
+	const overflow = 1.0 / (2.220446049250313e-16 * 2.220446049250313e-16)
+	d1 := math.Sqrt(cnu*cnu + op.mv*(snu*snv)*(snu*snv))
+	d2 := math.Sqrt(op.mu*cnu*cnu + op.mv*cnv*cnv)
+	t1 := math.Copysign(overflow, snu)
+	if d1 != 0.0 {
+		t1 = snu * dnv / d1
+	}
+	t2 := math.Copysign(overflow, snu)
+	if d2 != 0.0 {
+		t2 = support.Sinh(op.e * support.Asinh(op.e*snu/d2))
+	}
+	taup := t1*support.Hypot(1.0, t2) - t2*support.Hypot(1.0, t1)
+	lam := 0.0
+	if d1 != 0.0 && d2 != 0.0 {
+		lam = math.Atan2(dnu*snv, cnu*cnv) - op.e*math.Atan2(op.e*cnu*snv, dnu*cnv)
+	}
+	return taup, lam
+}
+
+// dwdzeta returns the derivative of w with respect to zeta, Lee 54.21
+func (op *TMercExact) dwdzeta(snu, cnu, dnu, snv, cnv, dnv float64) (float64, float64) {
+	s := cnv*cnv + op.mu*(snu*snv)*(snu*snv)
+	d := op.mv * s * s
+	du := cnu * dnu * dnv * (cnv*cnv - op.mu*(snu*snv)*(snu*snv)) / d
+	dv := -snu * snv * cnv * ((dnu*dnv)*(dnu*dnv) + op.mu*cnu*cnu) / d
+	return du, dv
+}
+
+// zetainv0 returns a starting guess for zetainv, and whether it is good
+// enough as it is
+func (op *TMercExact) zetainv0(psi, lam float64) (float64, float64, bool) {
+	e := op.e
+	switch {
+	case psi < -e*math.Pi/4.0 && lam > (1.0-2.0*e)*math.Pi/2.0 && psi < lam-(1.0-e)*math.Pi/2.0:
+		// near the log singularity at w0 = K + iK', the south pole
+		psix := 1.0 - psi/e
+		lamx := (math.Pi/2.0 - lam) / e
+		u := support.Asinh(math.Sin(lamx)/support.Hypot(math.Cos(lamx), support.Sinh(psix))) * (1.0 + op.mu/2.0)
+		v := math.Atan2(math.Cos(lamx), support.Sinh(psix)) * (1.0 + op.mu/2.0)
+		return op.eu.K - u, op.ev.K - v, false
+
+	case psi < e*math.Pi/2.0 && lam > (1.0-2.0*e)*math.Pi/2.0:
+		// near w0 = iK', where zeta = zeta0 - (mv e / 3) (w - w0)^3
+		dlam := lam - (1.0-e)*math.Pi/2.0
+		rad := support.Hypot(psi, dlam)
+		ang := math.Atan2(dlam-psi, psi+dlam) - 0.75*math.Pi
+		good := rad < e*tmExactTaytol
+		rad = math.Cbrt(3.0 / (op.mv * e) * rad)
+		ang /= 3.0
+		return rad * math.Cos(ang), rad*math.Sin(ang) + op.ev.K, good
+	}
+
+	// elsewhere, the spherical transverse Mercator, Lee 12.6, scaled to
+	// put the pole in the right place
+	v := support.Asinh(math.Sin(lam) / support.Hypot(math.Cos(lam), support.Sinh(psi)))
+	u := math.Atan2(support.Sinh(psi), math.Cos(lam))
+	return u * op.eu.K / (math.Pi / 2.0), v * op.eu.K / (math.Pi / 2.0), false
+}
+
+// zetainv returns the w = u + iv of the conformal latitude, as taup,
+// and the longitude, by Newton's method
+func (op *TMercExact) zetainv(taup, lam float64) (float64, float64) {
+	psi := support.Asinh(taup)
+	scal := 1.0 / support.Hypot(1.0, taup)
+	u, v, good := op.zetainv0(psi, lam)
+	if good {
+		return u, v
+	}
+	stol2 := tmExactTol2 / math.Max(psi*psi, 1.0)
+	for i, trip := 0, false; i < tmExactNumit; i++ {
+		snu, cnu, dnu := op.eu.SnCnDn(u)
+		snv, cnv, dnv := op.ev.SnCnDn(v)
+		tau1, lam1 := op.zeta(snu, cnu, dnu, snv, cnv, dnv)
+		du1, dv1 := op.dwdzeta(snu, cnu, dnu, snv, cnv, dnv)
+		tau1 = (tau1 - taup) * scal
+		lam1 -= lam
+		delu := tau1*du1 - lam1*dv1
+		delv := tau1*dv1 + lam1*du1
+		u -= delu
+		v -= delv
+		if trip {
+			break
+		}
+		if !(delu*delu+delv*delv >= stol2) {
+			trip = true
+		}
+	}
+	return u, v
+}
+
+// sigma returns the northing and easting, xi and eta, of the point
+// w = u + iv, in units of the major axis, Lee 55.4
+func (op *TMercExact) sigma(snu, cnu, dnu, v, snv, cnv, dnv float64) (float64, float64) {
+	d := op.mu*cnu*cnu + op.mv*cnv*cnv
+	xi := op.eu.Einc(snu, cnu, dnu) - op.mu*snu*cnu*dnu/d
+	eta := v - op.ev.Einc(snv, cnv, dnv) + op.mv*snv*cnv*dnv/d
+	return xi, eta
+}
+
+// dwdsigma returns the derivative of w with respect to sigma, the
+// reciprocal of Lee 55.9
+func (op *TMercExact) dwdsigma(snu, cnu, dnu, snv, cnv, dnv float64) (float64, float64) {
+	s := cnv*cnv + op.mu*(snu*snv)*(snu*snv)
+	d := op.mv * s * s
+	dnr := dnu * cnv * dnv
+	dni := -op.mu * snu * cnu * snv
+	return (dnr*dnr - dni*dni) / d, 2.0 * dnr * dni / d
+}
+
+// sigmainv0 returns a starting guess for sigmainv, and whether it is good
+// enough as it is
+func (op *TMercExact) sigmainv0(xi, eta float64) (float64, float64, bool) {
+	eu, ev := op.eu, op.ev
+	switch {
+	case eta > 1.25*ev.KE || (xi < -0.25*eu.E && xi < eta-ev.KE):
+		// near the simple pole at w0 = K + iK'
+		x := xi - eu.E
+		y := eta - ev.KE
+		r2 := x*x + y*y
+		return eu.K + x/r2, ev.K - y/r2, false
+
+	case (eta > 0.75*ev.KE && xi < 0.25*eu.E) || eta > ev.KE:
+		// near w0 = iK', where sigma = sigma0 - (mv / 3) (w - w0)^3
+		deta := eta - ev.KE
+		rad := support.Hypot(xi, deta)
+		ang := math.Atan2(deta-xi, xi+deta) - 0.75*math.Pi
+		good := rad < 2.0*tmExactTaytol
+		rad = math.Cbrt(3.0 / op.mv * rad)
+		ang /= 3.0
+		return rad * math.Cos(ang), rad*math.Sin(ang) + ev.K, good
+	}
+
+	// elsewhere, w = sigma K/E, which is right in the limit e -> 0
+	return xi * eu.K / eu.E, eta * eu.K / eu.E, false
+}
+
+// sigmainv returns the w = u + iv of the northing and easting, by
+// Newton's method, with the iterations it took and the final residual
+func (op *TMercExact) sigmainv(xi, eta float64) (float64, float64, int, float64) {
+	u, v, good := op.sigmainv0(xi, eta)
+	if good {
+		return u, v, 0, 0.0
+	}
+	iterations, residual := 0, 0.0
+	for i, trip := 0, false; i < tmExactNumit; i++ {
+		snu, cnu, dnu := op.eu.SnCnDn(u)
+		snv, cnv, dnv := op.ev.SnCnDn(v)
+		xi1, eta1 := op.sigma(snu, cnu, dnu, v, snv, cnv, dnv)
+		du1, dv1 := op.dwdsigma(snu, cnu, dnu, snv, cnv, dnv)
+		xi1 -= xi
+		eta1 -= eta
+		delu := xi1*du1 - eta1*dv1
+		delv := xi1*dv1 + eta1*du1
+		u -= delu
+		v -= delv
+		iterations = i + 1
+		residual = math.Sqrt(delu*delu + delv*delv)
+		if trip {
+			break
+		}
+		if !(delu*delu+delv*delv >= tmExactTol2) {
+			trip = true
+		}
+	}
+	return u, v, iterations, residual
+}
+
+//---------------------------------------------------------------------
+
+// eatanhe is e atanh(e x)
+func eatanhe(x, e float64) float64 {
+	return e * math.Atanh(e*x)
+}
+
+// taupf returns tan(chi), of the conformal latitude chi, for tau =
+// tan(phi)
+func taupf(tau, e float64) float64 {
+	if math.IsInf(tau, 0) {
+		return tau
+	}
+	tau1 := support.Hypot(1.0, tau)
+	sig := support.Sinh(eatanhe(tau/tau1, e))
+	return support.Hypot(1.0, sig)*tau - sig*tau1
+}
+
+// tauf is the inverse of taupf, by Newton's method
+func tauf(taup, e float64) float64 {
+	const numit = 5
+	tol := math.Sqrt(2.220446049250313e-16) / 10.0
+	taumax := 2.0 / math.Sqrt(2.220446049250313e-16)
+
+	e2m := 1.0 - e*e
+	tau := taup / e2m
+	if math.Abs(taup) > 70.0 {
+		tau = taup * support.Exp(eatanhe(1.0, e))
+	}
+	stol := tol * math.Max(1.0, math.Abs(taup))
+	if !(math.Abs(tau) < taumax) {
+		return tau
+	}
+	for i := 0; i < numit; i++ {
+		taupa := taupf(tau, e)
+		dtau := (taup - taupa) * (1.0 + e2m*tau*tau) /
+			(e2m * support.Hypot(1.0, tau) * support.Hypot(1.0, taupa))
+		tau += dtau
+		if !(math.Abs(dtau) >= stol) {
+			break
+		}
+	}
+	return tau
+}
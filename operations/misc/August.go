@@ -5,7 +5,7 @@
 // is provided under the MIT-style license in `LICENSE.md` and may
 // additionally be subject to the copyrights of the PROJ.4 authors.
 
-package operations
+package misc
 
 import (
 	"math"
@@ -16,7 +16,7 @@ import (
 func init() {
 	core.RegisterConvertLPToXY("august",
 		"August Epicycloidal",
-		"\n\tMisc Sph, no inv.",
+		"\n\tMisc Sph",
 		NewAugust,
 	)
 }
@@ -59,7 +59,16 @@ func (op *August) Forward(lp *core.CoordLP) (*core.CoordXY, error) {
 	return xy, nil
 }
 
-// Inverse is not allowed
-func (*August) Inverse(*core.CoordXY) (*core.CoordLP, error) {
-	panic("no such conversion")
+// Inverse is found numerically, as there is no closed form; the guess
+// scales the point back to the graticule, and is kept off the poles,
+// which the outer parallels bulge towards
+func (op *August) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
+	lp, _, _, err := op.InverseWithConvergence(xy)
+	return lp, err
+}
+
+// InverseWithConvergence implements core.IIterativeInverse
+func (op *August) InverseWithConvergence(xy *core.CoordXY) (*core.CoordLP, int, float64, error) {
+	guess := core.CoordLP{Lam: 0.6 * xy.X, Phi: math.Max(-1.5, math.Min(1.5, 0.56*xy.Y))}
+	return core.GenericInverse("august", op.Forward, xy, guess)
 }
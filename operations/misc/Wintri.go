@@ -5,7 +5,7 @@
 // is provided under the MIT-style license in `LICENSE.md` and may
 // additionally be subject to the copyrights of the PROJ.4 authors.
 
-package operations
+package misc
 
 import (
 	"math"
@@ -21,6 +21,9 @@ func init() {
 		"\n\tPCyl., Sph.\n\tlat_1= (default: 50.467°)",
 		NewWintri,
 	)
+	core.RegisterParameters("wintri",
+		core.Parameter{Name: "lat_1", Type: core.ParameterAngle, Unit: "degrees", Default: "50.4598", Description: "standard parallel of the equirectangular part; the default is acos(2/pi)"},
+	)
 }
 
 // Wintri implements core.IOperation and core.ConvertLPToXY
@@ -81,6 +84,12 @@ func (op *Wintri) Forward(lp *core.CoordLP) (*core.CoordXY, error) {
 
 // Inverse Operation
 func (op *Wintri) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
+	lp, _, _, err := op.InverseWithConvergence(xy)
+	return lp, err
+}
+
+// InverseWithConvergence implements core.IIterativeInverse
+func (op *Wintri) InverseWithConvergence(xy *core.CoordXY) (*core.CoordLP, int, float64, error) {
 	var lp core.CoordLP
 
 	x := xy.X
@@ -104,16 +113,22 @@ func (op *Wintri) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
 		lam = -math.Pi
 	}
 
-	for range maxIter {
+	converged := false
+	iterations, residual := 0, 0.0
+	for iterations < maxIter {
+		iterations++
+
 		testLP := core.CoordLP{Phi: phi, Lam: lam}
 		testXY, err := op.Forward(&testLP)
 		if err != nil {
-			return nil, err
+			return nil, iterations, residual, err
 		}
 
 		dx := testXY.X - x
 		dy := testXY.Y - y
-		if math.Abs(dx) < tolerance && math.Abs(dy) < tolerance {
+		residual = math.Max(math.Abs(dx), math.Abs(dy))
+		if residual < tolerance {
+			converged = true
 			break
 		}
 
@@ -145,7 +160,7 @@ func (op *Wintri) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
 
 		det := dxdPhi*dydLam - dydPhi*dxdLam
 		if math.Abs(det) < 1e-15 {
-			return nil, merror.New(merror.ToleranceCondition, "Jacobian determinant too small in Winkel Tripel inverse")
+			return nil, iterations, residual, merror.New(merror.ToleranceCondition, "Jacobian determinant too small in Winkel Tripel inverse")
 		}
 
 		dphi := (dydLam*dx - dxdLam*dy) / det
@@ -165,25 +180,41 @@ func (op *Wintri) Inverse(xy *core.CoordXY) (*core.CoordLP, error) {
 			phi = -math.Pi * 0.5
 		}
 
-		for lam > math.Pi {
-			lam -= 2 * math.Pi
-		}
-		for lam < -math.Pi {
-			lam += 2 * math.Pi
+		// clamp, rather than wrap, so that points on the edge of the
+		// map don't flip from one side to the other
+		if lam > math.Pi {
+			lam = math.Pi
+		} else if lam < -math.Pi {
+			lam = -math.Pi
 		}
 	}
 
+	if !converged {
+		return nil, iterations, residual, merror.NewConvergenceError("wintri", iterations, residual)
+	}
+
 	lp.Phi = phi
 	lp.Lam = lam
 
-	return &lp, nil
+	return &lp, iterations, residual, nil
 }
 
 func (op *Wintri) wintriSetup(system *core.System) error {
-	system.Ellipsoid.Es = 0.0
+	// spherical only: replace the ellipsoid, rather than zeroing its
+	// eccentricity and leaving the values derived from it behind
+	sphere, err := core.NewEllipsoid(system.Ellipsoid.A, 0.0)
+	if err != nil {
+		return err
+	}
+	system.Ellipsoid = sphere
+
 	op.lat1 = math.Acos(2.0 / math.Pi)
 
-	if val, ok := system.ProjString.GetAsFloat("lat_1"); ok {
+	val, ok, err := system.ProjString.GetAsDegrees("lat_1")
+	if err != nil {
+		return err
+	}
+	if ok {
 		op.lat1 = support.DDToR(val)
 	}
 
@@ -0,0 +1,71 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+// Package operations registers every coordinate operation we support
+// with the core package.
+//
+// The operations themselves live in one subpackage per projection
+// family, each of which registers its operations when imported:
+//
+//	operations/azimuthal    aeqd, airy, gnom, laea, stere, sterea, ups
+//	operations/conic        aea, leac, lcc
+//	operations/cylindrical  merc, eqc, cea, utm, etmerc, omerc
+//	operations/misc         august, wintri
+//
+// Importing this package imports them all. Binaries that only need some
+// families can import just those instead, and the linker will leave the
+// others out.
+//
+// Operations from outside this repo are added the same way: a package
+// whose init function calls core.RegisterConvertLPToXY (and, optionally,
+// core.RegisterDomain and core.RegisterParameters).
+package operations
+
+import (
+	"github.com/oahumap/proj/operations/azimuthal"
+	"github.com/oahumap/proj/operations/conic"
+	"github.com/oahumap/proj/operations/cylindrical"
+	"github.com/oahumap/proj/operations/misc"
+)
+
+// The operation types and constructors the package exported before the
+// families were split out; later operations are only in their families
+type (
+	Aeqd          = azimuthal.Aeqd
+	Airy          = azimuthal.Airy
+	AzimuthalBase = azimuthal.AzimuthalBase
+	Aea           = conic.Aea
+	LCC           = conic.LCC
+	Eqc           = cylindrical.Eqc
+	EtMerc        = cylindrical.EtMerc
+	Merc          = cylindrical.Merc
+	August        = misc.August
+	Wintri        = misc.Wintri
+)
+
+var (
+	NewAeqd   = azimuthal.NewAeqd
+	NewAiry   = azimuthal.NewAiry
+	NewAea    = conic.NewAea
+	NewLeac   = conic.NewLeac
+	NewLCC    = conic.NewLCC
+	NewEqc    = cylindrical.NewEqc
+	NewEtMerc = cylindrical.NewEtMerc
+	NewMerc   = cylindrical.NewMerc
+	NewUtm    = cylindrical.NewUtm
+	NewAugust = misc.NewAugust
+	NewWintri = misc.NewWintri
+)
+
+// MercMaxLat is the Web Mercator latitude limit, as per cylindrical.MercMaxLat
+var MercMaxLat = cylindrical.MercMaxLat
+
+// The LCC inverse iteration limits
+const (
+	LCCIterationEpsilon = conic.LCCIterationEpsilon
+	LCCMaxIterations    = conic.LCCMaxIterations
+)
@@ -9,9 +9,13 @@ package operations_test
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/operations"
+	"github.com/oahumap/proj/operations/conic"
+	"github.com/oahumap/proj/operations/cylindrical"
 	"github.com/oahumap/proj/support"
 	"github.com/stretchr/testify/assert"
 )
@@ -94,6 +98,21 @@ var testdata = []data{
 		fwd: [][]float64{
 			{2, 1, 189109.886908621, 94583.752387504},
 		},
+		inv: [][]float64{
+			{189109.886908621, 94583.752387504, 2, 1},
+		},
+	}, {
+		// builtins.gie:107
+		proj:  "+proj=aeqd +ellps=GRS80 +lat_0=0",
+		delta: 0.1 * 0.001,
+		fwd: [][]float64{
+			{90, 0, 10018754.1714, 0},
+			{45, 45, 3860398.3783, 5430089.0490},
+		},
+		inv: [][]float64{
+			{10018754.1714, 0, 90, 0},
+			{3860398.3783, 5430089.0490, 45, 45},
+		},
 	}, {
 		// builtins.gie:428
 		proj:  "+proj=august   +a=6400000    +lat_1=0 +lat_2=2",
@@ -101,6 +120,9 @@ var testdata = []data{
 		fwd: [][]float64{
 			{2, 1, 223404.978180972, 111722.340289763},
 		},
+		inv: [][]float64{
+			{223404.978180972, 111722.340289763, 2, 1},
+		},
 	}, {
 		// builtins.gie:1104
 		proj:  "proj=eqc   +a=6400000    +lat_1=0.5 +lat_2=2",
@@ -133,6 +155,78 @@ var testdata = []data{
 			{-200, 100, -0.001796359, 0.000904232},
 			{-200, -100, -0.001796358, -0.000904233},
 		},
+	}, {
+		// builtins.gie:3266
+		proj:  "+proj=omerc   +ellps=GRS80  +lat_1=0.5 +lat_2=2",
+		delta: 0.1 * 0.001,
+		fwd: [][]float64{
+			{2, 1, 222650.796885261, 110642.229314984},
+			{2, -1, 222650.796885261, -110642.229314984},
+			{-2, 1, -222650.796885262, 110642.229314984},
+			{-2, -1, -222650.796885262, -110642.229314984},
+		},
+		inv: [][]float64{
+			{200, 100, 0.001796631, 0.000904369},
+			{200, -100, 0.001796631, -0.000904369},
+			{-200, 100, -0.001796631, 0.000904369},
+			{-200, -100, -0.001796631, -0.000904369},
+		},
+	}, {
+		// builtins.gie:3289
+		proj:  "+proj=omerc   +ellps=GRS80  +lat_1=0.5 +lat_2=2 +no_rot",
+		delta: 0.1 * 0.001,
+		fwd: [][]float64{
+			{2, 1, 110642.229314984, 222650.796885261},
+			{2, -1, -110642.229314984, 222650.796885261},
+			{-2, 1, 110642.229314984, -222650.796885262},
+			{-2, -1, -110642.229314984, -222650.796885262},
+		},
+		inv: [][]float64{
+			{200, 100, 0.000898315, 0.001808739},
+			{200, -100, -0.000898315, 0.001808739},
+			{-200, 100, 0.000898315, -0.001808739},
+			{-200, -100, -0.000898315, -0.001808739},
+		},
+	}, {
+		// builtins.gie:757
+		proj:  "+proj=cea   +ellps=GRS80  +lat_1=0.5 +lat_2=2",
+		delta: 0.1 * 0.001,
+		fwd: [][]float64{
+			{2, 1, 222638.981586547, 110568.812396267},
+			{2, -1, 222638.981586547, -110568.812396266},
+		},
+		inv: [][]float64{
+			{200, 100, 0.001796631, 0.000904369},
+			{200, -100, 0.001796631, -0.000904369},
+		},
+	}, {
+		// builtins.gie:2146
+		proj:  "+proj=laea   +ellps=GRS80  +lat_1=0.5 +lat_2=2",
+		delta: 0.1 * 0.001,
+		fwd: [][]float64{
+			{2, 1, 222602.471450095, 110589.827224410},
+			{2, -1, 222602.471450095, -110589.827224409},
+			{-2, 1, -222602.471450095, 110589.827224410},
+			{-2, -1, -222602.471450095, -110589.827224409},
+		},
+		inv: [][]float64{
+			{200, 100, 0.001796631, 0.000904369},
+			{200, -100, 0.001796631, -0.000904369},
+			{-200, 100, -0.001796631, 0.000904369},
+			{-200, -100, -0.001796631, -0.000904369},
+		},
+	}, {
+		// builtins.gie:2169
+		proj:  "+proj=laea   +R=6400000    +lat_1=0.5 +lat_2=2",
+		delta: 0.1 * 0.001,
+		fwd: [][]float64{
+			{2, 1, 223365.281370125, 111716.668072916},
+			{2, -1, 223365.281370125, -111716.668072916},
+		},
+		inv: [][]float64{
+			{200, 100, 0.001790493, 0.000895247},
+			{200, -100, 0.001790493, -0.000895247},
+		},
 	}, {
 		// builtins.gie:5124
 		proj:  "+proj=wintri   +a=6400000    +lat_1=0 +lat_2=2",
@@ -146,6 +240,25 @@ var testdata = []data{
 	},
 }
 
+// newOp returns the operation for the proj string, or the error from
+// setting it up
+func newOp(proj string) (core.IConvertLPToXY, error) {
+	ps, err := support.NewProjString(proj)
+	if err != nil {
+		return nil, err
+	}
+	_, opx, err := core.NewSystem(ps)
+	if err != nil {
+		return nil, err
+	}
+	return opx.(core.IConvertLPToXY), nil
+}
+
+// forward projects the point (lon, lat), in degrees
+func forward(op core.IConvertLPToXY, lon, lat float64) (*core.CoordXY, error) {
+	return op.Forward(&core.CoordLP{Lam: support.DDToR(lon), Phi: support.DDToR(lat)})
+}
+
 func TestConvert(t *testing.T) {
 	assert := assert.New(t)
 
@@ -187,16 +300,468 @@ func TestConvert(t *testing.T) {
 	}
 }
 
+func TestPoles(t *testing.T) {
+	assert := assert.New(t)
+
+	withPolicy := func(proj string, policy core.PolePolicy) core.IConvertLPToXY {
+		op, err := newOp(proj)
+		assert.NoError(err, proj)
+		op.(*core.ConvertLPToXY).System.PolePolicy = policy
+		return op
+	}
+
+	for _, proj := range []string{"+proj=merc +ellps=WGS84", "+proj=merc +a=6378137 +b=6378137"} {
+
+		// by default, exactly at a pole is an error, but just inside is fine
+		op := withPolicy(proj, core.PoleError)
+		for _, lat := range []float64{90.0, -90.0} {
+			_, err := forward(op, 10.0, lat)
+			assert.Error(err, proj)
+		}
+		xy, err := forward(op, 10.0, 89.9999)
+		assert.NoError(err, proj)
+		assert.True(xy.Y > 4.0e7, proj)
+		xy, err = forward(op, 10.0, -89.9999)
+		assert.NoError(err, proj)
+		assert.True(xy.Y < -4.0e7, proj)
+
+		// infinity
+		op = withPolicy(proj, core.PoleInfinity)
+		xy, err = forward(op, 10.0, 90.0)
+		assert.NoError(err, proj)
+		assert.True(math.IsInf(xy.Y, 1), proj)
+		assert.InDelta(1113194.91, xy.X, 0.01, proj)
+		xy, err = forward(op, 10.0, -90.0)
+		assert.NoError(err, proj)
+		assert.True(math.IsInf(xy.Y, -1), proj)
+		xy, err = forward(op, 10.0, 89.9999)
+		assert.NoError(err, proj)
+		assert.False(math.IsInf(xy.Y, 0), proj)
+
+		// clamping, to the square of Web Mercator
+		op = withPolicy(proj, core.PoleClamp)
+		limit, err := forward(op, 10.0, support.RToDD(operations.MercMaxLat))
+		assert.NoError(err, proj)
+		for _, lat := range []float64{90.0, 89.9999, 86.0} {
+			xy, err = forward(op, 10.0, lat)
+			assert.NoError(err, proj)
+			assert.InDelta(limit.Y, xy.Y, 1.0e-6, proj)
+			xy, err = forward(op, 10.0, -lat)
+			assert.NoError(err, proj)
+			assert.InDelta(-limit.Y, xy.Y, 1.0e-6, proj)
+		}
+		xy, err = forward(op, 10.0, 80.0)
+		assert.NoError(err, proj)
+		assert.True(xy.Y < limit.Y, proj)
+	}
+
+	xy, err := forward(withPolicy("+proj=merc +a=6378137 +b=6378137", core.PoleClamp), 10.0, 90.0)
+	assert.NoError(err)
+	assert.InDelta(math.Pi*6378137, xy.Y, 1.0e-6)
+
+	// eqc is not singular at the poles, whatever the policy
+	for _, policy := range []core.PolePolicy{core.PoleError, core.PoleInfinity, core.PoleClamp} {
+		op := withPolicy("+proj=eqc +a=6378137 +b=6378137", policy)
+		xy, err := forward(op, 10.0, 90.0)
+		assert.NoError(err)
+		assert.InDelta(math.Pi/2*6378137, xy.Y, 1.0e-6)
+		xy, err = forward(op, 10.0, -90.0)
+		assert.NoError(err)
+		assert.InDelta(-math.Pi/2*6378137, xy.Y, 1.0e-6)
+	}
+
+	// with +lat_max, the policy applies beyond it rather than at the poles
+	for _, proj := range []string{"+proj=merc +ellps=WGS84 +lat_max=85", "+proj=merc +a=6378137 +b=6378137 +lat_max=85"} {
+		limit, err := forward(withPolicy(proj, core.PoleError), 10.0, 85.0)
+		assert.NoError(err, proj)
+
+		op := withPolicy(proj, core.PoleError)
+		for _, lat := range []float64{89.9999, -89.9999, 85.0001} {
+			_, err := forward(op, 10.0, lat)
+			assert.Error(err, proj)
+		}
+		xy, err := forward(op, 10.0, -85.0)
+		assert.NoError(err, proj)
+		assert.InDelta(-limit.Y, xy.Y, 1.0e-6, proj)
+
+		op = withPolicy(proj, core.PoleInfinity)
+		xy, err = forward(op, 10.0, 89.9999)
+		assert.NoError(err, proj)
+		assert.True(math.IsInf(xy.Y, 1), proj)
+		xy, err = forward(op, 10.0, 84.9999)
+		assert.NoError(err, proj)
+		assert.False(math.IsInf(xy.Y, 0), proj)
+
+		op = withPolicy(proj, core.PoleClamp)
+		for _, lat := range []float64{90.0, 89.9999, 85.5} {
+			xy, err = forward(op, 10.0, lat)
+			assert.NoError(err, proj)
+			assert.InDelta(limit.Y, xy.Y, 1.0e-6, proj)
+			xy, err = forward(op, 10.0, -lat)
+			assert.NoError(err, proj)
+			assert.InDelta(-limit.Y, xy.Y, 1.0e-6, proj)
+		}
+	}
+
+	// and must be a latitude
+	for _, proj := range []string{"+proj=merc +lat_max=0", "+proj=merc +lat_max=91"} {
+		_, err := newOp(proj)
+		assert.Error(err, proj)
+	}
+}
+
+func TestEqc(t *testing.T) {
+	assert := assert.New(t)
+
+	// x = R cos(lat_ts) lon, y = R (lat - lat_0), as for EPSG's
+	// spherical method, with lat_ts in degrees
+	for _, tc := range []struct {
+		proj string
+		x, y float64
+	}{
+		{"+proj=eqc +R=6371007", 1111950.4881760636, 6115727.68496835},
+		{"+proj=eqc +R=6400000 +lat_ts=60", 558505.3606381856, 6143558.967020},
+		{"+proj=eqc +R=6400000 +lat_ts=-60 +lat_0=30", 558505.3606381856, 2792526.803191},
+	} {
+		op, err := newOp(tc.proj)
+		assert.NoError(err, tc.proj)
+		xy, err := forward(op, 10.0, 55.0)
+		assert.NoError(err, tc.proj)
+		assert.InDelta(tc.x, xy.X, 1.0e-6, tc.proj)
+		assert.InDelta(tc.y, xy.Y, 1.0e-6, tc.proj)
+
+		lp, err := op.Inverse(xy)
+		assert.NoError(err, tc.proj)
+		assert.InDelta(10.0, support.RToDD(lp.Lam), 1.0e-12, tc.proj)
+		assert.InDelta(55.0, support.RToDD(lp.Phi), 1.0e-12, tc.proj)
+	}
+
+	for _, proj := range []string{"+proj=eqc +R=1 +lat_ts=90", "+proj=eqc +R=1 +lat_ts=-100"} {
+		_, err := newOp(proj)
+		assert.Error(err, proj)
+	}
+}
+
+func TestCea(t *testing.T) {
+	assert := assert.New(t)
+
+	// the corner of the EASE-Grid 2.0 global grid, as NSIDC gives it
+	op, err := newOp("+proj=cea +lon_0=0 +lat_ts=30 +x_0=0 +y_0=0 +ellps=WGS84 +units=m")
+	assert.NoError(err)
+	xy, err := forward(op, -180.0, 85.0445664)
+	assert.NoError(err)
+	assert.InDelta(-17367530.45, xy.X, 0.01)
+	assert.InDelta(7314540.83, xy.Y, 0.01)
+	lp, err := op.Inverse(xy)
+	assert.NoError(err)
+	assert.InDelta(-180.0, support.RToDD(lp.Lam), 1.0e-9)
+	assert.InDelta(85.0445664, support.RToDD(lp.Phi), 1.0e-7)
+
+	// Gall-Peters on the sphere, where the poles are at R / cos(lat_ts)
+	op, err = newOp("+proj=cea +R=1 +lat_ts=45")
+	assert.NoError(err)
+	xy, err = op.Forward(&core.CoordLP{Lam: support.DDToR(90.0), Phi: support.PiOverTwo})
+	assert.NoError(err)
+	assert.InDelta(math.Pi/2.0*math.Sqrt(0.5), xy.X, 1.0e-12)
+	assert.InDelta(math.Sqrt2, xy.Y, 1.0e-12)
+	lp, err = op.Inverse(xy)
+	assert.NoError(err)
+	assert.InDelta(90.0, support.RToDD(lp.Phi), 1.0e-9)
+	_, err = op.Inverse(&core.CoordXY{X: 0.0, Y: 1.5})
+	assert.Error(err)
+
+	for _, proj := range []string{"+proj=cea +R=1 +lat_ts=90", "+proj=cea +ellps=GRS80 +lat_ts=-100"} {
+		_, err := newOp(proj)
+		assert.Error(err, proj)
+	}
+}
+
+func TestLaea(t *testing.T) {
+	assert := assert.New(t)
+
+	// EPSG Guidance Note 7-2, 3.2.3.2: ETRS89 / LAEA Europe, less its false
+	// origin
+	op, err := newOp("+proj=laea +lat_0=52 +lon_0=10 +ellps=GRS80")
+	assert.NoError(err)
+	xy, err := forward(op, 5.0, 50.0)
+	assert.NoError(err)
+	assert.InDelta(3962799.45-4321000.0, xy.X, 0.01)
+	assert.InDelta(2999718.85-3210000.0, xy.Y, 0.01)
+
+	// every aspect, on the sphere and the ellipsoid, comes back
+	for _, proj := range []string{
+		"+proj=laea +lat_0=52 +ellps=GRS80",
+		"+proj=laea +lat_0=90 +ellps=WGS84",
+		"+proj=laea +lat_0=-90 +ellps=WGS84",
+		"+proj=laea +lat_0=52 +R=6371228",
+		"+proj=laea +lat_0=0 +R=6371228",
+		"+proj=laea +lat_0=90 +R=6371228",
+		"+proj=laea +lat_0=-90 +R=6371228",
+	} {
+		op, err := newOp(proj)
+		assert.NoError(err, proj)
+		for _, lp := range []*core.CoordLP{{Lam: 0.3, Phi: 0.4}, {Lam: -2.0, Phi: -0.2}} {
+			xy, err := op.Forward(lp)
+			assert.NoError(err, proj)
+			back, err := op.Inverse(xy)
+			assert.NoError(err, proj)
+			assert.InDelta(lp.Lam, back.Lam, 1.0e-9, proj)
+			assert.InDelta(lp.Phi, back.Phi, 1.0e-9, proj)
+		}
+	}
+
+	// the antipode of the center can't be projected
+	op, err = newOp("+proj=laea +lat_0=90 +R=1")
+	assert.NoError(err)
+	_, err = op.Forward(&core.CoordLP{Lam: 0.0, Phi: -support.PiOverTwo})
+	assert.Error(err)
+	_, err = op.Inverse(&core.CoordXY{X: 2.5, Y: 0.0})
+	assert.Error(err)
+}
+
+func TestStere(t *testing.T) {
+	assert := assert.New(t)
+
+	// EPSG Guidance Note 7-2, 3.2.2.1 and 3.2.2.2: the polar stereographic,
+	// variants A and B, less their false origins; UPS is variant A, with
+	// its false origin
+	for _, tc := range []struct {
+		proj        string
+		lon, lat    float64
+		x, y, delta float64
+	}{
+		{"+proj=stere +lat_0=90 +lon_0=0 +k=0.994 +ellps=WGS84", 44.0, 73.0, 3320416.75 - 2000000.0, 632668.43 - 2000000.0, 0.01},
+		{"+proj=ups +ellps=WGS84", 44.0, 73.0, 3320416.75, 632668.43, 0.01},
+		{"+proj=stere +lat_0=-90 +lat_ts=-71 +lon_0=70 +ellps=WGS84", 120.0, -75.0, 7255380.79 - 6000000.0, 7053389.56 - 6000000.0, 0.01},
+
+		// 3.2.2.3: the oblique stereographic, as on the Dutch RD grid
+		{"+proj=sterea +lat_0=52.15616055555555 +lon_0=5.38763888888889 +k=0.9999079 +ellps=bessel", 6.0, 53.0, 196105.283 - 155000.0, 557057.739 - 463000.0, 0.001},
+	} {
+		op, err := newOp(tc.proj)
+		assert.NoError(err, tc.proj)
+		xy, err := forward(op, tc.lon, tc.lat)
+		assert.NoError(err, tc.proj)
+		assert.InDelta(tc.x, xy.X, tc.delta, tc.proj)
+		assert.InDelta(tc.y, xy.Y, tc.delta, tc.proj)
+	}
+
+	// the latitude of true scale may be DMS, with a hemisphere
+	op, err := newOp("+proj=stere +lat_0=-90 +lat_ts=-71 +lon_0=70 +ellps=WGS84")
+	assert.NoError(err)
+	dms, err := newOp("+proj=stere +lat_0=-90 +lat_ts=71d0'0\"S +lon_0=70 +ellps=WGS84")
+	assert.NoError(err)
+	xy, err := forward(op, 120.0, -75.0)
+	assert.NoError(err)
+	xyDMS, err := forward(dms, 120.0, -75.0)
+	assert.NoError(err)
+	assert.Equal(xy, xyDMS)
+
+	// every aspect, on the sphere and the ellipsoid, comes back
+	for _, proj := range []string{
+		"+proj=stere +lat_0=52 +ellps=GRS80",
+		"+proj=stere +lat_0=0 +ellps=GRS80",
+		"+proj=stere +lat_0=90 +lat_ts=70 +ellps=WGS84",
+		"+proj=stere +lat_0=-90 +ellps=WGS84",
+		"+proj=stere +lat_0=52 +R=6371228",
+		"+proj=stere +lat_0=0 +R=6371228",
+		"+proj=stere +lat_0=90 +lat_ts=70 +R=6371228",
+		"+proj=stere +lat_0=-90 +R=6371228",
+		"+proj=sterea +lat_0=52 +ellps=bessel",
+		"+proj=sterea +lat_0=-40 +R=6371228",
+		"+proj=ups +south +ellps=WGS84",
+	} {
+		op, err := newOp(proj)
+		assert.NoError(err, proj)
+		for _, lp := range []*core.CoordLP{{Lam: 0.3, Phi: 0.4}, {Lam: -2.0, Phi: -0.2}} {
+			xy, err := op.Forward(lp)
+			assert.NoError(err, proj)
+			back, err := op.Inverse(xy)
+			assert.NoError(err, proj)
+			assert.InDelta(lp.Lam, back.Lam, 1.0e-9, proj)
+			assert.InDelta(lp.Phi, back.Phi, 1.0e-9, proj)
+		}
+	}
+
+	// the opposite pole can't be projected, and UPS needs an ellipsoid
+	op, err = newOp("+proj=stere +lat_0=90 +R=1")
+	assert.NoError(err)
+	_, err = op.Forward(&core.CoordLP{Lam: 0.0, Phi: -support.PiOverTwo})
+	assert.Error(err)
+	op, err = newOp("+proj=stere +lat_0=90 +ellps=WGS84")
+	assert.NoError(err)
+	_, err = op.Forward(&core.CoordLP{Lam: 0.0, Phi: -support.PiOverTwo})
+	assert.Error(err)
+	_, err = newOp("+proj=ups +R=6371228")
+	assert.Error(err)
+}
+
+func TestLabrd(t *testing.T) {
+	assert := assert.New(t)
+
+	// the gie tests cover the projection itself; lat_0 is required, as
+	// the conformal sphere is tangent along it
+	for _, proj := range []string{"+proj=labrd +ellps=intl", "+proj=labrd +ellps=intl +lat_0=0 +azi=18.9"} {
+		_, err := newOp(proj)
+		assert.Error(err, proj)
+	}
+
+	op, err := newOp("+proj=labrd +ellps=intl +lat_0=-18.9 +azi=18.9 +k_0=0.9995")
+	assert.NoError(err)
+
+	xy, err := forward(op, 1.0, -20.0)
+	assert.NoError(err)
+	lp, err := op.Inverse(xy)
+	assert.NoError(err)
+	assert.InDelta(1.0, support.RToDD(lp.Lam), 1.0e-9)
+	assert.InDelta(-20.0, support.RToDD(lp.Phi), 1.0e-9)
+}
+
+func TestTMerc(t *testing.T) {
+	assert := assert.New(t)
+
+	// tmerc is etmerc unless +approx is given
+	exact, err := newOp("+proj=tmerc +ellps=GRS80 +lon_0=9")
+	assert.NoError(err)
+	assert.IsType(&operations.EtMerc{}, exact.(*core.ConvertLPToXY).Algorithm)
+	approx, err := newOp("+proj=tmerc +ellps=GRS80 +lon_0=9 +approx")
+	assert.NoError(err)
+	assert.IsType(&cylindrical.TMercApprox{}, approx.(*core.ConvertLPToXY).Algorithm)
+
+	// the approximation is within a millimeter near the central meridian,
+	// but not far from it
+	for _, tc := range []struct {
+		lon, lat, delta float64
+	}{
+		{11.0, 1.0, 1.0e-3},
+		{12.0, 45.0, 1.0e-3},
+		{13.0, 60.0, 1.0e-3},
+		{49.0, 20.0, 100.0},
+	} {
+		a, err := forward(exact, tc.lon, tc.lat)
+		assert.NoError(err)
+		b, err := forward(approx, tc.lon, tc.lat)
+		assert.NoError(err)
+		if tc.delta < 1.0 {
+			assert.InDelta(a.X, b.X, tc.delta)
+			assert.InDelta(a.Y, b.Y, tc.delta)
+
+			lp, err := approx.Inverse(b)
+			assert.NoError(err)
+			assert.InDelta(tc.lon, support.RToDD(lp.Lam), 1.0e-9)
+			assert.InDelta(tc.lat, support.RToDD(lp.Phi), 1.0e-9)
+		} else {
+			assert.False(math.Abs(a.X-b.X) < tc.delta && math.Abs(a.Y-b.Y) < tc.delta)
+		}
+	}
+	_, err = forward(approx, 100.0, 0.0)
+	assert.Error(err)
+
+	// and so is utm
+	utm, err := newOp("+proj=utm +zone=32 +ellps=GRS80 +approx")
+	assert.NoError(err)
+	assert.IsType(&cylindrical.TMercApprox{}, utm.(*core.ConvertLPToXY).Algorithm)
+	etmerc, err := newOp("+proj=utm +zone=32 +ellps=GRS80")
+	assert.NoError(err)
+	a, err := forward(etmerc, 11.0, 45.0)
+	assert.NoError(err)
+	b, err := forward(utm, 11.0, 45.0)
+	assert.NoError(err)
+	assert.InDelta(657630.6407, b.X, 1.0e-3)
+	assert.InDelta(a.X, b.X, 1.0e-3)
+	assert.InDelta(a.Y, b.Y, 1.0e-3)
+
+	// +exact is within a few nanometers of etmerc where the series holds,
+	// and good far beyond, where it doesn't
+	exactly, err := newOp("+proj=tmerc +ellps=GRS80 +lon_0=9 +exact")
+	assert.NoError(err)
+	assert.IsType(&cylindrical.TMercExact{}, exactly.(*core.ConvertLPToXY).Algorithm)
+	for _, tc := range []struct {
+		lon, lat float64
+	}{
+		{11.0, 1.0}, {12.0, 45.0}, {-20.0, 30.0}, {53.0, 80.0}, {69.0, 10.0}, {98.0, 1.0}, {-81.0, -30.0},
+	} {
+		b, err := forward(exactly, tc.lon, tc.lat)
+		assert.NoError(err)
+		if math.Abs(tc.lon-9.0) < 30.0 {
+			a, err := forward(exact, tc.lon, tc.lat)
+			assert.NoError(err)
+			assert.InDelta(a.X, b.X, 1.0e-8)
+			assert.InDelta(a.Y, b.Y, 1.0e-8)
+		}
+		lp, err := exactly.Inverse(b)
+		assert.NoError(err)
+		assert.InDelta(tc.lon, support.RToDD(lp.Lam), 1.0e-12)
+		assert.InDelta(tc.lat, support.RToDD(lp.Phi), 1.0e-12)
+	}
+
+	// the pole is a quarter meridian, 10001965.7293 m on GRS80, north
+	pole, err := forward(exactly, 9.0, 90.0)
+	assert.NoError(err)
+	assert.InDelta(0.0, pole.X, 1.0e-9)
+	assert.InDelta(10001965.7293, pole.Y, 1.0e-4)
+
+	// and so is utm and etmerc, but +approx and +exact don't mix
+	utm, err = newOp("+proj=utm +zone=32 +ellps=GRS80 +exact")
+	assert.NoError(err)
+	assert.IsType(&cylindrical.TMercExact{}, utm.(*core.ConvertLPToXY).Algorithm)
+	b, err = forward(utm, 11.0, 45.0)
+	assert.NoError(err)
+	assert.InDelta(a.X, b.X, 1.0e-9)
+	assert.InDelta(a.Y, b.Y, 1.0e-9)
+	etmerc, err = newOp("+proj=etmerc +ellps=GRS80 +exact")
+	assert.NoError(err)
+	assert.IsType(&cylindrical.TMercExact{}, etmerc.(*core.ConvertLPToXY).Algorithm)
+	for _, proj := range []string{
+		"+proj=tmerc +ellps=GRS80 +approx +exact",
+		"+proj=utm +zone=32 +ellps=GRS80 +approx +exact",
+		"+proj=tmerc +ellps=GRS80 +exact=1",
+	} {
+		_, err := newOp(proj)
+		assert.Error(err, proj)
+	}
+}
+
 func BenchmarkConvertEtMerc(b *testing.B) {
 
 	ps, _ := support.NewProjString("+proj=utm +zone=32 +ellps=GRS80")
 	_, opx, _ := core.NewSystem(ps)
 	op := opx.(core.IConvertLPToXY)
-	input := &core.CoordLP{Lam: support.DDToR(12.0), Phi: support.DDToR(55.0)}
 
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
+		// Forward adjusts its input for the central meridian
+		input := &core.CoordLP{Lam: support.DDToR(12.0), Phi: support.DDToR(55.0)}
+		_, _ = op.Forward(input)
+	}
+}
+
+func BenchmarkConvertTMercApprox(b *testing.B) {
+
+	ps, _ := support.NewProjString("+proj=utm +zone=32 +ellps=GRS80 +approx")
+	_, opx, _ := core.NewSystem(ps)
+	op := opx.(core.IConvertLPToXY)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		// Forward adjusts its input for the central meridian
+		input := &core.CoordLP{Lam: support.DDToR(12.0), Phi: support.DDToR(55.0)}
+		_, _ = op.Forward(input)
+	}
+}
+
+func BenchmarkConvertTMercExact(b *testing.B) {
+
+	ps, _ := support.NewProjString("+proj=utm +zone=32 +ellps=GRS80 +exact")
+	_, opx, _ := core.NewSystem(ps)
+	op := opx.(core.IConvertLPToXY)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		// Forward adjusts its input for the central meridian
+		input := &core.CoordLP{Lam: support.DDToR(12.0), Phi: support.DDToR(55.0)}
 		_, _ = op.Forward(input)
 	}
 }
@@ -267,3 +832,443 @@ func BenchmarkConvertWintri(b *testing.B) {
 		_, _ = op.Forward(input)
 	}
 }
+
+func TestFlags(t *testing.T) {
+	assert := assert.New(t)
+
+	// leac's +south is the mirror image of the northern cone
+	op, err := newOp("+proj=leac +ellps=GRS80 +lat_1=10")
+	assert.NoError(err)
+	north, err := forward(op, 2.0, 20.0)
+	assert.NoError(err)
+	op, err = newOp("+proj=leac +ellps=GRS80 +lat_1=-10 +south")
+	assert.NoError(err)
+	south, err := forward(op, 2.0, -20.0)
+	assert.NoError(err)
+	assert.InDelta(north.X, south.X, 1.0e-6)
+	assert.InDelta(-north.Y, south.Y, 1.0e-6)
+
+	// a flag may be given a boolean value
+	for _, tc := range []struct {
+		proj, same string
+		lat        float64
+	}{
+		{"+proj=leac +ellps=GRS80 +lat_1=-10 +south", "+proj=leac +ellps=GRS80 +lat_1=-10 +south=true", -20.0},
+		{"+proj=leac +ellps=GRS80 +lat_1=-10", "+proj=leac +ellps=GRS80 +lat_1=-10 +south=F", -20.0},
+		{"+proj=utm +zone=30 +ellps=GRS80", "+proj=utm +zone=30 +ellps=GRS80 +south=f", 20.0},
+	} {
+		op, err := newOp(tc.proj)
+		assert.NoError(err, tc.proj)
+		same, err := newOp(tc.same)
+		assert.NoError(err, tc.same)
+		xy, err := forward(op, 2.0, tc.lat)
+		assert.NoError(err, tc.proj)
+		xySame, err := forward(same, 2.0, tc.lat)
+		assert.NoError(err, tc.same)
+		assert.Equal(xy, xySame, tc.same)
+	}
+}
+
+func TestOmerc(t *testing.T) {
+	assert := assert.New(t)
+
+	points := [][]float64{{2, 1}, {-10, 40}, {25, -60}}
+
+	// the same points project the same way under op and other
+	same := func(op, other core.IConvertLPToXY, tag string) {
+		for _, p := range points {
+			xy, err := forward(op, p[0], p[1])
+			assert.NoError(err, tag)
+			xyOther, err := forward(other, p[0], p[1])
+			assert.NoError(err, tag)
+			assert.Equal(xy, xyOther, tag)
+		}
+	}
+
+	// on the sphere, a central line along the equator is plain Mercator,
+	// however it is given
+	merc, err := newOp("+proj=merc +R=6400000")
+	assert.NoError(err)
+	for _, proj := range []string{
+		"+proj=omerc +R=6400000 +alpha=90",
+		"+proj=omerc +R=6400000 +gamma=90",
+		"+proj=omerc +R=6400000 +alpha=90 +gamma=90",
+	} {
+		op, err := newOp(proj)
+		assert.NoError(err, proj)
+		for _, p := range points {
+			expected, err := forward(merc, p[0], p[1])
+			assert.NoError(err, proj)
+			xy, err := forward(op, p[0], p[1])
+			assert.NoError(err, proj)
+			assert.InDelta(expected.X, xy.X, 1.0e-6, proj)
+			assert.InDelta(expected.Y, xy.Y, 1.0e-6, proj)
+		}
+	}
+
+	// gamma defaults to alpha
+	base := "+proj=omerc +ellps=GRS80 +lat_0=45 +lonc=10 +alpha=30 +k=0.9996 +x_0=100 +y_0=200"
+	a, err := newOp(base)
+	assert.NoError(err)
+	b, err := newOp(base + " +gamma=30")
+	assert.NoError(err)
+	same(a, b, base)
+	c, err := newOp(base + " +gamma=20")
+	assert.NoError(err)
+	for _, p := range points {
+		xyA, err := forward(a, p[0], p[1])
+		assert.NoError(err)
+		xyC, err := forward(c, p[0], p[1])
+		assert.NoError(err)
+		assert.NotEqual(xyA, xyC)
+	}
+
+	// the angles may be given in DMS, or with a hemisphere
+	for _, proj := range []string{
+		"+proj=omerc +ellps=GRS80 +lat_0=45 +lonc=10E +alpha=30d0'0\" +k=0.9996 +x_0=100 +y_0=200",
+		"+proj=omerc +ellps=GRS80 +lat_0=45 +lonc=10d +alpha=30 +gamma=30.0n +k=0.9996 +x_0=100 +y_0=200",
+	} {
+		op, err := newOp(proj)
+		assert.NoError(err, proj)
+		same(a, op, proj)
+	}
+	west, err := newOp("+proj=omerc +ellps=GRS80 +lat_0=40 +lat_1=38 +lon_1=5W +lat_2=44 +lon_2=8")
+	assert.NoError(err)
+	east, err := newOp("+proj=omerc +ellps=GRS80 +lat_0=40 +lat_1=38 +lon_1=-5 +lat_2=44 +lon_2=8")
+	assert.NoError(err)
+	same(east, west, "lon_1=5W")
+
+	// no_off (or no_uoff) shifts the origin along the rotated central line
+	noOff, err := newOp(base + " +no_off")
+	assert.NoError(err)
+	noUoff, err := newOp(base + " +no_uoff")
+	assert.NoError(err)
+	same(noOff, noUoff, "no_uoff")
+	var shift *core.CoordXY
+	for _, p := range points {
+		xy, err := forward(a, p[0], p[1])
+		assert.NoError(err)
+		xyNoOff, err := forward(noOff, p[0], p[1])
+		assert.NoError(err)
+		d := &core.CoordXY{X: xyNoOff.X - xy.X, Y: xyNoOff.Y - xy.Y}
+		if shift == nil {
+			shift = d
+			assert.True(math.Hypot(d.X, d.Y) > 1000.0)
+			assert.InDelta(math.Tan(support.DDToR(30.0)), d.X/d.Y, 1.0e-9)
+			continue
+		}
+		assert.InDelta(shift.X, d.X, 1.0e-6)
+		assert.InDelta(shift.Y, d.Y, 1.0e-6)
+	}
+
+	// every form round-trips
+	for _, proj := range []string{
+		base,
+		base + " +gamma=20",
+		"+proj=omerc +ellps=GRS80 +lat_0=45 +lonc=10 +gamma=20 +no_off",
+		base + " +no_rot",
+		"+proj=omerc +ellps=GRS80 +lat_0=40 +lat_1=38 +lon_1=-5 +lat_2=44 +lon_2=8",
+		"+proj=omerc +ellps=GRS80 +lat_0=-30 +lat_1=-38 +lon_1=175 +lat_2=-25 +lon_2=-170 +no_rot",
+	} {
+		op, err := newOp(proj)
+		assert.NoError(err, proj)
+		for _, p := range points {
+			xy, err := forward(op, p[0], p[1])
+			assert.NoError(err, proj)
+			lp, err := op.Inverse(xy)
+			assert.NoError(err, proj)
+			assert.InDelta(p[0], support.RToDD(lp.Lam), 1.0e-9, proj)
+			assert.InDelta(p[1], support.RToDD(lp.Phi), 1.0e-9, proj)
+		}
+	}
+
+	// the two points must make a line
+	for _, proj := range []string{
+		"+proj=omerc +ellps=GRS80 +lat_1=10 +lat_2=10 +lon_2=5",
+		"+proj=omerc +ellps=GRS80 +lat_1=0 +lat_2=10",
+		"+proj=omerc +ellps=GRS80 +lat_1=10 +lat_2=90",
+	} {
+		_, err := newOp(proj)
+		assert.Error(err, proj)
+	}
+}
+
+func TestSingularities(t *testing.T) {
+	assert := assert.New(t)
+
+	// a tangent lcc, where the secant formula for the cone constant is
+	// 0/0: the EPSG Guidance Note 7-2 example (Jamaica National Grid)
+	op, err := newOp("+proj=lcc +lat_1=18 +lat_0=18 +lon_0=-77 +k_0=1 +x_0=250000 +y_0=150000 +ellps=clrk66")
+	assert.NoError(err)
+	xy, err := forward(op, -(76.0 + 56.0/60.0 + 37.26/3600.0), 17.0+55.0/60.0+55.80/3600.0)
+	assert.NoError(err)
+	assert.InDelta(255966.58, xy.X, 0.01)
+	assert.InDelta(142493.51, xy.Y, 0.01)
+
+	// lat_1 = -lat_2 makes the cone a cylinder
+	for _, proj := range []string{
+		"+proj=lcc +ellps=GRS80 +lat_1=-30 +lat_2=30",
+		"+proj=lcc +ellps=GRS80 +lat_1=0",
+		"+proj=aea +ellps=GRS80 +lat_1=-30 +lat_2=30",
+	} {
+		_, err = newOp(proj)
+		assert.Error(err, proj)
+	}
+
+	// the pole at the apex of a cone is a point, the other one is infinitely
+	// far away
+	op, err = newOp("+proj=lcc +ellps=GRS80 +lat_1=33 +lat_2=45 +lat_0=90")
+	assert.NoError(err)
+	xy, err = forward(op, 10.0, 90.0)
+	assert.NoError(err)
+	assert.InDelta(0.0, xy.X, 1.0e-6)
+	assert.InDelta(0.0, xy.Y, 1.0e-6)
+	_, err = forward(op, 10.0, -90.0)
+	assert.Error(err)
+
+	op, err = newOp("+proj=lcc +ellps=GRS80 +lat_1=-33 +lat_2=-45")
+	assert.NoError(err)
+	_, err = forward(op, 10.0, 90.0)
+	assert.Error(err)
+	xy, err = forward(op, 10.0, -90.0)
+	assert.NoError(err)
+	assert.False(math.IsNaN(xy.X) || math.IsInf(xy.Y, 0))
+
+	// beyond the reach of the etmerc series is an error, not an infinity
+	op, err = newOp("+proj=etmerc +ellps=GRS80")
+	assert.NoError(err)
+	_, err = forward(op, 90.0, 0.0)
+	assert.Error(err)
+	_, err = op.Inverse(&core.CoordXY{X: 2.0e7, Y: 0.0})
+	assert.Error(err)
+
+	// points on the edge of the wintri map invert to that edge
+	op, err = newOp("+proj=wintri +a=6400000")
+	assert.NoError(err)
+	for _, lon := range []float64{180.0, -180.0} {
+		xy, err = forward(op, lon, 45.0)
+		assert.NoError(err)
+		lp, err := op.Inverse(xy)
+		assert.NoError(err)
+		assert.InDelta(lon, support.RToDD(lp.Lam), 1.0e-9)
+		assert.InDelta(45.0, support.RToDD(lp.Phi), 1.0e-9)
+	}
+}
+
+func TestPolarCones(t *testing.T) {
+	assert := assert.New(t)
+
+	// a cone tangent at a pole is the polar stereographic: the EPSG
+	// Guidance Note 7-2 example (Polar Stereographic variant A)
+	op, err := newOp("+proj=lcc +ellps=WGS84 +lat_1=90 +lat_0=90 +k_0=0.994 +x_0=2000000 +y_0=2000000")
+	assert.NoError(err)
+	xy, err := forward(op, 44.0, 73.0)
+	assert.NoError(err)
+	assert.InDelta(3320416.75, xy.X, 0.01)
+	assert.InDelta(632668.43, xy.Y, 0.01)
+	lp, err := op.Inverse(xy)
+	assert.NoError(err)
+	assert.InDelta(44.0, support.RToDD(lp.Lam), 1.0e-9)
+	assert.InDelta(73.0, support.RToDD(lp.Phi), 1.0e-9)
+	xy, err = forward(op, 44.0, 90.0)
+	assert.NoError(err)
+	assert.InDelta(2000000.0, xy.X, 1.0e-6)
+	assert.InDelta(2000000.0, xy.Y, 1.0e-6)
+	lp, err = op.Inverse(xy)
+	assert.NoError(err)
+	assert.InDelta(90.0, support.RToDD(lp.Phi), 1.0e-9)
+
+	// and its mirror image at the south pole
+	op, err = newOp("+proj=lcc +ellps=WGS84 +lat_1=-90 +lat_0=-90")
+	assert.NoError(err)
+	south, err := forward(op, 44.0, -73.0)
+	assert.NoError(err)
+	op, err = newOp("+proj=lcc +ellps=WGS84 +lat_1=90 +lat_0=90")
+	assert.NoError(err)
+	north, err := forward(op, 44.0, 73.0)
+	assert.NoError(err)
+	assert.InDelta(north.X, south.X, 1.0e-6)
+	assert.InDelta(-north.Y, south.Y, 1.0e-6)
+
+	// a secant cone can't have one of its parallels at a pole, and an
+	// oblique mercator's central line has no azimuth there
+	for _, proj := range []string{
+		"+proj=lcc +ellps=GRS80 +lat_1=90 +lat_2=30",
+		"+proj=lcc +ellps=GRS80 +lat_1=30 +lat_2=-90",
+		"+proj=omerc +ellps=GRS80 +lat_0=90 +alpha=30",
+		"+proj=omerc +ellps=GRS80 +lat_0=-90 +gamma=30",
+	} {
+		_, err = newOp(proj)
+		assert.Error(err, proj)
+	}
+
+	// the Albers and Lambert equal area cones already have their limits
+	for _, proj := range []string{
+		"+proj=aea +ellps=GRS80 +lat_1=90 +lat_2=90",
+		"+proj=aea +ellps=GRS80 +lat_1=90 +lat_2=30 +lat_0=90",
+		"+proj=leac +ellps=GRS80 +lat_1=-90 +south",
+	} {
+		op, err = newOp(proj)
+		assert.NoError(err, proj)
+		xy, err = forward(op, 10.0, 45.0)
+		assert.NoError(err, proj)
+		lp, err = op.Inverse(xy)
+		assert.NoError(err, proj)
+		assert.InDelta(10.0, support.RToDD(lp.Lam), 1.0e-9, proj)
+		assert.InDelta(45.0, support.RToDD(lp.Phi), 1.0e-9, proj)
+	}
+}
+
+func TestLCC(t *testing.T) {
+	assert := assert.New(t)
+
+	// roundTrip projects (lon, lat) with the operation for proj, checks
+	// that it comes back, and returns the projected point
+	roundTrip := func(proj string, lon, lat float64) *core.CoordXY {
+		op, err := newOp(proj)
+		assert.NoError(err, proj)
+		xy, err := forward(op, lon, lat)
+		assert.NoError(err)
+		lp, err := op.Inverse(&core.CoordXY{X: xy.X, Y: xy.Y})
+		assert.NoError(err)
+		assert.InDelta(lon, support.RToDD(lp.Lam), 1.0e-9)
+		assert.InDelta(lat, support.RToDD(lp.Phi), 1.0e-9)
+		return xy
+	}
+
+	// the EPSG Guidance Note 7-2 example (Lambert Conic Conformal 2SP,
+	// Texas South Central), with the false easting of 2000000 US survey
+	// feet in meters
+	xy := roundTrip("+proj=lcc +ellps=clrk66 +lat_1=28.38333333333333 +lat_2=30.28333333333333 "+
+		"+lat_0=27.83333333333333 +lon_0=-99 +x_0=609601.2192024384 +y_0=0", -96.0, 28.5)
+	assert.InDelta(2963503.91*1200.0/3937.0, xy.X, 0.01)
+	assert.InDelta(254759.80*1200.0/3937.0, xy.Y, 0.01)
+
+	// k_0 scales the cone about the false origin
+	xyScaled := roundTrip("+proj=lcc +ellps=clrk66 +lat_1=28.38333333333333 +lat_2=30.28333333333333 "+
+		"+lat_0=27.83333333333333 +lon_0=-99 +x_0=609601.2192024384 +y_0=0 +k_0=0.9999", -96.0, 28.5)
+	assert.InDelta(0.9999*(xy.X-609601.2192024384), xyScaled.X-609601.2192024384, 1.0e-6)
+	assert.InDelta(0.9999*xy.Y, xyScaled.Y, 1.0e-6)
+
+	// the angles may be DMS, or have a hemisphere, as in PROJ
+	xyDMS := roundTrip("+proj=lcc +ellps=clrk66 +lat_1=28d23'N +lat_2=30d17'N "+
+		"+lat_0=27d50'N +lon_0=99W +x_0=609601.2192024384 +y_0=0", -96.0, 28.5)
+	assert.InDelta(xy.X, xyDMS.X, 1.0e-6)
+	assert.InDelta(xy.Y, xyDMS.Y, 1.0e-6)
+	assert.Equal(roundTrip("+proj=aea +ellps=GRS80 +lat_1=29.5 +lat_2=45.5 +lat_0=23 +lon_0=-96", -100.0, 40.0),
+		roundTrip("+proj=aea +ellps=GRS80 +lat_1=29d30'N +lat_2=45.5N +lat_0=23N +lon_0=96W", -100.0, 40.0))
+
+	// and anything else is an error, not 0
+	for _, proj := range []string{
+		"+proj=lcc +ellps=GRS80 +lat_1=30 +lat_2=60 +lat_0=45X",
+		"+proj=lcc +ellps=GRS80 +lat_1=north +lat_2=60",
+		"+proj=aea +ellps=GRS80 +lat_1=30 +lat_2=6O",
+		"+proj=merc +ellps=GRS80 +lon_0=ten",
+		"+proj=merc +ellps=GRS80 +lat_ts=",
+	} {
+		_, err := newOp(proj)
+		assert.Error(err, proj)
+	}
+
+	// a south-oriented cone is the mirror image of the north-oriented one,
+	// on both sides of lon_0
+	north := "+proj=lcc +ellps=GRS80 +lat_1=30 +lat_2=60 +lon_0=20 +x_0=500000 +y_0=100000"
+	south := "+proj=lcc +ellps=GRS80 +lat_1=-30 +lat_2=-60 +lon_0=20 +x_0=500000 +y_0=-100000"
+	for _, lon := range []float64{-100.0, 5.0, 20.0, 35.0, 170.0} {
+		xyN := roundTrip(north, lon, 45.0)
+		xyS := roundTrip(south, lon, -45.0)
+		assert.InDelta(xyN.X, xyS.X, 1.0e-6)
+		assert.InDelta(xyN.Y, -xyS.Y, 1.0e-6)
+	}
+
+	// each inverse reports its own convergence, so the operation can be
+	// shared
+	op, err := newOp(north)
+	assert.NoError(err)
+	lcc := op.(*core.ConvertLPToXY)
+	assert.True(lcc.IsIterative())
+	xy, err = forward(op, 10.0, 45.0)
+	assert.NoError(err)
+	_, iterations, residual, err := lcc.InverseWithConvergence(xy)
+	assert.NoError(err)
+	assert.True(iterations > 0)
+	assert.True(residual < conic.LCCIterationEpsilon)
+}
+
+func TestAiryNoCut(t *testing.T) {
+	assert := assert.New(t)
+
+	// beyond the hemisphere, up to but not including the point opposite
+	// the center, where the projection is undefined
+	for _, tc := range []struct {
+		proj             string
+		lon, lat         float64
+		antiLon, antiLat float64
+	}{
+		{"+proj=airy +a=6400000 +no_cut", 120.0, 0.0, 180.0, 0.0},
+		{"+proj=airy +a=6400000 +no_cut +lat_0=90", 0.0, -30.0, 0.0, -90.0},
+		{"+proj=airy +a=6400000 +no_cut +lat_0=-90", 0.0, 30.0, 0.0, 90.0},
+		{"+proj=airy +a=6400000 +no_cut +lat_0=45", 180.0, 0.0, 180.0, -45.0},
+	} {
+		op, err := newOp(tc.proj)
+		assert.NoError(err)
+
+		xy, err := forward(op, tc.lon, tc.lat)
+		assert.NoError(err, tc.proj)
+		if err == nil {
+			assert.False(math.IsNaN(xy.X) || math.IsInf(xy.X, 0), tc.proj)
+			assert.False(math.IsNaN(xy.Y) || math.IsInf(xy.Y, 0), tc.proj)
+		}
+
+		_, err = forward(op, tc.antiLon, tc.antiLat)
+		assert.Error(err, tc.proj)
+	}
+}
+
+func TestNumericalInverses(t *testing.T) {
+	assert := assert.New(t)
+
+	// round trips over the whole domain, which is a hemisphere for airy
+	for _, proj := range []string{
+		"+proj=airy +a=6400000",
+		"+proj=airy +a=6400000 +lat_0=90",
+		"+proj=airy +a=6400000 +lat_0=-90",
+		"+proj=airy +a=6400000 +lat_0=45 +lat_b=30",
+		"+proj=august +a=6400000",
+	} {
+		op, err := newOp(proj)
+		assert.NoError(err)
+
+		for lon := -180.0; lon <= 180.0; lon += 15.0 {
+			for lat := -90.0; lat <= 90.0; lat += 15.0 {
+				xy, err := forward(op, lon, lat)
+				if err != nil {
+					continue
+				}
+				tag := fmt.Sprintf("%s (%g, %g)", proj, lon, lat)
+
+				lp, err := op.Inverse(xy)
+				assert.NoError(err, tag)
+				if err != nil {
+					continue
+				}
+				assert.InDelta(lat, support.RToDD(lp.Phi), 1.0e-8, tag)
+				if math.Abs(lat) < 90.0 {
+					dlon := math.Mod(math.Abs(support.RToDD(lp.Lam)-lon), 360.0)
+					assert.InDelta(0.0, math.Min(dlon, 360.0-dlon), 1.0e-8, tag)
+				}
+			}
+		}
+
+		// the convergence is reported as for the other iterative inverses
+		assert.True(op.(*core.ConvertLPToXY).IsIterative())
+		_, iterations, _, err := op.(*core.ConvertLPToXY).InverseWithConvergence(&core.CoordXY{X: 100000.0, Y: 100000.0})
+		assert.NoError(err)
+		assert.True(iterations > 0)
+
+		// and is finite when the guess is already the point
+		_, _, residual, err := op.(*core.ConvertLPToXY).InverseWithConvergence(&core.CoordXY{X: 0.0, Y: 0.0})
+		assert.NoError(err)
+		assert.True(residual < core.GenericInverseEpsilon, proj)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+// Package rhumb computes rhumb lines (loxodromes) on the ellipsoid: the
+// lines of constant azimuth, which are straight on a Mercator chart.
+//
+// All angles are in degrees and all distances in meters.
+package rhumb
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/oahumap/proj/support"
+)
+
+// tolerance below which a rhumb line is treated as running east-west
+const tol = 1.0e-12
+
+// Ellipsoid is the figure of the earth on which rhumb lines are computed
+type Ellipsoid struct {
+	a  float64   // major axis
+	e  float64   // eccentricity
+	es float64   // eccentricity squared
+	en []float64 // meridian distance coefficients
+}
+
+// NewEllipsoid returns the ellipsoid with major axis a and flattening f
+func NewEllipsoid(a, f float64) (*Ellipsoid, error) {
+	if !(a > 0.0) || !(f >= 0.0 && f < 1.0) {
+		return nil, fmt.Errorf("invalid ellipsoid: a=%g, f=%g", a, f)
+	}
+	es := f * (2.0 - f)
+	return &Ellipsoid{
+		a:  a,
+		e:  math.Sqrt(es),
+		es: es,
+		en: support.Enfn(es),
+	}, nil
+}
+
+// WGS84 is the WGS84 ellipsoid
+var WGS84, _ = NewEllipsoid(6378137.0, 1.0/298.257223563)
+
+// meridian returns the meridian distance from the equator to phi (radians)
+func (el *Ellipsoid) meridian(phi float64) float64 {
+	return el.a * support.Mlfn(phi, math.Sin(phi), math.Cos(phi), el.en)
+}
+
+// isometric returns the isometric latitude of phi (radians)
+func (el *Ellipsoid) isometric(phi float64) float64 {
+	return support.Asinh(math.Tan(phi)) - el.e*math.Atanh(el.e*math.Sin(phi))
+}
+
+// parallel returns the radius of the parallel at phi (radians)
+func (el *Ellipsoid) parallel(phi float64) float64 {
+	return el.a * support.Msfn(math.Sin(phi), math.Cos(phi), el.es)
+}
+
+// Inverse returns the length and azimuth (clockwise from north) of the
+// rhumb line from point 1 to point 2, taking the shorter way around in
+// longitude.
+func (el *Ellipsoid) Inverse(lon1, lat1, lon2, lat2 float64) (distance, azimuth float64) {
+	phi1 := support.DDToR(lat1)
+	phi2 := support.DDToR(lat2)
+	dlam := support.DDToR(math.Remainder(lon2-lon1, 360.0))
+
+	dpsi := el.isometric(phi2) - el.isometric(phi1)
+	alpha := math.Atan2(dlam, dpsi)
+
+	cosAlpha := math.Cos(alpha)
+	if math.Abs(cosAlpha) < tol || math.Abs(phi2-phi1) < tol {
+		// along a parallel
+		distance = math.Abs(dlam) * el.parallel(phi1)
+	} else {
+		distance = (el.meridian(phi2) - el.meridian(phi1)) / cosAlpha
+	}
+
+	return distance, support.RToDD(alpha)
+}
+
+// Direct returns the point reached by following the rhumb line with the
+// given azimuth (clockwise from north) for the given distance.
+//
+// It fails if the line would pass a pole, which a rhumb line only reaches
+// in the limit, after circling it infinitely many times.
+func (el *Ellipsoid) Direct(lon1, lat1, azimuth, distance float64) (lon2, lat2 float64, err error) {
+	phi1 := support.DDToR(lat1)
+	alpha := support.DDToR(azimuth)
+	cosAlpha := math.Cos(alpha)
+
+	m2 := el.meridian(phi1) + distance*cosAlpha
+	quarter := el.meridian(support.PiOverTwo)
+	if math.Abs(m2) > quarter {
+		return 0.0, 0.0, fmt.Errorf("rhumb line passes a pole")
+	}
+
+	phi2, err := support.InvMlfn(m2/el.a, el.es, el.en)
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+
+	var dlam float64
+	if math.Abs(cosAlpha) < tol || math.Abs(phi2-phi1) < tol {
+		// along a parallel
+		dlam = distance * math.Sin(alpha) / el.parallel(phi1)
+	} else {
+		dlam = math.Tan(alpha) * (el.isometric(phi2) - el.isometric(phi1))
+	}
+
+	lon2 = math.Remainder(lon1+support.RToDD(dlam), 360.0)
+	return lon2, support.RToDD(phi2), nil
+}
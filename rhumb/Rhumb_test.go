@@ -0,0 +1,74 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package rhumb_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj/rhumb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInverse(t *testing.T) {
+	assert := assert.New(t)
+
+	// JFK to LHR, as computed by GeographicLib's RhumbSolve
+	distance, azimuth := rhumb.WGS84.Inverse(-73.8, 40.6, -0.5, 51.6)
+	assert.InDelta(5771083.383, distance, 0.01)
+	assert.InDelta(77.76838971, azimuth, 1.0e-8)
+
+	// due east along the equator, across the antimeridian
+	distance, azimuth = rhumb.WGS84.Inverse(179.0, 0.0, -179.0, 0.0)
+	assert.InDelta(222638.98, distance, 0.01)
+	assert.InDelta(90.0, azimuth, 1.0e-12)
+
+	// due south along a meridian, twice the 1105854.8m from 10N to the equator
+	distance, azimuth = rhumb.WGS84.Inverse(10.0, 10.0, 10.0, -10.0)
+	assert.InDelta(2211709.7, distance, 0.1)
+	assert.InDelta(180.0, azimuth, 1.0e-12)
+}
+
+func TestDirect(t *testing.T) {
+	assert := assert.New(t)
+
+	// travelling about NE from JFK, as computed by GeographicLib's RhumbSolve
+	lon, lat, err := rhumb.WGS84.Direct(-73.8, 40.6, 51.0, 5.5e6)
+	assert.NoError(err)
+	assert.InDelta(71.68889988, lat, 1.0e-7)
+	assert.InDelta(0.25551982, lon, 1.0e-7)
+
+	// and back again
+	for _, tc := range [][]float64{
+		{-73.8, 40.6, -0.5, 51.6},
+		{170.0, -30.0, -170.0, -35.0},
+		{0.0, 45.0, 90.0, 45.0},
+	} {
+		distance, azimuth := rhumb.WGS84.Inverse(tc[0], tc[1], tc[2], tc[3])
+		lon, lat, err := rhumb.WGS84.Direct(tc[0], tc[1], azimuth, distance)
+		assert.NoError(err)
+		assert.InDelta(tc[2], lon, 1.0e-9)
+		assert.InDelta(tc[3], lat, 1.0e-9)
+	}
+
+	_, _, err = rhumb.WGS84.Direct(0.0, 80.0, 10.0, 2.0e6)
+	assert.Error(err)
+}
+
+func TestNewEllipsoid(t *testing.T) {
+	assert := assert.New(t)
+
+	sphere, err := rhumb.NewEllipsoid(6371000.0, 0.0)
+	assert.NoError(err)
+	distance, _ := sphere.Inverse(0.0, 0.0, 0.0, 90.0)
+	assert.InDelta(6371000.0*3.14159265358979/2, distance, 1.0e-3)
+
+	_, err = rhumb.NewEllipsoid(0.0, 0.0)
+	assert.Error(err)
+	_, err = rhumb.NewEllipsoid(6371000.0, 1.0)
+	assert.Error(err)
+}
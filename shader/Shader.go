@@ -0,0 +1,269 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+// Package shader emits the forward formulas of projections as GLSL or
+// WGSL functions, so that map renderers can reproject vertices on the GPU
+// and get the same results as this library does on the CPU.
+//
+// GPUs work in 32-bit floats, so the results agree to about the precision
+// of a float32, a few parts in ten million: a meter or so for coordinates
+// the size of the Earth. Renderers needing better should project relative
+// to a local origin.
+package shader
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/oahumap/proj"
+	"github.com/oahumap/proj/core"
+	"github.com/oahumap/proj/merror"
+	"github.com/oahumap/proj/operations/conic"
+	"github.com/oahumap/proj/support"
+)
+
+// Constant is a named value baked into a Program
+type Constant struct {
+	Name  string
+	Value float64
+}
+
+// Program is the forward formula of a projection, with its parameters
+// resolved to constants, ready to be emitted as shader source
+type Program struct {
+	Operation string // "merc", "lcc", "stere" or "ups"
+	Axis      string // of the output, as for +axis: "enu" unless the system says otherwise
+	Constants []Constant
+
+	steps []step // the body of the function
+}
+
+// step assigns an expression, written in the subset of syntax common to
+// GLSL and WGSL, to a new variable
+type step struct {
+	name string
+	expr string
+}
+
+// Compile returns the program for the forward conversion of lon/lat
+// degrees to the given system, which may be given in any of the ways the
+// proj package takes, such as a proj string, "EPSG:3857" or WKT. The
+// output is on the system's axes, swapped or reversed as +axis says.
+//
+// Only merc, lcc, stere and ups are supported: the other operations,
+// including the oblique stereographic sterea, give an error.
+func Compile(def string) (*Program, error) {
+	ps, err := proj.ResolveDefinition(def)
+	if err != nil {
+		return nil, err
+	}
+
+	sys, op, err := core.NewSystem(ps)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Program{Operation: sys.OpDescr.ID, Axis: sys.Axis}
+	p.constant("PI", math.Pi)
+	p.constant("TWO_PI", 2.0*math.Pi)
+	p.constant("HALF_PI", support.PiOverTwo)
+	p.constant("LAM0", sys.Lam0+sys.FromGreenwich)
+	p.constant("A", sys.Ellipsoid.A)
+	p.constant("X0", sys.X0)
+	p.constant("Y0", sys.Y0)
+	p.constant("FROM_METER", sys.FromMeter)
+
+	// as per core.ConvertLPToXY's forward preparation
+	p.step("l", "radians(lonlat.x) - LAM0")
+	p.step("lam", "l - TWO_PI * floor((l + PI) / TWO_PI)")
+	p.step("phi", "clamp(radians(lonlat.y), -HALF_PI, HALF_PI)")
+
+	switch p.Operation {
+	case "merc":
+		p.constant("K0", sys.K0)
+		if sys.Ellipsoid.Es == 0.0 {
+			p.step("x", "K0 * lam")
+			p.step("y", "K0 * log(tan(0.5 * HALF_PI + 0.5 * phi))")
+		} else {
+			p.tsfn(sys.Ellipsoid.E, "phi")
+			p.step("x", "K0 * lam")
+			p.step("y", "-K0 * log(t)")
+		}
+
+	case "lcc":
+		n, f, rho0 := op.(*core.ConvertLPToXY).Algorithm.(*conic.LCC).Cone()
+		p.constant("K0", sys.K0)
+		p.constant("N", n)
+		p.constant("F", f)
+		p.constant("RHO0", rho0)
+		p.tsfn(sys.Ellipsoid.E, "phi")
+		p.step("rho", "F * pow(t, N)")
+		p.step("x", "K0 * rho * sin(N * lam)")
+		p.step("y", "K0 * (RHO0 - rho * cos(N * lam))")
+
+	case "stere", "ups":
+		err := p.stere(sys)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, merror.New(merror.NotYetSupported)
+	}
+
+	// as per core.ConvertLPToXY's forward finalization, for classic
+	// operations, and core.System's AxisForward
+	if p.Axis == "enu" {
+		p.step("xm", "FROM_METER * (A * x + X0)")
+		p.step("ym", "FROM_METER * (A * y + Y0)")
+		return p, nil
+	}
+	p.step("easting", "FROM_METER * (A * x + X0)")
+	p.step("northing", "FROM_METER * (A * y + Y0)")
+	for i, name := range []string{"xm", "ym"} {
+		switch p.Axis[i] {
+		case 'e':
+			p.step(name, "easting")
+		case 'w':
+			p.step(name, "-easting")
+		case 'n':
+			p.step(name, "northing")
+		case 's':
+			p.step(name, "-northing")
+		}
+	}
+
+	return p, nil
+}
+
+func (p *Program) constant(name string, value float64) {
+	p.Constants = append(p.Constants, Constant{Name: name, Value: value})
+}
+
+func (p *Program) step(name, expr string) {
+	p.steps = append(p.steps, step{name: name, expr: expr})
+}
+
+// tsfn computes t for the latitude in the variable phi, as per
+// support.Tsfn
+func (p *Program) tsfn(e float64, phi string) {
+	p.constant("E", e)
+	p.step("esinphi", "E * sin("+phi+")")
+	p.step("t", "tan(0.5 * (HALF_PI - "+phi+")) / pow((1.0 - esinphi) / (1.0 + esinphi), 0.5 * E)")
+}
+
+// stere computes x and y, as per operations/azimuthal's Stere. On the
+// sphere its formulas are those for the ellipsoid with e = 0, and the
+// equatorial aspect is the oblique one with the origin on the equator.
+func (p *Program) stere(sys *core.System) error {
+	PE := sys.Ellipsoid
+	e := PE.E
+
+	phits := support.PiOverTwo
+	if sys.OpDescr.ID == "stere" {
+		lat, ok, err := sys.ProjString.GetAsDegrees("lat_ts")
+		if err != nil {
+			return err
+		}
+		if ok {
+			phits = math.Abs(support.DDToR(lat))
+		}
+	}
+
+	if math.Abs(math.Abs(sys.Phi0)-support.PiOverTwo) < eps10 {
+		var akm1 float64
+		if math.Abs(phits-support.PiOverTwo) < eps10 {
+			akm1 = 2.0 * sys.K0 / math.Sqrt(support.Pow(1.0+e, 1.0+e)*support.Pow(1.0-e, 1.0-e))
+		} else {
+			t := math.Sin(phits)
+			akm1 = math.Cos(phits) / support.Tsfn(phits, t, e)
+			t *= e
+			akm1 /= math.Sqrt(1.0 - t*t)
+		}
+
+		// the south pole is the north one turned over
+		p.constant("AKM1", akm1)
+		p.constant("POLE", math.Copysign(1.0, sys.Phi0))
+		p.step("pphi", "POLE * phi")
+		p.tsfn(e, "pphi")
+		p.step("x", "AKM1 * t * sin(lam)")
+		p.step("y", "-POLE * AKM1 * t * cos(lam)")
+		return nil
+	}
+
+	sinphi0 := math.Sin(sys.Phi0)
+	X1 := 2.0*math.Atan(ssfn(sys.Phi0, sinphi0, e)) - support.PiOverTwo
+	p.constant("AKM1", 2.0*sys.K0*math.Cos(sys.Phi0)/math.Sqrt(1.0-e*e*sinphi0*sinphi0))
+	p.constant("SINX1", math.Sin(X1))
+	p.constant("COSX1", math.Cos(X1))
+	p.constant("E", e)
+
+	// X is the conformal latitude
+	p.step("esinphi", "E * sin(phi)")
+	p.step("X", "2.0 * atan(tan(0.5 * (HALF_PI + phi)) * pow((1.0 - esinphi) / (1.0 + esinphi), 0.5 * E)) - HALF_PI")
+	p.step("A", "AKM1 / (COSX1 * (1.0 + SINX1 * sin(X) + COSX1 * cos(X) * cos(lam)))")
+	p.step("x", "A * cos(X) * sin(lam)")
+	p.step("y", "A * (COSX1 * sin(X) - SINX1 * cos(X) * cos(lam))")
+	return nil
+}
+
+// ssfn is tan(pi/4 + phi/2) ((1 - e sin(phi)) / (1 + e sin(phi)))^(e/2),
+// as per operations/azimuthal
+func ssfn(phi, sinphi, e float64) float64 {
+	sinphi *= e
+	return math.Tan(0.5*(support.PiOverTwo+phi)) * support.Pow((1.0-sinphi)/(1.0+sinphi), 0.5*e)
+}
+
+const eps10 = 1.e-10
+
+// GLSL returns the program as a GLSL function with the given name, taking
+// lon/lat degrees and returning x/y
+func (p *Program) GLSL(name string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "// %s forward, generated by github.com/oahumap/proj/shader\n", p.Operation)
+	fmt.Fprintf(&b, "vec2 %s(vec2 lonlat) {\n", name)
+	for _, c := range p.Constants {
+		fmt.Fprintf(&b, "    const float %s = %s;\n", c.Name, literal(c.Value))
+	}
+	for _, s := range p.steps {
+		fmt.Fprintf(&b, "    float %s = %s;\n", s.name, s.expr)
+	}
+	b.WriteString("    return vec2(xm, ym);\n}\n")
+
+	return b.String()
+}
+
+// WGSL returns the program as a WGSL function with the given name, taking
+// lon/lat degrees and returning x/y
+func (p *Program) WGSL(name string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "// %s forward, generated by github.com/oahumap/proj/shader\n", p.Operation)
+	fmt.Fprintf(&b, "fn %s(lonlat: vec2<f32>) -> vec2<f32> {\n", name)
+	for _, c := range p.Constants {
+		fmt.Fprintf(&b, "    const %s: f32 = %s;\n", c.Name, literal(c.Value))
+	}
+	for _, s := range p.steps {
+		fmt.Fprintf(&b, "    let %s = %s;\n", s.name, s.expr)
+	}
+	b.WriteString("    return vec2<f32>(xm, ym);\n}\n")
+
+	return b.String()
+}
+
+// literal formats a float constant so that both languages read it as a
+// float, to the precision of a float64 (the compiler rounds it)
+func literal(v float64) string {
+	s := strconv.FormatFloat(v, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eEn") {
+		s += ".0"
+	}
+	return s
+}
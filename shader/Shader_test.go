@@ -0,0 +1,197 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package shader_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/oahumap/proj/shader"
+	"github.com/stretchr/testify/assert"
+)
+
+// forward mirrors the emitted formulas, in float32 as on a GPU
+func forward(p *shader.Program, lon, lat float64) (float64, float64) {
+	c := map[string]float32{}
+	for _, k := range p.Constants {
+		c[k.Name] = float32(k.Value)
+	}
+	f := func(v float64) float64 { return float64(float32(v)) }
+
+	l := f(lon*math.Pi/180.0) - float64(c["LAM0"])
+	lam := f(l - float64(c["TWO_PI"])*math.Floor((l+float64(c["PI"]))/float64(c["TWO_PI"])))
+	phi := f(lat * math.Pi / 180.0)
+
+	e := float64(c["E"])
+	tsfn := func(phi float64) float64 {
+		esinphi := f(e * math.Sin(phi))
+		return f(math.Tan(0.5*(float64(c["HALF_PI"])-phi)) / math.Pow((1.0-esinphi)/(1.0+esinphi), 0.5*e))
+	}
+	t := tsfn(phi)
+
+	var x, y float64
+	switch p.Operation {
+	case "merc":
+		x = f(float64(c["K0"]) * lam)
+		y = f(-float64(c["K0"]) * math.Log(t))
+		if e == 0.0 {
+			y = f(float64(c["K0"]) * math.Log(math.Tan(0.5*float64(c["HALF_PI"])+0.5*phi)))
+		}
+	case "lcc":
+		n := float64(c["N"])
+		k0 := float64(c["K0"])
+		rho := f(float64(c["F"]) * math.Pow(t, n))
+		x = f(k0 * rho * math.Sin(n*lam))
+		y = f(k0 * (float64(c["RHO0"]) - rho*math.Cos(n*lam)))
+	case "stere", "ups":
+		akm1 := float64(c["AKM1"])
+		if pole, ok := c["POLE"]; ok {
+			t = tsfn(f(float64(pole) * phi))
+			x = f(akm1 * t * math.Sin(lam))
+			y = f(-float64(pole) * akm1 * t * math.Cos(lam))
+			break
+		}
+		sinX1, cosX1 := float64(c["SINX1"]), float64(c["COSX1"])
+		esinphi := f(e * math.Sin(phi))
+		X := f(2.0*math.Atan(math.Tan(0.5*(float64(c["HALF_PI"])+phi))*math.Pow((1.0-esinphi)/(1.0+esinphi), 0.5*e)) - float64(c["HALF_PI"]))
+		A := f(akm1 / (cosX1 * (1.0 + sinX1*math.Sin(X) + cosX1*math.Cos(X)*math.Cos(lam))))
+		x = f(A * math.Cos(X) * math.Sin(lam))
+		y = f(A * (cosX1*math.Sin(X) - sinX1*math.Cos(X)*math.Cos(lam)))
+	}
+
+	a := float64(c["A"])
+	easting := f(float64(c["FROM_METER"]) * (a*x + float64(c["X0"])))
+	northing := f(float64(c["FROM_METER"]) * (a*y + float64(c["Y0"])))
+
+	var out [2]float64
+	for i := range out {
+		switch p.Axis[i] {
+		case 'e':
+			out[i] = easting
+		case 'w':
+			out[i] = -easting
+		case 'n':
+			out[i] = northing
+		case 's':
+			out[i] = -northing
+		}
+	}
+	return out[0], out[1]
+}
+
+func TestCompile(t *testing.T) {
+	assert := assert.New(t)
+
+	defs := []string{
+		"3395",
+		"3857",
+		"EPSG:3857",
+		"urn:ogc:def:crs:EPSG::3857",
+		"900913",
+		"ESRI:102100",
+		"+proj=merc +lat_ts=30 +lon_0=10 +x_0=500 +y_0=-200 +ellps=GRS80",
+		"+proj=lcc +lat_1=33 +lat_2=45 +lat_0=39 +lon_0=-96 +x_0=0 +y_0=0 +ellps=GRS80 +units=m",
+		"+proj=lcc +lat_1=40.66666666666666 +lat_2=41.03333333333333 +lat_0=40.16666666666666 +lon_0=-74 +x_0=300000 +y_0=0 +ellps=GRS80 +units=us-ft",
+		// tangent cones, with a scale factor
+		"+proj=lcc +lat_1=18 +lat_0=18 +lon_0=-77 +x_0=250000 +y_0=150000 +ellps=clrk66",
+		"+proj=lcc +lat_1=46.8 +lat_0=46.8 +lon_0=2.337229166666667 +k_0=0.99987742 +x_0=600000 +y_0=2200000 +ellps=clrk80",
+		// polar stereographic, with and without lat_ts, and UPS
+		"3413",
+		"3995",
+		"+proj=stere +lat_0=-90 +k=0.994 +x_0=2000000 +y_0=2000000 +ellps=WGS84",
+		"+proj=ups +ellps=WGS84",
+		// oblique and equatorial stereographic
+		"+proj=stere +lat_0=52 +lon_0=5 +k=0.9999 +x_0=155000 +y_0=463000 +ellps=bessel",
+		"+proj=stere +lat_0=0 +lon_0=-20 +R=6371000",
+		// axes swapped or reversed
+		"+proj=merc +ellps=WGS84 +axis=neu",
+		"+proj=lcc +lat_1=33 +lat_2=45 +lat_0=39 +lon_0=-96 +ellps=GRS80 +axis=wsu",
+		"+proj=stere +lat_0=90 +lat_ts=70 +ellps=WGS84 +axis=nwu",
+	}
+	points := []float64{-73.9, 40.7, -96.0, 39.0, 12.5, -33.3, 179.9, 60.0}
+
+	for _, def := range defs {
+		p, err := shader.Compile(def)
+		if !assert.NoError(err, def) {
+			continue
+		}
+
+		expected, err := proj.Convert(def, points)
+		assert.NoError(err, def)
+
+		for i := 0; i < len(points); i += 2 {
+			x, y := forward(p, points[i], points[i+1])
+			// float32 precision
+			assert.InDelta(expected[i], x, 2.0+1e-6*math.Abs(expected[i]), def)
+			assert.InDelta(expected[i+1], y, 2.0+1e-6*math.Abs(expected[i+1]), def)
+		}
+	}
+}
+
+func TestCompileHighPrecision(t *testing.T) {
+	assert := assert.New(t)
+
+	// standard parallels a hair apart, where the cone constant needs
+	// extended precision; it is then the sine of the latitude between
+	proj.SetHighPrecision(true)
+	defer proj.SetHighPrecision(false)
+
+	p, err := shader.Compile("+proj=lcc +lat_1=40 +lat_2=40.0000001 +ellps=GRS80")
+	assert.NoError(err)
+	for _, c := range p.Constants {
+		if c.Name == "N" {
+			assert.InDelta(math.Sin((40.0+0.5e-7)*math.Pi/180.0), c.Value, 1e-13)
+		}
+	}
+}
+
+func TestCompileUnsupported(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := shader.Compile("4087")
+	assert.Error(err)
+
+	_, err = shader.Compile("+proj=lcc +lat_1=0 +lat_2=0 +ellps=GRS80")
+	assert.Error(err)
+
+	_, err = shader.Compile("999999")
+	assert.Error(err)
+
+	// the oblique stereographic on the Gauss conformal sphere, RD New
+	_, err = shader.Compile("EPSG:28992")
+	assert.Error(err)
+
+	_, err = shader.Compile("+proj=lcc +lat_1=north +ellps=GRS80")
+	assert.Error(err)
+}
+
+func TestSource(t *testing.T) {
+	assert := assert.New(t)
+
+	p, err := shader.Compile("+proj=lcc +lat_1=33 +lat_2=45 +lat_0=39 +lon_0=-96 +ellps=GRS80")
+	assert.NoError(err)
+
+	glsl := p.GLSL("to_albers")
+	assert.Contains(glsl, "vec2 to_albers(vec2 lonlat) {\n")
+	assert.Contains(glsl, "    const float FROM_METER = 1.0;\n")
+	assert.Contains(glsl, "    float rho = F * pow(t, N);\n")
+	assert.Contains(glsl, "    return vec2(xm, ym);\n}\n")
+
+	wgsl := p.WGSL("to_albers")
+	assert.Contains(wgsl, "fn to_albers(lonlat: vec2<f32>) -> vec2<f32> {\n")
+	assert.Contains(wgsl, "    const FROM_METER: f32 = 1.0;\n")
+	assert.Contains(wgsl, "    let rho = F * pow(t, N);\n")
+	assert.Contains(wgsl, "    return vec2<f32>(xm, ym);\n}\n")
+
+	p, err = shader.Compile("+proj=merc +ellps=WGS84 +axis=nwu")
+	assert.NoError(err)
+	glsl = p.GLSL("to_merc")
+	assert.Contains(glsl, "    float xm = northing;\n")
+	assert.Contains(glsl, "    float ym = -easting;\n")
+}
@@ -0,0 +1,25 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support
+
+import "math"
+
+// AngularUnitsTableEntry holds info about an angular unit
+type AngularUnitsTableEntry struct {
+	ID        string
+	Name      string
+	ToRadians float64
+}
+
+// AngularUnitsTable is the global list of angular units we know about,
+// keyed by the names PROJ uses for them
+var AngularUnitsTable = map[string]*AngularUnitsTableEntry{
+	"deg":  {"deg", "Degree", math.Pi / 180.0},
+	"rad":  {"rad", "Radian", 1.0},
+	"grad": {"grad", "Grad", math.Pi / 200.0},
+}
@@ -0,0 +1,28 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj/support"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAngularUnitsTable(t *testing.T) {
+	assert := assert.New(t)
+
+	for key, value := range support.AngularUnitsTable {
+		assert.Equal(key, value.ID)
+	}
+
+	// a right angle
+	assert.InDelta(math.Pi/2, 90.0*support.AngularUnitsTable["deg"].ToRadians, 1.0e-15)
+	assert.InDelta(math.Pi/2, 100.0*support.AngularUnitsTable["grad"].ToRadians, 1.0e-15)
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support
+
+import "fmt"
+
+func init() {
+
+	// each WGS 84 UTM zone covers its 6° of longitude, in one hemisphere
+	for zone := 1; zone <= 60; zone++ {
+		west := float64(-186 + 6*zone)
+		north := 32600 + zone
+		AreasTable[north] = &AreasTableEntry{
+			north, west, 0.0, west + 6.0, 84.0,
+			fmt.Sprintf("Between %s and %s, northern hemisphere between equator and 84°N", meridian(west), meridian(west+6.0)),
+		}
+		south := 32700 + zone
+		AreasTable[south] = &AreasTableEntry{
+			south, west, -80.0, west + 6.0, 0.0,
+			fmt.Sprintf("Between %s and %s, southern hemisphere between 80°S and equator", meridian(west), meridian(west+6.0)),
+		}
+	}
+}
+
+func meridian(lon float64) string {
+	switch {
+	case lon < 0:
+		return fmt.Sprintf("%g°W", -lon)
+	case lon > 0:
+		return fmt.Sprintf("%g°E", lon)
+	}
+	return "0°"
+}
+
+//---------------------------------------------------------------------
+
+// AreasTableEntry holds the area of use of an SRID, as the bounding box
+// given by EPSG, in degrees. If West is greater than East, the area
+// crosses the antimeridian.
+type AreasTableEntry struct {
+	SRID  int
+	West  float64
+	South float64
+	East  float64
+	North float64
+	Name  string
+}
+
+// AreasTable is the global list of known areas of use, for the SRIDs in
+// SRIDsTable
+var AreasTable = map[int]*AreasTableEntry{
+	2046:   {2046, 14.0, -34.88, 16.0, -22.13, "South Africa - between 14°E and 16°E"},
+	2047:   {2047, 16.0, -34.88, 18.0, -22.13, "South Africa - between 16°E and 18°E"},
+	2048:   {2048, 18.0, -34.88, 20.0, -22.13, "South Africa - between 18°E and 20°E"},
+	2049:   {2049, 20.0, -34.88, 22.0, -22.13, "South Africa - between 20°E and 22°E"},
+	2050:   {2050, 22.0, -34.88, 24.0, -22.13, "South Africa - between 22°E and 24°E"},
+	2051:   {2051, 24.0, -34.88, 26.0, -22.13, "South Africa - between 24°E and 26°E"},
+	2052:   {2052, 26.0, -34.88, 28.0, -22.13, "South Africa - between 26°E and 28°E"},
+	2053:   {2053, 28.0, -34.88, 30.0, -22.13, "South Africa - between 28°E and 30°E"},
+	2054:   {2054, 30.0, -34.88, 32.0, -22.13, "South Africa - between 30°E and 32°E"},
+	2055:   {2055, 32.0, -34.88, 34.0, -22.13, "South Africa - between 32°E and 34°E"},
+	3031:   {3031, -180.0, -90.0, 180.0, -60.0, "Antarctica"},
+	3035:   {3035, -35.58, 24.6, 44.83, 84.73, "Europe - European Union (EU) countries and candidates. Europe - onshore and offshore"},
+	3395:   {3395, -180.0, -80.0, 180.0, 84.0, "World between 80°S and 84°N"},
+	3408:   {3408, -180.0, 0.0, 180.0, 90.0, "World - N hemisphere"},
+	3409:   {3409, -180.0, -90.0, 180.0, 0.0, "World - S hemisphere"},
+	3410:   {3410, -180.0, -86.0, 180.0, 86.0, "World between 86°S and 86°N"},
+	3413:   {3413, -180.0, 60.0, 180.0, 90.0, "Northern hemisphere - north of 60°N onshore and offshore, including Arctic"},
+	3561:   {3561, -156.1, 18.87, -154.74, 20.33, "United States (USA) - Hawaii - Hawaii County - onshore"},
+	3562:   {3562, -157.36, 20.45, -155.93, 21.26, "United States (USA) - Hawaii - Maui, Kahoolawe, Lanai, Molokai - onshore"},
+	3563:   {3563, -158.33, 21.2, -157.61, 21.75, "United States (USA) - Hawaii - Oahu - onshore"},
+	3564:   {3564, -159.85, 21.81, -159.23, 22.29, "United States (USA) - Hawaii - Kauai - onshore"},
+	3565:   {3565, -160.3, 21.73, -159.99, 22.07, "United States (USA) - Hawaii - Niihau - onshore"},
+	3857:   {3857, -180.0, -85.06, 180.0, 85.06, "World between 85.06°S and 85.06°N"},
+	3976:   {3976, -180.0, -90.0, 180.0, -60.0, "Southern hemisphere - south of 60°S onshore and offshore"},
+	3995:   {3995, -180.0, 60.0, 180.0, 90.0, "Northern hemisphere - north of 60°N onshore and offshore, including Arctic"},
+	4087:   {4087, -180.0, -90.0, 180.0, 90.0, "World"},
+	4135:   {4135, -160.3, 18.87, -154.74, 22.29, "United States (USA) - Hawaii - main islands onshore"},
+	4169:   {4169, -170.88, -14.43, -169.38, -14.11, "American Samoa - Tutuila, Aunu'u, Ofu, Olesega and Ta'u islands"},
+	4258:   {4258, -16.1, 32.88, 40.18, 84.73, "Europe - onshore and offshore"},
+	4269:   {4269, 167.65, 14.92, -40.73, 86.45, "North America - onshore and offshore"},
+	4289:   {4289, 3.2, 50.75, 7.22, 53.7, "Netherlands - onshore, including Waddenzee, Dutch Wadden Islands and 12-mile offshore coastal zone"},
+	4326:   {4326, -180.0, -90.0, 180.0, 90.0, "World"},
+	4675:   {4675, 144.58, 13.2, 145.01, 13.7, "Guam - onshore"},
+	6628:   {6628, -156.1, 18.87, -154.74, 20.33, "United States (USA) - Hawaii - Hawaii County - onshore"},
+	6629:   {6629, -157.36, 20.45, -155.93, 21.26, "United States (USA) - Hawaii - Maui, Kahoolawe, Lanai, Molokai - onshore"},
+	6630:   {6630, -158.33, 21.2, -157.61, 21.75, "United States (USA) - Hawaii - Oahu - onshore"},
+	6631:   {6631, -159.85, 21.81, -159.23, 22.29, "United States (USA) - Hawaii - Kauai - onshore"},
+	6632:   {6632, -160.3, 21.73, -159.99, 22.07, "United States (USA) - Hawaii - Niihau - onshore"},
+	6633:   {6633, -158.33, 21.2, -157.61, 21.75, "United States (USA) - Hawaii - Oahu - onshore"},
+	6634:   {6634, -160.3, 19.51, -156.0, 22.29, "United States (USA) - Hawaii - between 162°W and 156°W - onshore"},
+	6635:   {6635, -156.0, 18.87, -154.74, 20.86, "United States (USA) - Hawaii - between 156°W and 150°W - onshore"},
+	6931:   {6931, -180.0, 0.0, 180.0, 90.0, "World - N hemisphere"},
+	6932:   {6932, -180.0, -90.0, 180.0, 0.0, "World - S hemisphere"},
+	6933:   {6933, -180.0, -86.0, 180.0, 86.0, "World between 86°S and 86°N"},
+	8441:   {8441, 43.18, -25.64, 50.56, -11.89, "Madagascar - onshore"},
+	28992:  {28992, 3.2, 50.75, 7.22, 53.7, "Netherlands - onshore, including Waddenzee, Dutch Wadden Islands and 12-mile offshore coastal zone"},
+	29701:  {29701, 43.18, -25.64, 50.56, -11.89, "Madagascar - onshore"},
+	30100:  {30100, -180.0, -90.0, 180.0, 90.0, "Moon"},
+	30110:  {30110, -180.0, -90.0, 180.0, 90.0, "Moon"},
+	32661:  {32661, -180.0, 60.0, 180.0, 90.0, "Northern hemisphere - north of 60°N onshore and offshore, including Arctic"},
+	32662:  {32662, -180.0, -90.0, 180.0, 90.0, "World"},
+	32761:  {32761, -180.0, -90.0, 180.0, -60.0, "Southern hemisphere - south of 60°S onshore and offshore - Antarctica"},
+	49900:  {49900, -180.0, -90.0, 180.0, 90.0, "Mars"},
+	49910:  {49910, -180.0, -90.0, 180.0, 90.0, "Mars"},
+	54001:  {54001, -180.0, -90.0, 180.0, 90.0, "World"},
+	102007: {102007, -160.3, 18.87, -154.74, 22.29, "United States (USA) - Hawaii - onshore"},
+}
@@ -0,0 +1,37 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj/support"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAreasTable(t *testing.T) {
+	assert := assert.New(t)
+
+	// every SRID has an area
+	for srid := range support.SRIDsTable {
+		assert.Contains(support.AreasTable, srid)
+	}
+
+	for key, value := range support.AreasTable {
+		assert.Equal(key, value.SRID)
+		assert.True(value.South < value.North, value.Name)
+		assert.True(value.West >= -180.0 && value.East <= 180.0, value.Name)
+	}
+
+	zone := support.AreasTable[32633]
+	assert.Equal(12.0, zone.West)
+	assert.Equal(18.0, zone.East)
+	assert.Equal("Between 12°E and 18°E, northern hemisphere between equator and 84°N", zone.Name)
+	assert.Equal(-180.0, support.AreasTable[32701].West)
+	assert.Equal(-80.0, support.AreasTable[32701].South)
+}
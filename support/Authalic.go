@@ -0,0 +1,83 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support
+
+import "math"
+
+/* determine latitude from authalic latitude */
+const p00 = .33333333333333333333 /*   1 /     3 */
+const p01 = .17222222222222222222 /*  31 /   180 */
+const p02 = .10257936507936507937 /* 517 /  5040 */
+const p10 = .06388888888888888888 /*  23 /   360 */
+const p11 = .06640211640211640212 /* 251 /  3780 */
+const p20 = .01677689594356261023 /* 761 / 45360 */
+const apaSize = 3
+
+// Authset returns the coefficients of the series used by Authlat for an
+// ellipsoid with eccentricity squared es
+func Authset(es float64) []float64 {
+	var t float64
+
+	apa := make([]float64, apaSize)
+
+	apa[0] = es * p00
+	t = es * es
+	apa[0] += t * p01
+	apa[1] = t * p10
+	t *= es
+	apa[0] += t * p02
+	apa[1] += t * p11
+	apa[2] = t * p20
+
+	return apa
+}
+
+// Authlat returns the geodetic latitude for the authalic latitude beta
+// (radians), using the coefficients from Authset
+func Authlat(beta float64, apa []float64) float64 {
+	t := beta + beta
+	return beta + apa[0]*math.Sin(t) + apa[1]*math.Sin(t+t) + apa[2]*math.Sin(t+t+t)
+}
+
+// AuthlatExact returns Authlat refined by Newton's method, so that the
+// authalic latitude of the result is beta to within rounding rather than
+// to the 1e-10 or so of the series. qp is Qsfn(1, e, oneEs).
+func AuthlatExact(beta float64, apa []float64, e, oneEs, qp float64) float64 {
+	phi := Authlat(beta, apa)
+	q := math.Sin(beta) * qp
+	for i := 0; i < 5; i++ {
+		sinphi := math.Sin(phi)
+		cosphi := math.Cos(phi)
+		if cosphi < epsilon {
+			// at the poles, where the series is exact anyway
+			break
+		}
+		w := 1.0 - e*e*sinphi*sinphi
+		dphi := (Qsfn(sinphi, e, oneEs) - q) * w * w / (2.0 * oneEs * cosphi)
+		phi -= dphi
+		if math.Abs(dphi) < 1.0e-15 {
+			break
+		}
+	}
+	return phi
+}
+
+// Authalic returns the authalic latitude for the geodetic latitude phi
+// (radians), i.e. the latitude on the sphere of equal surface area. e is
+// the eccentricity and oneEs is 1 - e^2.
+func Authalic(phi, e, oneEs float64) float64 {
+	if e < epsilon {
+		return phi
+	}
+	qp := Qsfn(1.0, e, oneEs)
+	ratio := Qsfn(math.Sin(phi), e, oneEs) / qp
+	if math.Abs(ratio) > 1.0 {
+		ratio = math.Copysign(1.0, ratio)
+	}
+	return math.Asin(ratio)
+}
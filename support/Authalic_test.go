@@ -0,0 +1,44 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj/support"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthalic(t *testing.T) {
+	assert := assert.New(t)
+
+	apa := support.Authset(grs80Es)
+	assert.Len(apa, 3)
+
+	// the poles and the equator are fixed points
+	for _, phi := range []float64{-math.Pi / 2, 0.0, math.Pi / 2} {
+		assert.InDelta(phi, support.Authalic(phi, grs80E, 1.0-grs80Es), 1.0e-15)
+		assert.InDelta(phi, support.Authlat(phi, apa), 1.0e-15)
+	}
+
+	for _, deg := range []float64{-60.0, -15.0, 30.0, 45.0, 80.0} {
+		phi := support.DDToR(deg)
+		beta := support.Authalic(phi, grs80E, 1.0-grs80Es)
+
+		// the authalic latitude is a little closer to the equator
+		assert.True(math.Abs(beta) < math.Abs(phi))
+		assert.InDelta(phi, support.Authlat(beta, apa), 1.0e-9)
+		qp := support.Qsfn(1.0, grs80E, 1.0-grs80Es)
+		assert.InDelta(phi, support.AuthlatExact(beta, apa, grs80E, 1.0-grs80Es, qp), 1.0e-15)
+	}
+
+	// on the sphere, they're the same
+	assert.Equal(0.5, support.Authalic(0.5, 0.0, 1.0))
+	assert.Equal(0.5, support.Authlat(0.5, support.Authset(0.0)))
+}
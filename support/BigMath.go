@@ -0,0 +1,187 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support
+
+import (
+	"math"
+	"math/big"
+)
+
+// The extended-precision math of the setup computations, for the
+// constants which float64 can't get right when the parameters are close
+// to a degenerate case, such as the cone constant of a conic whose
+// standard parallels are nearly the same. It is slow, so it is only used
+// at setup, and only when asked for; the per-point code is always
+// float64.
+
+// BigPrecision is the precision, in bits, of the extended-precision
+// computations: a little more than double-double arithmetic gives
+const BigPrecision = 128
+
+var (
+	bigPi  = mustParseBig("3.14159265358979323846264338327950288419716939937510582097494459")
+	bigLn2 = mustParseBig("0.69314718055994530941723212145817656807550013436025525412068001")
+)
+
+func mustParseBig(s string) *big.Float {
+	f, _, err := big.ParseFloat(s, 10, BigPrecision, big.ToNearestEven)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func newBig() *big.Float {
+	return new(big.Float).SetPrec(BigPrecision)
+}
+
+// NewBig returns x at BigPrecision
+func NewBig(x float64) *big.Float {
+	return newBig().SetFloat64(x)
+}
+
+// BigDDToR converts decimal degrees to radians, without rounding the
+// radians to float64 first
+func BigDDToR(deg float64) *big.Float {
+	r := NewBig(deg)
+	r.Mul(r, bigPi)
+	return r.Quo(r, NewBig(180.0))
+}
+
+// negligible reports whether adding term to sum no longer changes it at
+// BigPrecision
+func negligible(term, sum *big.Float) bool {
+	if term.Sign() == 0 {
+		return true
+	}
+	if sum.Sign() == 0 {
+		return false
+	}
+	return term.MantExp(nil) < sum.MantExp(nil)-BigPrecision-2
+}
+
+// BigSinCos returns the sine and cosine of x, which is an angle of no
+// more than a few radians, such as a latitude
+func BigSinCos(x *big.Float) (*big.Float, *big.Float) {
+	x2 := newBig().Mul(x, x)
+	x2.Neg(x2)
+
+	sin := newBig().Set(x)
+	cos := NewBig(1.0)
+	sinTerm := newBig().Set(x)
+	cosTerm := NewBig(1.0)
+	k := newBig()
+	for i := 1; !negligible(sinTerm, sin) || !negligible(cosTerm, cos); i++ {
+		// cos: x^2i/(2i)!, sin: x^(2i+1)/(2i+1)!
+		cosTerm.Mul(cosTerm, x2)
+		cosTerm.Quo(cosTerm, k.SetInt64(int64((2*i-1)*(2*i))))
+		cos.Add(cos, cosTerm)
+		sinTerm.Mul(sinTerm, x2)
+		sinTerm.Quo(sinTerm, k.SetInt64(int64((2*i)*(2*i+1))))
+		sin.Add(sin, sinTerm)
+	}
+	return sin, cos
+}
+
+// bigAtanh returns atanh(z), for |z| well below 1
+func bigAtanh(z *big.Float) *big.Float {
+	z2 := newBig().Mul(z, z)
+	sum := newBig().Set(z)
+	power := newBig().Set(z)
+	term := newBig()
+	for n := int64(3); ; n += 2 {
+		power.Mul(power, z2)
+		term.Quo(power, NewBig(float64(n)))
+		if negligible(term, sum) {
+			return sum
+		}
+		sum.Add(sum, term)
+	}
+}
+
+// BigLog returns the natural logarithm of x, which must be positive
+func BigLog(x *big.Float) *big.Float {
+	// x = m 2^k, with m in [0.5, 1), and log(m) = 2 atanh((m-1)/(m+1))
+	m := newBig()
+	k := x.MantExp(m)
+	z := newBig().Sub(m, NewBig(1.0))
+	z.Quo(z, newBig().Add(m, NewBig(1.0)))
+	log := bigAtanh(z)
+	log.Mul(log, NewBig(2.0))
+	return log.Add(log, newBig().Mul(bigLn2, NewBig(float64(k))))
+}
+
+// BigExp returns e^x
+func BigExp(x *big.Float) *big.Float {
+	// x = k log(2) + r, with |r| <= log(2)/2
+	f, _ := newBig().Quo(x, bigLn2).Float64()
+	k := math.Round(f)
+	r := newBig().Mul(bigLn2, NewBig(k))
+	r.Sub(x, r)
+
+	sum := NewBig(1.0)
+	term := NewBig(1.0)
+	for n := int64(1); ; n++ {
+		term.Mul(term, r)
+		term.Quo(term, NewBig(float64(n)))
+		if negligible(term, sum) {
+			break
+		}
+		sum.Add(sum, term)
+	}
+	return sum.SetMantExp(sum, int(k))
+}
+
+// BigPow returns x^y, for positive x
+func BigPow(x, y *big.Float) *big.Float {
+	return BigExp(newBig().Mul(BigLog(x), y))
+}
+
+// BigMsfn is Msfn in extended precision
+func BigMsfn(sinphi, cosphi *big.Float, es float64) *big.Float {
+	d := newBig().Mul(sinphi, sinphi)
+	d.Mul(d, NewBig(es))
+	d.Sub(NewBig(1.0), d)
+	d.Sqrt(d)
+	return d.Quo(cosphi, d)
+}
+
+// BigTsfn is Tsfn in extended precision, for latitudes short of the
+// south pole
+func BigTsfn(sinphi, cosphi *big.Float, e float64) *big.Float {
+	// tan((pi/2 - phi)/2) is cos(phi)/(1 + sin(phi))
+	t := newBig().Add(NewBig(1.0), sinphi)
+	t.Quo(cosphi, t)
+	if e == 0.0 {
+		return t
+	}
+
+	con := newBig().Mul(sinphi, NewBig(e))
+	ratio := newBig().Sub(NewBig(1.0), con)
+	ratio.Quo(ratio, con.Add(NewBig(1.0), con))
+	return t.Quo(t, BigPow(ratio, NewBig(0.5*e)))
+}
+
+// BigQsfn is Qsfn in extended precision
+func BigQsfn(sinphi *big.Float, e, oneEs float64) *big.Float {
+	if e < epsilon {
+		return newBig().Add(sinphi, sinphi)
+	}
+
+	con := newBig().Mul(sinphi, NewBig(e))
+	div1 := newBig().Mul(con, con)
+	div1.Sub(NewBig(1.0), div1)
+	q := newBig().Quo(sinphi, div1)
+
+	ratio := newBig().Sub(NewBig(1.0), con)
+	ratio.Quo(ratio, con.Add(NewBig(1.0), con))
+	log := BigLog(ratio)
+	log.Mul(log, NewBig(0.5/e))
+	q.Sub(q, log)
+	return q.Mul(q, NewBig(oneEs))
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/oahumap/proj/support"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBigMath(t *testing.T) {
+	assert := assert.New(t)
+
+	f := func(x *big.Float) float64 {
+		v, _ := x.Float64()
+		return v
+	}
+
+	for _, deg := range []float64{-90.0, -45.0, 0.0, 1.0e-9, 30.0, 60.0, 89.999} {
+		phi := support.BigDDToR(deg)
+		assert.InDelta(support.DDToR(deg), f(phi), 4.0e-16)
+		sin, cos := support.BigSinCos(phi)
+		assert.InDelta(math.Sin(support.DDToR(deg)), f(sin), 1.0e-15, "%g", deg)
+		assert.InDelta(math.Cos(support.DDToR(deg)), f(cos), 1.0e-15, "%g", deg)
+
+		// and the rest agree with their float64 versions
+		e, es := 0.0818191910428158, 0.00669438002290
+		sinphi, cosphi := math.Sin(support.DDToR(deg)), math.Cos(support.DDToR(deg))
+		assert.InDelta(support.Msfn(sinphi, cosphi, es), f(support.BigMsfn(sin, cos, es)), 1.0e-15)
+		assert.InDelta(support.Qsfn(sinphi, e, 1.0-es), f(support.BigQsfn(sin, e, 1.0-es)), 1.0e-15)
+		if deg > -90.0 {
+			// near the north pole, it is Tsfn which is off
+			expected := support.Tsfn(support.DDToR(deg), sinphi, e)
+			assert.InDelta(expected, f(support.BigTsfn(sin, cos, e)), 1.0e-11*expected)
+		}
+	}
+
+	// sin^2 + cos^2 is 1 well beyond float64
+	sin, cos := support.BigSinCos(support.BigDDToR(37.0))
+	one := new(big.Float).Add(new(big.Float).Mul(sin, sin), new(big.Float).Mul(cos, cos))
+	one.Sub(one, support.NewBig(1.0))
+	assert.True(one.Sign() == 0 || one.MantExp(nil) < -100)
+
+	for _, x := range []float64{1.0e-12, 0.5, 1.0, 2.0, 10.0, 12345.678} {
+		assert.InDelta(math.Log(x), f(support.BigLog(support.NewBig(x))), 1.0e-15*math.Max(1.0, math.Abs(math.Log(x))), "%g", x)
+		assert.InDelta(math.Exp(x/1000.0), f(support.BigExp(support.NewBig(x/1000.0))), 1.0e-15*math.Exp(x/1000.0), "%g", x)
+		assert.InDelta(x, f(support.BigExp(support.BigLog(support.NewBig(x)))), 1.0e-15*x, "%g", x)
+	}
+	assert.InDelta(math.Exp(-20.0), f(support.BigExp(support.NewBig(-20.0))), 1.0e-24)
+	assert.InDelta(math.Pow(0.3, 1.7), f(support.BigPow(support.NewBig(0.3), support.NewBig(1.7))), 1.0e-15)
+}
@@ -8,8 +8,10 @@
 package support
 
 import (
+	"math"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/oahumap/proj/merror"
 
@@ -21,12 +23,19 @@ import (
 // Using an 8-part regexp, we support this format:
 //    [+-] nnn [°Dd] nnn ['Mm] nnn.nnn ["Ss] [NnEeWwSs]
 //
+// Plain decimal degrees, such as "2.337229167", are accepted too, as
+// dmstor() accepts them, with or without a hemisphere, as in "30.5W".
+//
 // TODO: the original dmstor() may support more, but the parsing code
 // is messy and we don't have any testcases at this time.
 func DMSToDD(input string) (float64, error) {
 
 	mlog.Debugf("%s", input)
 
+	if dd, ok := parseDecimalDegrees(input); ok {
+		return dd, nil
+	}
+
 	deg := `\s*(-|\+)?\s*(\d+)\s*([°Dd]?)` // t1, t2, t3
 	min := `\s*(\d+)?\s*(['Mm]?)`          // t4, t5
 	sec := `\s*(\d+\.?\d*)?\s*(["Ss])?`    // t6, t7
@@ -77,6 +86,27 @@ func DMSToDD(input string) (float64, error) {
 	return dd, nil
 }
 
+// parseDecimalDegrees parses plain decimal degrees, which may end with a
+// hemisphere letter
+func parseDecimalDegrees(input string) (float64, bool) {
+	s := strings.TrimSpace(input)
+	sign := 1.0
+	if n := len(s); n > 1 {
+		switch s[n-1] {
+		case 'N', 'n', 'E', 'e':
+			s = strings.TrimSpace(s[:n-1])
+		case 'S', 's', 'W', 'w':
+			s = strings.TrimSpace(s[:n-1])
+			sign = -1.0
+		}
+	}
+	dd, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsInf(dd, 0) || math.IsNaN(dd) {
+		return 0.0, false
+	}
+	return sign * dd, true
+}
+
 // DMSToR converts a DMS string to radians
 func DMSToR(input string) (float64, error) {
 
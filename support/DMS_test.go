@@ -69,6 +69,13 @@ func TestDMSToDD(t *testing.T) {
 		{`134d1m3.3s`, convert(134, 1, 3.3)},
 		{`-134d1'3.3s E`, -convert(134, 1, 3.3)},
 		{`-134d1'3.3s W`, convert(134, 1, 3.3)},
+
+		{`2.337229167`, 2.337229167},
+		{` -0.5 `, -0.5},
+		{`30.5W`, -30.5},
+		{`30.5 n`, 30.5},
+		{`-30.5s`, 30.5},
+		{`W`, FAIL},
 	}
 
 	for _, d := range data {
@@ -0,0 +1,43 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support
+
+// DatumRegionsTableEntry holds the Helmert parameters of a datum for one
+// region, such as an island, where they differ from those for the whole
+// datum by more than the datum's accuracy. The region is the bounding box
+// of its area of use, in degrees.
+type DatumRegionsTableEntry struct {
+	Datum   string // the key of the datum in DatumsTable
+	Region  string
+	West    float64
+	South   float64
+	East    float64
+	North   float64
+	ToWGS84 string // e.g. "towgs84=89,-279,-183"
+}
+
+// DatumRegionsTable is the global list of regional datum shifts, by the
+// key of the datum in DatumsTable
+//
+// The Old Hawaiian shifts are NIMA's, island by island (TR8350.2); the
+// datums of Guam and American Samoa each cover a single island group, so
+// have only the one region.
+var DatumRegionsTable = map[string][]*DatumRegionsTableEntry{
+	"OldHawaiian": {
+		{"OldHawaiian", "Hawaii", -156.1, 18.87, -154.74, 20.33, "towgs84=89,-279,-183"},
+		{"OldHawaiian", "Maui", -157.36, 20.45, -155.93, 21.26, "towgs84=65,-290,-190"},
+		{"OldHawaiian", "Oahu", -158.33, 21.2, -157.61, 21.75, "towgs84=58,-283,-182"},
+		{"OldHawaiian", "Kauai", -159.85, 21.81, -159.23, 22.29, "towgs84=45,-290,-172"},
+	},
+	"Guam1963": {
+		{"Guam1963", "Guam", 144.58, 13.2, 145.01, 13.7, "towgs84=-100,-248,259"},
+	},
+	"AmericanSamoa1962": {
+		{"AmericanSamoa1962", "American Samoa", -170.88, -14.43, -169.38, -14.11, "towgs84=-115,118,426"},
+	},
+}
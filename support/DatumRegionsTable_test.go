@@ -0,0 +1,34 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/oahumap/proj/support"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatumRegionsTable(t *testing.T) {
+	assert := assert.New(t)
+
+	for key, regions := range support.DatumRegionsTable {
+		assert.Contains(support.DatumsTable, key)
+		for _, region := range regions {
+			assert.Equal(key, region.Datum)
+			assert.True(region.West < region.East && region.South < region.North, region.Region)
+			assert.True(strings.HasPrefix(region.ToWGS84, "towgs84="), region.Region)
+			_, err := support.NewProjString(region.ToWGS84)
+			assert.NoError(err, region.Region)
+		}
+	}
+
+	assert.Len(support.DatumRegionsTable["OldHawaiian"], 4)
+	assert.Equal("towgs84=61,-285,-181", support.DatumsTable["OldHawaiian"].DefinitionString)
+}
@@ -46,4 +46,10 @@ var DatumsTable = map[string]*DatumTableEntry{
 	"mod_airy":      {"ire65", "towgs84=482.530,-130.596,564.557,-1.042,-0.214,-0.631,8.15", "mod_airy", "Ireland 1965", nil},
 	"intl":          {"nzgd49", "towgs84=59.47,-5.04,187.44,0.47,-0.1,1.024,-4.5993", "intl", "New Zealand Geodetic Datum 1949", nil},
 	"airy":          {"OSGB36", "towgs84=446.448,-125.157,542.060,0.1502,0.2470,0.8421,-20.4894", "airy", "Airy 1830", nil},
+
+	// the Pacific island datums, with the shift for all of their islands;
+	// see DatumRegionsTable for the island by island shifts
+	"OldHawaiian":       {"OldHawaiian", "towgs84=61,-285,-181", "clrk66", "Old Hawaiian", nil},
+	"Guam1963":          {"Guam1963", "towgs84=-100,-248,259", "clrk66", "Guam 1963", nil},
+	"AmericanSamoa1962": {"AmericanSamoa1962", "towgs84=-115,118,426", "clrk66", "American Samoa 1962", nil},
 }
@@ -63,4 +63,9 @@ var EllipsoidsTable = map[string]*EllipsoidsTableEntry{
 	"WGS72":     {"WGS72", "a=6378135.0", "rf=298.26", "WGS 72"},
 	"WGS84":     {"WGS84", "a=6378137.0", "rf=298.257223563", "WGS 84"},
 	"sphere":    {"sphere", "a=6370997.0", "b=6370997.0", "Normal Sphere (r=6370997)"},
+
+	// other bodies, as the IAU/IAG Working Group on Cartographic
+	// Coordinates and Rotational Elements gives them for 2000
+	"Mars2000": {"Mars2000", "a=3396190.0", "b=3376200.0", "Mars 2000 (IAU/IAG)"},
+	"Moon2000": {"Moon2000", "a=1737400.0", "b=1737400.0", "Moon 2000 (IAU/IAG)"},
 }
@@ -0,0 +1,204 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support
+
+import (
+	"math"
+)
+
+// The elliptic integrals and Jacobi elliptic functions of the exact
+// transverse Mercator, after GeographicLib's EllipticFunction: the
+// integrals in Carlson's symmetric forms, by duplication (B. C. Carlson,
+// "Computing elliptic integrals by duplication", Numer. Math. 33, 1979),
+// and sn, cn and dn by Bulirsch's descending Landen transformation.
+
+const float64Epsilon = 2.220446049250313e-16
+
+var (
+	tolRF  = math.Pow(3.0*float64Epsilon*0.01, 1.0/8.0)
+	tolRD  = math.Pow(0.2*float64Epsilon*0.01, 1.0/8.0)
+	tolRG0 = 2.7 * math.Sqrt(float64Epsilon*0.01)
+	tolJAC = math.Sqrt(float64Epsilon * 0.01)
+)
+
+// CarlsonRF is Carlson's symmetric integral of the first kind,
+// R_F(x, y, z), for non-negative x, y and z, at most one of them zero
+func CarlsonRF(x, y, z float64) float64 {
+	a0 := (x + y + z) / 3.0
+	an := a0
+	q := math.Max(math.Max(math.Abs(a0-x), math.Abs(a0-y)), math.Abs(a0-z)) / tolRF
+	x0, y0, z0 := x, y, z
+	mul := 1.0
+	for q >= mul*math.Abs(an) {
+		lam := math.Sqrt(x0)*math.Sqrt(y0) + math.Sqrt(y0)*math.Sqrt(z0) + math.Sqrt(z0)*math.Sqrt(x0)
+		an = (an + lam) / 4.0
+		x0 = (x0 + lam) / 4.0
+		y0 = (y0 + lam) / 4.0
+		z0 = (z0 + lam) / 4.0
+		mul *= 4.0
+	}
+	X := (a0 - x) / (mul * an)
+	Y := (a0 - y) / (mul * an)
+	Z := -(X + Y)
+	e2 := X*Y - Z*Z
+	e3 := X * Y * Z
+	return (e3*(6930.0*e3+e2*(15015.0*e2-16380.0)+17160.0) +
+		e2*((10010.0-5775.0*e2)*e2-24024.0) + 240240.0) /
+		(240240.0 * math.Sqrt(an))
+}
+
+// carlsonRF0 is R_F(0, x, y), by the arithmetic-geometric mean
+func carlsonRF0(x, y float64) float64 {
+	xn, yn := math.Sqrt(x), math.Sqrt(y)
+	if xn < yn {
+		xn, yn = yn, xn
+	}
+	for math.Abs(xn-yn) > tolRG0*xn {
+		t := (xn + yn) / 2.0
+		yn = math.Sqrt(xn * yn)
+		xn = t
+	}
+	return math.Pi / (xn + yn)
+}
+
+// CarlsonRD is Carlson's degenerate integral of the second kind,
+// R_D(x, y, z), for non-negative x and y, at most one of them zero, and
+// positive z
+func CarlsonRD(x, y, z float64) float64 {
+	a0 := (x + y + 3.0*z) / 5.0
+	an := a0
+	q := math.Max(math.Max(math.Abs(a0-x), math.Abs(a0-y)), math.Abs(a0-z)) / tolRD
+	x0, y0, z0 := x, y, z
+	mul := 1.0
+	s := 0.0
+	for q >= mul*math.Abs(an) {
+		lam := math.Sqrt(x0)*math.Sqrt(y0) + math.Sqrt(y0)*math.Sqrt(z0) + math.Sqrt(z0)*math.Sqrt(x0)
+		s += 1.0 / (mul * math.Sqrt(z0) * (z0 + lam))
+		an = (an + lam) / 4.0
+		x0 = (x0 + lam) / 4.0
+		y0 = (y0 + lam) / 4.0
+		z0 = (z0 + lam) / 4.0
+		mul *= 4.0
+	}
+	X := (a0 - x) / (mul * an)
+	Y := (a0 - y) / (mul * an)
+	Z := -(X + Y) / 3.0
+	e2 := X*Y - 6.0*Z*Z
+	e3 := (3.0*X*Y - 8.0*Z*Z) * Z
+	e4 := 3.0 * (X*Y - Z*Z) * Z * Z
+	e5 := X * Y * Z * Z * Z
+	return ((471240.0-540540.0*e2)*e5+
+		(612612.0*e2-540540.0*e3-556920.0)*e4+
+		e3*(306306.0*e3+e2*(675675.0*e2-706860.0)+680680.0)+
+		e2*((417690.0-255255.0*e2)*e2-875160.0)+4084080.0)/
+		(4084080.0*mul*an*math.Sqrt(an)) + 3.0*s
+}
+
+// carlsonRG0 is R_G(0, x, y), Carlson's symmetric integral of the second
+// kind with one argument zero, by the arithmetic-geometric mean
+func carlsonRG0(x, y float64) float64 {
+	x0 := math.Sqrt(math.Max(x, y))
+	y0 := math.Sqrt(math.Min(x, y))
+	xn, yn := x0, y0
+	s := 0.0
+	mul := 0.25
+	for math.Abs(xn-yn) > tolRG0*xn {
+		t := (xn + yn) / 2.0
+		yn = math.Sqrt(xn * yn)
+		xn = t
+		mul *= 2.0
+		t = xn - yn
+		s += mul * t * t
+	}
+	m := (x0 + y0) / 2.0
+	return (m*m - s) * math.Pi / (2.0 * (xn + yn))
+}
+
+// Elliptic holds the complete elliptic integrals of a parameter m, the
+// square of the modulus, in (0, 1), and gives the Jacobi elliptic
+// functions and the incomplete integral of the second kind of it
+type Elliptic struct {
+	M  float64 // the parameter
+	Mc float64 // the complementary parameter, 1 - M
+	K  float64 // the complete integral of the first kind
+	E  float64 // the complete integral of the second kind
+	KE float64 // K - E, without the loss of precision of subtracting
+}
+
+// NewElliptic returns the Elliptic of the parameter m, which must be in
+// (0, 1)
+func NewElliptic(m float64) *Elliptic {
+	mc := 1.0 - m
+	return &Elliptic{
+		M:  m,
+		Mc: mc,
+		K:  carlsonRF0(mc, 1.0),
+		E:  2.0 * carlsonRG0(mc, 1.0),
+		KE: m * CarlsonRD(0.0, mc, 1.0) / 3.0,
+	}
+}
+
+// SnCnDn returns the Jacobi elliptic functions sn, cn and dn of x, by
+// Bulirsch's sncndn
+func (el *Elliptic) SnCnDn(x float64) (sn, cn, dn float64) {
+	const num = 13
+	var m, n [num]float64
+
+	mc := el.Mc
+	c := 0.0
+	l := 0
+	for a := 1.0; l < num; l++ {
+		// this converges quadratically, in 5 trips at most
+		m[l] = a
+		mc = math.Sqrt(mc)
+		n[l] = mc
+		c = (a + mc) / 2.0
+		if !(math.Abs(a-mc) > tolJAC*a) {
+			l++
+			break
+		}
+		mc *= a
+		a = c
+	}
+
+	x *= c
+	sn, cn = math.Sincos(x)
+	dn = 1.0
+	if sn != 0.0 {
+		a := cn / sn
+		c *= a
+		for l--; l >= 0; l-- {
+			b := m[l]
+			a *= c
+			c *= dn
+			dn = (n[l] + a) / (b + a)
+			a = c / b
+		}
+		a = 1.0 / math.Sqrt(c*c+1.0)
+		sn = math.Copysign(a, sn)
+		cn = c * sn
+	}
+	return sn, cn, dn
+}
+
+// Einc returns the incomplete integral of the second kind, E(phi, m), of
+// the amplitude phi whose sine, cosine and delta are sn, cn and dn, as
+// SnCnDn gives them, with the usual symmetries for phi beyond pi/2
+func (el *Elliptic) Einc(sn, cn, dn float64) float64 {
+	cn2, dn2, sn2 := cn*cn, dn*dn, sn*sn
+	ei := el.E
+	if cn2 != 0.0 {
+		ei = math.Abs(sn) * (el.Mc*CarlsonRF(cn2, dn2, 1.0) +
+			el.M*el.Mc*sn2*CarlsonRD(cn2, 1.0, dn2)/3.0 +
+			el.M*math.Abs(cn)/dn)
+	}
+	if math.Signbit(cn) {
+		ei = 2.0*el.E - ei
+	}
+	return math.Copysign(ei, sn)
+}
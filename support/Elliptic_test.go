@@ -0,0 +1,56 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj/support"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElliptic(t *testing.T) {
+	assert := assert.New(t)
+
+	// the complete integrals, from Abramowitz and Stegun, table 17.1
+	el := support.NewElliptic(0.5)
+	assert.InDelta(1.854074677301372, el.K, 1.0e-15)
+	assert.InDelta(1.350643881047675, el.E, 1.0e-15)
+	assert.InDelta(el.K-el.E, el.KE, 1.0e-15)
+
+	// R_F(1, 2, 0) is the lemniscate constant, 1.31102877714605990523...
+	assert.InDelta(1.3110287771460599, support.CarlsonRF(1.0, 2.0, 0.0), 1.0e-15)
+
+	// the incomplete integrals, by Simpson's rule, against sn, cn and dn
+	// of them
+	simpson := func(f func(float64) float64, b float64) float64 {
+		const n = 20000
+		h := b / n
+		s := f(0.0) + f(b)
+		for i := 1; i < n; i++ {
+			s += float64(2+2*(i%2)) * f(float64(i)*h)
+		}
+		return s * h / 3.0
+	}
+	for _, m := range []float64{0.00669438, 0.5, 0.99330562} {
+		el = support.NewElliptic(m)
+		delta := func(th float64) float64 { return math.Sqrt(1.0 - m*math.Sin(th)*math.Sin(th)) }
+		for _, phi := range []float64{0.1, 0.7, 1.5, 2.5, -1.0} {
+			u := simpson(func(th float64) float64 { return 1.0 / delta(th) }, phi)
+			sn, cn, dn := el.SnCnDn(u)
+			assert.InDelta(math.Sin(phi), sn, 1.0e-12)
+			assert.InDelta(math.Cos(phi), cn, 1.0e-12)
+			assert.InDelta(delta(phi), dn, 1.0e-12)
+			assert.InDelta(1.0, sn*sn+cn*cn, 1.0e-15)
+			assert.InDelta(1.0, dn*dn+m*sn*sn, 1.0e-15)
+
+			assert.InDelta(simpson(delta, phi), el.Einc(sn, cn, dn), 1.0e-12)
+		}
+	}
+}
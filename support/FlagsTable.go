@@ -0,0 +1,27 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support
+
+// FlagsTable lists the keys which are flags, such as "+south": keys which
+// are usually given without a value, and are read with GetAsBool
+var FlagsTable = map[string]bool{
+	"approx":  true, // use the faster, less accurate tmerc/utm formulas
+	"czech":   true, // krovak: flip the signs of the axes, as used in the Czech Republic
+	"exact":   true, // use the exact tmerc/utm formulas, good far from the central meridian
+	"geoc":    true, // take latitudes as geocentric
+	"guam":    true, // aeqd: use the Guam elliptical formulas
+	"no_cut":  true, // airy: don't cut at the hemisphere limit
+	"no_defs": true, // don't read the defaults file
+	"no_rot":  true, // omerc/ob_tran: don't rotate the axes
+	"no_off":  true, // omerc: don't offset the origin to the center
+	"no_uoff": true, // omerc: same as no_off
+	"ns":      true, // nsper and others: don't use the spherical shortcut
+	"over":    true, // don't wrap longitudes to [-180, 180]
+	"south":   true, // utm/leac: southern hemisphere
+	"wktext":  true, // keep the proj string in WKT output
+}
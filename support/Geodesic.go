@@ -0,0 +1,127 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support
+
+import (
+	"math"
+
+	"github.com/oahumap/proj/merror"
+)
+
+// The geodesic problems on the ellipsoid, solved with Vincenty's
+// iterations rather than Karney's series as in PROJ's geodesic.c: they
+// agree to well under a millimeter, except that the inverse fails to
+// converge for nearly antipodal points.
+
+const (
+	geodTol   = 1.0e-12
+	geodNiter = 200
+)
+
+// GeodInverse returns the length s12 (in units of a) of the geodesic from
+// (lat1, lon1) to (lat2, lon2), and its azimuth azi1 at the first point,
+// on the ellipsoid with semimajor axis a and flattening f. Angles are in
+// radians. It fails with merror.NonConvergence for nearly antipodal
+// points.
+func GeodInverse(a, f, lat1, lon1, lat2, lon2 float64) (s12, azi1 float64, err error) {
+	b := a * (1.0 - f)
+	L := lon2 - lon1
+	u1 := math.Atan((1.0 - f) * math.Tan(lat1))
+	u2 := math.Atan((1.0 - f) * math.Tan(lat2))
+	sinU1, cosU1 := math.Sin(u1), math.Cos(u1)
+	sinU2, cosU2 := math.Sin(u2), math.Cos(u2)
+
+	var sinLam, cosLam, sinSigma, cosSigma, sigma, cos2Alpha, cos2SigmaM float64
+	lam := L
+	i := 0
+	for ; i < geodNiter; i++ {
+		sinLam, cosLam = math.Sin(lam), math.Cos(lam)
+		sinSigma = Hypot(cosU2*sinLam, cosU1*sinU2-sinU1*cosU2*cosLam)
+		if sinSigma == 0.0 {
+			// coincident points
+			return 0.0, 0.0, nil
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLam
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLam / sinSigma
+		cos2Alpha = 1.0 - sinAlpha*sinAlpha
+		cos2SigmaM = 0.0
+		if cos2Alpha != 0.0 {
+			// not on the equator
+			cos2SigmaM = cosSigma - 2.0*sinU1*sinU2/cos2Alpha
+		}
+		C := f / 16.0 * cos2Alpha * (4.0 + f*(4.0-3.0*cos2Alpha))
+		prev := lam
+		lam = L + (1.0-C)*f*sinAlpha*
+			(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1.0+2.0*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lam-prev) < geodTol {
+			break
+		}
+	}
+	if i == geodNiter {
+		return 0.0, 0.0, merror.New(merror.NonConvergence)
+	}
+
+	uu := cos2Alpha * (a*a - b*b) / (b * b)
+	A, B := geodAB(uu)
+	deltaSigma := B * sinSigma * (cos2SigmaM + B/4.0*(cosSigma*(-1.0+2.0*cos2SigmaM*cos2SigmaM)-
+		B/6.0*cos2SigmaM*(-3.0+4.0*sinSigma*sinSigma)*(-3.0+4.0*cos2SigmaM*cos2SigmaM)))
+
+	s12 = b * A * (sigma - deltaSigma)
+	azi1 = math.Atan2(cosU2*sinLam, cosU1*sinU2-sinU1*cosU2*cosLam)
+	return s12, azi1, nil
+}
+
+// GeodDirect returns the point (lat2, lon2) reached by following the
+// geodesic from (lat1, lon1) with azimuth azi1 for a length s12 (in units
+// of a), on the ellipsoid with semimajor axis a and flattening f. Angles
+// are in radians.
+func GeodDirect(a, f, lat1, lon1, azi1, s12 float64) (lat2, lon2 float64) {
+	b := a * (1.0 - f)
+	sinAlpha1, cosAlpha1 := math.Sin(azi1), math.Cos(azi1)
+	u1 := math.Atan((1.0 - f) * math.Tan(lat1))
+	sinU1, cosU1 := math.Sin(u1), math.Cos(u1)
+	sigma1 := math.Atan2(math.Tan(u1), cosAlpha1)
+	sinAlpha := cosU1 * sinAlpha1
+	cos2Alpha := 1.0 - sinAlpha*sinAlpha
+
+	uu := cos2Alpha * (a*a - b*b) / (b * b)
+	A, B := geodAB(uu)
+
+	var sinSigma, cosSigma, cos2SigmaM float64
+	sigma := s12 / (b * A)
+	for i := 0; i < geodNiter; i++ {
+		cos2SigmaM = math.Cos(2.0*sigma1 + sigma)
+		sinSigma, cosSigma = math.Sin(sigma), math.Cos(sigma)
+		deltaSigma := B * sinSigma * (cos2SigmaM + B/4.0*(cosSigma*(-1.0+2.0*cos2SigmaM*cos2SigmaM)-
+			B/6.0*cos2SigmaM*(-3.0+4.0*sinSigma*sinSigma)*(-3.0+4.0*cos2SigmaM*cos2SigmaM)))
+		prev := sigma
+		sigma = s12/(b*A) + deltaSigma
+		if math.Abs(sigma-prev) < geodTol {
+			break
+		}
+	}
+	cos2SigmaM = math.Cos(2.0*sigma1 + sigma)
+	sinSigma, cosSigma = math.Sin(sigma), math.Cos(sigma)
+
+	t := sinU1*sinSigma - cosU1*cosSigma*cosAlpha1
+	lat2 = math.Atan2(sinU1*cosSigma+cosU1*sinSigma*cosAlpha1, (1.0-f)*Hypot(sinAlpha, t))
+	lam := math.Atan2(sinSigma*sinAlpha1, cosU1*cosSigma-sinU1*sinSigma*cosAlpha1)
+	C := f / 16.0 * cos2Alpha * (4.0 + f*(4.0-3.0*cos2Alpha))
+	L := lam - (1.0-C)*f*sinAlpha*
+		(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1.0+2.0*cos2SigmaM*cos2SigmaM)))
+	lon2 = lon1 + L
+	return lat2, lon2
+}
+
+// geodAB returns Vincenty's series A and B for u^2 = cos^2(alpha) e'^2
+func geodAB(uu float64) (A, B float64) {
+	A = 1.0 + uu/16384.0*(4096.0+uu*(-768.0+uu*(320.0-175.0*uu)))
+	B = uu / 1024.0 * (256.0 + uu*(-128.0+uu*(74.0-47.0*uu)))
+	return A, B
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj/support"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeodesic(t *testing.T) {
+	assert := assert.New(t)
+
+	// Flinders Peak to Buninyong, the Geoscience Australia example
+	lat1 := support.DDToR(-(37.0 + 57.0/60.0 + 3.72030/3600.0))
+	lon1 := support.DDToR(144.0 + 25.0/60.0 + 29.52440/3600.0)
+	lat2 := support.DDToR(-(37.0 + 39.0/60.0 + 10.15610/3600.0))
+	lon2 := support.DDToR(143.0 + 55.0/60.0 + 35.38390/3600.0)
+
+	s12, azi1, err := support.GeodInverse(grs80A, grs80F, lat1, lon1, lat2, lon2)
+	assert.NoError(err)
+	assert.InDelta(54972.271, s12, 1.0e-3)
+	assert.InDelta(306.0+52.0/60.0+5.37/3600.0, support.RToDD(azi1)+360.0, 1.0e-5)
+
+	lat, lon := support.GeodDirect(grs80A, grs80F, lat1, lon1, azi1, s12)
+	assert.InDelta(lat2, lat, 1.0e-12)
+	assert.InDelta(lon2, lon, 1.0e-12)
+
+	// coincident points
+	s12, _, err = support.GeodInverse(grs80A, grs80F, lat1, lon1, lat1, lon1)
+	assert.NoError(err)
+	assert.Equal(0.0, s12)
+
+	// nearly antipodal points don't converge
+	_, _, err = support.GeodInverse(grs80A, grs80F, 0.0, 0.0, 0.5e-5, math.Pi-1.0e-5)
+	assert.Error(err)
+}
@@ -0,0 +1,43 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+//go:build !proj_portable
+
+package support
+
+import (
+	"math"
+)
+
+// The math functions whose results depend on the machine: on amd64, Exp,
+// Log and Hypot are written in assembly (Exp using FMA instructions if
+// the CPU has them), and the rest call them, while other architectures
+// use the portable Go code. The operations call these rather than the
+// math package, so that building with the proj_portable tag swaps them
+// for copies of the portable code, which give the same results
+// everywhere, as the README explains.
+
+// Exp is math.Exp
+func Exp(x float64) float64 { return math.Exp(x) }
+
+// Log is math.Log
+func Log(x float64) float64 { return math.Log(x) }
+
+// Hypot is math.Hypot
+func Hypot(p, q float64) float64 { return math.Hypot(p, q) }
+
+// Pow is math.Pow
+func Pow(x, y float64) float64 { return math.Pow(x, y) }
+
+// Sinh is math.Sinh
+func Sinh(x float64) float64 { return math.Sinh(x) }
+
+// Cosh is math.Cosh
+func Cosh(x float64) float64 { return math.Cosh(x) }
+
+// Asinh is math.Asinh
+func Asinh(x float64) float64 { return math.Asinh(x) }
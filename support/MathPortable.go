@@ -0,0 +1,337 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+//
+// The functions in this file are copied from the portable code of Go's
+// math package, which is Copyright 2009 The Go Authors, under the
+// BSD-style license in `LICENSE-go`, and derived in turn from FreeBSD's
+// msun library.
+
+//go:build proj_portable
+
+package support
+
+import (
+	"math"
+)
+
+// Under the proj_portable tag, these are the portable Go code of the math
+// package, whatever the architecture, so that (with FMA fusion turned off
+// too; see the README) conversions give bit-identical results on amd64,
+// arm64 and the rest.
+
+// Exp is math.Exp, as the math package computes it without assembly
+func Exp(x float64) float64 {
+	const (
+		Ln2Hi = 6.93147180369123816490e-01
+		Ln2Lo = 1.90821492927058770002e-10
+		Log2e = 1.44269504088896338700e+00
+
+		Overflow  = 7.09782712893383973096e+02
+		Underflow = -7.45133219101941108420e+02
+		NearZero  = 1.0 / (1 << 28) // 2**-28
+	)
+
+	switch {
+	case math.IsNaN(x):
+		return x
+	case x > Overflow:
+		return math.Inf(1)
+	case x < Underflow:
+		return 0
+	case -NearZero < x && x < NearZero:
+		return 1 + x
+	}
+
+	// reduce; computed as r = hi - lo for extra precision
+	var k int
+	switch {
+	case x < 0:
+		k = int(Log2e*x - 0.5)
+	case x > 0:
+		k = int(Log2e*x + 0.5)
+	}
+	hi := x - float64(k)*Ln2Hi
+	lo := float64(k) * Ln2Lo
+
+	return expmulti(hi, lo, k)
+}
+
+// expmulti returns e**r × 2**k where r = hi - lo and |r| ≤ ln(2)/2
+func expmulti(hi, lo float64, k int) float64 {
+	const (
+		P1 = 1.66666666666666657415e-01
+		P2 = -2.77777777770155933842e-03
+		P3 = 6.61375632143793436117e-05
+		P4 = -1.65339022054652515390e-06
+		P5 = 4.13813679705723846039e-08
+	)
+
+	r := hi - lo
+	t := r * r
+	c := r - t*(P1+t*(P2+t*(P3+t*(P4+t*P5))))
+	y := 1 - ((lo - (r*c)/(2-c)) - hi)
+	return math.Ldexp(y, k)
+}
+
+// Log is math.Log, as the math package computes it without assembly
+func Log(x float64) float64 {
+	const (
+		Ln2Hi = 6.93147180369123816490e-01
+		Ln2Lo = 1.90821492927058770002e-10
+		L1    = 6.666666666666735130e-01
+		L2    = 3.999999999940941908e-01
+		L3    = 2.857142874366239149e-01
+		L4    = 2.222219843214978396e-01
+		L5    = 1.818357216161805012e-01
+		L6    = 1.531383769920937332e-01
+		L7    = 1.479819860511658591e-01
+	)
+
+	switch {
+	case math.IsNaN(x) || math.IsInf(x, 1):
+		return x
+	case x < 0:
+		return math.NaN()
+	case x == 0:
+		return math.Inf(-1)
+	}
+
+	// reduce
+	f1, ki := math.Frexp(x)
+	if f1 < math.Sqrt2/2 {
+		f1 *= 2
+		ki--
+	}
+	f := f1 - 1
+	k := float64(ki)
+
+	s := f / (2 + f)
+	s2 := s * s
+	s4 := s2 * s2
+	t1 := s2 * (L1 + s4*(L3+s4*(L5+s4*L7)))
+	t2 := s4 * (L2 + s4*(L4+s4*L6))
+	R := t1 + t2
+	hfsq := 0.5 * f * f
+	return k*Ln2Hi - ((hfsq - (s*(hfsq+R) + k*Ln2Lo)) - f)
+}
+
+// Hypot is math.Hypot, as the math package computes it without assembly
+func Hypot(p, q float64) float64 {
+	p, q = math.Abs(p), math.Abs(q)
+	switch {
+	case math.IsInf(p, 1) || math.IsInf(q, 1):
+		return math.Inf(1)
+	case math.IsNaN(p) || math.IsNaN(q):
+		return math.NaN()
+	}
+	if p < q {
+		p, q = q, p
+	}
+	if p == 0 {
+		return 0
+	}
+	q = q / p
+	return p * math.Sqrt(1+q*q)
+}
+
+// Pow is math.Pow, using the portable Exp and Log
+func Pow(x, y float64) float64 {
+	switch {
+	case y == 0 || x == 1:
+		return 1
+	case y == 1:
+		return x
+	case math.IsNaN(x) || math.IsNaN(y):
+		return math.NaN()
+	case x == 0:
+		switch {
+		case y < 0:
+			if math.Signbit(x) && isOddInt(y) {
+				return math.Inf(-1)
+			}
+			return math.Inf(1)
+		case y > 0:
+			if math.Signbit(x) && isOddInt(y) {
+				return x
+			}
+			return 0
+		}
+	case math.IsInf(y, 0):
+		switch {
+		case x == -1:
+			return 1
+		case (math.Abs(x) < 1) == math.IsInf(y, 1):
+			return 0
+		default:
+			return math.Inf(1)
+		}
+	case math.IsInf(x, 0):
+		if math.IsInf(x, -1) {
+			return Pow(1/x, -y) // Pow(-0, -y)
+		}
+		switch {
+		case y < 0:
+			return 0
+		case y > 0:
+			return math.Inf(1)
+		}
+	case y == 0.5:
+		return math.Sqrt(x)
+	case y == -0.5:
+		return 1 / math.Sqrt(x)
+	}
+
+	yi, yf := math.Modf(math.Abs(y))
+	if yf != 0 && x < 0 {
+		return math.NaN()
+	}
+	if yi >= 1<<63 {
+		// a large even int, which overflows or underflows for all x
+		// but -1
+		switch {
+		case x == -1:
+			return 1
+		case (math.Abs(x) < 1) == (y > 0):
+			return 0
+		default:
+			return math.Inf(1)
+		}
+	}
+
+	// ans = a1 * 2**ae (= 1 for now)
+	a1 := 1.0
+	ae := 0
+
+	// ans *= x**yf
+	if yf != 0 {
+		if yf > 0.5 {
+			yf--
+			yi++
+		}
+		a1 = Exp(yf * Log(x))
+	}
+
+	// ans *= x**yi, by multiplying in successive squarings of x
+	// according to the bits of yi, accumulating powers of two into ae
+	x1, xe := math.Frexp(x)
+	for i := int64(yi); i != 0; i >>= 1 {
+		if xe < -1<<12 || 1<<12 < xe {
+			// ae is past the range of a float64 exponent already
+			ae += xe
+			break
+		}
+		if i&1 == 1 {
+			a1 *= x1
+			ae += xe
+		}
+		x1 *= x1
+		xe <<= 1
+		if x1 < .5 {
+			x1 += x1
+			xe--
+		}
+	}
+
+	// ans = a1*2**ae, inverted for y < 0
+	if y < 0 {
+		a1 = 1 / a1
+		ae = -ae
+	}
+	return math.Ldexp(a1, ae)
+}
+
+func isOddInt(x float64) bool {
+	if math.Abs(x) >= (1 << 53) {
+		// too big to have a fractional part, so even
+		return false
+	}
+	xi, xf := math.Modf(x)
+	return xf == 0 && int64(xi)&1 == 1
+}
+
+// Sinh is math.Sinh, using the portable Exp
+func Sinh(x float64) float64 {
+	// the coefficients are #2029 from Hart & Cheney (20.36D)
+	const (
+		P0 = -0.6307673640497716991184787251e+6
+		P1 = -0.8991272022039509355398013511e+5
+		P2 = -0.2894211355989563807284660366e+4
+		P3 = -0.2630563213397497062819489e+2
+		Q0 = -0.6307673640497716991212077277e+6
+		Q1 = 0.1521517378790019070696485176e+5
+		Q2 = -0.173678953558233699533450911e+3
+	)
+
+	sign := false
+	if x < 0 {
+		x = -x
+		sign = true
+	}
+
+	var temp float64
+	switch {
+	case x > 21:
+		temp = Exp(x) * 0.5
+	case x > 0.5:
+		ex := Exp(x)
+		temp = (ex - 1/ex) * 0.5
+	default:
+		sq := x * x
+		temp = (((P3*sq+P2)*sq+P1)*sq + P0) * x
+		temp = temp / (((sq+Q2)*sq+Q1)*sq + Q0)
+	}
+
+	if sign {
+		temp = -temp
+	}
+	return temp
+}
+
+// Cosh is math.Cosh, using the portable Exp
+func Cosh(x float64) float64 {
+	x = math.Abs(x)
+	if x > 21 {
+		return Exp(x) * 0.5
+	}
+	ex := Exp(x)
+	return (ex + 1/ex) * 0.5
+}
+
+// Asinh is math.Asinh, using the portable Log
+func Asinh(x float64) float64 {
+	const (
+		Ln2      = 6.93147180559945286227e-01
+		NearZero = 1.0 / (1 << 28) // 2**-28
+		Large    = 1 << 28         // 2**28
+	)
+
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return x
+	}
+	sign := false
+	if x < 0 {
+		x = -x
+		sign = true
+	}
+
+	var temp float64
+	switch {
+	case x > Large:
+		temp = Log(x) + Ln2
+	case x > 2:
+		temp = Log(2*x + 1/(math.Sqrt(x*x+1)+x))
+	case x < NearZero:
+		temp = x
+	default:
+		temp = math.Log1p(x + x*x/(1+math.Sqrt(1+x*x)))
+	}
+
+	if sign {
+		temp = -temp
+	}
+	return temp
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj/support"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMath checks the functions against the math package: exactly, by
+// default, and to within an ulp or two under the proj_portable tag,
+// where they are the portable code rather than amd64's assembly
+func TestMath(t *testing.T) {
+	assert := assert.New(t)
+
+	same := func(name string, expected, actual float64) {
+		switch {
+		case math.IsNaN(expected):
+			assert.True(math.IsNaN(actual), name)
+		case math.IsInf(expected, 0) || expected == 0.0:
+			assert.Equal(expected, actual, name)
+		default:
+			assert.InEpsilon(expected, actual, 4.0e-16, name)
+		}
+	}
+
+	values := []float64{
+		0.0, math.Copysign(0.0, -1.0), 1.0e-300, 1.0e-9, 0.25, 0.5, 1.0, 1.5,
+		math.Sqrt2, math.Pi, 7.25, 21.5, 100.0, 709.0, 710.0, 1.0e300,
+		math.Inf(1), math.NaN(),
+	}
+	for _, v := range values {
+		for _, x := range []float64{v, -v} {
+			same("Exp", math.Exp(x), support.Exp(x))
+			same("Log", math.Log(x), support.Log(x))
+			same("Sinh", math.Sinh(x), support.Sinh(x))
+			same("Cosh", math.Cosh(x), support.Cosh(x))
+			same("Asinh", math.Asinh(x), support.Asinh(x))
+			for _, y := range []float64{0.0, 0.5, -0.5, 2.0, -3.0, 0.0818, 1.0 / 3.0, math.Inf(1)} {
+				same("Pow", math.Pow(x, y), support.Pow(x, y))
+				same("Hypot", math.Hypot(x, y), support.Hypot(x, y))
+			}
+		}
+	}
+}
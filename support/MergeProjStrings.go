@@ -0,0 +1,161 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support
+
+import (
+	"strconv"
+
+	"github.com/oahumap/proj/merror"
+)
+
+// numericKeys are the keys whose values must be numbers
+var numericKeys = []string{
+	"x_0", "y_0", "k_0", "k",
+	"a", "b", "rf", "f", "es", "e", "R",
+}
+
+// angleKeys are the keys whose values must be angles, in decimal degrees
+// or DMS
+var angleKeys = []string{
+	"lat_0", "lon_0", "lat_1", "lat_2", "lat_ts", "lonc", "alpha", "gamma",
+}
+
+// conflictingKeys are pairs of keys which say the same thing, so that
+// overriding one removes the other
+var conflictingKeys = map[string]string{
+	"units":    "to_meter",
+	"to_meter": "units",
+}
+
+// MergeProjStrings returns the base definition, given as a proj string or
+// a bare SRID such as "32633", with the parameters of the overrides
+// replacing or added to its own: e.g. "+x_0=0 +units=ft".
+//
+// Overriding a key replaces its first occurrence and drops any others.
+// The operation itself (proj) can't be overridden. The result is checked
+// to still make sense: known units, ellipsoid and datum, numeric values
+// where numbers are needed, and so on.
+func MergeProjStrings(base, overrides string) (*ProjString, error) {
+	var ps *ProjString
+
+	if srid, err := strconv.Atoi(base); err == nil {
+		preset, ok := SRIDPreset(srid)
+		if !ok {
+			return nil, merror.New(merror.UnknownProjection, base)
+		}
+		ps = preset
+	} else {
+		ps, err = NewProjString(base)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	over, err := NewProjString(overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pair := range over.Pairs {
+		if pair.Key == "proj" {
+			return nil, merror.New(merror.InvalidProjectionSyntax, "proj can't be overridden")
+		}
+		ps.remove(conflictingKeys[pair.Key])
+		ps.set(pair)
+	}
+
+	err = validateMerged(ps)
+	if err != nil {
+		return nil, err
+	}
+
+	return ps, nil
+}
+
+// set replaces the first occurrence of the key, dropping any others, or
+// adds the pair if the key isn't present
+func (pl *ProjString) set(pair Pair) {
+	pairs := pl.Pairs[:0]
+	found := false
+	for _, p := range pl.Pairs {
+		if p.Key == pair.Key {
+			if found {
+				continue
+			}
+			p = pair
+			found = true
+		}
+		pairs = append(pairs, p)
+	}
+	if !found {
+		pairs = append(pairs, pair)
+	}
+	pl.Pairs = pairs
+}
+
+// remove drops every occurrence of the key
+func (pl *ProjString) remove(key string) {
+	pairs := pl.Pairs[:0]
+	for _, p := range pl.Pairs {
+		if p.Key != key {
+			pairs = append(pairs, p)
+		}
+	}
+	pl.Pairs = pairs
+}
+
+// validateMerged checks the values of the keys a merge may have changed
+func validateMerged(ps *ProjString) error {
+	if ps.CountKey("proj") != 1 {
+		return merror.New(merror.InvalidProjectionSyntax, "proj must appear exactly once")
+	}
+	projName, _ := ps.GetAsString("proj")
+	if _, ok := ProjectionsTable[projName]; !ok {
+		return merror.New(merror.UnknownProjection, projName)
+	}
+
+	for _, key := range numericKeys {
+		if ps.ContainsKey(key) {
+			if _, ok := ps.GetAsFloat(key); !ok {
+				return merror.New(merror.InvalidProjectionSyntax, key)
+			}
+		}
+	}
+	for _, key := range angleKeys {
+		if ps.ContainsKey(key) {
+			if _, ok := ps.GetAsAngle(key); !ok {
+				return merror.New(merror.InvalidProjectionSyntax, key)
+			}
+		}
+	}
+
+	if units, ok := ps.GetAsString("units"); ok {
+		if _, ok := UnitsTable[units]; !ok {
+			return merror.New(merror.InvalidProjectionSyntax, "units="+units)
+		}
+	}
+	if ellps, ok := ps.GetAsString("ellps"); ok {
+		if _, ok := EllipsoidsTable[ellps]; !ok {
+			return merror.New(merror.UnknownEllipseParameter, ellps)
+		}
+	}
+	if datum, ok := ps.GetAsString("datum"); ok {
+		if _, ok := DatumsTable[datum]; !ok {
+			return merror.New(merror.InvalidProjectionSyntax, "datum="+datum)
+		}
+	}
+
+	if projName == "utm" && ps.ContainsKey("zone") {
+		zone, ok := ps.GetAsInt("zone")
+		if !ok || zone < 1 || zone > 60 {
+			return merror.New(merror.InvalidUTMZone)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj/support"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeProjStrings(t *testing.T) {
+	assert := assert.New(t)
+
+	// SRID base: replace, add, and drop a conflicting key
+	ps, err := support.MergeProjStrings("32633", "+x_0=0 +units=ft +south")
+	assert.NoError(err)
+	assert.Equal("+proj=utm +zone=33 +datum=WGS84 +units=ft +no_defs +x_0=0 +south", ps.Definition())
+
+	ps, err = support.MergeProjStrings("+proj=merc +to_meter=0.3048 +k_0=1 +k_0=2", "+units=m +k_0=0.5")
+	assert.NoError(err)
+	assert.Equal("+proj=merc +k_0=0.5 +units=m", ps.Definition())
+
+	// angles may be in DMS
+	ps, err = support.MergeProjStrings("+proj=merc +lat_ts=45", "+lat_0=12d30'N +lon_0=-0.5")
+	assert.NoError(err)
+	assert.Equal("+proj=merc +lat_ts=45 +lat_0=12d30'N +lon_0=-0.5", ps.Definition())
+
+	// the base can't be changed underneath us
+	preset, ok := support.SRIDPreset(32633)
+	assert.True(ok)
+	assert.Equal("+proj=utm +zone=33 +datum=WGS84 +units=m +no_defs", preset.Definition())
+
+	bad := [][2]string{
+		{"32633", "+proj=merc"},
+		{"32633", "+zone=61"},
+		{"32633", "+x_0=east"},
+		{"32633", "+lon_0=east"},
+		{"32633", "+units=furlong"},
+		{"32633", "+ellps=flat"},
+		{"32633", "+datum=mars"},
+		{"999999", "+x_0=0"},
+		{"+proj=nosuch", "+x_0=0"},
+		{"+proj=utm +zone=33", "+x_0=1=2"},
+	}
+	for _, b := range bad {
+		_, err = support.MergeProjStrings(b[0], b[1])
+		assert.Error(err, b[1])
+	}
+}
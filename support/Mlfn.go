@@ -34,7 +34,8 @@ const eps = 1e-11
 const maxIter = 10
 const enSize = 5
 
-// Enfn is ..?
+// Enfn returns the coefficients of the series used by Mlfn and InvMlfn
+// for an ellipsoid with eccentricity squared es
 func Enfn(es float64) []float64 {
 	var t float64
 
@@ -51,14 +52,18 @@ func Enfn(es float64) []float64 {
 	return en
 }
 
-// Mlfn is ..?
+// Mlfn returns the meridional distance from the equator to latitude phi
+// (radians), on an ellipsoid with unit major axis. sphi and cphi are the
+// sine and cosine of phi, and en comes from Enfn.
 func Mlfn(phi float64, sphi float64, cphi float64, en []float64) float64 {
 	cphi *= sphi
 	sphi *= sphi
 	return (en[0]*phi - cphi*(en[1]+sphi*(en[2]+sphi*(en[3]+sphi*en[4]))))
 }
 
-// InvMlfn is ..?
+// InvMlfn is the inverse of Mlfn: it returns the latitude whose
+// meridional distance is arg. The latitude is found by iteration, which
+// fails with merror.InvMlfn if it doesn't converge.
 func InvMlfn(arg float64, es float64, en []float64) (float64, error) {
 	var s, t, phi float64
 	k := 1. / (1. - es)
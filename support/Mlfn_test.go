@@ -0,0 +1,39 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj/support"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMlfn(t *testing.T) {
+	assert := assert.New(t)
+
+	en := support.Enfn(grs80Es)
+	assert.Len(en, 5)
+
+	// the quarter meridian of GRS80
+	assert.InDelta(10001965.7293, grs80A*support.Mlfn(math.Pi/2, 1.0, 0.0, en), 1.0e-3)
+
+	// on the sphere, the distance is just the latitude
+	sphere := support.Enfn(0.0)
+	assert.InDelta(0.5, support.Mlfn(0.5, math.Sin(0.5), math.Cos(0.5), sphere), 1.0e-15)
+
+	for _, deg := range []float64{-75.0, -10.0, 0.0, 33.3, 89.9} {
+		phi := support.DDToR(deg)
+		m := support.Mlfn(phi, math.Sin(phi), math.Cos(phi), en)
+
+		back, err := support.InvMlfn(m, grs80Es, en)
+		assert.NoError(err)
+		assert.InDelta(phi, back, 1.0e-11)
+	}
+}
@@ -9,7 +9,13 @@ package support
 
 import "math"
 
-// Msfn is to "determine constant small m"
+// Msfn is to "determine constant small m", the function m(phi) used by the
+// conic and cylindrical projections:
+//
+//	m = cos(phi) / sqrt(1 - es sin^2(phi))
+//
+// i.e. the radius of the parallel at phi on an ellipsoid with unit major
+// axis and eccentricity squared es.
 func Msfn(sinphi, cosphi, es float64) float64 {
 	return (cosphi / math.Sqrt(1.-es*sinphi*sinphi))
 }
@@ -0,0 +1,36 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj/support"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMsfn(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, deg := range []float64{-60.0, 0.0, 45.0, 90.0} {
+		phi := support.DDToR(deg)
+		sinphi, cosphi := math.Sin(phi), math.Cos(phi)
+
+		// on the sphere, m is just the cosine
+		assert.InDelta(cosphi, support.Msfn(sinphi, cosphi, 0.0), 1.0e-15)
+
+		// on the ellipsoid, a*m is the radius of the parallel
+		nu := grs80A / math.Sqrt(1.0-grs80Es*sinphi*sinphi)
+		assert.InDelta(nu*cosphi, grs80A*support.Msfn(sinphi, cosphi, grs80Es), 1.0e-6)
+	}
+
+	// 45 degrees on GRS80
+	phi := support.DDToR(45.0)
+	assert.InDelta(0.7082931707, support.Msfn(math.Sin(phi), math.Cos(phi), grs80Es), 1.0e-10)
+}
@@ -16,7 +16,12 @@ import (
 const tol = 1.0e-10
 const nIter = 15
 
-// Phi2 is to "determine latitude angle phi-2"
+// Phi2 is to "determine latitude angle phi-2", the inverse of Tsfn: given
+// t and the eccentricity e, it returns the latitude phi (radians) such
+// that Tsfn(phi, sin(phi), e) = t.
+//
+// The latitude is found by iteration, which fails with merror.Phi2 if it
+// doesn't converge.
 func Phi2(ts, e float64) (float64, error) {
 	var eccnth, Phi, con float64
 	var i int
@@ -27,7 +32,7 @@ func Phi2(ts, e float64) (float64, error) {
 	for {
 
 		con = e * math.Sin(Phi)
-		dphi := PiOverTwo - 2.*math.Atan(ts*math.Pow((1.-con)/(1.+con), eccnth)) - Phi
+		dphi := PiOverTwo - 2.*math.Atan(ts*Pow((1.-con)/(1.+con), eccnth)) - Phi
 		Phi += dphi
 		i--
 		if math.Abs(dphi) > tol && i != 0 {
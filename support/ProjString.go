@@ -39,6 +39,11 @@ type ProjString struct {
 // NewProjString returns a new ProjString from a string
 // of the form "+proj=utm +zone=11 +datum=WGS84",
 // with the leading "+" is optional and ignoring extra whitespace
+//
+// As found in metadata files, trailing semicolons are dropped, from the
+// string and from each parameter, and parameter names are taken
+// case-insensitively, e.g. "+PROJ=UTM +Zone=11;" is "+proj=utm +zone=11";
+// the sphere radius keys, such as "+R" and "+R_A", keep their case.
 func NewProjString(source string) (*ProjString, error) {
 
 	ret := &ProjString{
@@ -52,9 +57,14 @@ func NewProjString(source string) (*ProjString, error) {
 
 		var pair Pair
 
-		if w[0:1] == "+" {
+		w = strings.TrimRight(w, ";")
+		if w != "" && w[0:1] == "+" {
 			w = w[1:]
 		}
+		if w == "" {
+			// "+ proj=utm", or "+zone=11 ;"
+			continue
+		}
 
 		v := strings.Split(w, "=")
 
@@ -79,12 +89,40 @@ func NewProjString(source string) (*ProjString, error) {
 			return nil, merror.New(merror.InvalidProjectionSyntax, v)
 		}
 
+		pair.Key = normalizeKey(pair.Key)
+		if pair.Key == "proj" {
+			// the operation ids are all lower case
+			pair.Value = strings.ToLower(pair.Value)
+		}
 		ret.Add(pair)
 	}
 
 	return ret, nil
 }
 
+// the keys which aren't lower case; R_A and R_a differ only in case, so
+// must be given exactly
+var mixedCaseKeys = map[string]string{
+	"r":       "R",
+	"r_v":     "R_V",
+	"r_g":     "R_g",
+	"r_h":     "R_h",
+	"r_lat_a": "R_lat_a",
+	"r_lat_g": "R_lat_g",
+}
+
+// normalizeKey returns the key in its proper case
+func normalizeKey(key string) string {
+	if key == "R_A" || key == "R_a" {
+		return key
+	}
+	lower := strings.ToLower(key)
+	if mixed, ok := mixedCaseKeys[lower]; ok {
+		return mixed
+	}
+	return lower
+}
+
 // handle extra whitespace in lines like "  +proj = merc   x = 1.2  "
 func collapse(s string) string {
 	re_leadclose_whtsp := regexp.MustCompile(`^[\s\p{Zs}]+|[\s\p{Zs}]+$`)
@@ -121,6 +159,19 @@ func (pl *ProjString) String() string {
 	return string(b)
 }
 
+// Definition returns the list in proj string form, e.g. "+proj=utm +zone=11"
+func (pl *ProjString) Definition() string {
+	words := make([]string, len(pl.Pairs))
+	for i, pair := range pl.Pairs {
+		if pair.Value == "" {
+			words[i] = "+" + pair.Key
+		} else {
+			words[i] = "+" + pair.Key + "=" + pair.Value
+		}
+	}
+	return strings.Join(words, " ")
+}
+
 // Len returns the number of pairs in the list
 func (pl *ProjString) Len() int {
 	return len(pl.Pairs)
@@ -214,6 +265,68 @@ func (pl *ProjString) GetAsFloat(key string) (float64, bool) {
 	return f, true
 }
 
+// GetAsAngle returns the value of the first occurrence of the key, an
+// angle in degrees, in radians: as per PROJ's dmstor(), the degrees may be
+// decimal or DMS, as in "12d30'", and may end with a hemisphere, as in
+// "30.5W", which is -30.5
+func (pl *ProjString) GetAsAngle(key string) (float64, bool) {
+
+	value, ok := pl.get(key)
+	if !ok {
+		return 0.0, false
+	}
+
+	r, err := DMSToR(value)
+	if err != nil {
+		return 0.0, false
+	}
+
+	return r, true
+}
+
+// GetAsDegrees returns the value of the first occurrence of the key, an
+// angle, in decimal degrees, and whether the key is given at all. As for
+// GetAsAngle, the value may be DMS or end with a hemisphere; a value which
+// is not an angle is an error, rather than being read as 0.
+func (pl *ProjString) GetAsDegrees(key string) (value float64, given bool, err error) {
+
+	value2, ok := pl.get(key)
+	if !ok {
+		return 0.0, false, nil
+	}
+
+	dd, err := DMSToDD(value2)
+	if err != nil {
+		return 0.0, true, merror.New(merror.InvalidProjectionSyntax, key+"="+value2)
+	}
+
+	return dd, true, nil
+}
+
+// GetAsBool returns the value of the first occurrence of the key, as a
+// flag: as per PROJ, a key with no value, as in "+south", or a value
+// starting with "t" or "T" is true, and one starting with "f" or "F" is
+// false. An absent key is false, and ok is false only if the value is not
+// a flag.
+func (pl *ProjString) GetAsBool(key string) (value bool, ok bool) {
+
+	value2, ok := pl.get(key)
+	if !ok {
+		return false, true
+	}
+
+	switch {
+	case value2 == "":
+		return true, true
+	case value2[0] == 't' || value2[0] == 'T':
+		return true, true
+	case value2[0] == 'f' || value2[0] == 'F':
+		return false, true
+	}
+
+	return false, false
+}
+
 // GetAsFloats returns the value of the first occurrence of the key,
 // interpreted as comma-separated floats
 func (pl *ProjString) GetAsFloats(key string) ([]float64, bool) {
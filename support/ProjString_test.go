@@ -97,6 +97,56 @@ func TestPairListGets(t *testing.T) {
 	vi, ok := pl.GetAsInt("k4")
 	assert.True(ok)
 	assert.Equal(678, vi)
+
+	// angles, in radians, from decimal or DMS degrees
+	pl, err = support.NewProjString("+alpha=30.5W +gamma=12d30'N +lonc=-7.25 +bogus=x")
+	assert.NoError(err)
+	va, ok := pl.GetAsAngle("alpha")
+	assert.True(ok)
+	assert.InDelta(support.DDToR(-30.5), va, 1.0e-15)
+	va, ok = pl.GetAsAngle("gamma")
+	assert.True(ok)
+	assert.InDelta(support.DDToR(12.5), va, 1.0e-15)
+	va, ok = pl.GetAsAngle("lonc")
+	assert.True(ok)
+	assert.Equal(support.DDToR(-7.25), va)
+	_, ok = pl.GetAsAngle("bogus")
+	assert.False(ok)
+	_, ok = pl.GetAsAngle("k99")
+	assert.False(ok)
+
+	// and in degrees, exactly as given if decimal
+	vd, given, err := pl.GetAsDegrees("lonc")
+	assert.NoError(err)
+	assert.True(given)
+	assert.Equal(-7.25, vd)
+	vd, _, err = pl.GetAsDegrees("alpha")
+	assert.NoError(err)
+	assert.Equal(-30.5, vd)
+	_, given, err = pl.GetAsDegrees("bogus")
+	assert.True(given)
+	assert.Error(err)
+	_, given, err = pl.GetAsDegrees("k99")
+	assert.False(given)
+	assert.NoError(err)
+}
+
+func TestPairListGetAsBool(t *testing.T) {
+	assert := assert.New(t)
+
+	pl, err := support.NewProjString("+proj=utm +south +over=T +czech=true +no_rot=f +no_cut=False +geoc=1")
+	assert.NoError(err)
+
+	for key, expected := range map[string]bool{
+		"south": true, "over": true, "czech": true, "no_rot": false, "no_cut": false, "absent": false,
+	} {
+		v, ok := pl.GetAsBool(key)
+		assert.True(ok, key)
+		assert.Equal(expected, v, key)
+	}
+
+	_, ok := pl.GetAsBool("geoc")
+	assert.False(ok)
 }
 
 func TestPairListParsing(t *testing.T) {
@@ -122,4 +172,31 @@ func TestPairListParsing(t *testing.T) {
 	assert.Equal("k5", pl.Get(3).Value)
 
 	assert.True(len(pl.String()) > 10)
+
+	assert.Equal("+proj=v1 +k2=v2 +k3=v3 +k4=k5", pl.Definition())
+	pl, err = support.NewProjString("proj=utm +south zone=33")
+	assert.NoError(err)
+	assert.Equal("+proj=utm +south +zone=33", pl.Definition())
+
+	// as found in metadata files
+	for _, source := range []string{
+		"+proj=utm\t+zone=33   +south +ellps=WGS84",
+		"proj=utm zone=33 south ellps=WGS84",
+		"+proj=utm +zone=33 +south +ellps=WGS84;",
+		"+proj=utm; +zone=33; +south; +ellps=WGS84 ;",
+		"+PROJ=UTM +Zone=33 +SOUTH +ELLPS=WGS84",
+		"+ proj = utm + zone=33 +south +ellps=WGS84;\n",
+	} {
+		pl, err = support.NewProjString(source)
+		assert.NoError(err, source)
+		assert.Equal("+proj=utm +zone=33 +south +ellps=WGS84", pl.Definition(), source)
+	}
+
+	// the sphere radius keys keep their case
+	pl, err = support.NewProjString("+PROJ=MERC +r=6371000 +R_A +R_LAT_A=45")
+	assert.NoError(err)
+	assert.Equal("+proj=merc +R=6371000 +R_A +R_lat_a=45", pl.Definition())
+	pl, err = support.NewProjString("+proj=merc +ellps=WGS84 +R_a")
+	assert.NoError(err)
+	assert.Equal("+proj=merc +ellps=WGS84 +R_a", pl.Definition())
 }
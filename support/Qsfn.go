@@ -13,7 +13,14 @@ import (
 
 const epsilon = 1.0e-7
 
-// Qsfn is ..?
+// Qsfn is to "determine small q", the function q(phi) used by the equal
+// area projections (aea, cea, laea, ...):
+//
+//	q = (1 - es) (sin(phi) / (1 - es sin^2(phi)) - 1/(2e) ln((1 - e sin(phi)) / (1 + e sin(phi))))
+//
+// where e is the eccentricity and oneEs is 1 - e^2. For the sphere (and
+// very small e) this is 2 sin(phi). Returns math.MaxFloat64 if the
+// computation would divide by zero.
 func Qsfn(sinphi, e, oneEs float64) float64 {
 	var con, div1, div2 float64
 
@@ -27,7 +34,7 @@ func Qsfn(sinphi, e, oneEs float64) float64 {
 			return math.MaxFloat64
 		}
 
-		return (oneEs * (sinphi/div1 - (.5/e)*math.Log((1.-con)/div2)))
+		return (oneEs * (sinphi/div1 - (.5/e)*Log((1.-con)/div2)))
 	}
 	return (sinphi + sinphi)
 }
@@ -0,0 +1,35 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj/support"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQsfn(t *testing.T) {
+	assert := assert.New(t)
+
+	// on the sphere (or close enough), q is 2 sin(phi)
+	assert.InDelta(1.0, support.Qsfn(0.5, 0.0, 1.0), 1.0e-15)
+	assert.InDelta(-2.0, support.Qsfn(-1.0, 1.0e-8, 1.0), 1.0e-15)
+
+	// q is odd in phi
+	q := support.Qsfn(0.5, grs80E, 1.0-grs80Es)
+	assert.InDelta(-q, support.Qsfn(-0.5, grs80E, 1.0-grs80Es), 1.0e-15)
+
+	// q at the pole gives the radius of the authalic sphere
+	qp := support.Qsfn(1.0, grs80E, 1.0-grs80Es)
+	assert.InDelta(6371007.1810, grs80A*math.Sqrt(qp/2.0), 1.0e-3)
+
+	// e sin(phi) == 1 would divide by zero
+	assert.Equal(math.MaxFloat64, support.Qsfn(1.0, 1.0, 0.0))
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support
+
+import (
+	"bytes"
+	_ "embed" // for the presets blob
+	"encoding/gob"
+	"sync"
+
+	"github.com/oahumap/proj/merror"
+)
+
+//go:generate go run ../internal/gensrids -o SRIDsPresets.gob
+
+// sridPresetsBlob holds the definitions of SRIDsTable, already parsed into
+// pairs. Rerun "go generate" after changing the table.
+//
+//go:embed SRIDsPresets.gob
+var sridPresetsBlob []byte
+
+type sridPreset struct {
+	SRID  int
+	Pairs []Pair
+}
+
+var sridPresets map[int][]Pair
+var sridPresetsOnce sync.Once
+
+func loadSRIDPresets() {
+	presets := []sridPreset{}
+	err := gob.NewDecoder(bytes.NewReader(sridPresetsBlob)).Decode(&presets)
+	if err != nil {
+		// only possible if the blob is corrupt; fall back to parsing
+		merror.Pass(err)
+		presets = nil
+	}
+
+	sridPresets = make(map[int][]Pair, len(presets))
+	for _, preset := range presets {
+		sridPresets[preset.SRID] = preset.Pairs
+	}
+}
+
+// SRIDPreset returns the ProjString for an entry of SRIDsTable.
+//
+// The presets are precompiled, so this does no parsing unless the
+// generated blob is out of date for the SRID. The returned ProjString is
+// the caller's to modify.
+func SRIDPreset(srid int) (*ProjString, bool) {
+	sridPresetsOnce.Do(loadSRIDPresets)
+
+	pairs, ok := sridPresets[srid]
+	if !ok {
+		entry, ok := SRIDsTable[srid]
+		if !ok {
+			return nil, false
+		}
+		ps, err := NewProjString(entry.Definition)
+		if err != nil {
+			return nil, false
+		}
+		return ps, true
+	}
+
+	ps := &ProjString{
+		Pairs: make([]Pair, len(pairs)),
+	}
+	copy(ps.Pairs, pairs)
+
+	return ps, true
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj/support"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSRIDPreset(t *testing.T) {
+	assert := assert.New(t)
+
+	// if this fails, the blob is stale: run "go generate" in support
+	for srid, entry := range support.SRIDsTable {
+		expected, err := support.NewProjString(entry.Definition)
+		assert.NoError(err)
+
+		ps, ok := support.SRIDPreset(srid)
+		assert.True(ok)
+		assert.Equal(expected, ps, entry.Definition)
+	}
+
+	// callers get their own copy
+	ps, ok := support.SRIDPreset(3857)
+	assert.True(ok)
+	ps.Add(support.Pair{Key: "ellps", Value: "GRS80"})
+	ps, _ = support.SRIDPreset(3857)
+	assert.False(ps.ContainsKey("ellps"))
+
+	_, ok = support.SRIDPreset(9999)
+	assert.False(ok)
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support
+
+import "fmt"
+
+func init() {
+
+	// the WGS 84 UTM zones are regular enough to generate
+	for zone := 1; zone <= 60; zone++ {
+		north := 32600 + zone
+		SRIDsTable[north] = &SRIDsTableEntry{
+			north, "EPSG",
+			fmt.Sprintf("+proj=utm +zone=%d +datum=WGS84 +units=m +no_defs", zone),
+			fmt.Sprintf("WGS 84 / UTM zone %dN", zone),
+		}
+		south := 32700 + zone
+		SRIDsTable[south] = &SRIDsTableEntry{
+			south, "EPSG",
+			fmt.Sprintf("+proj=utm +zone=%d +south +datum=WGS84 +units=m +no_defs", zone),
+			fmt.Sprintf("WGS 84 / UTM zone %dS", zone),
+		}
+	}
+}
+
+//---------------------------------------------------------------------
+
+// SRIDsTableEntry holds the proj4 definition for an SRID, as found in
+// the proj4text column of PostGIS's spatial_ref_sys table
+type SRIDsTableEntry struct {
+	SRID       int
+	AuthName   string
+	Definition string
+	Name       string
+}
+
+// SRIDsTable is the global list of known SRIDs
+//
+// The definitions are taken verbatim from PostGIS, which occasionally
+// differs from epsg.io: for example, PostGIS defines 3857 with
+// "+nadgrids=@null +wktext" to suppress any datum shift.
+var SRIDsTable = map[int]*SRIDsTableEntry{
+	3395: {3395, "EPSG", "+proj=merc +lon_0=0 +k=1 +x_0=0 +y_0=0 +datum=WGS84 +units=m +no_defs", "WGS 84 / World Mercator"},
+	3857: {3857, "EPSG", "+proj=merc +a=6378137 +b=6378137 +lat_ts=0 +lon_0=0 +x_0=0 +y_0=0 +k=1 +units=m +nadgrids=@null +wktext +no_defs", "WGS 84 / Pseudo-Mercator"},
+	4087: {4087, "EPSG", "+proj=eqc +lat_ts=0 +lat_0=0 +lon_0=0 +x_0=0 +y_0=0 +datum=WGS84 +units=m +no_defs", "WGS 84 / World Equidistant Cylindrical"},
+	4258: {4258, "EPSG", "+proj=longlat +ellps=GRS80 +no_defs", "ETRS89"},
+	4269: {4269, "EPSG", "+proj=longlat +ellps=GRS80 +towgs84=0,0,0,0,0,0,0 +no_defs", "NAD83"},
+	4326: {4326, "EPSG", "+proj=longlat +datum=WGS84 +no_defs", "WGS 84"},
+
+	// the Plate Carree, under its deprecated EPSG code and ESRI's
+	32662: {32662, "EPSG", "+proj=eqc +lat_ts=0 +lat_0=0 +lon_0=0 +x_0=0 +y_0=0 +ellps=WGS84 +datum=WGS84 +units=m +no_defs", "WGS 84 / Plate Carree"},
+	54001: {54001, "ESRI", "+proj=eqc +lat_ts=0 +lat_0=0 +lon_0=0 +x_0=0 +y_0=0 +ellps=WGS84 +datum=WGS84 +units=m +no_defs", "World_Plate_Carree"},
+
+	// the Madagascar Laborde grid, with the Paris and the Greenwich meridian
+	8441:  {8441, "EPSG", "+proj=labrd +lat_0=-18.9 +lon_0=46.4372291666667 +azi=18.9 +k_0=0.9995 +x_0=400000 +y_0=800000 +ellps=intl +towgs84=-189,-242,-91,0,0,0,0 +units=m +no_defs", "Tananarive / Laborde Grid"},
+	29701: {29701, "EPSG", "+proj=labrd +lat_0=-18.9 +lon_0=44.1 +azi=18.9 +k_0=0.9995 +x_0=400000 +y_0=800000 +ellps=intl +towgs84=-189,-242,-91,0,0,0,0 +pm=paris +units=m +no_defs", "Tananarive (Paris) / Laborde Grid"},
+
+	// Hawaii: the State Plane zones and UTM zones on NAD83(PA11), and the
+	// Albers equal-area projection of the islands
+	6628:   {6628, "EPSG", "+proj=tmerc +lat_0=18.83333333333333 +lon_0=-155.5 +k=0.999966667 +x_0=500000 +y_0=0 +ellps=GRS80 +units=m +no_defs", "NAD83(PA11) / Hawaii zone 1"},
+	6629:   {6629, "EPSG", "+proj=tmerc +lat_0=20.33333333333333 +lon_0=-156.6666666666667 +k=0.999966667 +x_0=500000 +y_0=0 +ellps=GRS80 +units=m +no_defs", "NAD83(PA11) / Hawaii zone 2"},
+	6630:   {6630, "EPSG", "+proj=tmerc +lat_0=21.16666666666667 +lon_0=-158 +k=0.99999 +x_0=500000 +y_0=0 +ellps=GRS80 +units=m +no_defs", "NAD83(PA11) / Hawaii zone 3"},
+	6631:   {6631, "EPSG", "+proj=tmerc +lat_0=21.83333333333333 +lon_0=-159.5 +k=0.99999 +x_0=500000 +y_0=0 +ellps=GRS80 +units=m +no_defs", "NAD83(PA11) / Hawaii zone 4"},
+	6632:   {6632, "EPSG", "+proj=tmerc +lat_0=21.66666666666667 +lon_0=-160.1666666666667 +k=1 +x_0=500000 +y_0=0 +ellps=GRS80 +units=m +no_defs", "NAD83(PA11) / Hawaii zone 5"},
+	6633:   {6633, "EPSG", "+proj=tmerc +lat_0=21.16666666666667 +lon_0=-158 +k=0.99999 +x_0=500000.00101600 +y_0=0 +ellps=GRS80 +units=us-ft +no_defs", "NAD83(PA11) / Hawaii zone 3 (ftUS)"},
+	6634:   {6634, "EPSG", "+proj=utm +zone=4 +ellps=GRS80 +units=m +no_defs", "NAD83(PA11) / UTM zone 4N"},
+	6635:   {6635, "EPSG", "+proj=utm +zone=5 +ellps=GRS80 +units=m +no_defs", "NAD83(PA11) / UTM zone 5N"},
+	102007: {102007, "ESRI", "+proj=aea +lat_1=8 +lat_2=18 +lat_0=13 +lon_0=-157 +x_0=0 +y_0=0 +ellps=GRS80 +towgs84=0,0,0,0,0,0,0 +units=m +no_defs", "Hawaii_Albers_Equal_Area_Conic"},
+
+	// the Old Hawaiian datum, and its State Plane zones, with the mean
+	// Helmert shift to WGS 84 over the islands
+	4135: {4135, "EPSG", "+proj=longlat +ellps=clrk66 +towgs84=61,-285,-181,0,0,0,0 +no_defs", "Old Hawaiian"},
+	3561: {3561, "EPSG", "+proj=tmerc +lat_0=18.83333333333333 +lon_0=-155.5 +k=0.999966667 +x_0=152400.3048006096 +y_0=0 +ellps=clrk66 +towgs84=61,-285,-181,0,0,0,0 +units=us-ft +no_defs", "Old Hawaiian / Hawaii zone 1"},
+	3562: {3562, "EPSG", "+proj=tmerc +lat_0=20.33333333333333 +lon_0=-156.6666666666667 +k=0.999966667 +x_0=152400.3048006096 +y_0=0 +ellps=clrk66 +towgs84=61,-285,-181,0,0,0,0 +units=us-ft +no_defs", "Old Hawaiian / Hawaii zone 2"},
+	3563: {3563, "EPSG", "+proj=tmerc +lat_0=21.16666666666667 +lon_0=-158 +k=0.99999 +x_0=152400.3048006096 +y_0=0 +ellps=clrk66 +towgs84=61,-285,-181,0,0,0,0 +units=us-ft +no_defs", "Old Hawaiian / Hawaii zone 3"},
+	3564: {3564, "EPSG", "+proj=tmerc +lat_0=21.83333333333333 +lon_0=-159.5 +k=0.99999 +x_0=152400.3048006096 +y_0=0 +ellps=clrk66 +towgs84=61,-285,-181,0,0,0,0 +units=us-ft +no_defs", "Old Hawaiian / Hawaii zone 4"},
+	3565: {3565, "EPSG", "+proj=tmerc +lat_0=21.66666666666667 +lon_0=-160.1666666666667 +k=1 +x_0=152400.3048006096 +y_0=0 +ellps=clrk66 +towgs84=61,-285,-181,0,0,0,0 +units=us-ft +no_defs", "Old Hawaiian / Hawaii zone 5"},
+
+	// the datums of Guam and American Samoa
+	4675: {4675, "EPSG", "+proj=longlat +ellps=clrk66 +towgs84=-100,-248,259,0,0,0,0 +no_defs", "Guam 1963"},
+	4169: {4169, "EPSG", "+proj=longlat +ellps=clrk66 +towgs84=-115,118,426,0,0,0,0 +no_defs", "American Samoa 1962"},
+
+	// South Africa's Lo grids, on Hartebeesthoek94: transverse Mercator
+	// zones 2° wide, with the axes increasing to the west and the south
+	2046: {2046, "EPSG", "+proj=tmerc +lat_0=0 +lon_0=15 +k=1 +x_0=0 +y_0=0 +axis=wsu +ellps=WGS84 +towgs84=0,0,0,0,0,0,0 +units=m +no_defs", "Hartebeesthoek94 / Lo15"},
+	2047: {2047, "EPSG", "+proj=tmerc +lat_0=0 +lon_0=17 +k=1 +x_0=0 +y_0=0 +axis=wsu +ellps=WGS84 +towgs84=0,0,0,0,0,0,0 +units=m +no_defs", "Hartebeesthoek94 / Lo17"},
+	2048: {2048, "EPSG", "+proj=tmerc +lat_0=0 +lon_0=19 +k=1 +x_0=0 +y_0=0 +axis=wsu +ellps=WGS84 +towgs84=0,0,0,0,0,0,0 +units=m +no_defs", "Hartebeesthoek94 / Lo19"},
+	2049: {2049, "EPSG", "+proj=tmerc +lat_0=0 +lon_0=21 +k=1 +x_0=0 +y_0=0 +axis=wsu +ellps=WGS84 +towgs84=0,0,0,0,0,0,0 +units=m +no_defs", "Hartebeesthoek94 / Lo21"},
+	2050: {2050, "EPSG", "+proj=tmerc +lat_0=0 +lon_0=23 +k=1 +x_0=0 +y_0=0 +axis=wsu +ellps=WGS84 +towgs84=0,0,0,0,0,0,0 +units=m +no_defs", "Hartebeesthoek94 / Lo23"},
+	2051: {2051, "EPSG", "+proj=tmerc +lat_0=0 +lon_0=25 +k=1 +x_0=0 +y_0=0 +axis=wsu +ellps=WGS84 +towgs84=0,0,0,0,0,0,0 +units=m +no_defs", "Hartebeesthoek94 / Lo25"},
+	2052: {2052, "EPSG", "+proj=tmerc +lat_0=0 +lon_0=27 +k=1 +x_0=0 +y_0=0 +axis=wsu +ellps=WGS84 +towgs84=0,0,0,0,0,0,0 +units=m +no_defs", "Hartebeesthoek94 / Lo27"},
+	2053: {2053, "EPSG", "+proj=tmerc +lat_0=0 +lon_0=29 +k=1 +x_0=0 +y_0=0 +axis=wsu +ellps=WGS84 +towgs84=0,0,0,0,0,0,0 +units=m +no_defs", "Hartebeesthoek94 / Lo29"},
+	2054: {2054, "EPSG", "+proj=tmerc +lat_0=0 +lon_0=31 +k=1 +x_0=0 +y_0=0 +axis=wsu +ellps=WGS84 +towgs84=0,0,0,0,0,0,0 +units=m +no_defs", "Hartebeesthoek94 / Lo31"},
+	2055: {2055, "EPSG", "+proj=tmerc +lat_0=0 +lon_0=33 +k=1 +x_0=0 +y_0=0 +axis=wsu +ellps=WGS84 +towgs84=0,0,0,0,0,0,0 +units=m +no_defs", "Hartebeesthoek94 / Lo33"},
+
+	// NSIDC's EASE-Grids: the polar Lambert azimuthal equal-area grids and
+	// the global cylindrical equal-area grid, on the International 1924
+	// authalic sphere for the original grids and on WGS 84 for EASE-Grid 2.0
+	3408: {3408, "EPSG", "+proj=laea +lat_0=90 +lon_0=0 +x_0=0 +y_0=0 +a=6371228 +b=6371228 +units=m +no_defs", "NSIDC EASE-Grid North"},
+	3409: {3409, "EPSG", "+proj=laea +lat_0=-90 +lon_0=0 +x_0=0 +y_0=0 +a=6371228 +b=6371228 +units=m +no_defs", "NSIDC EASE-Grid South"},
+	3410: {3410, "EPSG", "+proj=cea +lon_0=0 +lat_ts=30 +x_0=0 +y_0=0 +a=6371228 +b=6371228 +units=m +no_defs", "NSIDC EASE-Grid Global"},
+	6931: {6931, "EPSG", "+proj=laea +lat_0=90 +lon_0=0 +x_0=0 +y_0=0 +datum=WGS84 +units=m +no_defs", "WGS 84 / NSIDC EASE-Grid 2.0 North"},
+	6932: {6932, "EPSG", "+proj=laea +lat_0=-90 +lon_0=0 +x_0=0 +y_0=0 +datum=WGS84 +units=m +no_defs", "WGS 84 / NSIDC EASE-Grid 2.0 South"},
+	6933: {6933, "EPSG", "+proj=cea +lon_0=0 +lat_ts=30 +x_0=0 +y_0=0 +datum=WGS84 +units=m +no_defs", "WGS 84 / NSIDC EASE-Grid 2.0 Global"},
+
+	// the polar stereographic grids: UPS, with k_0 at the pole (EPSG's
+	// variant A), and the Arctic, Antarctic and NSIDC sea ice grids, true
+	// to scale at lat_ts (variant B)
+	32661: {32661, "EPSG", "+proj=stere +lat_0=90 +lat_ts=90 +lon_0=0 +k=0.994 +x_0=2000000 +y_0=2000000 +datum=WGS84 +units=m +no_defs", "WGS 84 / UPS North (N,E)"},
+	32761: {32761, "EPSG", "+proj=stere +lat_0=-90 +lat_ts=-90 +lon_0=0 +k=0.994 +x_0=2000000 +y_0=2000000 +datum=WGS84 +units=m +no_defs", "WGS 84 / UPS South (N,E)"},
+	3995:  {3995, "EPSG", "+proj=stere +lat_0=90 +lat_ts=71 +lon_0=0 +k=1 +x_0=0 +y_0=0 +datum=WGS84 +units=m +no_defs", "WGS 84 / Arctic Polar Stereographic"},
+	3031:  {3031, "EPSG", "+proj=stere +lat_0=-90 +lat_ts=-71 +lon_0=0 +k=1 +x_0=0 +y_0=0 +datum=WGS84 +units=m +no_defs", "WGS 84 / Antarctic Polar Stereographic"},
+	3413:  {3413, "EPSG", "+proj=stere +lat_0=90 +lat_ts=70 +lon_0=-45 +k=1 +x_0=0 +y_0=0 +datum=WGS84 +units=m +no_defs", "WGS 84 / NSIDC Sea Ice Polar Stereographic North"},
+	3976:  {3976, "EPSG", "+proj=stere +lat_0=-90 +lat_ts=-70 +lon_0=0 +k=1 +x_0=0 +y_0=0 +datum=WGS84 +units=m +no_defs", "WGS 84 / NSIDC Sea Ice Polar Stereographic South"},
+
+	// Europe: ETRS89's pan-European Lambert azimuthal equal-area grid, the
+	// INSPIRE and EEA grid for statistics
+	3035: {3035, "EPSG", "+proj=laea +lat_0=52 +lon_0=10 +x_0=4321000 +y_0=3210000 +ellps=GRS80 +towgs84=0,0,0,0,0,0,0 +units=m +no_defs", "ETRS89-extended / LAEA Europe"},
+
+	// the Netherlands: Amersfoort, and the RD grid on the oblique
+	// stereographic, with the shift to WGS 84 of the RDNAPTRANS transformation
+	4289:  {4289, "EPSG", "+proj=longlat +ellps=bessel +towgs84=565.417,50.3319,465.552,-0.398957,0.343988,-1.8774,4.0725 +no_defs", "Amersfoort"},
+	28992: {28992, "EPSG", "+proj=sterea +lat_0=52.15616055555555 +lon_0=5.38763888888889 +k=0.9999079 +x_0=155000 +y_0=463000 +ellps=bessel +towgs84=565.417,50.3319,465.552,-0.398957,0.343988,-1.8774,4.0725 +units=m +no_defs", "Amersfoort / RD New"},
+
+	// planetary systems, by their IAU 2000 codes: these are resolved only
+	// with an "IAU:" or "IAU2000:" prefix, and lon/lat on them is in the
+	// body's own planetocentric coordinates
+	49900: {49900, "IAU2000", "+proj=longlat +a=3396190 +b=3376200 +no_defs", "Mars 2000"},
+	49910: {49910, "IAU2000", "+proj=eqc +lat_ts=0 +lat_0=0 +lon_0=0 +x_0=0 +y_0=0 +a=3396190 +b=3376200 +units=m +no_defs", "Mars_Equidistant_Cylindrical"},
+	30100: {30100, "IAU2000", "+proj=longlat +a=1737400 +b=1737400 +no_defs", "Moon 2000"},
+	30110: {30110, "IAU2000", "+proj=eqc +lat_ts=0 +lat_0=0 +lon_0=0 +x_0=0 +y_0=0 +a=1737400 +b=1737400 +units=m +no_defs", "Moon_Equidistant_Cylindrical"},
+}
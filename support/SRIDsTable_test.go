@@ -0,0 +1,33 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj/support"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSRIDsTable(t *testing.T) {
+	assert := assert.New(t)
+
+	// 6 fixed entries plus 120 UTM zones
+	assert.True(len(support.SRIDsTable) >= 126)
+
+	for key, value := range support.SRIDsTable {
+		assert.Equal(key, value.SRID)
+
+		_, err := support.NewProjString(value.Definition)
+		assert.NoError(err, value.Definition)
+	}
+
+	assert.Equal("WGS 84 / Pseudo-Mercator", support.SRIDsTable[3857].Name)
+	assert.Equal("+proj=utm +zone=33 +datum=WGS84 +units=m +no_defs", support.SRIDsTable[32633].Definition)
+	assert.Equal("+proj=utm +zone=33 +south +datum=WGS84 +units=m +no_defs", support.SRIDsTable[32733].Definition)
+}
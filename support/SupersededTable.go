@@ -0,0 +1,32 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support
+
+//---------------------------------------------------------------------
+
+// SupersededTableEntry records a deprecated or unofficial SRID and the
+// SRID that replaces it.
+//
+// An alias is the very same system under another code, so it can be used
+// in place of its replacement without any surprises.
+type SupersededTableEntry struct {
+	SRID       int
+	ReplacedBy int
+	Name       string
+	Alias      bool
+}
+
+// SupersededTable is the global list of SRIDs which are still seen in the
+// wild but should no longer be used
+var SupersededTable = map[int]*SupersededTableEntry{
+	3785:   {3785, 3857, "Popular Visualisation CRS / Mercator (deprecated)", false},
+	54004:  {54004, 3395, "World_Mercator (ESRI)", false},
+	102100: {102100, 3857, "WGS_1984_Web_Mercator_Auxiliary_Sphere (ESRI)", true},
+	102113: {102113, 3857, "WGS_1984_Web_Mercator (ESRI)", true},
+	900913: {900913, 3857, "Google Maps Global Mercator (unofficial)", true},
+}
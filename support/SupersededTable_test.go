@@ -0,0 +1,33 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj/support"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupersededTable(t *testing.T) {
+	assert := assert.New(t)
+
+	for key, value := range support.SupersededTable {
+		assert.Equal(key, value.SRID)
+
+		// the replacements are current, and known
+		_, superseded := support.SupersededTable[value.ReplacedBy]
+		assert.False(superseded)
+		_, ok := support.SRIDsTable[value.ReplacedBy]
+		assert.True(ok)
+	}
+
+	assert.Equal(3857, support.SupersededTable[900913].ReplacedBy)
+	assert.True(support.SupersededTable[900913].Alias)
+	assert.False(support.SupersededTable[3785].Alias)
+}
@@ -11,8 +11,20 @@ import (
 	"math"
 )
 
-// Tsfn is to "determine small t"
+// Tsfn is to "determine small t", the function t(phi) used by the
+// conformal projections (merc, lcc, stere, ...):
+//
+//	t = tan(pi/4 - phi/2) / ((1 - e sin(phi)) / (1 + e sin(phi)))^(e/2)
+//
+// where phi is the latitude in radians, sinphi its sine and e the
+// eccentricity. Phi2 is its inverse. Returns math.MaxFloat64 at the south
+// pole, where t is infinite, or if e sin(phi) is -1.
 func Tsfn(phi, sinphi, e float64) float64 {
+	// tan(pi/2) isn't infinite in floating point
+	if phi <= -PiOverTwo {
+		return math.MaxFloat64
+	}
+
 	sinphi *= e
 
 	/* avoid zero division, fail gracefully */
@@ -22,5 +34,5 @@ func Tsfn(phi, sinphi, e float64) float64 {
 	}
 
 	return (math.Tan(.5*(PiOverTwo-phi)) /
-		math.Pow((1.-sinphi)/(denominator), .5*e))
+		Pow((1.-sinphi)/(denominator), .5*e))
 }
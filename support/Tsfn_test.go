@@ -0,0 +1,63 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj/support"
+	"github.com/stretchr/testify/assert"
+)
+
+// the GRS80 ellipsoid
+const grs80A = 6378137.0
+const grs80F = 1.0 / 298.257222101
+
+var grs80Es = grs80F * (2.0 - grs80F)
+var grs80E = math.Sqrt(grs80Es)
+
+func TestTsfn(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, deg := range []float64{-80.0, -45.0, 0.0, 30.0, 60.0, 89.0} {
+		phi := support.DDToR(deg)
+
+		// on the sphere, t is just tan(pi/4 - phi/2)
+		assert.InDelta(math.Tan(math.Pi/4-phi/2), support.Tsfn(phi, math.Sin(phi), 0.0), 1.0e-15)
+
+		// and Phi2 is its inverse, on the ellipsoid too
+		ts := support.Tsfn(phi, math.Sin(phi), grs80E)
+		back, err := support.Phi2(ts, grs80E)
+		assert.NoError(err)
+		assert.InDelta(phi, back, 1.0e-10)
+	}
+
+	// the equator maps to 1 regardless of e
+	assert.InDelta(1.0, support.Tsfn(0.0, 0.0, grs80E), 1.0e-15)
+
+	// e sin(phi) == -1 would divide by zero
+	assert.Equal(math.MaxFloat64, support.Tsfn(-math.Pi/2, -1.0, 1.0))
+}
+
+func TestTsfnPoles(t *testing.T) {
+	assert := assert.New(t)
+
+	// t is zero at the north pole and infinite at the south pole
+	assert.Equal(0.0, support.Tsfn(math.Pi/2, 1.0, grs80E))
+	assert.Equal(math.MaxFloat64, support.Tsfn(-math.Pi/2, -1.0, grs80E))
+	assert.Equal(math.MaxFloat64, support.Tsfn(-math.Pi/2, -1.0, 0.0))
+
+	// and finite and monotonic just inside them
+	south := support.DDToR(-89.9999999)
+	ts := support.Tsfn(south, math.Sin(south), grs80E)
+	assert.True(ts > 1.0e9 && ts < math.MaxFloat64)
+	back, err := support.Phi2(ts, grs80E)
+	assert.NoError(err)
+	assert.InDelta(south, back, 1.0e-12)
+}
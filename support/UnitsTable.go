@@ -7,6 +7,11 @@
 
 package support
 
+import (
+	"math"
+	"sort"
+)
+
 // UnitsTableEntry holds info about a unit
 type UnitsTableEntry struct {
 	ID        string
@@ -39,3 +44,21 @@ var UnitsTable = map[string]*UnitsTableEntry{
 	"ind-ft": {"ind-ft", "0.30479841", "Indian Foot", 0.30479841},
 	"ind-ch": {"ind-ch", "20.11669506", "Indian Chain", 20.11669506},
 }
+
+// UnitsFor returns the id in the units table of the unit of the given
+// size in meters, to 12 significant digits; where two ids have the same
+// size, the first in sort order
+func UnitsFor(toMeter float64) (string, bool) {
+	ids := make([]string, 0, len(UnitsTable))
+	for id := range UnitsTable {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		if math.Abs(UnitsTable[id].ToMeters-toMeter) <= 1e-12*toMeter {
+			return id, true
+		}
+	}
+	return "", false
+}
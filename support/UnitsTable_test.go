@@ -22,3 +22,22 @@ func TestUnitsTable(t *testing.T) {
 		assert.Equal(key, value.ID)
 	}
 }
+
+func TestUnitsFor(t *testing.T) {
+	assert := assert.New(t)
+
+	id, ok := support.UnitsFor(0.3048)
+	assert.True(ok)
+	assert.Equal("ft", id)
+	id, ok = support.UnitsFor(1200.0 / 3937.0)
+	assert.True(ok)
+	assert.Equal("us-ft", id)
+
+	// the inch and the U.S. surveyor's inch have the same size here
+	id, ok = support.UnitsFor(0.0254)
+	assert.True(ok)
+	assert.Equal("in", id)
+
+	_, ok = support.UnitsFor(0.3)
+	assert.False(ok)
+}
@@ -0,0 +1,768 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/oahumap/proj/merror"
+)
+
+// ParseWKT returns the proj string of a CRS given as WKT: OGC WKT 1, ESRI
+// WKT (as in .prj files) or WKT 2 (2015 or 2019).
+//
+// Geographic and projected CRSs are supported, as is the horizontal part
+// of a compound CRS. The datum shift to WGS 84 is taken from TOWGS84 (WKT
+// 1) or from the abridged transformation of a BOUNDCRS (WKT 2). Only the
+// projection methods we have operations for are recognized; others fail.
+func ParseWKT(wkt string) (*ProjString, error) {
+	p := &wktParser{src: wkt}
+	root, err := p.node()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos != len(p.src) {
+		return nil, merror.New(merror.InvalidWKT, "trailing text at "+strconv.Itoa(p.pos))
+	}
+
+	def, err := wktCRS(root, "")
+	if err != nil {
+		return nil, err
+	}
+	return NewProjString(def)
+}
+
+//---------------------------------------------------------------------
+
+// wktNode is a WKT element, e.g. SPHEROID["WGS 84",6378137,298.257223563]:
+// its keyword, and its values, which are strings, numbers, enumerations
+// or elements
+type wktNode struct {
+	keyword string
+	values  []wktValue
+}
+
+type wktValue struct {
+	text string   // the string (unquoted), number or enumeration
+	node *wktNode // or the element
+}
+
+// child returns the first element of the node with one of the keywords
+func (n *wktNode) child(keywords ...string) *wktNode {
+	for _, v := range n.values {
+		if v.node == nil {
+			continue
+		}
+		for _, k := range keywords {
+			if v.node.keyword == k {
+				return v.node
+			}
+		}
+	}
+	return nil
+}
+
+// children returns every element of the node with the keyword
+func (n *wktNode) children(keyword string) []*wktNode {
+	var nodes []*wktNode
+	for _, v := range n.values {
+		if v.node != nil && v.node.keyword == keyword {
+			nodes = append(nodes, v.node)
+		}
+	}
+	return nodes
+}
+
+// text returns the i'th value of the node, if it isn't an element
+func (n *wktNode) text(i int) string {
+	if i >= len(n.values) || n.values[i].node != nil {
+		return ""
+	}
+	return n.values[i].text
+}
+
+// number returns the i'th value of the node as a number
+func (n *wktNode) number(i int) (float64, error) {
+	f, err := strconv.ParseFloat(n.text(i), 64)
+	if err != nil {
+		return 0.0, merror.New(merror.InvalidWKT, n.keyword+" needs a number")
+	}
+	return f, nil
+}
+
+// unit returns the size of the node's unit, in meters or radians, given
+// by one of the keywords, or def if it has none
+func (n *wktNode) unit(def float64, keywords ...string) (float64, error) {
+	u := n.child(keywords...)
+	if u == nil {
+		return def, nil
+	}
+	f, err := u.number(1)
+	if err != nil {
+		return 0.0, err
+	}
+	if f <= 0.0 {
+		return 0.0, merror.New(merror.InvalidWKT, u.keyword+" must be positive")
+	}
+	return f, nil
+}
+
+//---------------------------------------------------------------------
+
+type wktParser struct {
+	src string
+	pos int
+}
+
+func (p *wktParser) skipSpace() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *wktParser) fail(what string) error {
+	return merror.New(merror.InvalidWKT, what+" at "+strconv.Itoa(p.pos))
+}
+
+// node parses an element: a keyword followed by its values in brackets
+// or parentheses
+func (p *wktParser) node() (*wktNode, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.src) && (isWKTKeywordChar(p.src[p.pos])) {
+		p.pos++
+	}
+	n := &wktNode{keyword: strings.ToUpper(p.src[start:p.pos])}
+	if n.keyword == "" {
+		return nil, p.fail("expected a keyword")
+	}
+
+	p.skipSpace()
+	if p.pos >= len(p.src) || (p.src[p.pos] != '[' && p.src[p.pos] != '(') {
+		return nil, p.fail("expected [ after " + n.keyword)
+	}
+	p.pos++
+
+	for {
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		n.values = append(n.values, v)
+
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			return nil, p.fail("unterminated " + n.keyword)
+		}
+		switch p.src[p.pos] {
+		case ',':
+			p.pos++
+		case ']', ')':
+			p.pos++
+			return n, nil
+		default:
+			return nil, p.fail("expected , or ] in " + n.keyword)
+		}
+	}
+}
+
+// value parses a quoted string, in which "" stands for a quote, a number
+// or enumeration, or an element
+func (p *wktParser) value() (wktValue, error) {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return wktValue{}, p.fail("expected a value")
+	}
+
+	if p.src[p.pos] == '"' {
+		var b strings.Builder
+		p.pos++
+		for {
+			i := strings.IndexByte(p.src[p.pos:], '"')
+			if i < 0 {
+				return wktValue{}, p.fail("unterminated string")
+			}
+			b.WriteString(p.src[p.pos : p.pos+i])
+			p.pos += i + 1
+			if p.pos < len(p.src) && p.src[p.pos] == '"' {
+				b.WriteByte('"')
+				p.pos++
+				continue
+			}
+			return wktValue{text: b.String()}, nil
+		}
+	}
+
+	start := p.pos
+	for p.pos < len(p.src) && !strings.ContainsRune(",[]()", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	rest := p.pos
+	p.skipSpace()
+	if p.pos < len(p.src) && (p.src[p.pos] == '[' || p.src[p.pos] == '(') {
+		p.pos = start
+		n, err := p.node()
+		if err != nil {
+			return wktValue{}, err
+		}
+		return wktValue{node: n}, nil
+	}
+	p.pos = rest
+	return wktValue{text: strings.TrimSpace(p.src[start:rest])}, nil
+}
+
+func isWKTKeywordChar(c byte) bool {
+	return c == '_' || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')
+}
+
+//---------------------------------------------------------------------
+
+// wktName normalizes the name of a method, parameter or datum, so that
+// the EPSG, OGC and ESRI spellings compare equal: "Transverse Mercator",
+// "Transverse_Mercator" and "transverse_mercator" are all
+// "transversemercator"
+func wktName(name string) string {
+	var b strings.Builder
+	for _, c := range strings.ToLower(name) {
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') {
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// wktMethods maps the normalized names of projection methods to our
+// operations
+var wktMethods = map[string]string{
+	"transversemercator":                        "tmerc",
+	"gausskruger":                               "tmerc",
+	"mercator":                                  "merc",
+	"mercator1sp":                               "merc",
+	"mercator2sp":                               "merc",
+	"mercatorvarianta":                          "merc",
+	"mercatorvariantb":                          "merc",
+	"popularvisualisationpseudomercator":        "webmerc",
+	"mercatorauxiliarysphere":                   "webmerc",
+	"lambertconformalconic":                     "lcc",
+	"lambertconformalconic1sp":                  "lcc",
+	"lambertconformalconic2sp":                  "lcc",
+	"albers":                                    "aea",
+	"albersequalarea":                           "aea",
+	"albersconicequalarea":                      "aea",
+	"lambertazimuthalequalarea":                 "laea",
+	"lambertazimuthalequalareaspherical":        "laea",
+	"equirectangular":                           "eqc",
+	"equidistantcylindrical":                    "eqc",
+	"platecarree":                               "eqc",
+	"cylindricalequalarea":                      "cea",
+	"lambertcylindricalequalarea":               "cea",
+	"lambertcylindricalequalareaspherical":      "cea",
+	"hotineobliquemercator":                     "omerc",
+	"hotineobliquemercatorvarianta":             "omerc",
+	"hotineobliquemercatorazimuthnaturalorigin": "omerc",
+	"obliquemercator":                           "omerc-b",
+	"hotineobliquemercatorvariantb":             "omerc-b",
+	"hotineobliquemercatorazimuthcenter":        "omerc-b",
+	"labordeobliquemercator":                    "labrd",
+	"gnomonic":                                  "gnom",
+	"stereographic":                             "stere",
+	"polarstereographic":                        "stere-polar",
+	"polarstereographicvarianta":                "stere-polar",
+	"polarstereographicvariantb":                "stere-polar",
+	"stereographicnorthpole":                    "stere-polar",
+	"stereographicsouthpole":                    "stere-polar",
+	"obliquestereographic":                      "sterea",
+	"doublestereographic":                       "sterea",
+}
+
+// wktParameters maps the normalized names of projection parameters to
+// proj string keys
+var wktParameters = map[string]string{
+	"latitudeofnaturalorigin":       "lat_0",
+	"latitudeoforigin":              "lat_0",
+	"latitudeoffalseorigin":         "lat_0",
+	"latitudeofprojectioncentre":    "lat_0",
+	"latitudeofprojectioncenter":    "lat_0",
+	"latitudeofcentre":              "lat_0",
+	"latitudeofcenter":              "lat_0",
+	"longitudeofnaturalorigin":      "lon_0",
+	"longitudeoforigin":             "lon_0",
+	"longitudeoffalseorigin":        "lon_0",
+	"centralmeridian":               "lon_0",
+	"longitudeofprojectioncentre":   "lon_0",
+	"longitudeofprojectioncenter":   "lon_0",
+	"longitudeofcentre":             "lon_0",
+	"longitudeofcenter":             "lon_0",
+	"scalefactoratnaturalorigin":    "k_0",
+	"scalefactoroninitialline":      "k_0",
+	"scalefactor":                   "k_0",
+	"falseeasting":                  "x_0",
+	"eastingatfalseorigin":          "x_0",
+	"eastingatprojectioncentre":     "x_0",
+	"eastingatprojectioncenter":     "x_0",
+	"falsenorthing":                 "y_0",
+	"northingatfalseorigin":         "y_0",
+	"northingatprojectioncentre":    "y_0",
+	"northingatprojectioncenter":    "y_0",
+	"latitudeof1ststandardparallel": "lat_1",
+	"latitudeofstandardparallel":    "lat_1",
+	"standardparallel1":             "lat_1",
+	"latitudeof2ndstandardparallel": "lat_2",
+	"standardparallel2":             "lat_2",
+	"azimuthofinitialline":          "alpha",
+	"azimuth":                       "alpha",
+	"anglefromrectifiedtoskewgrid":  "gamma",
+	"rectifiedgridangle":            "gamma",
+
+	// ESRI's Web Mercator says which sphere it is on, which is always the
+	// same for us
+	"auxiliaryspheretype": "",
+}
+
+// wktLinear are the proj string keys whose values are lengths, in the
+// unit of the projected CRS unless the parameter gives its own
+var wktLinear = map[string]bool{"x_0": true, "y_0": true}
+
+// wktWGS84Datums are the normalized names of the datums on which
+// coordinates are taken to be the same as on WGS 84, as PROJ does
+var wktWGS84Datums = map[string]bool{
+	"wgs84":                                  true,
+	"wgs1984":                                true,
+	"dwgs1984":                               true,
+	"worldgeodeticsystem1984":                true,
+	"worldgeodeticsystem1984ensemble":        true,
+	"northamericandatum1983":                 true,
+	"dnorthamerican1983":                     true,
+	"europeanterrestrialreferencesystem1989": true,
+	"europeanterrestrialreferencesystem1989ensemble": true,
+	"detrs1989": true,
+}
+
+//---------------------------------------------------------------------
+
+// wktCRS returns the proj string of a CRS element; towgs84, if not
+// empty, is the datum shift given by an enclosing BOUNDCRS
+func wktCRS(n *wktNode, towgs84 string) (string, error) {
+	switch n.keyword {
+	case "GEOGCS", "GEOGCRS", "GEOGRAPHICCRS", "BASEGEOGCRS":
+		return wktGeographic(n, towgs84)
+	case "GEODCRS", "GEODETICCRS", "BASEGEODCRS":
+		if cs := n.child("CS"); cs != nil && !strings.EqualFold(cs.text(0), "ellipsoidal") {
+			return "", merror.New(merror.UnsupportedWKT, "geocentric CRS")
+		}
+		return wktGeographic(n, towgs84)
+	case "PROJCS", "PROJCRS", "PROJECTEDCRS":
+		return wktProjected(n, towgs84)
+	case "COMPD_CS", "COMPOUNDCRS":
+		for _, v := range n.values {
+			if v.node != nil {
+				return wktCRS(v.node, towgs84)
+			}
+		}
+		return "", merror.New(merror.InvalidWKT, "compound CRS without components")
+	case "BOUNDCRS":
+		return wktBound(n)
+	}
+	return "", merror.New(merror.UnsupportedWKT, n.keyword)
+}
+
+// wktGeographic returns the proj string of a geographic CRS
+func wktGeographic(n *wktNode, towgs84 string) (string, error) {
+	datum, err := wktDatum(n, towgs84)
+	if err != nil {
+		return "", err
+	}
+
+	unit, err := wktAngularUnit(n)
+	if err != nil {
+		return "", err
+	}
+	units := ""
+	for _, id := range []string{"deg", "grad", "rad"} {
+		if sameUnit(unit, AngularUnitsTable[id].ToRadians) {
+			units = id
+		}
+	}
+	if units == "" {
+		return "", merror.New(merror.UnsupportedWKT, "angular unit "+strconv.FormatFloat(unit, 'g', -1, 64))
+	}
+
+	def := "+proj=longlat " + datum
+	if units != "deg" {
+		def += " +units=" + units
+	}
+	return def + " +no_defs", nil
+}
+
+// wktAngularUnit returns the size in radians of the angular unit of a
+// geographic CRS, from its UNIT (WKT 1), its ANGLEUNIT or those of its
+// axes (WKT 2); degrees if it gives none
+func wktAngularUnit(n *wktNode) (float64, error) {
+	deg := AngularUnitsTable["deg"].ToRadians
+	if n.child("UNIT", "ANGLEUNIT") == nil {
+		if axis := n.child("AXIS"); axis != nil {
+			return axis.unit(deg, "ANGLEUNIT", "UNIT")
+		}
+	}
+	return n.unit(deg, "UNIT", "ANGLEUNIT")
+}
+
+// wktDatum returns the proj string keys for the datum, ellipsoid and
+// prime meridian of a geographic CRS
+func wktDatum(n *wktNode, towgs84 string) (string, error) {
+	datum := n.child("DATUM", "GEODETICDATUM", "TRF", "ENSEMBLE")
+	if datum == nil {
+		return "", merror.New(merror.InvalidWKT, n.keyword+" without a datum")
+	}
+	ellps := datum.child("SPHEROID", "ELLIPSOID")
+	if ellps == nil {
+		return "", merror.New(merror.InvalidWKT, "datum without an ellipsoid")
+	}
+	a, err := ellps.number(1)
+	if err != nil {
+		return "", err
+	}
+	rf, err := ellps.number(2)
+	if err != nil {
+		return "", err
+	}
+	f, err := ellps.unit(1.0, "LENGTHUNIT", "UNIT")
+	if err != nil {
+		return "", err
+	}
+	a *= f
+
+	def := "+a=" + formatWKTFloat(a)
+	if rf == 0.0 {
+		def += " +b=" + formatWKTFloat(a)
+	} else {
+		def += " +rf=" + formatWKTFloat(rf)
+	}
+
+	if towgs84 == "" {
+		if t := datum.child("TOWGS84"); t != nil {
+			params := make([]string, len(t.values))
+			for i := range t.values {
+				v, err := t.number(i)
+				if err != nil {
+					return "", err
+				}
+				params[i] = formatWKTFloat(v)
+			}
+			towgs84 = strings.Join(params, ",")
+		} else if wktWGS84Datums[wktName(datum.text(0))] {
+			towgs84 = "0,0,0"
+		}
+	}
+	if towgs84 != "" {
+		def += " +towgs84=" + towgs84
+	}
+
+	if pm := n.child("PRIMEM"); pm != nil {
+		lon, err := pm.number(1)
+		if err != nil {
+			return "", err
+		}
+		// WKT 1 gives the prime meridian in degrees
+		unit, err := pm.unit(AngularUnitsTable["deg"].ToRadians, "ANGLEUNIT")
+		if err != nil {
+			return "", err
+		}
+		if lon != 0.0 {
+			def += " +pm=" + formatWKTFloat(wktDegrees(lon, unit))
+		}
+	}
+
+	return def, nil
+}
+
+// wktProjected returns the proj string of a projected CRS
+func wktProjected(n *wktNode, towgs84 string) (string, error) {
+	base := n.child("GEOGCS", "BASEGEOGCRS", "BASEGEODCRS", "GEOGCRS", "GEODCRS")
+	if base == nil {
+		return "", merror.New(merror.InvalidWKT, n.keyword+" without a base geographic CRS")
+	}
+	datum, err := wktDatum(base, towgs84)
+	if err != nil {
+		return "", err
+	}
+	angular, err := wktAngularUnit(base)
+	if err != nil {
+		return "", err
+	}
+	linear, err := wktLinearUnit(n)
+	if err != nil {
+		return "", err
+	}
+
+	// WKT 1 has a PROJECTION, with the parameters alongside; WKT 2 has a
+	// CONVERSION, with its METHOD and the parameters within
+	method := n.child("PROJECTION")
+	params := n
+	if conv := n.child("CONVERSION"); conv != nil {
+		method = conv.child("METHOD", "PROJECTION")
+		params = conv
+	}
+	if method == nil {
+		return "", merror.New(merror.InvalidWKT, n.keyword+" without a projection method")
+	}
+	methodName := wktName(method.text(0))
+	proj, ok := wktMethods[methodName]
+	if !ok {
+		return "", merror.New(merror.UnsupportedWKT, "projection method "+method.text(0))
+	}
+
+	keys := map[string]float64{}
+	var order []string
+	for _, p := range params.children("PARAMETER") {
+		key, ok := wktParameters[wktName(p.text(0))]
+		if !ok {
+			return "", merror.New(merror.UnsupportedWKT, "parameter "+p.text(0)+" of "+method.text(0))
+		}
+		if key == "" {
+			continue
+		}
+		v, err := p.number(1)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case key == "k_0":
+			f, err := p.unit(1.0, "SCALEUNIT")
+			if err != nil {
+				return "", err
+			}
+			v *= f
+		case wktLinear[key]:
+			f, err := p.unit(linear, "LENGTHUNIT", "UNIT")
+			if err != nil {
+				return "", err
+			}
+			v *= f
+		default:
+			f, err := p.unit(angular, "ANGLEUNIT", "UNIT")
+			if err != nil {
+				return "", err
+			}
+			v = wktDegrees(v, f)
+		}
+		if _, ok := keys[key]; !ok {
+			order = append(order, key)
+		}
+		keys[key] = v
+	}
+
+	rename := func(from, to string) {
+		for i, key := range order {
+			if key == from {
+				order[i] = to
+				keys[to] = keys[from]
+			}
+		}
+	}
+	flags := ""
+	switch proj {
+	case "merc", "cea", "eqc":
+		rename("lat_1", "lat_ts")
+	case "lcc":
+		// with one standard parallel, it is the latitude of origin
+		if _, ok := keys["lat_1"]; !ok {
+			order = append(order, "lat_1")
+			keys["lat_1"] = keys["lat_0"]
+		}
+	case "omerc", "omerc-b":
+		rename("lon_0", "lonc")
+		if proj == "omerc" {
+			flags = " +no_uoff"
+		}
+		proj = "omerc"
+	case "stere-polar":
+		// variant B and ESRI's poles give the latitude of true scale, as
+		// GDAL's WKT 1 does in the latitude of origin; the origin itself
+		// is the pole on that side
+		if lat0, ok := keys["lat_0"]; ok && math.Abs(lat0) != 90.0 {
+			rename("lat_0", "lat_ts")
+		}
+		rename("lat_1", "lat_ts")
+		hasOrigin := false
+		for _, key := range order {
+			hasOrigin = hasOrigin || key == "lat_0"
+		}
+		if !hasOrigin {
+			lat0 := 90.0
+			if keys["lat_ts"] < 0.0 || methodName == "stereographicsouthpole" {
+				lat0 = -90.0
+			}
+			order = append([]string{"lat_0"}, order...)
+			keys["lat_0"] = lat0
+		}
+		proj = "stere"
+	case "webmerc":
+		// the pseudo-mercator is spherical, whatever the datum
+		rename("lat_1", "lat_ts")
+		proj = "merc"
+		datum = "+a=6378137 +b=6378137 +nadgrids=@null +wktext"
+	}
+
+	def := "+proj=" + proj
+	for _, key := range order {
+		def += " +" + key + "=" + formatWKTFloat(keys[key])
+	}
+	def += flags + " " + datum
+
+	axis, err := wktAxis(n)
+	if err != nil {
+		return "", err
+	}
+	if axis != "enu" {
+		def += " +axis=" + axis
+	}
+
+	units, ok := UnitsFor(linear)
+	if !ok {
+		return "", merror.New(merror.UnsupportedWKT, "linear unit "+strconv.FormatFloat(linear, 'g', -1, 64))
+	}
+	return def + " +units=" + units + " +no_defs", nil
+}
+
+// wktLinearUnit returns the size in meters of the linear unit of a
+// projected CRS, from its UNIT (WKT 1), its LENGTHUNIT or those of its
+// axes (WKT 2); meters if it gives none
+func wktLinearUnit(n *wktNode) (float64, error) {
+	if n.child("UNIT", "LENGTHUNIT") == nil {
+		if axis := n.child("AXIS"); axis != nil {
+			return axis.unit(1.0, "LENGTHUNIT", "UNIT")
+		}
+	}
+	return n.unit(1.0, "UNIT", "LENGTHUNIT")
+}
+
+// wktAxis returns the +axis of a projected CRS from the directions of its
+// axes, east and north by default. As in PROJ, only the directions count,
+// not the order of the axes, and the axes of polar projections, which
+// point along a MERIDIAN rather than east or north, are taken to be the
+// usual ones: their "south" is toward the pole, not away from north.
+func wktAxis(n *wktNode) (string, error) {
+	axis := []byte("enu")
+	for _, a := range n.children("AXIS") {
+		if a.child("MERIDIAN") != nil {
+			continue
+		}
+		switch strings.ToLower(a.text(1)) {
+		case "east":
+			axis[0] = 'e'
+		case "west":
+			axis[0] = 'w'
+		case "north":
+			axis[1] = 'n'
+		case "south":
+			axis[1] = 's'
+		default:
+			return "", merror.New(merror.UnsupportedWKT, "axis direction "+a.text(1))
+		}
+	}
+	return string(axis), nil
+}
+
+// wktBound returns the proj string of the source CRS of a BOUNDCRS, with
+// its transformation to WGS 84 as +towgs84
+func wktBound(n *wktNode) (string, error) {
+	source := n.child("SOURCECRS")
+	transform := n.child("ABRIDGEDTRANSFORMATION")
+	if source == nil || transform == nil || len(source.values) == 0 || source.values[0].node == nil {
+		return "", merror.New(merror.InvalidWKT, "BOUNDCRS needs a source CRS and an abridged transformation")
+	}
+
+	method := transform.child("METHOD")
+	if method == nil {
+		return "", merror.New(merror.InvalidWKT, "abridged transformation without a method")
+	}
+	name := wktName(method.text(0))
+	coordinateFrame := strings.Contains(name, "coordinateframe")
+	if !coordinateFrame && !strings.Contains(name, "positionvector") && !strings.Contains(name, "geocentrictranslation") {
+		return "", merror.New(merror.UnsupportedWKT, "transformation method "+method.text(0))
+	}
+
+	// translations in meters, rotations in arc-seconds and the scale
+	// difference in parts per million, as for +towgs84
+	const arcsec = math.Pi / (180.0 * 3600.0)
+	names := []string{"xaxistranslation", "yaxistranslation", "zaxistranslation",
+		"xaxisrotation", "yaxisrotation", "zaxisrotation", "scaledifference"}
+	params := make([]float64, len(names))
+	count := 3
+	for _, p := range transform.children("PARAMETER") {
+		i := -1
+		for j, name := range names {
+			if wktName(p.text(0)) == name {
+				i = j
+			}
+		}
+		if i < 0 {
+			return "", merror.New(merror.UnsupportedWKT, "transformation parameter "+p.text(0))
+		}
+		v, err := p.number(1)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case i < 3:
+			f, err := p.unit(1.0, "LENGTHUNIT", "UNIT")
+			if err != nil {
+				return "", err
+			}
+			v *= f
+		case i < 6:
+			f, err := p.unit(arcsec, "ANGLEUNIT", "UNIT")
+			if err != nil {
+				return "", err
+			}
+			v *= f / arcsec
+			if coordinateFrame {
+				v = -v
+			}
+			count = 7
+		default:
+			f, err := p.unit(1.0e-6, "SCALEUNIT", "UNIT")
+			if err != nil {
+				return "", err
+			}
+			v *= f / 1.0e-6
+			count = 7
+		}
+		params[i] = v
+	}
+
+	towgs84 := make([]string, count)
+	for i := range towgs84 {
+		towgs84[i] = formatWKTFloat(params[i])
+	}
+	return wktCRS(source.values[0].node, strings.Join(towgs84, ","))
+}
+
+// wktDegrees returns an angle given in a unit of the given size in
+// radians in degrees, exactly if the unit is the degree
+func wktDegrees(v, unit float64) float64 {
+	if sameUnit(unit, DegToRad) {
+		return v
+	}
+	return v * unit / DegToRad
+}
+
+// sameUnit reports whether two unit sizes agree to the precision WKT
+// usually gives them
+func sameUnit(a, b float64) bool {
+	return math.Abs(a-b) <= 1.0e-12*math.Max(a, b)
+}
+
+func formatWKTFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,184 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package support_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj/support"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// EPSG:3413 in WKT 2, as PROJ gives it, less its ID and usage
+const nsidcNorthWKT2 = `PROJCRS["WGS 84 / NSIDC Sea Ice Polar Stereographic North",
+	BASEGEOGCRS["WGS 84",
+		DATUM["World Geodetic System 1984",
+			ELLIPSOID["WGS 84",6378137,298.257223563,LENGTHUNIT["metre",1]]],
+		PRIMEM["Greenwich",0,ANGLEUNIT["degree",0.0174532925199433]]],
+	CONVERSION["US NSIDC Sea Ice polar stereographic north",
+		METHOD["Polar Stereographic (variant B)",ID["EPSG",9829]],
+		PARAMETER["Latitude of standard parallel",70,ANGLEUNIT["degree",0.0174532925199433],ID["EPSG",8832]],
+		PARAMETER["Longitude of origin",-45,ANGLEUNIT["degree",0.0174532925199433],ID["EPSG",8833]],
+		PARAMETER["False easting",0,LENGTHUNIT["metre",1],ID["EPSG",8806]],
+		PARAMETER["False northing",0,LENGTHUNIT["metre",1],ID["EPSG",8807]]],
+	CS[Cartesian,2],
+		AXIS["easting (X)",south,MERIDIAN[45,ANGLEUNIT["degree",0.0174532925199433]],ORDER[1],LENGTHUNIT["metre",1]],
+		AXIS["northing (Y)",south,MERIDIAN[135,ANGLEUNIT["degree",0.0174532925199433]],ORDER[2],LENGTHUNIT["metre",1]]]`
+
+func TestParseWKT(t *testing.T) {
+	assert := assert.New(t)
+
+	// OGC WKT 1, with the datum shift given
+	ps, err := support.ParseWKT(`PROJCS["ED50 / UTM zone 31N",
+		GEOGCS["ED50",
+			DATUM["European_Datum_1950",
+				SPHEROID["International 1924",6378388,297],
+				TOWGS84[-87,-98,-121,0,0,0,0]],
+			PRIMEM["Greenwich",0],
+			UNIT["degree",0.0174532925199433]],
+		PROJECTION["Transverse_Mercator"],
+		PARAMETER["latitude_of_origin",0],
+		PARAMETER["central_meridian",3],
+		PARAMETER["scale_factor",0.9996],
+		PARAMETER["false_easting",500000],
+		PARAMETER["false_northing",0],
+		UNIT["metre",1],
+		AXIS["Easting",EAST],
+		AXIS["Northing",NORTH]]`)
+	assert.NoError(err)
+	assert.Equal("+proj=tmerc +lat_0=0 +lon_0=3 +k_0=0.9996 +x_0=500000 +y_0=0 +a=6378388 +rf=297 +towgs84=-87,-98,-121,0,0,0,0 +units=m +no_defs", ps.Definition())
+
+	// ESRI WKT, in US survey feet, on a datum taken to be WGS 84
+	ps, err = support.ParseWKT(`PROJCS["NAD_1983_StatePlane_Hawaii_3_FIPS_5103_Feet",GEOGCS["GCS_North_American_1983",DATUM["D_North_American_1983",SPHEROID["GRS_1980",6378137.0,298.257222101]],PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]],PROJECTION["Transverse_Mercator"],PARAMETER["False_Easting",1640416.666666667],PARAMETER["False_Northing",0.0],PARAMETER["Central_Meridian",-158.0],PARAMETER["Scale_Factor",0.99999],PARAMETER["Latitude_Of_Origin",21.16666666666667],UNIT["Foot_US",0.3048006096012192]]`)
+	assert.NoError(err)
+	x0, ok := ps.GetAsFloat("x_0")
+	assert.True(ok)
+	assert.InDelta(500000.0, x0, 1.0e-6)
+	units, _ := ps.GetAsString("units")
+	assert.Equal("us-ft", units)
+	towgs84, _ := ps.GetAsString("towgs84")
+	assert.Equal("0,0,0", towgs84)
+
+	// WKT 2:2019, with the units on each parameter and on the axes
+	ps, err = support.ParseWKT(`PROJCRS["ETRS89-extended / LAEA Europe",
+		BASEGEOGCRS["ETRS89",
+			ENSEMBLE["European Terrestrial Reference System 1989 ensemble",
+				MEMBER["European Terrestrial Reference Frame 1989"],
+				ELLIPSOID["GRS 1980",6378137,298.257222101,LENGTHUNIT["metre",1]],
+				ENSEMBLEACCURACY[0.1]],
+			PRIMEM["Greenwich",0,ANGLEUNIT["degree",0.0174532925199433]]],
+		CONVERSION["Europe Equal Area 2001",
+			METHOD["Lambert Azimuthal Equal Area",ID["EPSG",9820]],
+			PARAMETER["Latitude of natural origin",52,ANGLEUNIT["degree",0.0174532925199433]],
+			PARAMETER["Longitude of natural origin",10,ANGLEUNIT["degree",0.0174532925199433]],
+			PARAMETER["False easting",4321,LENGTHUNIT["kilometre",1000]],
+			PARAMETER["False northing",3210000,LENGTHUNIT["metre",1]]],
+		CS[Cartesian,2],
+			AXIS["northing (Y)",north,ORDER[1],LENGTHUNIT["metre",1]],
+			AXIS["easting (X)",east,ORDER[2],LENGTHUNIT["metre",1]]]`)
+	assert.NoError(err)
+	assert.Equal("+proj=laea +lat_0=52 +lon_0=10 +x_0=4321000 +y_0=3210000 +a=6378137 +rf=298.257222101 +towgs84=0,0,0 +units=m +no_defs", ps.Definition())
+
+	// a BOUNDCRS, whose coordinate frame rotations are the opposite of
+	// the position vector ones +towgs84 takes, and with west and south
+	// axes, as on the Lo grids
+	ps, err = support.ParseWKT(`BOUNDCRS[
+		SOURCECRS[PROJCRS["Lo19",
+			BASEGEOGCRS["Hartebeesthoek94",DATUM["Hartebeesthoek94",ELLIPSOID["WGS 84",6378137,298.257223563]]],
+			CONVERSION["Lo19",METHOD["Transverse Mercator (South Orientated)"]],
+			CS[Cartesian,2],AXIS["westing (Y)",west],AXIS["southing (X)",south],LENGTHUNIT["metre",1]]],
+		TARGETCRS[GEOGCRS["WGS 84",DATUM["World Geodetic System 1984",ELLIPSOID["WGS 84",6378137,298.257223563]]]],
+		ABRIDGEDTRANSFORMATION["to WGS 84",
+			METHOD["Coordinate Frame rotation (geocentric domain)"],
+			PARAMETER["X-axis translation",1,LENGTHUNIT["metre",1]],
+			PARAMETER["Y-axis translation",2,LENGTHUNIT["metre",1]],
+			PARAMETER["Z-axis translation",3,LENGTHUNIT["metre",1]],
+			PARAMETER["X-axis rotation",0.1,ANGLEUNIT["arc-second",4.84813681109536e-06]],
+			PARAMETER["Y-axis rotation",-0.2,ANGLEUNIT["arc-second",4.84813681109536e-06]],
+			PARAMETER["Z-axis rotation",0.3,ANGLEUNIT["arc-second",4.84813681109536e-06]],
+			PARAMETER["Scale difference",1.000001,SCALEUNIT["unity",1]]]]`)
+	assert.Error(err) // the south orientated method isn't ours
+
+	ps, err = support.ParseWKT(`BOUNDCRS[
+		SOURCECRS[PROJCRS["Lo19",
+			BASEGEOGCRS["Hartebeesthoek94",DATUM["Hartebeesthoek94",ELLIPSOID["WGS 84",6378137,298.257223563]]],
+			CONVERSION["Lo19",METHOD["Transverse Mercator"],PARAMETER["Longitude of natural origin",19]],
+			CS[Cartesian,2],AXIS["westing (Y)",west],AXIS["southing (X)",south],LENGTHUNIT["metre",1]]],
+		TARGETCRS[GEOGCRS["WGS 84",DATUM["World Geodetic System 1984",ELLIPSOID["WGS 84",6378137,298.257223563]]]],
+		ABRIDGEDTRANSFORMATION["to WGS 84",
+			METHOD["Coordinate Frame rotation (geocentric domain)"],
+			PARAMETER["X-axis translation",1,LENGTHUNIT["metre",1]],
+			PARAMETER["Y-axis translation",2,LENGTHUNIT["metre",1]],
+			PARAMETER["Z-axis translation",3,LENGTHUNIT["metre",1]],
+			PARAMETER["X-axis rotation",0.1,ANGLEUNIT["arc-second",4.84813681109536e-06]],
+			PARAMETER["Y-axis rotation",-0.2,ANGLEUNIT["arc-second",4.84813681109536e-06]],
+			PARAMETER["Z-axis rotation",0.3,ANGLEUNIT["arc-second",4.84813681109536e-06]],
+			PARAMETER["Scale difference",1.5,SCALEUNIT["parts per million",1e-06]]]]`)
+	assert.NoError(err)
+	floats, ok := ps.GetAsFloats("towgs84")
+	assert.True(ok)
+	assert.InDeltaSlice([]float64{1.0, 2.0, 3.0, -0.1, 0.2, -0.3, 1.5}, floats, 1.0e-9)
+	axis, _ := ps.GetAsString("axis")
+	assert.Equal("wsu", axis)
+
+	// geographic CRSs, in another unit and from another prime meridian,
+	// which is in degrees whatever the unit, and the horizontal part of a
+	// compound CRS
+	ps, err = support.ParseWKT(`GEOGCS["NTF (Paris)",DATUM["Nouvelle_Triangulation_Francaise_Paris",SPHEROID["Clarke 1880 (IGN)",6378249.2,293.4660212936269]],PRIMEM["Paris",2.33722917,AUTHORITY["EPSG","8903"]],UNIT["grad",0.01570796326794897]]`)
+	assert.NoError(err)
+	assert.Equal("+proj=longlat +a=6378249.2 +rf=293.4660212936269 +pm=2.33722917 +units=grad +no_defs", ps.Definition())
+	ps, err = support.ParseWKT(`COMPOUNDCRS["WGS 84 + EGM96 height",
+		GEOGCRS["WGS 84",DATUM["World Geodetic System 1984",ELLIPSOID["WGS 84",6378137,298.257223563]],CS[ellipsoidal,2]],
+		VERTCRS["EGM96 height",VDATUM["EGM96 geoid"],CS[vertical,1]]]`)
+	assert.NoError(err)
+	assert.Equal("+proj=longlat +a=6378137 +rf=298.257223563 +towgs84=0,0,0 +no_defs", ps.Definition())
+
+	// ESRI's Web Mercator is on the sphere, whatever its datum says
+	ps, err = support.ParseWKT(`PROJCS["WGS_1984_Web_Mercator_Auxiliary_Sphere",GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",SPHEROID["WGS_1984",6378137.0,298.257223563]],PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]],PROJECTION["Mercator_Auxiliary_Sphere"],PARAMETER["False_Easting",0.0],PARAMETER["False_Northing",0.0],PARAMETER["Central_Meridian",0.0],PARAMETER["Standard_Parallel_1",0.0],PARAMETER["Auxiliary_Sphere_Type",0.0],UNIT["Meter",1.0]]`)
+	assert.NoError(err)
+	assert.Equal("+proj=merc +x_0=0 +y_0=0 +lon_0=0 +lat_ts=0 +a=6378137 +b=6378137 +nadgrids=@null +wktext +units=m +no_defs", ps.Definition())
+
+	// the polar stereographic, whose latitude of true scale GDAL's WKT 1
+	// gives as the latitude of origin, and the oblique stereographic
+	ps, err = support.ParseWKT(`PROJCS["WGS 84 / Antarctic Polar Stereographic",GEOGCS["WGS 84",DATUM["WGS_1984",SPHEROID["WGS 84",6378137,298.257223563]],PRIMEM["Greenwich",0],UNIT["degree",0.0174532925199433]],PROJECTION["Polar_Stereographic"],PARAMETER["latitude_of_origin",-71],PARAMETER["central_meridian",0],PARAMETER["false_easting",0],PARAMETER["false_northing",0],UNIT["metre",1]]`)
+	assert.NoError(err)
+	assert.Equal("+proj=stere +lat_0=-90 +lat_ts=-71 +lon_0=0 +x_0=0 +y_0=0 +a=6378137 +rf=298.257223563 +towgs84=0,0,0 +units=m +no_defs", ps.Definition())
+	ps, err = support.ParseWKT(`PROJCRS["WGS 84 / UPS North (E,N)",BASEGEOGCRS["WGS 84",DATUM["World Geodetic System 1984",ELLIPSOID["WGS 84",6378137,298.257223563]]],CONVERSION["Universal Polar Stereographic North",METHOD["Polar Stereographic (variant A)"],PARAMETER["Latitude of natural origin",90,ANGLEUNIT["degree",0.0174532925199433]],PARAMETER["Longitude of natural origin",0,ANGLEUNIT["degree",0.0174532925199433]],PARAMETER["Scale factor at natural origin",0.994,SCALEUNIT["unity",1]],PARAMETER["False easting",2000000,LENGTHUNIT["metre",1]],PARAMETER["False northing",2000000,LENGTHUNIT["metre",1]]],CS[Cartesian,2],LENGTHUNIT["metre",1]]`)
+	assert.NoError(err)
+	assert.Equal("+proj=stere +lat_0=90 +lon_0=0 +k_0=0.994 +x_0=2000000 +y_0=2000000 +a=6378137 +rf=298.257223563 +towgs84=0,0,0 +units=m +no_defs", ps.Definition())
+	ps, err = support.ParseWKT(`PROJCS["Amersfoort / RD New",GEOGCS["Amersfoort",DATUM["Amersfoort",SPHEROID["Bessel 1841",6377397.155,299.1528128],TOWGS84[565.417,50.3319,465.552,-0.398957,0.343988,-1.8774,4.0725]],PRIMEM["Greenwich",0],UNIT["degree",0.0174532925199433]],PROJECTION["Oblique_Stereographic"],PARAMETER["latitude_of_origin",52.1561605555556],PARAMETER["central_meridian",5.38763888888889],PARAMETER["scale_factor",0.9999079],PARAMETER["false_easting",155000],PARAMETER["false_northing",463000],UNIT["metre",1]]`)
+	assert.NoError(err)
+	assert.Equal("+proj=sterea +lat_0=52.1561605555556 +lon_0=5.38763888888889 +k_0=0.9999079 +x_0=155000 +y_0=463000 +a=6377397.155 +rf=299.1528128 +towgs84=565.417,50.3319,465.552,-0.398957,0.343988,-1.8774,4.0725 +units=m +no_defs", ps.Definition())
+
+	// the polar axes of WKT 2 point along meridians, and aren't southings
+	// however they're named
+	ps, err = support.ParseWKT(nsidcNorthWKT2)
+	assert.NoError(err)
+	assert.Equal("+proj=stere +lat_0=90 +lat_ts=70 +lon_0=-45 +x_0=0 +y_0=0 +a=6378137 +rf=298.257223563 +towgs84=0,0,0 +units=m +no_defs", ps.Definition())
+
+	// failures
+	for _, wkt := range []string{
+		``,
+		`GEOGCS`,
+		`GEOGCS["WGS 84"`,
+		`GEOGCS["WGS 84,DATUM["WGS_1984",SPHEROID["WGS 84",6378137,298.257223563]]]`,
+		`GEOGCS["WGS 84",DATUM["WGS_1984",SPHEROID["WGS 84",6378137,298.257223563]]] extra`,
+		`GEOGCS["WGS 84",DATUM["WGS_1984",SPHEROID["WGS 84",big,298.257223563]]]`,
+		`GEOGCS["WGS 84"]`,
+		`GEOCCS["WGS 84",DATUM["WGS_1984",SPHEROID["WGS 84",6378137,298.257223563]]]`,
+		`GEODCRS["WGS 84",DATUM["World Geodetic System 1984",ELLIPSOID["WGS 84",6378137,298.257223563]],CS[Cartesian,3]]`,
+		`PROJCS["x",GEOGCS["WGS 84",DATUM["WGS_1984",SPHEROID["WGS 84",6378137,298.257223563]]],PROJECTION["Robinson"]]`,
+		`PROJCS["x",GEOGCS["WGS 84",DATUM["WGS_1984",SPHEROID["WGS 84",6378137,298.257223563]]],PROJECTION["Mercator_1SP"],PARAMETER["rectified_grid_angle",0],PARAMETER["bogus",1]]`,
+		`PROJCS["x",GEOGCS["WGS 84",DATUM["WGS_1984",SPHEROID["WGS 84",6378137,298.257223563]]],PROJECTION["Mercator_1SP"],UNIT["link",0.25]]`,
+		`PROJCS["WGS 84 / NSIDC Sea Ice Polar Stereographic North",AUTHORITY["EPSG","3413"]]`,
+	} {
+		_, err := support.ParseWKT(wkt)
+		assert.Error(err, wkt)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package testsupport
+
+import (
+	"math"
+	"testing"
+
+	"github.com/oahumap/proj/support"
+)
+
+// EarthRadius is the mean radius of the earth, in meters, used to turn
+// lon/lat differences into distances
+const EarthRadius = 6371008.8
+
+// Distance returns the great circle distance, in meters, between two
+// lon/lat points in degrees. It is spherical, so is only good to about
+// half a percent, which is plenty for tolerances.
+func Distance(lon1, lat1, lon2, lat2 float64) float64 {
+	phi1, phi2 := support.DDToR(lat1), support.DDToR(lat2)
+	dphi := phi2 - phi1
+	dlam := support.DDToR(lon2 - lon1)
+
+	h := math.Pow(math.Sin(dphi/2.0), 2) + math.Cos(phi1)*math.Cos(phi2)*math.Pow(math.Sin(dlam/2.0), 2)
+	return 2.0 * EarthRadius * math.Asin(math.Min(1.0, math.Sqrt(h)))
+}
+
+// AssertNearXY checks that each x/y point got is within tolerance meters
+// of the one wanted, which must be in meters too. It reports each point
+// which isn't, and returns whether all were.
+func AssertNearXY(t testing.TB, want, got []float64, tolerance float64) bool {
+	t.Helper()
+	return assertNear(t, want, got, tolerance, func(i int) float64 {
+		return math.Hypot(got[i]-want[i], got[i+1]-want[i+1])
+	})
+}
+
+// AssertNearLonLat checks that each lon/lat point got, in degrees, is
+// within tolerance meters on the ground of the one wanted. It reports
+// each point which isn't, and returns whether all were.
+func AssertNearLonLat(t testing.TB, want, got []float64, tolerance float64) bool {
+	t.Helper()
+	return assertNear(t, want, got, tolerance, func(i int) float64 {
+		return Distance(want[i], want[i+1], got[i], got[i+1])
+	})
+}
+
+func assertNear(t testing.TB, want, got []float64, tolerance float64, distance func(i int) float64) bool {
+	t.Helper()
+	if len(want) != len(got) {
+		t.Errorf("got %d values, want %d", len(got), len(want))
+		return false
+	}
+
+	ok := true
+	for i := 0; i+1 < len(want); i += 2 {
+		// NaN distances fail too
+		if d := distance(i); !(d <= tolerance) {
+			t.Errorf("point %d: got (%.10g, %.10g), want (%.10g, %.10g): off by %.3g m, more than %g m",
+				i/2, got[i], got[i+1], want[i], want[i+1], d, tolerance)
+			ok = false
+		}
+	}
+	return ok
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package testsupport_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/oahumap/proj/testsupport"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder records failures instead of failing the test
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestDistance(t *testing.T) {
+	assert := assert.New(t)
+
+	// a degree of the equator
+	assert.InDelta(111195.0, testsupport.Distance(0.0, 0.0, 1.0, 0.0), 1.0)
+	assert.InDelta(0.0, testsupport.Distance(10.0, 89.0, 10.0, 89.0), 1e-9)
+	assert.InDelta(343.5e3, testsupport.Distance(-0.1276, 51.5072, 2.3522, 48.8566), 1e3)
+}
+
+func TestAssertions(t *testing.T) {
+	assert := assert.New(t)
+
+	// a round trip of the cities through Web Mercator
+	input := testsupport.LonLat(testsupport.Cities)
+	xy, err := proj.Convert("3857", input)
+	assert.NoError(err)
+	lonlat, err := proj.Inverse("3857", append([]float64{}, xy...))
+	assert.NoError(err)
+	assert.True(testsupport.AssertNearLonLat(t, input, lonlat, 0.001))
+
+	r := &recorder{TB: t}
+	assert.True(testsupport.AssertNearXY(r, []float64{0, 0, 10, 10}, []float64{0.5, 0, 10, 10}, 1.0))
+	assert.Empty(r.errors)
+
+	assert.False(testsupport.AssertNearXY(r, []float64{0, 0, 10, 10}, []float64{0, 0, 13, 14}, 1.0))
+	assert.Len(r.errors, 1)
+	assert.Contains(r.errors[0], "point 1")
+	assert.Contains(r.errors[0], "off by 5 m")
+
+	r.errors = nil
+	assert.False(testsupport.AssertNearLonLat(r, []float64{0, 0}, []float64{0, 0.001}, 100.0))
+	assert.Len(r.errors, 1)
+
+	r.errors = nil
+	assert.False(testsupport.AssertNearXY(r, []float64{0, 0}, []float64{0, 0, 1, 1}, 1.0))
+	assert.Len(r.errors, 1)
+}
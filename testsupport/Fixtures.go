@@ -0,0 +1,85 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+// Package testsupport has coordinate fixtures and assertions for writing
+// regression tests of CRS configurations built on proj.
+package testsupport
+
+import (
+	"github.com/oahumap/proj"
+)
+
+// Place is a named lon/lat point, in degrees
+type Place struct {
+	Name string
+	Lon  float64
+	Lat  float64
+}
+
+// Cities are well-known cities, spread over all the continents and both
+// sides of the equator and the prime meridian
+var Cities = []Place{
+	{"London", -0.1276, 51.5072},
+	{"Paris", 2.3522, 48.8566},
+	{"New York", -74.0060, 40.7128},
+	{"Anchorage", -149.9003, 61.2181},
+	{"Honolulu", -157.8583, 21.3069},
+	{"Quito", -78.4678, -0.1807},
+	{"São Paulo", -46.6333, -23.5505},
+	{"Cape Town", 18.4241, -33.9249},
+	{"Reykjavík", -21.9426, 64.1466},
+	{"Singapore", 103.8198, 1.3521},
+	{"Tokyo", 139.6917, 35.6895},
+	{"Sydney", 151.2093, -33.8688},
+}
+
+// Extremes are points where projections tend to break down
+var Extremes = []Place{
+	{"Null Island", 0.0, 0.0},
+	{"North Pole", 0.0, 90.0},
+	{"South Pole", 0.0, -90.0},
+	{"Antimeridian East", 180.0, 0.0},
+	{"Antimeridian West", -180.0, 0.0},
+	{"Near North Pole", 45.0, 89.999},
+	{"Web Mercator Limit", 0.0, 85.0511287798},
+}
+
+// Corners returns the corners and center of the area of use of a CRS, as
+// given by proj.AreaOf
+func Corners(crs string) ([]Place, error) {
+	area, err := proj.AreaOf(crs)
+	if err != nil {
+		return nil, err
+	}
+
+	east := area.East
+	if area.West > east {
+		// the area crosses the antimeridian
+		east += 360.0
+	}
+	lon := (area.West + east) / 2.0
+	if lon > 180.0 {
+		lon -= 360.0
+	}
+
+	return []Place{
+		{"Southwest", area.West, area.South},
+		{"Southeast", area.East, area.South},
+		{"Northeast", area.East, area.North},
+		{"Northwest", area.West, area.North},
+		{"Center", lon, (area.South + area.North) / 2.0},
+	}, nil
+}
+
+// LonLat returns the places as a flat lon/lat slice, as Convert takes
+func LonLat(places []Place) []float64 {
+	output := make([]float64, 0, 2*len(places))
+	for _, p := range places {
+		output = append(output, p.Lon, p.Lat)
+	}
+	return output
+}
@@ -0,0 +1,41 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package testsupport_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj/testsupport"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixtures(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, p := range append(testsupport.Cities, testsupport.Extremes...) {
+		assert.True(p.Lon >= -180.0 && p.Lon <= 180.0, p.Name)
+		assert.True(p.Lat >= -90.0 && p.Lat <= 90.0, p.Name)
+	}
+
+	lonlat := testsupport.LonLat(testsupport.Cities[:2])
+	assert.Equal([]float64{-0.1276, 51.5072, 2.3522, 48.8566}, lonlat)
+
+	corners, err := testsupport.Corners("32633")
+	assert.NoError(err)
+	assert.Len(corners, 5)
+	assert.Equal(testsupport.Place{Name: "Northeast", Lon: 18.0, Lat: 84.0}, corners[2])
+	assert.Equal(testsupport.Place{Name: "Center", Lon: 15.0, Lat: 42.0}, corners[4])
+
+	// across the antimeridian
+	corners, err = testsupport.Corners("4269")
+	assert.NoError(err)
+	assert.InDelta(-116.54, corners[4].Lon, 1e-9)
+
+	_, err = testsupport.Corners("+proj=merc")
+	assert.Error(err)
+}
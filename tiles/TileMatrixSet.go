@@ -0,0 +1,194 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+// Package tiles implements OGC TileMatrixSets (OGC 17-083r4) in any CRS
+// this module can project to, converting between tiles, CRS coordinates
+// and lon/lat.
+package tiles
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/oahumap/proj"
+)
+
+// TileMatrixSet is a tiling scheme, as read from its JSON definition. It
+// is safe for concurrent use.
+type TileMatrixSet struct {
+	ID           string       `json:"id"`
+	CRS          CRS          `json:"crs"`
+	OrderedAxes  []string     `json:"orderedAxes,omitempty"`
+	TileMatrices []TileMatrix `json:"tileMatrices"`
+
+	converter  *proj.Converter
+	northFirst bool // the CRS axis order is northing, easting
+}
+
+// TileMatrix is one level of a TileMatrixSet
+type TileMatrix struct {
+	ID               string     `json:"id"`
+	ScaleDenominator float64    `json:"scaleDenominator"`
+	CellSize         float64    `json:"cellSize"`
+	CornerOfOrigin   string     `json:"cornerOfOrigin,omitempty"` // "topLeft" (the default) or "bottomLeft"
+	PointOfOrigin    [2]float64 `json:"pointOfOrigin"`            // in CRS axis order
+	TileWidth        int        `json:"tileWidth"`
+	TileHeight       int        `json:"tileHeight"`
+	MatrixWidth      int        `json:"matrixWidth"`
+	MatrixHeight     int        `json:"matrixHeight"`
+}
+
+// CRS is the coordinate reference system of a TileMatrixSet, which in
+// the JSON is either a URI string or an object with a "uri" member
+type CRS string
+
+// UnmarshalJSON accepts both forms of the crs member
+func (crs *CRS) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*crs = CRS(s)
+		return nil
+	}
+
+	var obj struct {
+		URI string `json:"uri"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	*crs = CRS(obj.URI)
+	return nil
+}
+
+// definition returns the proj definition of the CRS: the URI itself,
+// which proj resolves to an SRID, or else a proj string
+func (crs CRS) definition() string {
+	return strings.TrimSpace(string(crs))
+}
+
+// ParseTileMatrixSet reads a TileMatrixSet from its JSON definition.
+//
+// The CRS must be one this module supports, since it is needed for the
+// lon/lat conversions.
+func ParseTileMatrixSet(data []byte) (*TileMatrixSet, error) {
+	tms := &TileMatrixSet{}
+	err := json.Unmarshal(data, tms)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tms.TileMatrices) == 0 {
+		return nil, fmt.Errorf("tile matrix set %q has no tile matrices", tms.ID)
+	}
+	for i := range tms.TileMatrices {
+		tm := &tms.TileMatrices[i]
+		if tm.CellSize <= 0.0 || tm.TileWidth <= 0 || tm.TileHeight <= 0 {
+			return nil, fmt.Errorf("tile matrix %q has invalid dimensions", tm.ID)
+		}
+		switch tm.CornerOfOrigin {
+		case "":
+			tm.CornerOfOrigin = "topLeft"
+		case "topLeft", "bottomLeft":
+		default:
+			return nil, fmt.Errorf("tile matrix %q has unknown corner of origin %q", tm.ID, tm.CornerOfOrigin)
+		}
+	}
+
+	if len(tms.OrderedAxes) > 0 {
+		first := strings.ToUpper(tms.OrderedAxes[0])
+		tms.northFirst = first == "N" || first == "Y" || strings.HasPrefix(first, "LAT")
+	}
+
+	// in degrees, whatever the default Config says
+	tms.converter, err = proj.NewConverter(tms.CRS.definition())
+	if err != nil {
+		return nil, fmt.Errorf("tile matrix set %q: %v", tms.ID, err)
+	}
+
+	return tms, nil
+}
+
+// Matrix returns the tile matrix with the given ID
+func (tms *TileMatrixSet) Matrix(id string) (*TileMatrix, error) {
+	for i := range tms.TileMatrices {
+		if tms.TileMatrices[i].ID == id {
+			return &tms.TileMatrices[i], nil
+		}
+	}
+	return nil, fmt.Errorf("tile matrix set %q has no tile matrix %q", tms.ID, id)
+}
+
+// origin returns the point of origin as easting, northing
+func (tms *TileMatrixSet) origin(tm *TileMatrix) (float64, float64) {
+	if tms.northFirst {
+		return tm.PointOfOrigin[1], tm.PointOfOrigin[0]
+	}
+	return tm.PointOfOrigin[0], tm.PointOfOrigin[1]
+}
+
+// TileBounds returns the extent of a tile in CRS units, as easting and
+// northing regardless of the axis order of the CRS
+func (tms *TileMatrixSet) TileBounds(tm *TileMatrix, col, row int) (minX, minY, maxX, maxY float64) {
+	x0, y0 := tms.origin(tm)
+	w := tm.CellSize * float64(tm.TileWidth)
+	h := tm.CellSize * float64(tm.TileHeight)
+
+	minX = x0 + float64(col)*w
+	maxX = minX + w
+	if tm.CornerOfOrigin == "bottomLeft" {
+		minY = y0 + float64(row)*h
+		maxY = minY + h
+	} else {
+		maxY = y0 - float64(row)*h
+		minY = maxY - h
+	}
+	return minX, minY, maxX, maxY
+}
+
+// TileAt returns the tile containing the CRS point (easting, northing)
+func (tms *TileMatrixSet) TileAt(tm *TileMatrix, x, y float64) (col, row int, err error) {
+	x0, y0 := tms.origin(tm)
+	w := tm.CellSize * float64(tm.TileWidth)
+	h := tm.CellSize * float64(tm.TileHeight)
+
+	c := math.Floor((x - x0) / w)
+	var r float64
+	if tm.CornerOfOrigin == "bottomLeft" {
+		r = math.Floor((y - y0) / h)
+	} else {
+		r = math.Floor((y0 - y) / h)
+	}
+
+	if !(c >= 0 && c < float64(tm.MatrixWidth) && r >= 0 && r < float64(tm.MatrixHeight)) {
+		return 0, 0, fmt.Errorf("point (%f, %f) is outside tile matrix %q", x, y, tm.ID)
+	}
+	return int(c), int(r), nil
+}
+
+// TileForLonLat returns the tile containing the lon/lat point (degrees)
+func (tms *TileMatrixSet) TileForLonLat(tm *TileMatrix, lon, lat float64) (col, row int, err error) {
+	xy, err := tms.converter.Forward([]float64{lon, lat})
+	if err != nil {
+		return 0, 0, err
+	}
+	return tms.TileAt(tm, xy[0], xy[1])
+}
+
+// TileLonLatCorners returns the lon/lat (degrees) of the four corners of a
+// tile, counterclockwise from the bottom left, as [lon0, lat0, lon1, ...].
+// In a non-cylindrical CRS the tile is not a lon/lat rectangle.
+func (tms *TileMatrixSet) TileLonLatCorners(tm *TileMatrix, col, row int) ([]float64, error) {
+	minX, minY, maxX, maxY := tms.TileBounds(tm, col, row)
+	return tms.converter.Inverse([]float64{
+		minX, minY,
+		maxX, minY,
+		maxX, maxY,
+		minX, maxY,
+	})
+}
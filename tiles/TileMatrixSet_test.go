@@ -0,0 +1,175 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package tiles_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/oahumap/proj"
+	"github.com/oahumap/proj/tiles"
+	"github.com/stretchr/testify/assert"
+)
+
+// the first levels of the OGC WebMercatorQuad
+const webMercatorQuad = `{
+	"id": "WebMercatorQuad",
+	"crs": "http://www.opengis.net/def/crs/EPSG/0/3857",
+	"orderedAxes": ["X", "Y"],
+	"tileMatrices": [
+		{"id": "0", "scaleDenominator": 559082264.028717, "cellSize": 156543.033928041,
+		 "cornerOfOrigin": "topLeft", "pointOfOrigin": [-20037508.3427892, 20037508.3427892],
+		 "tileWidth": 256, "tileHeight": 256, "matrixWidth": 1, "matrixHeight": 1},
+		{"id": "2", "scaleDenominator": 139770566.007179, "cellSize": 39135.7584820102,
+		 "cornerOfOrigin": "topLeft", "pointOfOrigin": [-20037508.3427892, 20037508.3427892],
+		 "tileWidth": 256, "tileHeight": 256, "matrixWidth": 4, "matrixHeight": 4}
+	]
+}`
+
+// a made-up scheme over UTM zone 32N, with northing-first axes
+const utm32Tiles = `{
+	"id": "UTM32Tiles",
+	"crs": {"uri": "http://www.opengis.net/def/crs/EPSG/0/32632"},
+	"orderedAxes": ["N", "E"],
+	"tileMatrices": [
+		{"id": "a", "scaleDenominator": 357142.857142857, "cellSize": 100,
+		 "pointOfOrigin": [10000000, 0],
+		 "tileWidth": 256, "tileHeight": 256, "matrixWidth": 40, "matrixHeight": 400}
+	]
+}`
+
+func TestWebMercatorQuad(t *testing.T) {
+	assert := assert.New(t)
+
+	tms, err := tiles.ParseTileMatrixSet([]byte(webMercatorQuad))
+	assert.NoError(err)
+
+	tm, err := tms.Matrix("2")
+	assert.NoError(err)
+
+	col, row, err := tms.TileForLonLat(tm, -77.625583, 38.833846)
+	assert.NoError(err)
+	assert.Equal(1, col)
+	assert.Equal(1, row)
+
+	col, row, err = tms.TileForLonLat(tm, 151.2, -33.9)
+	assert.NoError(err)
+	assert.Equal(3, col)
+	assert.Equal(2, row)
+
+	minX, minY, maxX, maxY := tms.TileBounds(tm, 1, 1)
+	assert.InDelta(-10018754.17, minX, 0.01)
+	assert.InDelta(0.0, minY, 0.01)
+	assert.InDelta(0.0, maxX, 0.01)
+	assert.InDelta(10018754.17, maxY, 0.01)
+
+	corners, err := tms.TileLonLatCorners(tm, 1, 1)
+	assert.NoError(err)
+	assert.InDeltaSlice([]float64{
+		-90.0, 0.0,
+		0.0, 0.0,
+		0.0, 66.51326,
+		-90.0, 66.51326,
+	}, corners, 1e-5)
+
+	_, err = tms.Matrix("1")
+	assert.Error(err)
+
+	tm, _ = tms.Matrix("0")
+	_, _, err = tms.TileAt(tm, 0.0, 30000000.0)
+	assert.Error(err)
+}
+
+func TestTileMatrixSetDefaultConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	// lon/lat stay in degrees when the default is radians
+	defer func() { assert.NoError(proj.SetDefaultConfig(proj.Config{})) }()
+	assert.NoError(proj.SetDefaultConfig(proj.Config{AngularUnit: "rad"}))
+
+	tms, err := tiles.ParseTileMatrixSet([]byte(webMercatorQuad))
+	assert.NoError(err)
+	tm, err := tms.Matrix("2")
+	assert.NoError(err)
+
+	col, row, err := tms.TileForLonLat(tm, 151.2, -33.9)
+	assert.NoError(err)
+	assert.Equal(3, col)
+	assert.Equal(2, row)
+}
+
+func TestNonMercatorTiles(t *testing.T) {
+	assert := assert.New(t)
+
+	tms, err := tiles.ParseTileMatrixSet([]byte(utm32Tiles))
+	assert.NoError(err)
+
+	tm, err := tms.Matrix("a")
+	assert.NoError(err)
+	assert.Equal("topLeft", tm.CornerOfOrigin)
+
+	// 12E 55N is at (691875.63, 6098907.83); tiles are 25.6km square
+	col, row, err := tms.TileForLonLat(tm, 12.0, 55.0)
+	assert.NoError(err)
+	assert.Equal(27, col)
+	assert.Equal(152, row)
+
+	corners, err := tms.TileLonLatCorners(tm, col, row)
+	assert.NoError(err)
+	assert.True(corners[0] < 12.0 && corners[2] > 12.0)
+	assert.True(corners[1] < 55.0 && corners[5] > 55.0)
+}
+
+func TestTileMatrixSetConcurrency(t *testing.T) {
+	assert := assert.New(t)
+
+	tms, err := tiles.ParseTileMatrixSet([]byte(utm32Tiles))
+	assert.NoError(err)
+	tm, err := tms.Matrix("a")
+	assert.NoError(err)
+
+	expected, err := tms.TileLonLatCorners(tm, 27, 152)
+	assert.NoError(err)
+
+	// run with -race
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				corners, err := tms.TileLonLatCorners(tm, 27, 152)
+				assert.NoError(err)
+				assert.Equal(expected, corners)
+				col, row, err := tms.TileForLonLat(tm, 12.0, 55.0)
+				assert.NoError(err)
+				assert.Equal(27, col)
+				assert.Equal(152, row)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestParseTileMatrixSetErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := tiles.ParseTileMatrixSet([]byte(`{"id": "x", "crs": "EPSG:3857", "tileMatrices": []}`))
+	assert.Error(err)
+
+	_, err = tiles.ParseTileMatrixSet([]byte(`{"id": "x", "crs": "EPSG:9999",
+		"tileMatrices": [{"id": "0", "cellSize": 1, "tileWidth": 1, "tileHeight": 1}]}`))
+	assert.Error(err)
+
+	_, err = tiles.ParseTileMatrixSet([]byte(`{"id": "x", "crs": "EPSG:3857",
+		"tileMatrices": [{"id": "0", "cellSize": 1, "tileWidth": 1, "tileHeight": 1, "cornerOfOrigin": "middle"}]}`))
+	assert.Error(err)
+
+	_, err = tiles.ParseTileMatrixSet([]byte(`not json`))
+	assert.Error(err)
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package tiles
+
+import (
+	"math"
+
+	"github.com/oahumap/proj"
+)
+
+// WebMercatorRadius is the radius of the sphere of EPSG:3857, as used by
+// this module's conversions, so that resolutions computed here agree
+// exactly with the tile bounds
+var WebMercatorRadius = webMercatorRadius()
+
+func webMercatorRadius() float64 {
+	t, err := proj.NewTransformer("3857")
+	if err != nil {
+		panic(err)
+	}
+	return t.Ellipsoid().A
+}
+
+// StandardPixelSize is the size of a rendered pixel, in meters, that OGC
+// scale denominators assume
+const StandardPixelSize = 0.00028
+
+// WebMercatorResolution returns the size of a pixel, in EPSG:3857 meters,
+// at a zoom level, which may be fractional
+func WebMercatorResolution(zoom float64, tileSize int) float64 {
+	return 2.0 * math.Pi * WebMercatorRadius / (float64(tileSize) * math.Exp2(zoom))
+}
+
+// GroundResolution returns the size of a pixel, in meters on the ground,
+// at a latitude (degrees) and zoom level: the EPSG:3857 resolution shrunk
+// by the cosine of the latitude
+func GroundResolution(lat, zoom float64, tileSize int) float64 {
+	return WebMercatorResolution(zoom, tileSize) * math.Cos(lat*math.Pi/180.0)
+}
+
+// ScaleDenominator returns the scale denominator of a map at a latitude
+// (degrees) and zoom level, for pixels of StandardPixelSize. At the
+// equator this is the scale denominator of the OGC WebMercatorQuad.
+func ScaleDenominator(lat, zoom float64, tileSize int) float64 {
+	return GroundResolution(lat, zoom, tileSize) / StandardPixelSize
+}
+
+// ZoomForResolution returns the lowest zoom level whose ground resolution
+// at a latitude (degrees) is at least as fine as the given number of
+// meters per pixel, or 0 if every zoom level is
+func ZoomForResolution(metersPerPixel, lat float64, tileSize int) int {
+	zoom := math.Log2(GroundResolution(lat, 0.0, tileSize) / metersPerPixel)
+
+	// allow for rounding when the resolution is exactly that of a level
+	z := math.Ceil(zoom - 1.0e-9)
+	if !(z > 0.0) {
+		return 0
+	}
+	return int(z)
+}
@@ -0,0 +1,43 @@
+// Copyright (C) 2018, Michael P. Gerlek (Flaxen Consulting)
+//
+// Portions of this code were derived from the PROJ.4 software
+// In keeping with the terms of the PROJ.4 project, this software
+// is provided under the MIT-style license in `LICENSE.md` and may
+// additionally be subject to the copyrights of the PROJ.4 authors.
+
+package tiles_test
+
+import (
+	"testing"
+
+	"github.com/oahumap/proj/tiles"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebMercatorScales(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(6378137.0, tiles.WebMercatorRadius)
+
+	// the OGC WebMercatorQuad's own values
+	tms, err := tiles.ParseTileMatrixSet([]byte(webMercatorQuad))
+	assert.NoError(err)
+	for _, tm := range tms.TileMatrices {
+		zoom := map[string]float64{"0": 0.0, "2": 2.0}[tm.ID]
+		assert.InDelta(tm.CellSize, tiles.WebMercatorResolution(zoom, 256), 1.0e-6)
+		assert.InDelta(tm.CellSize, tiles.GroundResolution(0.0, zoom, 256), 1.0e-6)
+		assert.InDelta(tm.ScaleDenominator, tiles.ScaleDenominator(0.0, zoom, 256), 1.0e-3)
+	}
+
+	// half the size at 60 degrees, and half again each level down
+	assert.InDelta(156543.033928041/2.0, tiles.GroundResolution(60.0, 0.0, 256), 1.0e-6)
+	assert.InDelta(156543.033928041/8.0, tiles.GroundResolution(60.0, 2.0, 256), 1.0e-6)
+	assert.InDelta(156543.033928041/4.0, tiles.GroundResolution(0.0, 1.0, 512), 1.0e-6)
+	assert.InDelta(156543.033928041/1.4142135623730951, tiles.GroundResolution(0.0, 0.5, 256), 1.0e-6)
+
+	// 1 m/pixel needs zoom 18 at the equator (0.597 m), 17 at 60 degrees
+	assert.Equal(18, tiles.ZoomForResolution(1.0, 0.0, 256))
+	assert.Equal(17, tiles.ZoomForResolution(1.0, 60.0, 256))
+	assert.Equal(2, tiles.ZoomForResolution(39135.7584820102, 0.0, 256))
+	assert.Equal(0, tiles.ZoomForResolution(1.0e9, 0.0, 256))
+}